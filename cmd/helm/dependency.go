@@ -23,6 +23,7 @@ import (
 
 	"helm.sh/helm/v3/cmd/helm/require"
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/downloader"
 )
 
 const dependencyDesc = `
@@ -84,7 +85,7 @@ This will produce an error if the chart cannot be loaded.
 
 func newDependencyCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "dependency update|build|list",
+		Use:     "dependency update|build|list|graph",
 		Aliases: []string{"dep", "dependencies"},
 		Short:   "manage a chart's dependencies",
 		Long:    dependencyDesc,
@@ -94,6 +95,7 @@ func newDependencyCmd(out io.Writer) *cobra.Command {
 	cmd.AddCommand(newDependencyListCmd(out))
 	cmd.AddCommand(newDependencyUpdateCmd(out))
 	cmd.AddCommand(newDependencyBuildCmd(out))
+	cmd.AddCommand(newDependencyGraphCmd(out))
 
 	return cmd
 }
@@ -117,3 +119,9 @@ func newDependencyListCmd(out io.Writer) *cobra.Command {
 	}
 	return cmd
 }
+
+// archiveCache returns the cache of the chart archives downloaded from HTTP
+// repositories, which is kept in the repository cache.
+func archiveCache() *downloader.ArchiveCache {
+	return &downloader.ArchiveCache{Dir: filepath.Join(settings.RepositoryCache, downloader.ArchiveCacheDir)}
+}
@@ -60,7 +60,8 @@ func newDependencyBuildCmd(out io.Writer) *cobra.Command {
 				Getters:          getter.All(settings),
 				RepositoryConfig: settings.RepositoryConfig,
 				RepositoryCache:  settings.RepositoryCache,
-				Debug:            settings.Debug,
+				ArchiveCache:     archiveCache(),
+				Logger:           logger,
 			}
 			if client.Verify {
 				man.Verify = downloader.VerifyIfPossible
@@ -0,0 +1,136 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"helm.sh/helm/v3/cmd/helm/require"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/cli/output"
+	"helm.sh/helm/v3/pkg/downloader"
+	"helm.sh/helm/v3/pkg/getter"
+)
+
+const dependencyGraphDesc = `
+Show the full dependency tree of a chart.
+
+The dependencies of the chart, and theirs in turn, are resolved to the chart
+versions that would be installed: those in the charts/ directory, or else those
+locked in Chart.lock or matching the version constraints in the repositories.
+Charts that are not in the charts/ directory are downloaded to a temporary
+directory, and the chart itself is not changed.
+
+Each chart is shown with its version and repository, and the digest of its
+archive with --output json or yaml. Dependencies disabled by the conditions and
+tags of the default values are marked as such.
+
+With --dot, the graph is written in the DOT language, to be rendered with
+Graphviz:
+
+    $ helm dependency graph ./mychart --dot | dot -Tsvg > dependencies.svg
+`
+
+type dependencyGraphWriter struct {
+	root *downloader.DependencyNode
+}
+
+func newDependencyGraphCmd(out io.Writer) *cobra.Command {
+	client := action.NewDependency()
+	var outfmt output.Format
+	var dot bool
+
+	cmd := &cobra.Command{
+		Use:   "graph CHART",
+		Short: "show the dependency tree of a chart",
+		Long:  dependencyGraphDesc,
+		Args:  require.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chartpath := "."
+			if len(args) > 0 {
+				chartpath = filepath.Clean(args[0])
+			}
+			man := &downloader.Manager{
+				Out:              out,
+				ChartPath:        chartpath,
+				Keyring:          client.Keyring,
+				Getters:          getter.All(settings),
+				RepositoryConfig: settings.RepositoryConfig,
+				RepositoryCache:  settings.RepositoryCache,
+				Logger:           logger,
+			}
+			if client.Verify {
+				man.Verify = downloader.VerifyIfPossible
+			}
+			root, err := man.Graph()
+			if err != nil {
+				return err
+			}
+			if dot {
+				return root.WriteDOT(out)
+			}
+			return outfmt.Write(out, dependencyGraphWriter{root: root})
+		},
+	}
+
+	f := cmd.Flags()
+	f.BoolVar(&dot, "dot", false, "write the graph in the DOT language of Graphviz")
+	f.BoolVar(&client.Verify, "verify", false, "verify the downloaded packages against signatures")
+	f.StringVar(&client.Keyring, "keyring", defaultKeyring(), "keyring containing public keys")
+	bindOutputFlag(cmd, &outfmt)
+
+	return cmd
+}
+
+func (w dependencyGraphWriter) WriteTable(out io.Writer) error {
+	fmt.Fprintf(out, "%s %s\n", w.root.Name, w.root.Version)
+	writeDependencyTree(out, w.root, "")
+	return nil
+}
+
+func (w dependencyGraphWriter) WriteJSON(out io.Writer) error {
+	return output.EncodeJSON(out, w.root)
+}
+
+func (w dependencyGraphWriter) WriteYAML(out io.Writer) error {
+	return output.EncodeYAML(out, w.root)
+}
+
+func writeDependencyTree(out io.Writer, n *downloader.DependencyNode, indent string) {
+	for i, d := range n.Dependencies {
+		branch, next := "├── ", "│   "
+		if i == len(n.Dependencies)-1 {
+			branch, next = "└── ", "    "
+		}
+		line := fmt.Sprintf("%s %s", d.Name, d.Version)
+		if d.Alias != "" {
+			line += " as " + d.Alias
+		}
+		if d.Repository != "" {
+			line += " from " + d.Repository
+		}
+		if !d.Enabled {
+			line += " (disabled)"
+		}
+		fmt.Fprintf(out, "%s%s%s\n", indent, branch, line)
+		writeDependencyTree(out, d, indent+next)
+	}
+}
@@ -48,6 +48,27 @@ func TestDependencyListCmd(t *testing.T) {
 			name:   "Dependencies in chart archive",
 			cmd:    "dependency list testdata/testcharts/reqtest-0.1.0.tgz",
 			golden: "output/dependency-list-archive.txt",
+		}, {
+			name:   "Dependencies in requirements.yaml of a v2 chart",
+			cmd:    "dependency list testdata/testcharts/chart-with-requirements-v2",
+			golden: "output/dependency-list-requirements-v2.txt",
 		}}
 	runTestCmd(t, tests)
 }
+
+func TestDependencyGraphCmd(t *testing.T) {
+	tests := []cmdTestCase{{
+		name:   "Dependency tree of a chart dir",
+		cmd:    "dependency graph testdata/testcharts/reqtest",
+		golden: "output/dependency-graph.txt",
+	}, {
+		name:   "Dependency graph in DOT",
+		cmd:    "dependency graph testdata/testcharts/reqtest --dot",
+		golden: "output/dependency-graph-dot.txt",
+	}, {
+		name:   "Dependency graph in JSON",
+		cmd:    "dependency graph testdata/testcharts/reqtest -o json",
+		golden: "output/dependency-graph.json",
+	}}
+	runTestCmd(t, tests)
+}
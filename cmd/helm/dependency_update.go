@@ -65,7 +65,8 @@ func newDependencyUpdateCmd(out io.Writer) *cobra.Command {
 				Getters:          getter.All(settings),
 				RepositoryConfig: settings.RepositoryConfig,
 				RepositoryCache:  settings.RepositoryCache,
-				Debug:            settings.Debug,
+				ArchiveCache:     archiveCache(),
+				Logger:           logger,
 			}
 			if client.Verify {
 				man.Verify = downloader.VerifyAlways
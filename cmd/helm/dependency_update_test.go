@@ -26,6 +26,7 @@ import (
 	"helm.sh/helm/v3/internal/test/ensure"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/downloader"
 	"helm.sh/helm/v3/pkg/helmpath"
 	"helm.sh/helm/v3/pkg/provenance"
 	"helm.sh/helm/v3/pkg/repo"
@@ -145,8 +146,11 @@ func TestDependencyUpdateCmd_DontDeleteOldChartsOnError(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	// Chart repo is down
+	// Chart repo is down, and the charts are not cached
 	srv.Stop()
+	if err := os.RemoveAll(dir(downloader.ArchiveCacheDir)); err != nil {
+		t.Fatal(err)
+	}
 
 	_, output, err = executeActionCommand(fmt.Sprintf("dependency update %s --repository-config %s --repository-cache %s", dir(chartname), dir("repositories.yaml"), dir()))
 	if err == nil {
@@ -0,0 +1,119 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gosuri/uitable"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"helm.sh/helm/v3/cmd/helm/require"
+	"helm.sh/helm/v3/internal/completion"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/cli/output"
+)
+
+var driftHelp = `
+This command compares the manifests of a release with the live objects in the
+cluster and reports the resources that drifted:
+
+- added: resources left over from earlier revisions that still exist
+- removed: resources of the release that are missing from the cluster
+- changed: resources whose live state differs from the release manifest
+
+Only the fields set in the manifests are compared, so values defaulted by the
+API server are not reported. Use '--exit-code' to make the command fail when
+drift is found, e.g. before upgrading with '--reuse-values'.
+`
+
+func newDriftCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
+	client := action.NewDrift(cfg)
+	var outfmt output.Format
+	var exitCode bool
+
+	cmd := &cobra.Command{
+		Use:   "drift RELEASE_NAME",
+		Short: "detect drift between a release and the cluster",
+		Long:  driftHelp,
+		Args:  require.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			report, err := client.Run(args[0])
+			if err != nil {
+				return err
+			}
+			if err := outfmt.Write(out, &driftPrinter{report}); err != nil {
+				return err
+			}
+			if exitCode && report.HasDrift() {
+				return errors.Errorf("release %q has drifted from the cluster", report.Release)
+			}
+			return nil
+		},
+	}
+
+	completion.RegisterValidArgsFunc(cmd, func(cmd *cobra.Command, args []string, toComplete string) ([]string, completion.BashCompDirective) {
+		if len(args) != 0 {
+			return nil, completion.BashCompDirectiveNoFileComp
+		}
+		return compListReleases(toComplete, cfg)
+	})
+
+	f := cmd.Flags()
+	f.IntVar(&client.Version, "revision", 0, "if set, compare the named release with revision")
+	f.BoolVar(&exitCode, "exit-code", false, "return an error if drift is detected")
+	bindOutputFlag(cmd, &outfmt)
+
+	return cmd
+}
+
+type driftPrinter struct {
+	report *action.DriftReport
+}
+
+func (d driftPrinter) WriteJSON(out io.Writer) error {
+	return output.EncodeJSON(out, d.report)
+}
+
+func (d driftPrinter) WriteYAML(out io.Writer) error {
+	return output.EncodeYAML(out, d.report)
+}
+
+func (d driftPrinter) WriteTable(out io.Writer) error {
+	if !d.report.HasDrift() {
+		_, err := fmt.Fprintf(out, "No drift detected for release %q\n", d.report.Release)
+		return err
+	}
+	table := uitable.New()
+	table.AddRow("DRIFT", "KIND", "NAMESPACE", "NAME", "FIELDS")
+	for _, group := range []struct {
+		name      string
+		resources []*action.ResourceDrift
+	}{
+		{"added", d.report.Added},
+		{"removed", d.report.Removed},
+		{"changed", d.report.Changed},
+	} {
+		for _, r := range group.resources {
+			table.AddRow(group.name, r.Kind, r.Namespace, r.Name, strings.Join(r.Fields, ", "))
+		}
+	}
+	return output.EncodeTable(out, table)
+}
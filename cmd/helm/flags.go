@@ -18,6 +18,7 @@ package main
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -27,17 +28,26 @@ import (
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/cli/output"
 	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/images"
+	"helm.sh/helm/v3/pkg/kube"
 	"helm.sh/helm/v3/pkg/postrender"
+	"helm.sh/helm/v3/pkg/resolver"
 )
 
 const outputFlag = "output"
 const postRenderFlag = "post-renderer"
+const imageMappingFlag = "image-mapping"
+const resolveValuesFlag = "resolve-values"
 
 func addValueOptionsFlags(f *pflag.FlagSet, v *values.Options) {
 	f.StringSliceVarP(&v.ValueFiles, "values", "f", []string{}, "specify values in a YAML file or a URL (can specify multiple)")
 	f.StringArrayVar(&v.Values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&v.StringValues, "set-string", []string{}, "set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&v.FileValues, "set-file", []string{}, "set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
+	f.BoolVar(&v.NullDeletes, "null-deletes", false, "make null values delete keys set by earlier values files, so that chart defaults apply instead")
+	f.StringVar((*string)(&v.ListMerge), "list-merge", string(values.ListReplace), fmt.Sprintf("how lists of later values files merge with earlier ones: %q, %q or %q", values.ListReplace, values.ListAppend, values.ListMergeByKey))
+	f.StringVar(&v.MergeKey, "list-merge-key", values.DefaultMergeKey, fmt.Sprintf("key identifying the list items merged with --list-merge=%s", values.ListMergeByKey))
+	f.BoolVar(&v.DisableDecryption, "disable-decryption", false, "read values files encrypted with SOPS as is, instead of decrypting them with the sops command")
 }
 
 func addChartPathOptionsFlags(f *pflag.FlagSet, c *action.ChartPathOptions) {
@@ -70,6 +80,22 @@ func bindOutputFlag(cmd *cobra.Command, varRef *output.Format) {
 	})
 }
 
+// addServerSideApplyFlags adds the flags choosing how resources are applied
+// to the cluster
+func addServerSideApplyFlags(f *pflag.FlagSet, o *action.ServerSideApplyOptions) {
+	f.BoolVar(&o.ServerSideApply, "server-side", false, "apply resources with server-side apply instead of a client-side three-way merge patch")
+	f.StringVar(&o.FieldManager, "field-manager", kube.DefaultFieldManager, "name of the manager used to track field ownership when using server-side apply")
+	f.Var(newConflictPolicyValue(kube.ConflictPolicyFail, &o.ConflictPolicy), "conflict-policy", fmt.Sprintf("how to handle fields owned by other managers when using server-side apply. Allowed values: %s, %s, %s", kube.ConflictPolicyFail, kube.ConflictPolicyForce, kube.ConflictPolicyIgnore))
+	f.StringSliceVar(&o.IgnoreManagers, "ignore-managers", []string{}, "with --conflict-policy=ignore, only leave fields owned by these managers untouched (can specify multiple or separate values with commas: hpa,controller)")
+}
+
+// addApplyRetryFlags adds the flags choosing how creating and updating
+// resources is retried
+func addApplyRetryFlags(f *pflag.FlagSet, o *action.ApplyRetryOptions) {
+	f.IntVar(&o.ApplyRetry.Retries, "apply-retries", 0, "number of times creating or updating a resource is retried after a conflict or an admission webhook timeout")
+	f.DurationVar(&o.ApplyRetry.MaxDelay, "apply-retry-max-delay", kube.DefaultRetryMaxDelay, fmt.Sprintf("maximum delay between retries of creating or updating a resource. Delays start at %s and double after each retry", kube.DefaultRetryDelay))
+}
+
 type outputValue output.Format
 
 func newOutputValue(defaultValue output.Format, p *output.Format) *outputValue {
@@ -97,12 +123,62 @@ func (o *outputValue) Set(s string) error {
 	return nil
 }
 
+type conflictPolicyValue kube.ConflictPolicy
+
+func newConflictPolicyValue(defaultValue kube.ConflictPolicy, p *kube.ConflictPolicy) *conflictPolicyValue {
+	*p = defaultValue
+	return (*conflictPolicyValue)(p)
+}
+
+func (c *conflictPolicyValue) String() string {
+	return string(*c)
+}
+
+func (c *conflictPolicyValue) Type() string {
+	return "string"
+}
+
+func (c *conflictPolicyValue) Set(s string) error {
+	opts := kube.ApplyOptions{ConflictPolicy: kube.ConflictPolicy(s)}
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	*c = conflictPolicyValue(s)
+	return nil
+}
+
 func bindPostRenderFlag(cmd *cobra.Command, varRef *postrender.PostRenderer) {
-	cmd.Flags().Var(&postRenderer{varRef}, postRenderFlag, "the path to an executable to be used for post rendering. If it exists in $PATH, the binary will be used, otherwise it will try to look for the executable at the given path")
+	renderers := &postRenderers{target: varRef}
+	cmd.Flags().Var(&postRenderer{renderers}, postRenderFlag, "the path to an executable to be used for post rendering. If it exists in $PATH, the binary will be used, otherwise it will try to look for the executable at the given path")
+	cmd.Flags().Var(&imageMapping{renderers}, imageMappingFlag, "a YAML file mapping registries and images to the ones replacing them in the rendered manifests, after any other post rendering, to relocate images to mirrored registries")
+}
+
+// postRenderers are the post renderers set by flags, run in turn as the post
+// renderer they are bound to.
+type postRenderers struct {
+	target *postrender.PostRenderer
+	exec   postrender.PostRenderer
+	images postrender.PostRenderer
+}
+
+func (p *postRenderers) update() {
+	var rs []postrender.PostRenderer
+	for _, r := range []postrender.PostRenderer{p.exec, p.images} {
+		if r != nil {
+			rs = append(rs, r)
+		}
+	}
+	switch len(rs) {
+	case 0:
+	case 1:
+		*p.target = rs[0]
+	default:
+		*p.target = postrender.Chain(rs...)
+	}
 }
 
 type postRenderer struct {
-	renderer *postrender.PostRenderer
+	renderers *postRenderers
 }
 
 func (p postRenderer) String() string {
@@ -121,6 +197,66 @@ func (p postRenderer) Set(s string) error {
 	if err != nil {
 		return err
 	}
-	*p.renderer = pr
+	p.renderers.exec = pr
+	p.renderers.update()
+	return nil
+}
+
+type imageMapping struct {
+	renderers *postRenderers
+}
+
+func (m imageMapping) String() string {
+	return ""
+}
+
+func (m imageMapping) Type() string {
+	return "string"
+}
+
+func (m imageMapping) Set(s string) error {
+	if s == "" {
+		return nil
+	}
+	mapping, err := images.LoadMapping(s)
+	if err != nil {
+		return err
+	}
+	m.renderers.images = mapping
+	m.renderers.update()
+	return nil
+}
+
+func bindResolveValuesFlag(cmd *cobra.Command, varRef *resolver.Resolver) {
+	cmd.Flags().Var(&valuesResolver{varRef}, resolveValuesFlag, "resolve valueFrom references in values, such as {valueFrom: \"env:DB_PASSWORD\"}, when rendering. References are resolved by the env and file providers, and by the value providers of installed plugins")
+	cmd.Flags().Lookup(resolveValuesFlag).NoOptDefVal = "true"
+}
+
+type valuesResolver struct {
+	resolver *resolver.Resolver
+}
+
+func (v valuesResolver) String() string {
+	return strconv.FormatBool(*v.resolver != nil)
+}
+
+func (v valuesResolver) Type() string {
+	return "bool"
+}
+
+func (v valuesResolver) Set(s string) error {
+	resolve, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	if !resolve {
+		*v.resolver = nil
+		return nil
+	}
+	providers, err := resolver.WithPlugins(settings)
+	if err != nil {
+		return err
+	}
+	*v.resolver = providers
 	return nil
 }
@@ -25,6 +25,7 @@ import (
 	"helm.sh/helm/v3/cmd/helm/require"
 	"helm.sh/helm/v3/internal/completion"
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/cli/output"
 )
 
 var getManifestHelp = `
@@ -33,10 +34,21 @@ This command fetches the generated manifest for a given release.
 A manifest is a YAML-encoded representation of the Kubernetes resources that
 were generated from this release's chart(s). If a chart is dependent on other
 charts, those resources will also be included in the manifest.
+
+The resources can be filtered by kind, name, label selector or the path of the
+template they were rendered from, and listed as JSON or YAML with --output:
+
+    $ helm get manifest juno --kind Deployment,Service -l app=web
+    $ helm get manifest juno --template 'mychart/templates/*.yaml' -o json
 `
 
+type manifestWriter struct {
+	resources []*action.Resource
+}
+
 func newGetManifestCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	client := action.NewGet(cfg)
+	var outfmt output.Format
 
 	cmd := &cobra.Command{
 		Use:   "manifest RELEASE_NAME",
@@ -44,12 +56,20 @@ func newGetManifestCmd(cfg *action.Configuration, out io.Writer) *cobra.Command
 		Long:  getManifestHelp,
 		Args:  require.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			res, err := client.Run(args[0])
+			filter := client.Filter
+			if outfmt == output.Table && len(filter.Kinds) == 0 && len(filter.Names) == 0 && filter.Selector == "" && len(filter.Templates) == 0 {
+				res, err := client.Run(args[0])
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(out, res.Manifest)
+				return nil
+			}
+			resources, err := client.RunManifest(args[0])
 			if err != nil {
 				return err
 			}
-			fmt.Fprintln(out, res.Manifest)
-			return nil
+			return outfmt.Write(out, manifestWriter{resources})
 		},
 	}
 
@@ -70,6 +90,35 @@ func newGetManifestCmd(cfg *action.Configuration, out io.Writer) *cobra.Command
 		}
 		return nil, completion.BashCompDirectiveNoFileComp
 	})
+	f.StringSliceVar(&client.Filter.Kinds, "kind", []string{}, "only show resources of these kinds (can specify multiple or separate values with commas: Deployment,Service)")
+	f.StringSliceVar(&client.Filter.Names, "name", []string{}, "only show resources with these names (can specify multiple or separate values with commas)")
+	f.StringVarP(&client.Filter.Selector, "selector", "l", "", "only show resources matching this label selector, such as app=web,tier!=db")
+	f.StringArrayVar(&client.Filter.Templates, "template", []string{}, "only show resources rendered from templates matching this pattern, such as 'mychart/templates/*.yaml' (can specify multiple)")
+	bindOutputFlag(cmd, &outfmt)
 
 	return cmd
 }
+
+func (m manifestWriter) WriteTable(out io.Writer) error {
+	for _, r := range m.resources {
+		fmt.Fprintf(out, "---\n%s\n", r.Manifest)
+	}
+	return nil
+}
+
+func (m manifestWriter) WriteJSON(out io.Writer) error {
+	return output.EncodeJSON(out, m.list())
+}
+
+func (m manifestWriter) WriteYAML(out io.Writer) error {
+	return output.EncodeYAML(out, m.list())
+}
+
+// list returns the resources, as an empty list rather than null if there are
+// none.
+func (m manifestWriter) list() []*action.Resource {
+	if m.resources == nil {
+		return []*action.Resource{}
+	}
+	return m.resources
+}
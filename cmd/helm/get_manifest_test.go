@@ -23,6 +23,33 @@ import (
 )
 
 func TestGetManifest(t *testing.T) {
+	rel := release.Mock(&release.MockReleaseOptions{Name: "web"})
+	rel.Manifest = `---
+# Source: web/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+---
+# Source: web/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  labels:
+    app: web
+---
+# Source: web/charts/db/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: db
+  labels:
+    app: db
+`
+
 	tests := []cmdTestCase{{
 		name:   "get manifest with release",
 		cmd:    "get manifest juno",
@@ -33,6 +60,27 @@ func TestGetManifest(t *testing.T) {
 		cmd:       "get manifest",
 		golden:    "output/get-manifest-no-args.txt",
 		wantError: true,
+	}, {
+		name:   "get manifest filtered by kind and selector",
+		cmd:    "get manifest web --kind service -l app=web",
+		golden: "output/get-manifest-filtered.txt",
+		rels:   []*release.Release{rel},
+	}, {
+		name:   "get manifest filtered by template as json",
+		cmd:    "get manifest web --template 'web/charts/*/templates/*' -o json",
+		golden: "output/get-manifest-filtered.json",
+		rels:   []*release.Release{rel},
+	}, {
+		name:   "get manifest without matches as yaml",
+		cmd:    "get manifest web --name none -o yaml",
+		golden: "output/get-manifest-filtered-none.yaml",
+		rels:   []*release.Release{rel},
+	}, {
+		name:      "get manifest with invalid selector",
+		cmd:       "get manifest web -l 'app in'",
+		golden:    "output/get-manifest-invalid-selector.txt",
+		rels:      []*release.Release{rel},
+		wantError: true,
 	}}
 	runTestCmd(t, tests)
 }
@@ -29,9 +29,16 @@ import (
 
 var getNotesHelp = `
 This command shows notes provided by the chart of a named release.
+
+With '--compare', it shows how the notes of the release changed instead, as a
+unified diff from the notes of the compared revision to those of '--revision'
+(the latest revision by default). Nothing is shown if the notes are the same:
+
+    $ helm get notes my-release --revision 3 --compare 2
 `
 
 func newGetNotesCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
+	var compare int
 	client := action.NewGet(cfg)
 
 	cmd := &cobra.Command{
@@ -40,6 +47,14 @@ func newGetNotesCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 		Long:  getNotesHelp,
 		Args:  require.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if compare != 0 {
+				diff, err := client.RunNotesDiff(args[0], compare)
+				if err != nil {
+					return err
+				}
+				fmt.Fprint(out, diff)
+				return nil
+			}
 			res, err := client.Run(args[0])
 			if err != nil {
 				return err
@@ -61,13 +76,15 @@ func newGetNotesCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	f.IntVar(&client.Version, "revision", 0, "get the named release with revision")
-	flag := f.Lookup("revision")
-	completion.RegisterFlagCompletionFunc(flag, func(cmd *cobra.Command, args []string, toComplete string) ([]string, completion.BashCompDirective) {
-		if len(args) == 1 {
-			return compListRevisions(cfg, args[0])
-		}
-		return nil, completion.BashCompDirectiveNoFileComp
-	})
+	f.IntVar(&compare, "compare", 0, "show the changes to the notes since this revision of the named release")
+	for _, name := range []string{"revision", "compare"} {
+		completion.RegisterFlagCompletionFunc(f.Lookup(name), func(cmd *cobra.Command, args []string, toComplete string) ([]string, completion.BashCompDirective) {
+			if len(args) == 1 {
+				return compListRevisions(cfg, args[0])
+			}
+			return nil, completion.BashCompDirectiveNoFileComp
+		})
+	}
 
 	return cmd
 }
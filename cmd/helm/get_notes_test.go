@@ -37,6 +37,58 @@ func TestGetNotesCmd(t *testing.T) {
 	runTestCmd(t, tests)
 }
 
+func TestGetNotesCompareCmd(t *testing.T) {
+	mk := func(vers int, notes string) *release.Release {
+		rel := release.Mock(&release.MockReleaseOptions{Name: "the-limerick", Version: vers})
+		rel.Info.Notes = notes
+		return rel
+	}
+	releases := []*release.Release{
+		mk(1, "There was a young lady of Niger\nWho smiled as she rode on a tiger\n"),
+		mk(2, "There was a young lady of Riga\nWho smiled as she rode on a tiger\n"),
+		mk(3, "There was a young lady of Riga\nWho smiled as she rode on a tiger\n"),
+	}
+
+	tests := []cmdTestCase{{
+		name:   "compare notes with an earlier revision",
+		cmd:    "get notes the-limerick --compare 1",
+		golden: "output/get-notes-compare.txt",
+		rels:   releases,
+	}, {
+		name:   "compare notes of two revisions",
+		cmd:    "get notes the-limerick --revision 1 --compare 2",
+		golden: "output/get-notes-compare-reverse.txt",
+		rels:   releases,
+	}, {
+		name:   "compare unchanged notes",
+		cmd:    "get notes the-limerick --revision 3 --compare 2",
+		golden: "output/get-notes-compare-same.txt",
+		rels:   releases,
+	}, {
+		name:      "compare notes with a missing revision",
+		cmd:       "get notes the-limerick --compare 4",
+		golden:    "output/get-notes-compare-missing.txt",
+		rels:      releases,
+		wantError: true,
+	}}
+	runTestCmd(t, tests)
+}
+
+func TestGetNotesCompareCompletion(t *testing.T) {
+	releases := []*release.Release{
+		release.Mock(&release.MockReleaseOptions{Name: "musketeers", Version: 11, Status: release.StatusDeployed}),
+		release.Mock(&release.MockReleaseOptions{Name: "musketeers", Version: 10, Status: release.StatusSuperseded}),
+		release.Mock(&release.MockReleaseOptions{Name: "musketeers", Version: 9, Status: release.StatusSuperseded}),
+		release.Mock(&release.MockReleaseOptions{Name: "musketeers", Version: 8, Status: release.StatusSuperseded}),
+	}
+	runTestCmd(t, []cmdTestCase{{
+		name:   "completion for compare flag",
+		cmd:    "__complete get notes musketeers --compare ''",
+		rels:   releases,
+		golden: "output/revision-comp.txt",
+	}})
+}
+
 func TestGetNotesRevisionCompletion(t *testing.T) {
 	revisionFlagCompletionTest(t, "get notes")
 }
@@ -19,7 +19,9 @@ package main
 import (
 	"fmt"
 	"io"
+	"sort"
 
+	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 
 	"helm.sh/helm/v3/cmd/helm/require"
@@ -30,15 +32,24 @@ import (
 
 var getValuesHelp = `
 This command downloads a values file for a given release.
+
+With '--origins', it also shows where each user-supplied value came from: the
+values file that last changed it, or the flag that set it, as recorded when
+the release was installed or upgraded. Values reused from an earlier release
+keep their origin.
 `
 
 type valuesWriter struct {
 	vals      map[string]interface{}
 	allValues bool
+	// sources are the origins of the values, if requested.
+	sources map[string]string
+	origins bool
 }
 
 func newGetValuesCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	var outfmt output.Format
+	var origins bool
 	client := action.NewGetValues(cfg)
 
 	cmd := &cobra.Command{
@@ -47,11 +58,11 @@ func newGetValuesCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 		Long:  getValuesHelp,
 		Args:  require.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			vals, err := client.Run(args[0])
+			vals, sources, err := client.RunWithSources(args[0])
 			if err != nil {
 				return err
 			}
-			return outfmt.Write(out, &valuesWriter{vals, client.AllValues})
+			return outfmt.Write(out, &valuesWriter{vals, client.AllValues, sources, origins})
 		},
 	}
 
@@ -73,6 +84,7 @@ func newGetValuesCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 		return nil, completion.BashCompDirectiveNoFileComp
 	})
 	f.BoolVarP(&client.AllValues, "all", "a", false, "dump all (computed) values")
+	f.BoolVar(&origins, "origins", false, "show the values file or flag each user-supplied value came from")
 	bindOutputFlag(cmd, &outfmt)
 
 	return cmd
@@ -84,13 +96,36 @@ func (v valuesWriter) WriteTable(out io.Writer) error {
 	} else {
 		fmt.Fprintln(out, "USER-SUPPLIED VALUES:")
 	}
-	return output.EncodeYAML(out, v.vals)
+	if err := output.EncodeYAML(out, v.vals); err != nil || !v.origins {
+		return err
+	}
+
+	keys := make([]string, 0, len(v.sources))
+	for k := range v.sources {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fmt.Fprintln(out, "VALUE ORIGINS:")
+	table := uitable.New()
+	table.AddRow("KEY", "SOURCE")
+	for _, k := range keys {
+		table.AddRow(k, v.sources[k])
+	}
+	return output.EncodeTable(out, table)
 }
 
 func (v valuesWriter) WriteJSON(out io.Writer) error {
-	return output.EncodeJSON(out, v.vals)
+	return output.EncodeJSON(out, v.data())
 }
 
 func (v valuesWriter) WriteYAML(out io.Writer) error {
-	return output.EncodeYAML(out, v.vals)
+	return output.EncodeYAML(out, v.data())
+}
+
+// data returns the values, along with their origins if requested.
+func (v valuesWriter) data() interface{} {
+	if !v.origins {
+		return v.vals
+	}
+	return &mergedValuesWriter{Values: v.vals, Sources: v.sources}
 }
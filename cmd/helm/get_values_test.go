@@ -23,6 +23,9 @@ import (
 )
 
 func TestGetValuesCmd(t *testing.T) {
+	withSources := release.Mock(&release.MockReleaseOptions{Name: "thomas-guide"})
+	withSources.ValueSources = map[string]string{"name": "values-prod.yaml"}
+
 	tests := []cmdTestCase{{
 		name:   "get values with a release",
 		cmd:    "get values thomas-guide",
@@ -49,6 +52,16 @@ func TestGetValuesCmd(t *testing.T) {
 		cmd:    "get values thomas-guide --output yaml",
 		golden: "output/values.yaml",
 		rels:   []*release.Release{release.Mock(&release.MockReleaseOptions{Name: "thomas-guide"})},
+	}, {
+		name:   "get values with origins",
+		cmd:    "get values thomas-guide --origins",
+		golden: "output/get-values-origins.txt",
+		rels:   []*release.Release{withSources},
+	}, {
+		name:   "get values with origins to json",
+		cmd:    "get values thomas-guide --origins --output json",
+		golden: "output/get-values-origins.json",
+		rels:   []*release.Release{withSources},
 	}}
 	runTestCmd(t, tests)
 }
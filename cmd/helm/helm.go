@@ -31,8 +31,12 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/audit"
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/gates"
+	"helm.sh/helm/v3/pkg/kube"
+	"helm.sh/helm/v3/pkg/logging"
+	"helm.sh/helm/v3/pkg/transfer"
 )
 
 // FeatureGateOCI is the feature gate for checking if `helm chart` and `helm registry` commands should work
@@ -40,8 +44,25 @@ const FeatureGateOCI = gates.Gate("HELM_EXPERIMENTAL_OCI")
 
 var (
 	settings = cli.New()
+
+	// logger logs the warnings and errors of the SDK to stderr, and its
+	// other structured logs as debug messages
+	logger logging.Logger = cliLogger{
+		Logger: logging.FromFunc(debug),
+		warn:   logging.New(os.Stderr, logging.LevelWarn),
+	}
 )
 
+// cliLogger logs debug and info messages to the embedded Logger, and warnings
+// and errors to warn.
+type cliLogger struct {
+	logging.Logger
+	warn logging.Logger
+}
+
+func (l cliLogger) Warn(msg string, keyvals ...interface{})  { l.warn.Warn(msg, keyvals...) }
+func (l cliLogger) Error(msg string, keyvals ...interface{}) { l.warn.Error(msg, keyvals...) }
+
 func init() {
 	log.SetFlags(log.Lshortfile)
 }
@@ -64,10 +85,28 @@ func initKubeLogs() {
 func main() {
 	initKubeLogs()
 
+	// Downloads share a pool limited by the environment.
+	transfer.SetDefault(transfer.NewPool(settings.TransferLimits()))
+	// Requests are audited to the file set in the environment.
+	if settings.AuditLog != "" {
+		f, err := os.OpenFile(settings.AuditLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		audit.SetDefault(audit.NewJSONAuditor(f))
+	}
+
 	actionConfig := new(action.Configuration)
+	actionConfig.Logger = logger
+	actionConfig.WaitEvents = func(e kube.WaitEvent) {
+		fmt.Fprintf(os.Stderr, "WARNING: %s\n", e)
+	}
 	cmd := newRootCmd(actionConfig, os.Stdout, os.Args[1:])
 
-	if err := actionConfig.Init(settings.RESTClientGetter(), settings.Namespace(), os.Getenv("HELM_DRIVER"), debug); err != nil {
+	helmDriver, externalCommand := storageDriver()
+	actionConfig.ExternalDriverCommand = externalCommand
+	if err := actionConfig.Init(settings.RESTClientGetter(), settings.Namespace(), helmDriver, debug); err != nil {
 		log.Fatal(err)
 	}
 
@@ -17,7 +17,6 @@ limitations under the License.
 package main
 
 import (
-	"fmt"
 	"io"
 	"time"
 
@@ -34,6 +33,7 @@ import (
 	"helm.sh/helm/v3/pkg/cli/values"
 	"helm.sh/helm/v3/pkg/downloader"
 	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/release"
 )
 
@@ -74,20 +74,45 @@ set for a key called 'foo', the 'newbar' value would take precedence:
 
     $ helm install --set foo=bar --set foo=newbar  myredis ./redis
 
+With '--interactive', Helm walks the values schema of the chart and asks for
+each value not set with '--values' or '--set', showing its description, default
+and allowed values. The answers are written to a values file, RELEASE_NAME-values.yaml
+unless '--interactive-values-file' is set, to be passed with '--values' next time:
+
+    $ helm install --interactive myredis ./redis
+
 
 To check the generated manifests of a release without installing the chart,
 the '--debug' and '--dry-run' flags can be combined.
 
+Resources are installed in the namespace given in their manifest, or else in
+the namespace of the release. The release tracks all of these namespaces and
+its resources are deleted from them on uninstall. To restrict the namespaces a
+chart may reach into, use '--allowed-namespaces':
+
+    $ helm install --allowed-namespaces monitoring,team-* platform ./platform
+
+With '--create-namespace', the namespace of the release is created if it does
+not exist, with the labels and annotations of the 'namespace' section of
+Chart.yaml and those of '--namespace-labels' and '--namespace-annotations'. An
+existing namespace is left alone, unless '--adopt-namespace' is set to add the
+labels and annotations to it. The release records whether it manages its
+namespace, so that 'helm uninstall --delete-namespace' can delete it:
+
+    $ helm install --create-namespace --namespace-labels team=payments -n payments pay ./pay
+
 If --verify is set, the chart MUST have a provenance file, and the provenance
 file MUST pass all verification steps.
 
-There are five different ways you can express the chart you want to install:
+There are six different ways you can express the chart you want to install:
 
 1. By chart reference: helm install mymaria example/mariadb
 2. By path to a packaged chart: helm install mynginx ./nginx-1.2.3.tgz
 3. By path to an unpacked chart directory: helm install mynginx ./nginx
 4. By absolute URL: helm install mynginx https://example.com/charts/nginx-1.2.3.tgz
 5. By chart reference and repo url: helm install --repo https://example.com/charts/ mynginx nginx
+6. By OCI registry reference: helm install mynginx oci://example.com/charts/nginx --version 1.2.3
+   (requires HELM_EXPERIMENTAL_OCI to be set)
 
 CHART REFERENCES
 
@@ -107,6 +132,7 @@ func newInstallCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	client := action.NewInstall(cfg)
 	valueOpts := &values.Options{}
 	var outfmt output.Format
+	var interactive interactiveOptions
 
 	cmd := &cobra.Command{
 		Use:   "install [NAME] [CHART]",
@@ -114,8 +140,11 @@ func newInstallCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 		Long:  installDesc,
 		Args:  require.MinimumNArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
-			rel, err := runInstall(args, client, valueOpts, out)
+			rel, err := runInstall(args, client, valueOpts, &interactive, out)
 			if err != nil {
+				if client.DryRun {
+					return reportPolicyViolations(out, outfmt, err)
+				}
 				return err
 			}
 
@@ -129,8 +158,11 @@ func newInstallCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	})
 
 	addInstallFlags(cmd.Flags(), client, valueOpts)
+	addInteractiveFlags(cmd.Flags(), &interactive)
 	bindOutputFlag(cmd, &outfmt)
 	bindPostRenderFlag(cmd, &client.PostRenderer)
+	bindResolveValuesFlag(cmd, &client.ValuesResolver)
+	bindPolicyFlag(cmd, &client.Policy)
 
 	return cmd
 }
@@ -147,14 +179,27 @@ func addInstallFlags(f *pflag.FlagSet, client *action.Install, valueOpts *values
 	f.BoolVar(&client.Devel, "devel", false, "use development versions, too. Equivalent to version '>0.0.0-0'. If --version is set, this is ignored")
 	f.BoolVar(&client.DependencyUpdate, "dependency-update", false, "run helm dependency update before installing the chart")
 	f.BoolVar(&client.DisableOpenAPIValidation, "disable-openapi-validation", false, "if set, the installation process will not validate rendered templates against the Kubernetes OpenAPI Schema")
+	f.StringSliceVar(&client.KindOrder, "kind-order", []string{}, "kinds to install before all others, in order (can specify multiple or separate values with commas: CustomResourceDefinition,MyOperator)")
+	f.StringToStringVar(&client.Labels, "labels", nil, "labels to attach to the release, such as the owning team (can specify multiple or separate values with commas: team=payments,ticket=OPS-123)")
+	f.BoolVar(&client.CreateNamespace, "create-namespace", false, "create the release namespace if not present")
+	f.StringToStringVar(&client.NamespaceLabels, "namespace-labels", nil, "labels to set on the namespace created by --create-namespace, overriding those of the chart (can specify multiple or separate values with commas: team=payments,env=prod)")
+	f.StringToStringVar(&client.NamespaceAnnotations, "namespace-annotations", nil, "annotations to set on the namespace created by --create-namespace, overriding those of the chart (can specify multiple or separate values with commas: owner=payments@example.com)")
+	f.BoolVar(&client.AdoptNamespace, "adopt-namespace", false, "with --create-namespace, add the labels and annotations to an existing release namespace. It is not deleted with the release")
+	f.StringSliceVar(&client.AllowedNamespaces, "allowed-namespaces", []string{}, "namespaces, other than the release namespace, the release may manage resources in. Accepts glob patterns. By default resources may be in any namespace (can specify multiple or separate values with commas: monitoring,team-*)")
+	f.BoolVar(&client.ValidateManifests, "validate-manifests", false, "validate each rendered manifest against the Kubernetes OpenAPI Schema before installing, reporting all unknown fields and type errors")
+	f.StringVar(&client.SchemaFile, "openapi-schema", "", "path to an OpenAPI v2 schema used by --validate-manifests when the cluster schema is unavailable, instead of the bundled Kubernetes schema")
+	addServerSideApplyFlags(f, &client.ServerSideApplyOptions)
+	addApplyRetryFlags(f, &client.ApplyRetryOptions)
 	f.BoolVar(&client.Atomic, "atomic", false, "if set, installation process purges chart on fail. The --wait flag will be set automatically if --atomic is used")
 	f.BoolVar(&client.SkipCRDs, "skip-crds", false, "if set, no CRDs will be installed. By default, CRDs are installed if not already present")
 	f.BoolVar(&client.SubNotes, "render-subchart-notes", false, "if set, render subchart notes along with the parent")
 	addValueOptionsFlags(f, valueOpts)
 	addChartPathOptionsFlags(f, &client.ChartPathOptions)
+	f.BoolVar(&client.FailOnDeprecated, "fail-on-deprecated", false, "refuse deprecated charts instead of warning about them")
+	f.StringVar(&client.VerificationPolicy, "verification-policy", settings.VerificationPolicy, "refuse charts that do not satisfy the verification policy in this file, checked before rendering")
 }
 
-func runInstall(args []string, client *action.Install, valueOpts *values.Options, out io.Writer) (*release.Release, error) {
+func runInstall(args []string, client *action.Install, valueOpts *values.Options, interactive *interactiveOptions, out io.Writer) (*release.Release, error) {
 	debug("Original chart version: %q", client.Version)
 	if client.Version == "" && client.Devel {
 		debug("setting version to >0.0.0-0")
@@ -167,6 +212,11 @@ func runInstall(args []string, client *action.Install, valueOpts *values.Options
 	}
 	client.ReleaseName = name
 
+	if registry.IsOCI(chart) && !FeatureGateOCI.IsEnabled() {
+		return nil, FeatureGateOCI.Error()
+	}
+
+	client.ChartPathOptions.Logger = logger
 	cp, err := client.ChartPathOptions.LocateChart(chart, settings)
 	if err != nil {
 		return nil, err
@@ -175,10 +225,11 @@ func runInstall(args []string, client *action.Install, valueOpts *values.Options
 	debug("CHART PATH: %s\n", cp)
 
 	p := getter.All(settings)
-	vals, err := valueOpts.MergeValues(p)
+	vals, sources, err := valueOpts.MergeValuesWithSources(p)
 	if err != nil {
 		return nil, err
 	}
+	client.ValueSources = sources
 
 	// Check chart dependencies to make sure all are present in /charts
 	chartRequested, err := loader.Load(cp)
@@ -191,10 +242,6 @@ func runInstall(args []string, client *action.Install, valueOpts *values.Options
 		return nil, err
 	}
 
-	if chartRequested.Metadata.Deprecated {
-		fmt.Fprintln(out, "WARNING: This chart is deprecated")
-	}
-
 	if req := chartRequested.Metadata.Dependencies; req != nil {
 		// If CheckDependencies returns an error, we have unfulfilled dependencies.
 		// As of Helm 2.4.0, this is treated as a stopping condition:
@@ -209,6 +256,9 @@ func runInstall(args []string, client *action.Install, valueOpts *values.Options
 					Getters:          p,
 					RepositoryConfig: settings.RepositoryConfig,
 					RepositoryCache:  settings.RepositoryCache,
+					ArchiveCache:     archiveCache(),
+					Logger:           logger,
+					VersionResolver:  client.ChartPathOptions.VersionResolver,
 				}
 				if err := man.Update(); err != nil {
 					return nil, err
@@ -219,6 +269,10 @@ func runInstall(args []string, client *action.Install, valueOpts *values.Options
 		}
 	}
 
+	if vals, err = interactive.promptValues(client.ReleaseName, chartRequested, vals); err != nil {
+		return nil, err
+	}
+
 	client.Namespace = settings.Namespace()
 	return client.Run(chartRequested, vals)
 }
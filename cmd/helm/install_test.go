@@ -105,6 +105,21 @@ func TestInstall(t *testing.T) {
 			name: "install with verification, valid",
 			cmd:  "install signtest testdata/testcharts/signtest-0.1.0.tgz --verify --keyring testdata/helm-test-key.pub",
 		},
+		// Install, enforcing a verification policy.
+		{
+			name:      "install with verification policy, unsigned",
+			cmd:       "install bogus testdata/testcharts/compressedchart-0.1.0.tgz --verification-policy testdata/verification-policy.yaml",
+			wantError: true,
+		},
+		{
+			name:      "install with verification policy, directory instead of file",
+			cmd:       "install bogus testdata/testcharts/signtest --verification-policy testdata/verification-policy.yaml",
+			wantError: true,
+		},
+		{
+			name: "install with verification policy, valid",
+			cmd:  "install signtest testdata/testcharts/signtest-0.1.0.tgz --verification-policy testdata/verification-policy.yaml",
+		},
 		// Install, chart with missing dependencies in /charts
 		{
 			name:      "install chart with missing dependencies",
@@ -189,6 +204,19 @@ func TestInstall(t *testing.T) {
 			cmd:    "install aeneas testdata/testcharts/deprecated --namespace default",
 			golden: "output/deprecated-chart.txt",
 		},
+		{
+			name:      "install refusing deprecated chart",
+			cmd:       "install aeneas testdata/testcharts/deprecated --namespace default --fail-on-deprecated",
+			golden:    "output/deprecated-chart-fail.txt",
+			wantError: true,
+		},
+		// Install interactively a chart without a values schema
+		{
+			name:      "install interactively without a values schema",
+			cmd:       "install aeneas testdata/testcharts/empty --interactive",
+			wantError: true,
+			golden:    "output/install-interactive-no-schema.txt",
+		},
 	}
 
 	runTestActionCmd(t, tests)
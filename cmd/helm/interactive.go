@@ -0,0 +1,77 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli/values"
+)
+
+// interactiveOptions are the options of install and upgrade for asking for
+// the values described by the values schema of the chart.
+type interactiveOptions struct {
+	enabled    bool
+	valuesFile string
+}
+
+func addInteractiveFlags(f *pflag.FlagSet, o *interactiveOptions) {
+	f.BoolVar(&o.enabled, "interactive", false, "ask for the values described by the values schema of the chart that are not set with --values or --set")
+	f.StringVar(&o.valuesFile, "interactive-values-file", "", "file the values answered with --interactive are written to (default \"RELEASE_NAME-values.yaml\")")
+}
+
+// promptValues asks for the values of ch not set in vals, writes the answers
+// to the values file and returns vals with the answers.
+//
+// Only the answers are written, so that values read from encrypted files are
+// not written in clear.
+func (o *interactiveOptions) promptValues(name string, ch *chart.Chart, vals map[string]interface{}) (map[string]interface{}, error) {
+	if o == nil || !o.enabled {
+		return vals, nil
+	}
+	if ch.Schema == nil {
+		return nil, errors.Errorf("chart %s has no values schema to ask for values with", ch.Name())
+	}
+
+	p := &values.Prompter{In: os.Stdin, Out: os.Stderr}
+	answers, err := p.Prompt(ch.Schema, ch.Values, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	file := o.valuesFile
+	if file == "" {
+		file = name + "-values.yaml"
+	}
+	data, err := yaml.Marshal(answers)
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(file, data, 0644); err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(os.Stderr, "Values written to %s, use them again with --values %s\n", file, file)
+	return chartutil.CoalesceTables(vals, answers), nil
+}
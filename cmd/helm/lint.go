@@ -27,6 +27,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/cli/values"
 	"helm.sh/helm/v3/pkg/getter"
 )
@@ -38,11 +39,23 @@ the chart is well-formed.
 If the linter encounters things that will cause the chart to fail installation,
 it will emit [ERROR] messages. If it encounters issues that break with convention
 or recommendation, it will emit [WARNING] messages.
+
+With '--kube-version', the chart is rendered for that version of Kubernetes, and
+resources using APIs removed in it are reported as errors, naming the API to
+use instead. Resources using APIs that are only deprecated are reported as
+warnings.
+
+With '--fix', the findings that can be corrected automatically are fixed in
+chart directories before they are linted, and each change is reported as a
+[FIXED] message: the chart name is set to the name of its directory, the
+version is normalized to SemVer 2, a commented icon field is added to fill in,
+and trailing whitespace is removed from templates.
 `
 
 func newLintCmd(out io.Writer) *cobra.Command {
 	client := action.NewLint()
 	valueOpts := &values.Options{}
+	var kubeVersion string
 
 	cmd := &cobra.Command{
 		Use:   "lint PATH",
@@ -69,6 +82,13 @@ func newLintCmd(out io.Writer) *cobra.Command {
 			}
 
 			client.Namespace = settings.Namespace()
+			if kubeVersion != "" {
+				kv, err := chartutil.ParseKubeVersion(kubeVersion)
+				if err != nil {
+					return err
+				}
+				client.KubeVersion = kv
+			}
 			vals, err := valueOpts.MergeValues(getter.All(settings))
 			if err != nil {
 				return err
@@ -92,6 +112,10 @@ func newLintCmd(out io.Writer) *cobra.Command {
 					}
 				}
 
+				for _, fix := range result.Fixes {
+					fmt.Fprintf(&message, "%s\n", fix)
+				}
+
 				for _, msg := range result.Messages {
 					fmt.Fprintf(&message, "%s\n", msg)
 				}
@@ -120,6 +144,8 @@ func newLintCmd(out io.Writer) *cobra.Command {
 	f := cmd.Flags()
 	f.BoolVar(&client.Strict, "strict", false, "fail on lint warnings")
 	f.BoolVar(&client.WithSubcharts, "with-subcharts", false, "lint dependent charts")
+	f.BoolVar(&client.Fix, "fix", false, "fix the findings that can be corrected automatically in chart directories")
+	f.StringVar(&kubeVersion, "kube-version", "", "Kubernetes version to lint for, reporting APIs deprecated or removed in it")
 	addValueOptionsFlags(f, valueOpts)
 
 	return cmd
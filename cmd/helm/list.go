@@ -19,7 +19,6 @@ package main
 import (
 	"fmt"
 	"io"
-	"os"
 	"strconv"
 
 	"github.com/gosuri/uitable"
@@ -50,6 +49,12 @@ Only items that match the filter will be returned.
     NAME                UPDATED                     CHART
     maudlin-arachnid    Mon May  9 16:07:08 2016    alpine-0.1.0
 
+Releases can also be selected by the labels attached to them with '--labels'
+on install and upgrade, using a Kubernetes label selector:
+
+    $ helm install --labels team=payments,ticket=OPS-123 checkout ./checkout
+    $ helm list --selector 'team=payments,ticket'
+
 If no results are found, 'helm list' will exit 0, but with no output (or in
 the case of no '-q' flag, only headers).
 
@@ -71,7 +76,9 @@ func newListCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 		Args:    require.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if client.AllNamespaces {
-				if err := cfg.Init(settings.RESTClientGetter(), "", os.Getenv("HELM_DRIVER"), debug); err != nil {
+				helmDriver, externalCommand := storageDriver()
+				cfg.ExternalDriverCommand = externalCommand
+				if err := cfg.Init(settings.RESTClientGetter(), "", helmDriver, debug); err != nil {
 					return err
 				}
 			}
@@ -108,19 +115,21 @@ func newListCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	f.IntVarP(&client.Limit, "max", "m", 256, "maximum number of releases to fetch")
 	f.IntVar(&client.Offset, "offset", 0, "next release name in the list, used to offset from start value")
 	f.StringVarP(&client.Filter, "filter", "f", "", "a regular expression (Perl compatible). Any releases that match the expression will be included in the results")
+	f.StringVarP(&client.Selector, "selector", "l", "", "selector (label query) to filter releases on, set with --labels on install and upgrade. Supports '=', '==', '!=', 'in', 'notin' and existence (e.g. -l team=payments,tier!=test)")
 	bindOutputFlag(cmd, &outfmt)
 
 	return cmd
 }
 
 type releaseElement struct {
-	Name       string `json:"name"`
-	Namespace  string `json:"namespace"`
-	Revision   string `json:"revision"`
-	Updated    string `json:"updated"`
-	Status     string `json:"status"`
-	Chart      string `json:"chart"`
-	AppVersion string `json:"app_version"`
+	Name       string            `json:"name"`
+	Namespace  string            `json:"namespace"`
+	Revision   string            `json:"revision"`
+	Updated    string            `json:"updated"`
+	Status     string            `json:"status"`
+	Chart      string            `json:"chart"`
+	AppVersion string            `json:"app_version"`
+	Labels     map[string]string `json:"labels,omitempty"`
 }
 
 type releaseListWriter struct {
@@ -138,6 +147,7 @@ func newReleaseListWriter(releases []*release.Release) *releaseListWriter {
 			Status:     r.Info.Status.String(),
 			Chart:      fmt.Sprintf("%s-%s", r.Chart.Metadata.Name, r.Chart.Metadata.Version),
 			AppVersion: r.Chart.Metadata.AppVersion,
+			Labels:     r.Labels,
 		}
 		t := "-"
 		if tspb := r.Info.LastDeployed; !tspb.IsZero() {
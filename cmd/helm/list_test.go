@@ -119,7 +119,8 @@ func TestListCmd(t *testing.T) {
 				LastDeployed: timestamp3,
 				Status:       release.StatusDeployed,
 			},
-			Chart: chartInfo,
+			Chart:  chartInfo,
+			Labels: map[string]string{"team": "birds"},
 		},
 		{
 			Name:      "iguana",
@@ -129,7 +130,8 @@ func TestListCmd(t *testing.T) {
 				LastDeployed: timestamp4,
 				Status:       release.StatusDeployed,
 			},
-			Chart: chartInfo,
+			Chart:  chartInfo,
+			Labels: map[string]string{"team": "reptiles", "tier": "test"},
 		},
 		{
 			Name:      "starlord",
@@ -218,6 +220,22 @@ func TestListCmd(t *testing.T) {
 		cmd:    "list -n milano",
 		golden: "output/list-namespace.txt",
 		rels:   releaseFixture,
+	}, {
+		name:   "list releases by label",
+		cmd:    "list --selector team -o json",
+		golden: "output/list-selector.json",
+		rels:   releaseFixture,
+	}, {
+		name:   "list releases by label value",
+		cmd:    "list -l 'team in (birds,fish),tier!=test'",
+		golden: "output/list-selector-value.txt",
+		rels:   releaseFixture,
+	}, {
+		name:      "list releases with an invalid selector",
+		cmd:       "list --selector 'team in ('",
+		golden:    "output/list-selector-invalid.txt",
+		rels:      releaseFixture,
+		wantError: true,
 	}}
 	runTestCmd(t, tests)
 }
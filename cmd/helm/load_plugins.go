@@ -22,11 +22,13 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/plugin"
 )
 
@@ -47,9 +49,9 @@ func loadPlugins(baseCmd *cobra.Command, out io.Writer) {
 		return
 	}
 
-	found, err := findPlugins(settings.PluginsDirectory)
+	found, err := installedPlugins()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to load plugins: %s", err)
+		fmt.Fprintf(os.Stderr, "failed to load plugins: %s\n", err)
 		return
 	}
 
@@ -180,3 +182,85 @@ func findPlugins(plugdirs string) ([]*plugin.Plugin, error) {
 	}
 	return found, nil
 }
+
+// pluginCache holds the plugins found in a plugins directory, so that it is
+// only scanned once.
+var pluginCache struct {
+	mu      sync.Mutex
+	loaded  bool
+	dir     string
+	plugins []*plugin.Plugin
+	err     error
+}
+
+// installedPlugins returns the plugins installed in the plugins directory,
+// scanning it only the first time.
+func installedPlugins() ([]*plugin.Plugin, error) {
+	pluginCache.mu.Lock()
+	defer pluginCache.mu.Unlock()
+	if !pluginCache.loaded || pluginCache.dir != settings.PluginsDirectory {
+		pluginCache.loaded = true
+		pluginCache.dir = settings.PluginsDirectory
+		pluginCache.plugins, pluginCache.err = findPlugins(settings.PluginsDirectory)
+	}
+	return pluginCache.plugins, pluginCache.err
+}
+
+// storageDriver returns the release storage driver named by HELM_DRIVER,
+// and the command the external driver runs.
+//
+// If it names a storage driver supplied by a plugin, the plugin environment
+// is set up and the external driver is returned, with the command of the
+// plugin.
+func storageDriver() (string, []string) {
+	name := os.Getenv("HELM_DRIVER")
+	switch name {
+	case "", "secret", "secrets", "configmap", "configmaps", "memory", "sqlite", "external":
+		return name, nil
+	}
+	if os.Getenv("HELM_NO_PLUGINS") == "1" {
+		return name, nil
+	}
+
+	found, err := installedPlugins()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load plugins: %s\n", err)
+		return name, nil
+	}
+	for _, plug := range found {
+		for _, d := range plug.Metadata.StorageDrivers {
+			if d.Name != name {
+				continue
+			}
+			plugin.SetupPluginEnv(settings, plug.Metadata.Name, plug.Dir)
+			command := strings.Fields(d.Command)
+			if len(command) == 0 {
+				return name, nil
+			}
+			command[0] = filepath.Join(plug.Dir, command[0])
+			return "external", command
+		}
+	}
+	return name, nil
+}
+
+// loadLifecycleHooks adds the release lifecycle hooks of plugins to cfg.
+func loadLifecycleHooks(cfg *action.Configuration) {
+	if os.Getenv("HELM_NO_PLUGINS") == "1" {
+		return
+	}
+
+	found, err := installedPlugins()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load plugins: %s\n", err)
+		return
+	}
+	for _, plug := range found {
+		for _, event := range lifecycleEvents {
+			if plug.Metadata.Hooks[event] != "" {
+				cfg.LifecycleHooks = append(cfg.LifecycleHooks, lifecycleHook(plug))
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"sort"
+
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+
+	"helm.sh/helm/v3/cmd/helm/require"
+	"helm.sh/helm/v3/pkg/cli/output"
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/getter"
+)
+
+var mergeValuesHelp = `
+This command merges values files and values set on the command line the way
+install, upgrade and template do, and shows where each merged value came from:
+the values file that last changed it, or the flag that set it.
+
+Use '-o yaml' or '-o json' to also print the merged values. The options
+controlling the merge, such as '--list-merge' and '--null-deletes', are the
+same as for the other commands.
+
+    $ helm merge-values -f values.yaml -f values-prod.yaml --set image.tag=1.2.3
+`
+
+func newMergeValuesCmd(out io.Writer) *cobra.Command {
+	valueOpts := &values.Options{}
+	var outfmt output.Format
+
+	cmd := &cobra.Command{
+		Use:   "merge-values",
+		Short: "merge values and show where each value came from",
+		Long:  mergeValuesHelp,
+		Args:  require.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vals, sources, err := valueOpts.MergeValuesWithSources(getter.All(settings))
+			if err != nil {
+				return err
+			}
+			return outfmt.Write(out, &mergedValuesWriter{Values: vals, Sources: sources})
+		},
+	}
+
+	addValueOptionsFlags(cmd.Flags(), valueOpts)
+	bindOutputFlag(cmd, &outfmt)
+	return cmd
+}
+
+type mergedValuesWriter struct {
+	Values  map[string]interface{} `json:"values"`
+	Sources map[string]string      `json:"sources"`
+}
+
+func (w *mergedValuesWriter) WriteTable(out io.Writer) error {
+	keys := make([]string, 0, len(w.Sources))
+	for k := range w.Sources {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	table := uitable.New()
+	table.AddRow("KEY", "SOURCE")
+	for _, k := range keys {
+		table.AddRow(k, w.Sources[k])
+	}
+	return output.EncodeTable(out, table)
+}
+
+func (w *mergedValuesWriter) WriteJSON(out io.Writer) error {
+	return output.EncodeJSON(out, w)
+}
+
+func (w *mergedValuesWriter) WriteYAML(out io.Writer) error {
+	return output.EncodeYAML(out, w)
+}
@@ -0,0 +1,39 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+)
+
+func TestMergeValuesCmd(t *testing.T) {
+	tests := []cmdTestCase{{
+		name:   "merge values",
+		cmd:    "merge-values -f testdata/values/base.yaml -f testdata/values/prod.yaml --set image.tag=1.2.3",
+		golden: "output/merge-values.txt",
+	}, {
+		name:   "merge values by key to yaml",
+		cmd:    "merge-values -f testdata/values/base.yaml -f testdata/values/prod.yaml --list-merge merge-by-key -o yaml",
+		golden: "output/merge-values.yaml",
+	}, {
+		name:      "merge values with an unknown list merge strategy",
+		cmd:       "merge-values -f testdata/values/base.yaml --list-merge zip",
+		golden:    "output/merge-values-unknown-strategy.txt",
+		wantError: true,
+	}}
+	runTestCmd(t, tests)
+}
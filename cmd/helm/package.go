@@ -30,6 +30,7 @@ import (
 	"helm.sh/helm/v3/pkg/cli/values"
 	"helm.sh/helm/v3/pkg/downloader"
 	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/sbom"
 )
 
 const packageDesc = `
@@ -46,11 +47,18 @@ provide '--keyring path/to/secret/keys' and '--key keyname'.
 
 If '--keyring' is not specified, Helm usually defaults to the public keyring
 unless your environment is otherwise configured.
+
+To describe a chart for supply-chain audits, use the '--sbom' flag. An SBOM
+listing its files, dependencies and container images is written next to the
+package, in the SPDX or CycloneDX format.
+
+  $ helm package --sbom spdx ./mychart
 `
 
 func newPackageCmd(out io.Writer) *cobra.Command {
 	client := action.NewPackage()
 	valueOpts := &values.Options{}
+	var sbomFormat string
 
 	cmd := &cobra.Command{
 		Use:   "package [CHART_PATH] [...]",
@@ -68,8 +76,16 @@ func newPackageCmd(out io.Writer) *cobra.Command {
 					return errors.New("--keyring is required for signing a package")
 				}
 			}
+			if sbomFormat != "" {
+				format, err := sbom.ParseFormat(sbomFormat)
+				if err != nil {
+					return err
+				}
+				client.SBOM = format
+			}
 			client.RepositoryConfig = settings.RepositoryConfig
 			client.RepositoryCache = settings.RepositoryCache
+			client.Out = out
 			p := getter.All(settings)
 			vals, err := valueOpts.MergeValues(p)
 			if err != nil {
@@ -91,9 +107,10 @@ func newPackageCmd(out io.Writer) *cobra.Command {
 						ChartPath:        path,
 						Keyring:          client.Keyring,
 						Getters:          p,
-						Debug:            settings.Debug,
+						Logger:           logger,
 						RepositoryConfig: settings.RepositoryConfig,
 						RepositoryCache:  settings.RepositoryCache,
+						ArchiveCache:     archiveCache(),
 					}
 
 					if err := downloadManager.Update(); err != nil {
@@ -117,6 +134,7 @@ func newPackageCmd(out io.Writer) *cobra.Command {
 	f.StringVar(&client.Version, "version", "", "set the version on the chart to this semver version")
 	f.StringVar(&client.AppVersion, "app-version", "", "set the appVersion on the chart to this version")
 	f.StringVarP(&client.Destination, "destination", "d", ".", "location to write the chart.")
+	f.StringVar(&sbomFormat, "sbom", "", "write an SBOM of the chart next to the package, in the given format (spdx, cyclonedx)")
 	f.BoolVarP(&client.DependencyUpdate, "dependency-update", "u", false, `update dependencies from "Chart.yaml" to dir "charts/" before packaging`)
 
 	return cmd
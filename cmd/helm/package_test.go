@@ -92,6 +92,27 @@ func TestPackage(t *testing.T) {
 			expect:  "",
 			hasfile: "alpine-0.1.0.tgz",
 		},
+		{
+			name:    "package --sbom spdx testdata/testcharts/alpine",
+			args:    []string{"testdata/testcharts/alpine"},
+			flags:   map[string]string{"sbom": "spdx"},
+			expect:  "",
+			hasfile: "alpine-0.1.0.spdx.json",
+		},
+		{
+			name:    "package --sbom cyclonedx testdata/testcharts/alpine",
+			args:    []string{"testdata/testcharts/alpine"},
+			flags:   map[string]string{"sbom": "cyclonedx"},
+			expect:  "",
+			hasfile: "alpine-0.1.0.cdx.json",
+		},
+		{
+			name:   "package --sbom with an unknown format",
+			args:   []string{"testdata/testcharts/alpine"},
+			flags:  map[string]string{"sbom": "swid"},
+			expect: "unknown SBOM format",
+			err:    true,
+		},
 		{
 			name:    "package testdata/testcharts/chart-missing-deps",
 			args:    []string{"testdata/testcharts/chart-missing-deps"},
@@ -16,6 +16,8 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"io"
 	"os"
 	"os/exec"
@@ -23,6 +25,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/plugin"
 )
 
@@ -45,6 +48,39 @@ func newPluginCmd(out io.Writer) *cobra.Command {
 	return cmd
 }
 
+// lifecycleEvents are the plugin hooks run for release lifecycle events.
+var lifecycleEvents = []string{plugin.PreRender, plugin.PostRender, plugin.PreApply, plugin.PostDeploy}
+
+// lifecycleHook returns the release lifecycle hook running the hooks of p.
+//
+// The hook command gets the action.LifecycleContext as JSON on its standard
+// input and HELM_LIFECYCLE_EVENT set to the event. Its output is written to
+// stderr, so it does not mix with the output of the command.
+func lifecycleHook(p *plugin.Plugin) action.LifecycleHook {
+	return func(ctx *action.LifecycleContext) error {
+		hook := p.Metadata.Hooks[string(ctx.Event)]
+		if hook == "" {
+			return nil
+		}
+		data, err := json.Marshal(ctx)
+		if err != nil {
+			return err
+		}
+
+		prog := exec.Command("sh", "-c", hook)
+		debug("running %s hook: %s", ctx.Event, prog)
+
+		plugin.SetupPluginEnv(settings, p.Metadata.Name, p.Dir)
+		prog.Env = append(os.Environ(), "HELM_LIFECYCLE_EVENT="+string(ctx.Event))
+		prog.Stdin = bytes.NewReader(data)
+		prog.Stdout, prog.Stderr = os.Stderr, os.Stderr
+		if err := prog.Run(); err != nil {
+			return errors.Errorf("plugin %s hook for %q exited with error", ctx.Event, p.Metadata.Name)
+		}
+		return nil
+	}
+}
+
 // runHook will execute a plugin hook.
 func runHook(p *plugin.Plugin, event string) error {
 	hook := p.Metadata.Hooks[event]
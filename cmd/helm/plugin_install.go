@@ -29,10 +29,24 @@ import (
 type pluginInstallOptions struct {
 	source  string
 	version string
+	verify  bool
+	keyring string
 }
 
 const pluginInstallDesc = `
-This command allows you to install a plugin from a url to a VCS repo or a local path.
+This command allows you to install a plugin from a url to a VCS repo, a local path
+or an OCI registry.
+
+Plugins in an OCI registry are referenced as oci://host/path/name:tag, optionally
+pinned to a manifest digest with @sha256:<digest>. Without a tag, the highest tag
+matching --version is installed. The registry may hold archives for several
+platforms, in which case the one for this platform is installed.
+
+Signed plugin archives are verified against the keyring, and refused if the
+verification fails. Archives served over HTTP are signed by a provenance file
+next to them, with the archive URL plus '.prov'. With --verify, unsigned
+plugins are refused too. Plugins from a local path or a VCS repo cannot be
+verified.
 `
 
 func newPluginInstallCmd(out io.Writer) *cobra.Command {
@@ -51,6 +65,8 @@ func newPluginInstallCmd(out io.Writer) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVar(&o.version, "version", "", "specify a version constraint. If this is not specified, the latest version is installed")
+	cmd.Flags().BoolVar(&o.verify, "verify", false, "refuse unsigned plugins. Signed plugins are always verified")
+	cmd.Flags().StringVar(&o.keyring, "keyring", defaultKeyring(), "location of public keys used for verification")
 	return cmd
 }
 
@@ -66,6 +82,9 @@ func (o *pluginInstallOptions) run(out io.Writer) error {
 	if err != nil {
 		return err
 	}
+	if err := installer.SetVerification(i, pluginVerification(o.verify, o.keyring)); err != nil {
+		return err
+	}
 	if err := installer.Install(i); err != nil {
 		return err
 	}
@@ -83,3 +102,13 @@ func (o *pluginInstallOptions) run(out io.Writer) error {
 	fmt.Fprintf(out, "Installed plugin: %s\n", p.Metadata.Name)
 	return nil
 }
+
+// pluginVerification returns how plugins are verified: signed plugins always
+// are, and unsigned plugins are refused if verify is set.
+func pluginVerification(verify bool, keyring string) installer.Verification {
+	v := installer.Verification{Strategy: installer.VerifyIfPossible, Keyring: keyring}
+	if verify {
+		v.Strategy = installer.VerifyAlways
+	}
+	return v
+}
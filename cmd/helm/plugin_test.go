@@ -16,13 +16,29 @@ limitations under the License.
 package main
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/openpgp/clearsign"
+
+	"helm.sh/helm/v3/internal/test/ensure"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/plugin"
+	"helm.sh/helm/v3/pkg/provenance"
 )
 
 func TestManuallyProcessArgs(t *testing.T) {
@@ -166,3 +182,170 @@ func TestLoadPlugins_HelmNoPlugins(t *testing.T) {
 		t.Fatalf("Expected 0 plugins, got %d", len(plugins))
 	}
 }
+
+func TestStorageDriverPlugin(t *testing.T) {
+	defer resetEnv()()
+	settings.PluginsDirectory = "testdata/helmhome/helm/plugins"
+	os.Unsetenv("HELM_NO_PLUGINS")
+
+	tests := []struct {
+		driver  string
+		expect  string
+		command []string
+	}{
+		{"", "", nil},
+		{"sqlite", "sqlite", nil},
+		{"unknown", "unknown", nil},
+		{"envstore", "external", []string{filepath.Join("testdata/helmhome/helm/plugins/env", "store.sh"), "--verbose"}},
+	}
+	for _, tt := range tests {
+		os.Setenv("HELM_DRIVER", tt.driver)
+		got, command := storageDriver()
+		if got != tt.expect {
+			t.Errorf("Expected driver %q for %q, got %q", tt.expect, tt.driver, got)
+		}
+		if !reflect.DeepEqual(command, tt.command) {
+			t.Errorf("Expected command %q for %q, got %q", tt.command, tt.driver, command)
+		}
+	}
+}
+
+func TestLifecycleHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin hooks run in sh")
+	}
+	defer resetEnv()()
+	dir := ensure.TempDir(t)
+	defer os.RemoveAll(dir)
+
+	p := &plugin.Plugin{
+		Dir: dir,
+		Metadata: &plugin.Metadata{
+			Name: "policy",
+			Hooks: plugin.Hooks{
+				plugin.PreApply:   `cat > "$HELM_PLUGIN_DIR/$HELM_LIFECYCLE_EVENT.json"`,
+				plugin.PostDeploy: "exit 1",
+			},
+		},
+	}
+	hook := lifecycleHook(p)
+
+	ctx := &action.LifecycleContext{Event: action.LifecyclePreApply, Action: "install", Name: "angry-panda"}
+	if err := hook(ctx); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, "pre-apply.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got action.LifecycleContext
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "angry-panda" || got.Action != "install" {
+		t.Errorf("unexpected context %+v", got)
+	}
+
+	// Events without a hook are ignored.
+	if err := hook(&action.LifecycleContext{Event: action.LifecyclePreRender}); err != nil {
+		t.Errorf("expected no error without a hook, got %s", err)
+	}
+
+	if err := hook(&action.LifecycleContext{Event: action.LifecyclePostDeploy}); err == nil {
+		t.Error("expected an error from a failing hook")
+	}
+}
+
+// pluginArchive returns a plugin archive holding the plugin.yaml of the
+// plugin named name.
+func pluginArchive(t *testing.T, name string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	metadata := []byte("name: " + name + "\nversion: 0.1.0\n")
+	if err := tw.WriteHeader(&tar.Header{Name: "plugin.yaml", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(metadata))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(metadata); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// signPluginArchive returns the provenance of the plugin archive named name,
+// signed with the test key.
+func signPluginArchive(t *testing.T, name string, archive []byte) []byte {
+	t.Helper()
+	signer, err := provenance.NewFromFiles("testdata/helm-test-key.secret", "testdata/helm-test-key.pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	w, err := clearsign.Encode(&buf, signer.Entity.PrivateKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprintf(w, "name: %s\n\n...\nfiles:\n  %s: sha256:%x\n", strings.TrimSuffix(name, ".tgz"), name, sha256.Sum256(archive))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestPluginInstallVerification(t *testing.T) {
+	const name = "signed-0.1.0.tgz"
+	archive := pluginArchive(t, "signed")
+	prov := signPluginArchive(t, name, archive)
+
+	tests := []struct {
+		name    string
+		archive []byte
+		prov    []byte
+		flags   string
+		wantErr string
+	}{
+		{name: "signed", archive: archive, prov: prov},
+		{name: "tampered", archive: pluginArchive(t, "tampered"), prov: prov, wantErr: "failed to verify plugin signed-0.1.0.tgz"},
+		{name: "unsigned", archive: archive},
+		{name: "unsigned with --verify", archive: archive, flags: "--verify", wantErr: "plugin is not signed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer ensure.HelmHome(t)()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/"+name:
+					w.Write(tt.archive)
+				case r.URL.Path == "/"+name+".prov" && tt.prov != nil:
+					w.Write(tt.prov)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer srv.Close()
+
+			cmd := fmt.Sprintf("plugin install %s/%s --keyring testdata/helm-test-key.pub %s", srv.URL, name, tt.flags)
+			_, out, err := executeActionCommand(cmd)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected the plugin to be installed, got %v", err)
+				}
+				if !strings.Contains(out, "Installed plugin: signed") {
+					t.Errorf("expected the plugin to be installed, got %q", out)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -30,7 +30,9 @@ import (
 )
 
 type pluginUpdateOptions struct {
-	names []string
+	names   []string
+	verify  bool
+	keyring string
 }
 
 func newPluginUpdateCmd(out io.Writer) *cobra.Command {
@@ -56,6 +58,8 @@ func newPluginUpdateCmd(out io.Writer) *cobra.Command {
 		return compListPlugins(toComplete), completion.BashCompDirectiveNoFileComp
 	})
 
+	cmd.Flags().BoolVar(&o.verify, "verify", false, "refuse unsigned plugins. Signed plugins are always verified")
+	cmd.Flags().StringVar(&o.keyring, "keyring", defaultKeyring(), "location of public keys used for verification")
 	return cmd
 }
 
@@ -78,7 +82,7 @@ func (o *pluginUpdateOptions) run(out io.Writer) error {
 
 	for _, name := range o.names {
 		if found := findPlugin(plugins, name); found != nil {
-			if err := updatePlugin(found); err != nil {
+			if err := updatePlugin(found, pluginVerification(o.verify, o.keyring)); err != nil {
 				errorPlugins = append(errorPlugins, fmt.Sprintf("Failed to update plugin %s, got error (%v)", name, err))
 			} else {
 				fmt.Fprintf(out, "Updated plugin: %s\n", name)
@@ -93,7 +97,7 @@ func (o *pluginUpdateOptions) run(out io.Writer) error {
 	return nil
 }
 
-func updatePlugin(p *plugin.Plugin) error {
+func updatePlugin(p *plugin.Plugin, verification installer.Verification) error {
 	exactLocation, err := filepath.EvalSymlinks(p.Dir)
 	if err != nil {
 		return err
@@ -107,6 +111,9 @@ func updatePlugin(p *plugin.Plugin) error {
 	if err != nil {
 		return err
 	}
+	if err := installer.SetVerification(i, verification); err != nil {
+		return err
+	}
 	if err := installer.Update(i); err != nil {
 		return err
 	}
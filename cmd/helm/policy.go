@@ -0,0 +1,97 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gosuri/uitable"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"helm.sh/helm/v3/pkg/cli/output"
+	"helm.sh/helm/v3/pkg/policy"
+)
+
+const policyFlag = "policy"
+
+func bindPolicyFlag(cmd *cobra.Command, varRef *policy.Evaluator) {
+	cmd.Flags().Var(&policyValue{target: varRef}, policyFlag, "a policy bundle, a Rego or CEL file or a directory of policy files, the rendered manifests must not violate (can specify multiple)")
+}
+
+type policyValue struct {
+	target     *policy.Evaluator
+	paths      []string
+	evaluators policy.Evaluators
+}
+
+func (p *policyValue) String() string {
+	return strings.Join(p.paths, ",")
+}
+
+func (p *policyValue) Type() string {
+	return "stringArray"
+}
+
+func (p *policyValue) Set(s string) error {
+	e, err := policy.Load(s)
+	if err != nil {
+		return err
+	}
+	p.paths = append(p.paths, s)
+	p.evaluators = append(p.evaluators, e)
+	*p.target = p.evaluators
+	return nil
+}
+
+// reportPolicyViolations writes the policy violations failing a dry run in
+// the output format, so that they can be processed, and returns the error
+// failing the command.
+func reportPolicyViolations(out io.Writer, outfmt output.Format, err error) error {
+	perr, ok := errors.Cause(err).(*policy.Error)
+	if !ok {
+		return err
+	}
+	if werr := outfmt.Write(out, &policyViolationsWriter{perr.Violations}); werr != nil {
+		return werr
+	}
+	return errors.New("rendered manifests violate policies")
+}
+
+type policyViolationsWriter struct {
+	violations []policy.Violation
+}
+
+func (w *policyViolationsWriter) WriteTable(out io.Writer) error {
+	fmt.Fprintln(out, "POLICY VIOLATIONS:")
+	table := uitable.New()
+	table.AddRow("POLICY", "SOURCE", "KIND", "NAME", "MESSAGE")
+	for _, v := range w.violations {
+		table.AddRow(v.Policy, v.Source, v.Kind, v.Name, v.Message)
+	}
+	return output.EncodeTable(out, table)
+}
+
+func (w *policyViolationsWriter) WriteJSON(out io.Writer) error {
+	return output.EncodeJSON(out, w.violations)
+}
+
+func (w *policyViolationsWriter) WriteYAML(out io.Writer) error {
+	return output.EncodeYAML(out, w.violations)
+}
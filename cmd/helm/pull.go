@@ -59,6 +59,7 @@ func newPullCmd(out io.Writer) *cobra.Command {
 			}
 
 			for i := 0; i < len(args); i++ {
+				client.Logger = logger
 				output, err := client.Run(args[i])
 				if err != nil {
 					return err
@@ -84,6 +85,7 @@ func newPullCmd(out io.Writer) *cobra.Command {
 	f.StringVar(&client.UntarDir, "untardir", ".", "if untar is specified, this flag specifies the name of the directory into which the chart is expanded")
 	f.StringVarP(&client.DestDir, "destination", "d", ".", "location to write the chart. If this and tardir are specified, tardir is appended to this")
 	addChartPathOptionsFlags(f, &client.ChartPathOptions)
+	f.BoolVar(&client.FailOnDeprecated, "fail-on-deprecated", false, "refuse deprecated charts instead of warning about them")
 
 	return cmd
 }
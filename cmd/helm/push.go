@@ -0,0 +1,83 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"helm.sh/helm/v3/cmd/helm/require"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/sbom"
+)
+
+const pushDesc = `
+Push a chart to an OCI registry.
+
+The chart may be a packaged chart or a chart directory, which is packaged
+first. A packaged chart is pushed along with its provenance file, if there is
+one next to it, and a chart directory is signed when packaged with --sign.
+With --sbom, an SBOM describing the files, dependencies and images of the
+chart is attached to it in the registry.
+
+The reference is of the form oci://host/path/name[:tag]. Without a tag, the
+chart version is used. The digest of the pushed chart is printed, so it can be
+pinned by later installs.
+`
+
+func newPushCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
+	client := action.NewPush(cfg)
+	var sbomFormat string
+
+	cmd := &cobra.Command{
+		Use:               "push [CHART] [REF]",
+		Short:             "push a chart and its provenance to a registry",
+		Long:              pushDesc,
+		Args:              require.ExactArgs(2),
+		Hidden:            !FeatureGateOCI.IsEnabled(),
+		PersistentPreRunE: checkOCIFeatureGate(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if client.Sign && client.Key == "" {
+				return errors.New("--key is required for signing a package")
+			}
+			if sbomFormat != "" {
+				format, err := sbom.ParseFormat(sbomFormat)
+				if err != nil {
+					return err
+				}
+				client.SBOM = format
+			}
+			digest, err := client.Run(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Digest: %s\n", digest)
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.BoolVar(&client.Sign, "sign", false, "use a PGP private key to sign a chart directory when packaging it")
+	f.StringVar(&client.Key, "key", "", "name of the key to use when signing. Used if --sign is true")
+	f.StringVar(&client.Keyring, "keyring", defaultKeyring(), "location of a public keyring")
+	f.StringVar(&sbomFormat, "sbom", "", "attach an SBOM of the chart to it, in the given format (spdx, cyclonedx)")
+
+	return cmd
+}
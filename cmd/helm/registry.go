@@ -30,7 +30,7 @@ This command consists of multiple subcommands to interact with registries.
 func newRegistryCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "registry",
-		Short:             "login to or logout from a registry",
+		Short:             "login to, logout from, or list registries",
 		Long:              registryHelp,
 		Hidden:            !FeatureGateOCI.IsEnabled(),
 		PersistentPreRunE: checkOCIFeatureGate(),
@@ -38,6 +38,7 @@ func newRegistryCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	cmd.AddCommand(
 		newRegistryLoginCmd(cfg, out),
 		newRegistryLogoutCmd(cfg, out),
+		newRegistryListCmd(cfg, out),
 	)
 	return cmd
 }
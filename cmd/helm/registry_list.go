@@ -0,0 +1,94 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"time"
+
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+
+	"helm.sh/helm/v3/cmd/helm/require"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/cli/output"
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+const registryListDesc = `
+List the registries with stored credentials.
+
+The source of the credentials is the credentials file, or the credentials
+store or credential helper that keeps them. The expiry is shown for tokens
+that carry one.
+`
+
+func newRegistryListCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
+	var outfmt output.Format
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "list registries with stored credentials",
+		Long:    registryListDesc,
+		Args:    require.NoArgs,
+		Hidden:  !FeatureGateOCI.IsEnabled(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logins, err := action.NewRegistryList(cfg).Run()
+			if err != nil {
+				return err
+			}
+			return outfmt.Write(out, &registryListWriter{logins})
+		},
+	}
+
+	bindOutputFlag(cmd, &outfmt)
+
+	return cmd
+}
+
+type registryListWriter struct {
+	logins []*registry.Login
+}
+
+func (r *registryListWriter) WriteTable(out io.Writer) error {
+	table := uitable.New()
+	table.AddRow("HOST", "USERNAME", "SOURCE", "EXPIRES")
+	for _, l := range r.logins {
+		expires := ""
+		if l.Expiry != nil {
+			expires = l.Expiry.Format(time.RFC3339)
+		}
+		table.AddRow(l.Host, l.Username, l.Source, expires)
+	}
+	return output.EncodeTable(out, table)
+}
+
+func (r *registryListWriter) WriteJSON(out io.Writer) error {
+	return output.EncodeJSON(out, r.list())
+}
+
+func (r *registryListWriter) WriteYAML(out io.Writer) error {
+	return output.EncodeYAML(out, r.list())
+}
+
+// list returns the logins, with an empty array instead of null for none
+func (r *registryListWriter) list() []*registry.Login {
+	if r.logins == nil {
+		return []*registry.Login{}
+	}
+	return r.logins
+}
@@ -27,18 +27,31 @@ import (
 
 const registryLogoutDesc = `
 Remove credentials stored for a remote registry.
+
+Use '--all' to remove the credentials stored for every registry.
 `
 
 func newRegistryLogoutCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
-	return &cobra.Command{
+	var all bool
+	cmd := &cobra.Command{
 		Use:    "logout [host]",
 		Short:  "logout from a registry",
 		Long:   registryLogoutDesc,
-		Args:   require.MinimumNArgs(1),
 		Hidden: !FeatureGateOCI.IsEnabled(),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if all {
+				return require.NoArgs(cmd, args)
+			}
+			return require.MinimumNArgs(1)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if all {
+				return action.NewRegistryLogout(cfg).RunAll(out)
+			}
 			hostname := args[0]
 			return action.NewRegistryLogout(cfg).Run(out, hostname)
 		},
 	}
+	cmd.Flags().BoolVar(&all, "all", false, "logout from every registry with stored credentials")
+	return cmd
 }
@@ -0,0 +1,50 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"helm.sh/helm/v3/cmd/helm/require"
+	"helm.sh/helm/v3/pkg/action"
+)
+
+const releaseHelp = `
+This command consists of multiple subcommands to manage release records.
+
+It can be used to export the histories of releases to an archive, and to import
+them into another cluster or storage driver, to push rendered releases to an
+OCI registry, and to re-encrypt stored releases after a key rotation.
+`
+
+func newReleaseCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "release export|import|push|reencrypt [ARGS]",
+		Short: "manage release records",
+		Long:  releaseHelp,
+		Args:  require.NoArgs,
+	}
+
+	cmd.AddCommand(newReleaseExportCmd(cfg, out))
+	cmd.AddCommand(newReleaseImportCmd(cfg, out))
+	cmd.AddCommand(newReleasePushCmd(cfg, out))
+	cmd.AddCommand(newReleaseReencryptCmd(cfg, out))
+
+	return cmd
+}
@@ -0,0 +1,84 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"helm.sh/helm/v3/internal/completion"
+	"helm.sh/helm/v3/pkg/action"
+)
+
+const releaseExportDesc = `
+This command writes the complete histories of releases to an archive.
+
+Every revision of the named releases, or of all releases in the namespace with
+'--all', is saved along with its status, so that the releases can be restored
+with 'helm release import' for disaster recovery or to move them to another
+cluster or storage driver.
+
+    $ helm release export myrelease --file myrelease.tgz
+`
+
+func newReleaseExportCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
+	client := action.NewReleaseExport(cfg)
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "export [RELEASE_NAME...]",
+		Short: "export release histories to an archive",
+		Long:  releaseExportDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if file == "" {
+				return errors.New("an archive file must be given with --file")
+			}
+			if client.All == (len(args) > 0) {
+				return errors.New("either release names or --all must be given")
+			}
+			f, err := os.Create(file)
+			if err != nil {
+				return errors.Wrap(err, "unable to create release archive")
+			}
+			rels, err := client.Run(f, args...)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				os.Remove(file)
+				return err
+			}
+			fmt.Fprintf(out, "Exported %d revision(s) to %s\n", len(rels), file)
+			return nil
+		},
+	}
+
+	// Function providing dynamic auto-completion
+	completion.RegisterValidArgsFunc(cmd, func(cmd *cobra.Command, args []string, toComplete string) ([]string, completion.BashCompDirective) {
+		return compListReleases(toComplete, cfg)
+	})
+
+	f := cmd.Flags()
+	f.StringVarP(&file, "file", "f", "", "the archive file to write")
+	f.BoolVar(&client.All, "all", false, "export all releases in the namespace")
+
+	return cmd
+}
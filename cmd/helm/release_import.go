@@ -0,0 +1,70 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"helm.sh/helm/v3/cmd/helm/require"
+	"helm.sh/helm/v3/pkg/action"
+)
+
+const releaseImportDesc = `
+This command restores the release histories saved by 'helm release export'.
+
+Every revision in the archive is stored in the current namespace with its
+original version and status. The resources of the releases are not created;
+this only restores the records Helm keeps about them. Revisions that already
+exist are only replaced with '--force'.
+
+    $ helm release import myrelease.tgz
+`
+
+func newReleaseImportCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
+	client := action.NewReleaseImport(cfg)
+
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "import release histories from an archive",
+		Long:  releaseImportDesc,
+		Args:  require.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client.Namespace = settings.Namespace()
+			f, err := os.Open(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unable to open release archive")
+			}
+			defer f.Close()
+			rels, err := client.Run(f)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Imported %d revision(s) from %s\n", len(rels), args[0])
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.BoolVar(&client.Force, "force", false, "replace revisions that already exist")
+
+	return cmd
+}
@@ -0,0 +1,78 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"helm.sh/helm/v3/cmd/helm/require"
+	"helm.sh/helm/v3/internal/completion"
+	"helm.sh/helm/v3/pkg/action"
+)
+
+const releasePushDesc = `
+This command pushes a rendered release to an OCI registry.
+
+The manifest, hooks and values of a revision of the release, the latest by
+default, are pushed as an artifact described by the release and its chart, so
+that the rendered bundle can be promoted between environments by its digest
+rather than rendered again in each one.
+
+The reference is of the form oci://host/path/name[:tag]. Without a tag, the
+release name and revision are used, such as 'myrelease-3'. The digest of the
+pushed bundle is printed.
+
+    $ helm release push myrelease oci://registry.example.com/bundles/myrelease
+`
+
+func newReleasePushCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
+	client := action.NewReleasePush(cfg)
+
+	cmd := &cobra.Command{
+		Use:               "push RELEASE_NAME REF",
+		Short:             "push a rendered release to a registry",
+		Long:              releasePushDesc,
+		Args:              require.ExactArgs(2),
+		Hidden:            !FeatureGateOCI.IsEnabled(),
+		PersistentPreRunE: checkOCIFeatureGate(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			digest, err := client.Run(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Digest: %s\n", digest)
+			return nil
+		},
+	}
+
+	// Function providing dynamic auto-completion
+	completion.RegisterValidArgsFunc(cmd, func(cmd *cobra.Command, args []string, toComplete string) ([]string, completion.BashCompDirective) {
+		if len(args) != 0 {
+			return nil, completion.BashCompDirectiveNoFileComp
+		}
+		return compListReleases(toComplete, cfg)
+	})
+
+	f := cmd.Flags()
+	f.IntVar(&client.Version, "revision", 0, "push the named release with revision")
+	f.BoolVarP(&client.AllValues, "all", "a", false, "bundle all (computed) values rather than only user-supplied ones")
+
+	return cmd
+}
@@ -0,0 +1,58 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"helm.sh/helm/v3/cmd/helm/require"
+	"helm.sh/helm/v3/pkg/action"
+)
+
+const releaseReencryptDesc = `
+This command writes back every revision of every release in the namespace.
+
+After rotating the keys in $HELM_DRIVER_ENCRYPTION_KEYS, by putting the new key
+first and keeping the old ones after it, run this command to encrypt all
+revisions with the new key. The old keys can be removed afterwards.
+
+    $ HELM_DRIVER_ENCRYPTION_KEYS=aes:new:new.key,aes:old:old.key helm release reencrypt
+`
+
+func newReleaseReencryptCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
+	client := action.NewReleaseReencrypt(cfg)
+
+	cmd := &cobra.Command{
+		Use:   "reencrypt",
+		Short: "encrypt stored releases with the current key",
+		Long:  releaseReencryptDesc,
+		Args:  require.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := client.Run()
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Re-encrypted %d revision(s)\n", n)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,144 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/kubernetes/fake"
+
+	"helm.sh/helm/v3/internal/test/ensure"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+func TestReleaseExportImport(t *testing.T) {
+	dir := ensure.TempDir(t)
+	defer os.RemoveAll(dir)
+	archive := filepath.Join(dir, "releases.tgz")
+
+	src := storageFixture()
+	for _, rel := range []*release.Release{
+		release.Mock(&release.MockReleaseOptions{Name: "aeneas", Version: 1, Status: release.StatusSuperseded}),
+		release.Mock(&release.MockReleaseOptions{Name: "aeneas", Version: 2}),
+	} {
+		if err := src.Create(rel); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, out, err := executeActionCommandC(src, "release export aeneas --file "+archive)
+	if err != nil {
+		t.Fatalf("Failed to export releases: %s", err)
+	}
+	if !strings.Contains(out, "Exported 2 revision(s)") {
+		t.Errorf("Unexpected output %q", out)
+	}
+
+	dst := storageFixture()
+	_, out, err = executeActionCommandC(dst, "release import "+archive)
+	if err != nil {
+		t.Fatalf("Failed to import releases: %s", err)
+	}
+	if !strings.Contains(out, "Imported 2 revision(s)") {
+		t.Errorf("Unexpected output %q", out)
+	}
+	rel, err := dst.Get("aeneas", 1)
+	if err != nil {
+		t.Fatalf("Failed to get imported release: %s", err)
+	}
+	if rel.Info.Status != release.StatusSuperseded {
+		t.Errorf("Expected status %s, got %s", release.StatusSuperseded, rel.Info.Status)
+	}
+
+	if _, _, err := executeActionCommandC(dst, "release import "+archive); err == nil {
+		t.Error("Expected an error importing existing revisions")
+	}
+	if _, _, err := executeActionCommandC(dst, "release import --force "+archive); err != nil {
+		t.Errorf("Failed to import releases with --force: %s", err)
+	}
+}
+
+func TestReleaseExportArgs(t *testing.T) {
+	tests := []cmdTestCase{{
+		name:      "export without file",
+		cmd:       "release export aeneas",
+		golden:    "output/release-export-no-file.txt",
+		wantError: true,
+	}, {
+		name:      "export without releases",
+		cmd:       "release export --file out.tgz",
+		golden:    "output/release-export-no-args.txt",
+		wantError: true,
+	}}
+	runTestCmd(t, tests)
+}
+
+func TestReleaseReencrypt(t *testing.T) {
+	oldKey, err := driver.NewAESKeyProvider("old", []byte("0123456789abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKey, err := driver.NewAESKeyProvider("new", []byte("fedcba9876543210"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secrets := fake.NewSimpleClientset().CoreV1().Secrets("default")
+	d := driver.NewSecrets(secrets)
+	d.Encryption = &driver.Encryption{Provider: oldKey}
+	store := storage.Init(d)
+	for _, rel := range []*release.Release{
+		release.Mock(&release.MockReleaseOptions{Name: "aeneas", Version: 1, Status: release.StatusSuperseded}),
+		release.Mock(&release.MockReleaseOptions{Name: "aeneas", Version: 2}),
+	} {
+		if err := store.Create(rel); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Rotate the key, keeping the old one to read the stored revisions.
+	d.Encryption = &driver.Encryption{Provider: newKey, Previous: []driver.KeyProvider{oldKey}}
+	_, out, err := executeActionCommandC(store, "release reencrypt")
+	if err != nil {
+		t.Fatalf("Failed to re-encrypt releases: %s", err)
+	}
+	if !strings.Contains(out, "Re-encrypted 2 revision(s)") {
+		t.Errorf("Unexpected output %q", out)
+	}
+
+	d.Encryption = &driver.Encryption{Provider: oldKey}
+	for v := 1; v <= 2; v++ {
+		if _, err := store.Get("aeneas", v); err == nil {
+			t.Errorf("Expected the old key to no longer decrypt revision %d", v)
+		}
+	}
+	d.Encryption = &driver.Encryption{Provider: newKey}
+	for v := 1; v <= 2; v++ {
+		rel, err := store.Get("aeneas", v)
+		if err != nil {
+			t.Fatalf("Failed to decrypt revision %d with the new key: %s", v, err)
+		}
+		if rel.Version != v {
+			t.Errorf("Expected revision %d, got %d", v, rel.Version)
+		}
+	}
+}
@@ -19,8 +19,11 @@ package main
 import (
 	"fmt"
 	"io"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"helm.sh/helm/v3/cmd/helm/require"
@@ -34,12 +37,21 @@ The test command runs the tests for a release.
 
 The argument this command takes is the name of a deployed release.
 The tests to be run are defined in the chart that was installed.
+
+Tests can be narrowed down by name with '--filter' or by label with
+'--selector', and run concurrently with '--parallel'. A JUnit XML report
+of the run can be written for CI systems with '--junit-report', and the
+'--output' flag prints a structured report of the tests as JSON or YAML.
+
+    $ helm test --parallel --filter name=smoke-test,!name=slow-test myrelease
 `
 
 func newReleaseTestCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	client := action.NewReleaseTesting(cfg)
-	var outfmt = output.Table
+	var outfmt output.Format
 	var outputLogs bool
+	var filter []string
+	var junitReport string
 
 	cmd := &cobra.Command{
 		Use:   "test [RELEASE]",
@@ -48,6 +60,12 @@ func newReleaseTestCmd(cfg *action.Configuration, out io.Writer) *cobra.Command
 		Args:  require.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client.Namespace = settings.Namespace()
+			filters, err := parseTestFilters(filter)
+			if err != nil {
+				return err
+			}
+			client.Filters = filters
+
 			rel, runErr := client.Run(args[0])
 			// We only return an error if we weren't even able to get the
 			// release, otherwise we keep going so we can print status and logs
@@ -56,7 +74,24 @@ func newReleaseTestCmd(cfg *action.Configuration, out io.Writer) *cobra.Command
 				return runErr
 			}
 
-			if err := outfmt.Write(out, &statusPrinter{rel, settings.Debug}); err != nil {
+			report, err := client.Report(rel)
+			if err != nil {
+				return err
+			}
+
+			if junitReport != "" {
+				f, err := os.Create(junitReport)
+				if err != nil {
+					return errors.Wrap(err, "unable to create JUnit report")
+				}
+				err = report.WriteJUnit(f)
+				f.Close()
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := outfmt.Write(out, &testReportPrinter{statusPrinter{rel, settings.Debug}, report}); err != nil {
 				return err
 			}
 
@@ -83,6 +118,40 @@ func newReleaseTestCmd(cfg *action.Configuration, out io.Writer) *cobra.Command
 	f := cmd.Flags()
 	f.DurationVar(&client.Timeout, "timeout", 300*time.Second, "time to wait for any individual Kubernetes operation (like Jobs for hooks)")
 	f.BoolVar(&outputLogs, "logs", false, "Dump the logs from test pods (this runs after all tests are complete, but before any cleanup)")
+	f.BoolVar(&client.Parallel, "parallel", false, "run the tests concurrently. The timeout applies to each test individually")
+	f.StringSliceVar(&filter, "filter", []string{}, "specify tests by attribute (currently \"name\") using attribute=value syntax or '!attribute=value' to exclude a test (can specify multiple or separate values with commas: name=test1,name=test2)")
+	f.StringVarP(&client.Selector, "selector", "l", "", "label selector the tests must match to be run (e.g. -l key1=value1,key2=value2)")
+	f.StringVar(&junitReport, "junit-report", "", "write a JUnit XML report of the test run to the given file")
+	bindOutputFlag(cmd, &outfmt)
 
 	return cmd
 }
+
+// parseTestFilters converts attribute=value and !attribute=value pairs into
+// the filters understood by the release testing action.
+func parseTestFilters(filter []string) (map[string][]string, error) {
+	filters := map[string][]string{}
+	for _, f := range filter {
+		parts := strings.SplitN(f, "=", 2)
+		if len(parts) != 2 || (parts[0] != "name" && parts[0] != "!name") {
+			return nil, errors.Errorf("invalid test filter %q, expected name=<test> or !name=<test>", f)
+		}
+		filters[parts[0]] = append(filters[parts[0]], parts[1])
+	}
+	return filters, nil
+}
+
+// testReportPrinter prints the report of a test run as JSON or YAML, and the
+// status of the release, which includes the test results, as a table.
+type testReportPrinter struct {
+	statusPrinter
+	report *action.TestReport
+}
+
+func (t testReportPrinter) WriteJSON(out io.Writer) error {
+	return output.EncodeJSON(out, t.report)
+}
+
+func (t testReportPrinter) WriteYAML(out io.Writer) error {
+	return output.EncodeYAML(out, t.report)
+}
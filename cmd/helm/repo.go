@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"helm.sh/helm/v3/cmd/helm/require"
+	"helm.sh/helm/v3/pkg/action"
 )
 
 var repoHelm = `
@@ -32,7 +33,7 @@ This command consists of multiple subcommands to interact with chart repositorie
 It can be used to add, remove, list, and index chart repositories.
 `
 
-func newRepoCmd(out io.Writer) *cobra.Command {
+func newRepoCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "repo add|remove|list|index|update [ARGS]",
 		Short: "add, list, remove, update, and index chart repositories",
@@ -40,11 +41,11 @@ func newRepoCmd(out io.Writer) *cobra.Command {
 		Args:  require.NoArgs,
 	}
 
-	cmd.AddCommand(newRepoAddCmd(out))
+	cmd.AddCommand(newRepoAddCmd(cfg, out))
 	cmd.AddCommand(newRepoListCmd(out))
 	cmd.AddCommand(newRepoRemoveCmd(out))
 	cmd.AddCommand(newRepoIndexCmd(out))
-	cmd.AddCommand(newRepoUpdateCmd(out))
+	cmd.AddCommand(newRepoUpdateCmd(cfg, out))
 
 	return cmd
 }
@@ -32,7 +32,9 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"helm.sh/helm/v3/cmd/helm/require"
+	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/repo"
 )
 
@@ -49,20 +51,40 @@ type repoAddOptions struct {
 
 	repoFile  string
 	repoCache string
+
+	// registryClient lists the charts of a registry added as a repository
+	registryClient *registry.Client
 }
 
-func newRepoAddCmd(out io.Writer) *cobra.Command {
+const repoAddDesc = `
+Add a chart repository, by its URL, to the local list of repositories.
+
+A namespace of an OCI registry can be added as a repository, as
+oci://host[/namespace] (requires HELM_EXPERIMENTAL_OCI to be set). Each
+repository under the namespace holding charts is a chart of the repository,
+and its version tags are the versions of the chart. Logins to registries are
+managed with 'helm registry login'.
+
+    $ helm repo add mycharts oci://example.com/charts
+`
+
+func newRepoAddCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	o := &repoAddOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "add [NAME] [URL]",
 		Short: "add a chart repository",
+		Long:  repoAddDesc,
 		Args:  require.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.name = args[0]
 			o.url = args[1]
 			o.repoFile = settings.RepositoryConfig
 			o.repoCache = settings.RepositoryCache
+			if registry.IsOCI(o.url) && !FeatureGateOCI.IsEnabled() {
+				return FeatureGateOCI.Error()
+			}
+			o.registryClient = cfg.RegistryClient
 
 			return o.run(out)
 		},
@@ -126,6 +148,9 @@ func (o *repoAddOptions) run(out io.Writer) error {
 	if err != nil {
 		return err
 	}
+	if o.registryClient != nil {
+		r.RegistryClient = o.registryClient
+	}
 
 	if _, err := r.DownloadIndexFile(); err != nil {
 		return errors.Wrapf(err, "looks like %q is not a valid chart repository or cannot be reached", o.url)
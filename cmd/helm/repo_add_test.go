@@ -46,6 +46,10 @@ func TestRepoAddCmd(t *testing.T) {
 		name:   "add a repository",
 		cmd:    fmt.Sprintf("repo add test-name %s --repository-config %s", srv.URL(), repoFile),
 		golden: "output/repo-add.txt",
+	}, {
+		name:      "add a registry namespace without the OCI feature gate, expect failure",
+		cmd:       fmt.Sprintf("repo add test-oci oci://localhost:5000/charts --repository-config %s", repoFile),
+		wantError: true,
 	}}
 
 	runTestCmd(t, tests)
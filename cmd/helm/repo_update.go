@@ -19,14 +19,16 @@ package main
 import (
 	"fmt"
 	"io"
-	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"helm.sh/helm/v3/cmd/helm/require"
+	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/repo"
+	"helm.sh/helm/v3/pkg/transfer"
 )
 
 const updateDesc = `
@@ -39,9 +41,11 @@ var errNoRepositories = errors.New("no repositories found. You must add one befo
 type repoUpdateOptions struct {
 	update   func([]*repo.ChartRepository, io.Writer)
 	repoFile string
+	// registryClient lists the charts of registries added as repositories
+	registryClient *registry.Client
 }
 
-func newRepoUpdateCmd(out io.Writer) *cobra.Command {
+func newRepoUpdateCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	o := &repoUpdateOptions{update: updateCharts}
 
 	cmd := &cobra.Command{
@@ -52,6 +56,7 @@ func newRepoUpdateCmd(out io.Writer) *cobra.Command {
 		Args:    require.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.repoFile = settings.RepositoryConfig
+			o.registryClient = cfg.RegistryClient
 			return o.run(out)
 		},
 	}
@@ -69,6 +74,9 @@ func (o *repoUpdateOptions) run(out io.Writer) error {
 		if err != nil {
 			return err
 		}
+		if o.registryClient != nil {
+			r.RegistryClient = o.registryClient
+		}
 		repos = append(repos, r)
 	}
 
@@ -78,18 +86,19 @@ func (o *repoUpdateOptions) run(out io.Writer) error {
 
 func updateCharts(repos []*repo.ChartRepository, out io.Writer) {
 	fmt.Fprintln(out, "Hang tight while we grab the latest from your chart repositories...")
-	var wg sync.WaitGroup
+	// The repositories are updated by the workers of the default pool,
+	// limited with HELM_MAX_CONCURRENT_DOWNLOADS.
+	updates := transfer.Default().Group()
 	for _, re := range repos {
-		wg.Add(1)
-		go func(re *repo.ChartRepository) {
-			defer wg.Done()
+		re := re
+		updates.Go(func() {
 			if _, err := re.DownloadIndexFile(); err != nil {
 				fmt.Fprintf(out, "...Unable to get an update from the %q chart repository (%s):\n\t%s\n", re.Config.Name, re.Config.URL, err)
 			} else {
 				fmt.Fprintf(out, "...Successfully got an update from the %q chart repository\n", re.Config.Name)
 			}
-		}(re)
+		})
 	}
-	wg.Wait()
+	updates.Wait()
 	fmt.Fprintln(out, "Update Complete. ⎈ Happy Helming!⎈ ")
 }
@@ -27,8 +27,9 @@ import (
 
 	"helm.sh/helm/v3/cmd/helm/require"
 	"helm.sh/helm/v3/internal/completion"
-	"helm.sh/helm/v3/internal/experimental/registry"
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
 )
 
 const (
@@ -66,16 +67,29 @@ Common actions for Helm:
 
 Environment variables:
 
-+------------------+-----------------------------------------------------------------------------+
-| Name             | Description                                                                 |
-+------------------+-----------------------------------------------------------------------------+
-| $XDG_CACHE_HOME  | set an alternative location for storing cached files.                       |
-| $XDG_CONFIG_HOME | set an alternative location for storing Helm configuration.                 |
-| $XDG_DATA_HOME   | set an alternative location for storing Helm data.                          |
-| $HELM_DRIVER     | set the backend storage driver. Values are: configmap, secret, memory       |
-| $HELM_NO_PLUGINS | disable plugins. Set HELM_NO_PLUGINS=1 to disable plugins.                  |
-| $KUBECONFIG      | set an alternative Kubernetes configuration file (default "~/.kube/config") |
-+------------------+-----------------------------------------------------------------------------+
++--------------------------------+---------------------------------------------------------------------------------------+
+| Name                           | Description                                                                           |
++--------------------------------+---------------------------------------------------------------------------------------+
+| $XDG_CACHE_HOME                | set an alternative location for storing cached files.                                 |
+| $XDG_CONFIG_HOME               | set an alternative location for storing Helm configuration.                           |
+| $XDG_DATA_HOME                 | set an alternative location for storing Helm data.                                    |
+| $HELM_CACHE_HOME               | set the directory Helm stores cached files in, instead of $XDG_CACHE_HOME/helm        |
+| $HELM_CONFIG_HOME              | set the directory Helm stores configuration in, instead of $XDG_CONFIG_HOME/helm      |
+| $HELM_DATA_HOME                | set the directory Helm stores data in, instead of $XDG_DATA_HOME/helm                 |
+| $HELM_REGISTRY_CONFIG          | set the file registry credentials are stored in                                       |
+| $HELM_REGISTRY_CACHE           | set the directory charts pulled from and saved for registries are cached in           |
+| $HELM_DRIVER                   | set the storage driver: configmap, secret, memory, sqlite, external or a plugin       |
+| $HELM_DRIVER_EXTERNAL_COMMAND  | set the command run by the external driver for each storage operation                 |
+| $HELM_DRIVER_SQLITE_PATH       | set the database file of the sqlite driver (default $XDG_DATA_HOME/helm/releases.db)  |
+| $HELM_DRIVER_ENCRYPTION_KEYS   | encrypt stored releases with the first of these keys (aes:ID:FILE or exec:ID:COMMAND) |
+| $HELM_DRIVER_SECRET_ZSTD       | compress releases in Secrets with zstd, which older versions of Helm cannot read      |
+| $HELM_NO_PLUGINS               | disable plugins. Set HELM_NO_PLUGINS=1 to disable plugins.                            |
+| $HELM_MAX_CONCURRENT_DOWNLOADS | set the number of charts and repository indexes downloaded at once                    |
+| $HELM_DOWNLOAD_BANDWIDTH       | limit the bandwidth shared by downloads, in bytes per second (e.g. 512k or 10MiB)     |
+| $HELM_AUDIT_LOG                | append a JSON line for every request to repositories and registries to this file      |
+| $HELM_VERIFICATION_POLICY      | refuse to install or upgrade charts violating the verification policy in this file    |
+| $KUBECONFIG                    | set an alternative Kubernetes configuration file (default "~/.kube/config")           |
++--------------------------------+---------------------------------------------------------------------------------------+
 
 Helm stores configuration based on the XDG base directory specification, so
 
@@ -83,6 +97,8 @@ Helm stores configuration based on the XDG base directory specification, so
 - configuration is stored in $XDG_CONFIG_HOME/helm
 - data is stored in $XDG_DATA_HOME/helm
 
+unless $HELM_CACHE_HOME, $HELM_CONFIG_HOME or $HELM_DATA_HOME set these directories.
+
 By default, the default directories depend on the Operating System. The defaults are listed below:
 
 +------------------+---------------------------+--------------------------------+-------------------------+
@@ -90,7 +106,7 @@ By default, the default directories depend on the Operating System. The defaults
 +------------------+---------------------------+--------------------------------+-------------------------+
 | Linux            | $HOME/.cache/helm         | $HOME/.config/helm             | $HOME/.local/share/helm |
 | macOS            | $HOME/Library/Caches/helm | $HOME/Library/Preferences/helm | $HOME/Library/helm      |
-| Windows          | %TEMP%\helm               | %APPDATA%\helm                 | %APPDATA%\helm          |
+| Windows          | %LOCALAPPDATA%\helm       | %APPDATA%\helm                 | %APPDATA%\helm          |
 +------------------+---------------------------+--------------------------------+-------------------------+
 `
 
@@ -142,18 +158,21 @@ func newRootCmd(actionConfig *action.Configuration, out io.Writer, args []string
 		newCreateCmd(out),
 		newDependencyCmd(out),
 		newPullCmd(out),
-		newShowCmd(out),
+		newShowCmd(actionConfig, out),
 		newLintCmd(out),
+		newMergeValuesCmd(out),
 		newPackageCmd(out),
-		newRepoCmd(out),
-		newSearchCmd(out),
+		newRepoCmd(actionConfig, out),
+		newSearchCmd(actionConfig, out),
 		newVerifyCmd(out),
 
 		// release commands
+		newDriftCmd(actionConfig, out),
 		newGetCmd(actionConfig, out),
 		newHistoryCmd(actionConfig, out),
 		newInstallCmd(actionConfig, out),
 		newListCmd(actionConfig, out),
+		newReleaseCmd(actionConfig, out),
 		newReleaseTestCmd(actionConfig, out),
 		newRollbackCmd(actionConfig, out),
 		newStatusCmd(actionConfig, out),
@@ -187,10 +206,17 @@ func newRootCmd(actionConfig *action.Configuration, out io.Writer, args []string
 	}
 
 	// Add *experimental* subcommands
-	registryClient, err := registry.NewClient(
+	registryOpts := []registry.ClientOption{
 		registry.ClientOptDebug(settings.Debug),
 		registry.ClientOptWriter(out),
-	)
+		registry.ClientOptLogger(logger),
+		registry.ClientOptCredentialsFile(settings.RegistryConfig),
+		registry.ClientOptCacheRoot(settings.RegistryCache),
+	}
+	if credentials := getter.PluginCredentialProvider(settings); credentials != nil {
+		registryOpts = append(registryOpts, registry.ClientOptCredentials(credentials.RegistryCredential))
+	}
+	registryClient, err := registry.NewClient(registryOpts...)
 	if err != nil {
 		// TODO: don't panic here, refactor newRootCmd to return error
 		panic(err)
@@ -199,10 +225,12 @@ func newRootCmd(actionConfig *action.Configuration, out io.Writer, args []string
 	cmd.AddCommand(
 		newRegistryCmd(actionConfig, out),
 		newChartCmd(actionConfig, out),
+		newPushCmd(actionConfig, out),
 	)
 
 	// Find and add plugins
 	loadPlugins(cmd, out)
+	loadLifecycleHooks(actionConfig)
 
 	return cmd
 }
@@ -20,6 +20,8 @@ import (
 	"io"
 
 	"github.com/spf13/cobra"
+
+	"helm.sh/helm/v3/pkg/action"
 )
 
 const searchDesc = `
@@ -28,7 +30,7 @@ they can be stored including the Helm Hub and repositories you have added. Use
 search subcommands to search different locations for charts.
 `
 
-func newSearchCmd(out io.Writer) *cobra.Command {
+func newSearchCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "search [keyword]",
@@ -37,7 +39,7 @@ func newSearchCmd(out io.Writer) *cobra.Command {
 	}
 
 	cmd.AddCommand(newSearchHubCmd(out))
-	cmd.AddCommand(newSearchRepoCmd(out))
+	cmd.AddCommand(newSearchRepoCmd(cfg, out))
 
 	return cmd
 }
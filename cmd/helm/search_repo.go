@@ -32,8 +32,10 @@ import (
 
 	"helm.sh/helm/v3/cmd/helm/search"
 	"helm.sh/helm/v3/internal/completion"
+	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/cli/output"
 	"helm.sh/helm/v3/pkg/helmpath"
+	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/repo"
 )
 
@@ -57,7 +59,18 @@ Examples:
     # Search for the latest stable release for nginx-ingress with a major version of 1
     $ helm search repo nginx-ingress --version ^1.0.0
 
+    # Search the charts under the namespace "charts" of an OCI registry, too
+    $ helm search repo nginx --registry oci://example.com/charts
+
 Repositories are managed with 'helm repo' commands.
+
+OCI registries are searched with --registry (requires HELM_EXPERIMENTAL_OCI to
+be set). Their charts are listed through the catalog API of the registry, and
+their versions from the tags of each chart. The results are named by OCI
+reference, so they can be installed directly.
+
+A namespace of an OCI registry can also be added as a repository with 'helm
+repo add', to be searched like any other repository.
 `
 
 // searchMaxScore suggests that any score higher than this is not considered a match.
@@ -72,9 +85,12 @@ type searchRepoOptions struct {
 	repoFile     string
 	repoCacheDir string
 	outputFormat output.Format
+	registries   []string
+	// registryClient lists the charts of the registries
+	registryClient *registry.Client
 }
 
-func newSearchRepoCmd(out io.Writer) *cobra.Command {
+func newSearchRepoCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	o := &searchRepoOptions{}
 
 	cmd := &cobra.Command{
@@ -84,6 +100,10 @@ func newSearchRepoCmd(out io.Writer) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			o.repoFile = settings.RepositoryConfig
 			o.repoCacheDir = settings.RepositoryCache
+			if len(o.registries) > 0 && !FeatureGateOCI.IsEnabled() {
+				return FeatureGateOCI.Error()
+			}
+			o.registryClient = cfg.RegistryClient
 			return o.run(out, args)
 		},
 	}
@@ -94,6 +114,7 @@ func newSearchRepoCmd(out io.Writer) *cobra.Command {
 	f.BoolVar(&o.devel, "devel", false, "use development versions (alpha, beta, and release candidate releases), too. Equivalent to version '>0.0.0-0'. If --version is set, this is ignored")
 	f.StringVar(&o.version, "version", "", "search using semantic versioning constraints on repositories you have added")
 	f.UintVar(&o.maxColWidth, "max-col-width", 50, "maximum column width for output table")
+	f.StringArrayVar(&o.registries, "registry", []string{}, "search the charts of an OCI registry, as oci://host[/namespace], too (can be specified multiple times)")
 	bindOutputFlag(cmd, &o.outputFormat)
 
 	return cmd
@@ -118,6 +139,15 @@ func (o *searchRepoOptions) run(out io.Writer, args []string) error {
 		}
 	}
 
+	for _, r := range res {
+		for _, name := range o.registries {
+			if strings.HasPrefix(r.Name, strings.TrimPrefix(name, registry.OCIScheme+"://")+"/") {
+				r.Name = registry.OCIScheme + "://" + r.Name
+				break
+			}
+		}
+	}
+
 	search.SortScore(res)
 	data, err := o.applyConstraint(res)
 	if err != nil {
@@ -174,11 +204,23 @@ func (o *searchRepoOptions) applyConstraint(res []*search.Result) ([]*search.Res
 func (o *searchRepoOptions) buildIndex(out io.Writer) (*search.Index, error) {
 	// Load the repositories.yaml
 	rf, err := repo.LoadFile(o.repoFile)
-	if isNotExist(err) || len(rf.Repositories) == 0 {
+	if (isNotExist(err) || len(rf.Repositories) == 0) && len(o.registries) == 0 {
 		return nil, errors.New("no repositories configured")
 	}
 
 	i := search.NewIndex()
+	for _, name := range o.registries {
+		// Only the latest version allowed by the constraint is indexed
+		// unless all versions are listed, as the manifest of each
+		// version is fetched from the registry.
+		ind, err := repo.RegistryIndex(o.registryClient, name, o.version, !o.versions, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to search registry %s", name)
+		}
+		// The scheme is added back to the results, since the search
+		// index cleans the repository names as paths.
+		i.AddRepo(strings.TrimPrefix(name, registry.OCIScheme+"://"), ind, o.versions || len(o.version) > 0)
+	}
 	for _, re := range rf.Repositories {
 		n := re.Name
 		f := filepath.Join(o.repoCacheDir, helmpath.CacheIndexFile(n))
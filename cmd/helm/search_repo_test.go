@@ -72,6 +72,10 @@ func TestSearchRepositoriesCmd(t *testing.T) {
 		name:   "search for 'alpine', expect valid yaml output",
 		cmd:    "search repo alpine --output yaml",
 		golden: "output/search-output-yaml.txt",
+	}, {
+		name:      "search a registry without the OCI feature gate, expect failure",
+		cmd:       "search repo alpine --registry oci://localhost:5000/charts",
+		wantError: true,
 	}}
 
 	settings.Debug = true
@@ -25,10 +25,15 @@ import (
 	"helm.sh/helm/v3/cmd/helm/require"
 	"helm.sh/helm/v3/internal/completion"
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/registry"
 )
 
 const showDesc = `
 This command consists of multiple subcommands to display information about a chart
+
+A chart in an OCI registry may be shown with an oci://host/path/name[:tag]
+reference (requires HELM_EXPERIMENTAL_OCI to be set). The chart is not stored
+in the local cache, and 'helm show chart' only pulls its Chart.yaml.
 `
 
 const showAllDesc = `
@@ -51,7 +56,12 @@ This command inspects a chart (directory, file, or URL) and displays the content
 of the README file
 `
 
-func newShowCmd(out io.Writer) *cobra.Command {
+const showCRDsDesc = `
+This command inspects a chart (directory, file, or URL) and displays the contents
+of the CustomResourceDefinition files
+`
+
+func newShowCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	client := action.NewShow(action.ShowAll)
 
 	showCommand := &cobra.Command{
@@ -77,16 +87,7 @@ func newShowCmd(out io.Writer) *cobra.Command {
 		Args:  require.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client.OutputFormat = action.ShowAll
-			cp, err := client.ChartPathOptions.LocateChart(args[0], settings)
-			if err != nil {
-				return err
-			}
-			output, err := client.Run(cp)
-			if err != nil {
-				return err
-			}
-			fmt.Fprint(out, output)
-			return nil
+			return runShow(cfg, out, client, args[0])
 		},
 	}
 
@@ -97,16 +98,7 @@ func newShowCmd(out io.Writer) *cobra.Command {
 		Args:  require.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client.OutputFormat = action.ShowValues
-			cp, err := client.ChartPathOptions.LocateChart(args[0], settings)
-			if err != nil {
-				return err
-			}
-			output, err := client.Run(cp)
-			if err != nil {
-				return err
-			}
-			fmt.Fprint(out, output)
-			return nil
+			return runShow(cfg, out, client, args[0])
 		},
 	}
 
@@ -117,16 +109,7 @@ func newShowCmd(out io.Writer) *cobra.Command {
 		Args:  require.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client.OutputFormat = action.ShowChart
-			cp, err := client.ChartPathOptions.LocateChart(args[0], settings)
-			if err != nil {
-				return err
-			}
-			output, err := client.Run(cp)
-			if err != nil {
-				return err
-			}
-			fmt.Fprint(out, output)
-			return nil
+			return runShow(cfg, out, client, args[0])
 		},
 	}
 
@@ -137,20 +120,22 @@ func newShowCmd(out io.Writer) *cobra.Command {
 		Args:  require.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client.OutputFormat = action.ShowReadme
-			cp, err := client.ChartPathOptions.LocateChart(args[0], settings)
-			if err != nil {
-				return err
-			}
-			output, err := client.Run(cp)
-			if err != nil {
-				return err
-			}
-			fmt.Fprint(out, output)
-			return nil
+			return runShow(cfg, out, client, args[0])
+		},
+	}
+
+	crdsSubCmd := &cobra.Command{
+		Use:   "crds [CHART]",
+		Short: "shows the chart's CRDs",
+		Long:  showCRDsDesc,
+		Args:  require.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client.OutputFormat = action.ShowCRDs
+			return runShow(cfg, out, client, args[0])
 		},
 	}
 
-	cmds := []*cobra.Command{all, readmeSubCmd, valuesSubCmd, chartSubCmd}
+	cmds := []*cobra.Command{all, readmeSubCmd, valuesSubCmd, chartSubCmd, crdsSubCmd}
 	for _, subCmd := range cmds {
 		addChartPathOptionsFlags(subCmd.Flags(), &client.ChartPathOptions)
 		showCommand.AddCommand(subCmd)
@@ -161,3 +146,29 @@ func newShowCmd(out io.Writer) *cobra.Command {
 
 	return showCommand
 }
+
+// runShow shows the chart name, pulling it from a registry for an OCI
+// reference.
+func runShow(cfg *action.Configuration, out io.Writer, client *action.Show, name string) error {
+	var output string
+	if registry.IsOCI(name) {
+		if !FeatureGateOCI.IsEnabled() {
+			return FeatureGateOCI.Error()
+		}
+		var err error
+		if output, err = client.RunOCI(cfg.RegistryClient, name); err != nil {
+			return err
+		}
+	} else {
+		client.ChartPathOptions.Logger = logger
+		cp, err := client.ChartPathOptions.LocateChart(name, settings)
+		if err != nil {
+			return err
+		}
+		if output, err = client.Run(cp); err != nil {
+			return err
+		}
+	}
+	fmt.Fprint(out, output)
+	return nil
+}
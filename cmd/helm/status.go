@@ -22,6 +22,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 
 	"helm.sh/helm/v3/cmd/helm/require"
@@ -42,11 +43,17 @@ The status consists of:
 - list of resources that this release consists of, sorted by kind
 - details on last test suite run, if applicable
 - additional notes provided by the chart
+
+With --health, the live state of the resources is read from the cluster and
+the health of each resource is shown instead of the release manifest. The
+status of a resource is one of Current, InProgress, Failed, Terminating,
+NotFound or Unknown, and the release is healthy if all of them are Current.
 `
 
 func newStatusCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	client := action.NewStatus(cfg)
 	var outfmt output.Format
+	var health bool
 
 	cmd := &cobra.Command{
 		Use:   "status RELEASE_NAME",
@@ -54,6 +61,14 @@ func newStatusCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 		Long:  statusHelp,
 		Args:  require.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if health {
+				report, err := client.RunReport(args[0])
+				if err != nil {
+					return err
+				}
+				return outfmt.Write(out, statusReportPrinter{report})
+			}
+
 			rel, err := client.Run(args[0])
 			if err != nil {
 				return err
@@ -84,6 +99,7 @@ func newStatusCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 		}
 		return nil, completion.BashCompDirectiveNoFileComp
 	})
+	f.BoolVar(&health, "health", false, "read the live state of the resources of the release and show their health")
 
 	bindOutputFlag(cmd, &outfmt)
 
@@ -112,6 +128,9 @@ func (s statusPrinter) WriteTable(out io.Writer) error {
 		fmt.Fprintf(out, "LAST DEPLOYED: %s\n", s.release.Info.LastDeployed.Format(time.ANSIC))
 	}
 	fmt.Fprintf(out, "NAMESPACE: %s\n", s.release.Namespace)
+	if len(s.release.Namespaces) > 1 {
+		fmt.Fprintf(out, "MANAGED NAMESPACES: %s\n", strings.Join(s.release.Namespaces, ", "))
+	}
 	fmt.Fprintf(out, "STATUS: %s\n", s.release.Info.Status.String())
 	fmt.Fprintf(out, "REVISION: %d\n", s.release.Version)
 
@@ -170,6 +189,67 @@ func (s statusPrinter) WriteTable(out io.Writer) error {
 	return nil
 }
 
+type statusReportPrinter struct {
+	report *action.StatusReport
+}
+
+func (s statusReportPrinter) WriteJSON(out io.Writer) error {
+	return output.EncodeJSON(out, s.report)
+}
+
+func (s statusReportPrinter) WriteYAML(out io.Writer) error {
+	return output.EncodeYAML(out, s.report)
+}
+
+func (s statusReportPrinter) WriteTable(out io.Writer) error {
+	r := s.report
+	fmt.Fprintf(out, "NAME: %s\n", r.Name)
+	if !r.LastDeployed.IsZero() {
+		fmt.Fprintf(out, "LAST DEPLOYED: %s\n", r.LastDeployed.Format(time.ANSIC))
+	}
+	fmt.Fprintf(out, "NAMESPACE: %s\n", r.Namespace)
+	fmt.Fprintf(out, "STATUS: %s\n", r.Status.String())
+	fmt.Fprintf(out, "REVISION: %d\n", r.Revision)
+	fmt.Fprintf(out, "HEALTHY: %t\n", r.Healthy)
+
+	if len(r.Resources) > 0 {
+		fmt.Fprintln(out, "RESOURCES:")
+		table := uitable.New()
+		table.AddRow("KIND", "NAME", "STATUS", "MESSAGE")
+		for _, res := range r.Resources {
+			table.AddRow(res.Kind, res.Name, res.Status, res.Message)
+		}
+		if err := output.EncodeTable(out, table); err != nil {
+			return err
+		}
+	}
+
+	if len(r.Hooks) > 0 {
+		fmt.Fprintln(out, "HOOKS:")
+		table := uitable.New()
+		table.AddRow("NAME", "KIND", "EVENTS", "PHASE")
+		for _, h := range r.Hooks {
+			events := make([]string, 0, len(h.Events))
+			for _, e := range h.Events {
+				events = append(events, e.String())
+			}
+			phase := h.LastRun.Phase.String()
+			if phase == "" {
+				phase = "Unknown"
+			}
+			table.AddRow(h.Name, h.Kind, strings.Join(events, ","), phase)
+		}
+		if err := output.EncodeTable(out, table); err != nil {
+			return err
+		}
+	}
+
+	if len(r.Notes) > 0 {
+		fmt.Fprintf(out, "NOTES:\n%s\n", strings.TrimSpace(r.Notes))
+	}
+	return nil
+}
+
 func executionsByHookEvent(rel *release.Release) map[release.HookEvent][]*release.Hook {
 	result := make(map[release.HookEvent][]*release.Hook)
 	for _, h := range rel.Hooks {
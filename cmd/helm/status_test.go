@@ -100,6 +100,34 @@ func TestStatusCmd(t *testing.T) {
 				},
 			},
 		),
+	}, {
+		name:   "get health of a deployed release",
+		cmd:    "status flummoxed-chickadee --health",
+		golden: "output/status-health.txt",
+		rels: releasesMockWithStatus(
+			&release.Info{
+				Status: release.StatusDeployed,
+				Notes:  "release notes",
+			},
+			&release.Hook{
+				Name:   "passing-pre-install",
+				Kind:   "Job",
+				Events: []release.HookEvent{release.HookPreInstall, release.HookPreUpgrade},
+				LastRun: release.HookExecution{
+					StartedAt:   mustParseTime("2006-01-02T15:00:05Z"),
+					CompletedAt: mustParseTime("2006-01-02T15:00:07Z"),
+					Phase:       release.HookPhaseSucceeded,
+				},
+			},
+		),
+	}, {
+		name:   "get health of a deployed release in json",
+		cmd:    "status flummoxed-chickadee --health -o json",
+		golden: "output/status-health.json",
+		rels: releasesMockWithStatus(&release.Info{
+			Status: release.StatusDeployed,
+			Notes:  "release notes",
+		}),
 	}}
 	runTestCmd(t, tests)
 }
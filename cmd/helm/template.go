@@ -26,13 +26,17 @@ import (
 
 	"helm.sh/helm/v3/pkg/releaseutil"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"helm.sh/helm/v3/cmd/helm/require"
 	"helm.sh/helm/v3/internal/completion"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli/output"
 	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/images"
 )
 
 const templateDesc = `
@@ -41,6 +45,42 @@ Render chart templates locally and display the output.
 Any values that would normally be looked up or retrieved in-cluster will be
 faked locally. Additionally, none of the server-side testing of chart validity
 (e.g. whether an API is supported) is done.
+
+With '--validate', the manifests are validated against the Kubernetes cluster
+you are currently pointing at. If '--kube-version' or '--schema-location' is
+also given, they are instead validated without a cluster, against the OpenAPI
+schema of that Kubernetes version. The schema is downloaded from the
+Kubernetes repository unless '--schema-location' names another path or URL,
+in which "{kubeVersion}" is replaced by the version, such as "v1.22.0".
+Downloaded schemas are cached, so later runs work offline.
+
+With '--capabilities-file', templates are rendered for the capabilities of a
+specific cluster rather than defaults: its Kubernetes version, the API
+versions it serves and the Helm version. The file is either YAML, such as
+
+    kubeVersion: v1.22.3
+    apiVersions:
+    - v1
+    - apps/v1
+    - monitoring.coreos.com/v1
+    helmVersion: v3.7.0
+
+or the output of 'kubectl api-versions'. Only the API versions it lists are
+available, along with those of '--api-versions', and '--kube-version'
+overrides its Kubernetes version.
+
+With '--list-images', the container images referenced by the rendered
+manifests are listed instead, one per line. Together with '--image-mapping',
+which relocates images to mirrored registries, this lists the images to mirror
+and checks the relocation of a chart for air-gapped clusters.
+
+With '--output-dir', the manifests are written to files named after their
+templates. '--split-output' writes each resource to its own file instead,
+named after its kind and name such as 'deployment_web.yaml', and
+'--group-by-namespace' writes the files to a directory per namespace, with
+cluster-scoped resources in the '_cluster' directory. '--output-index' also
+writes an 'index.yaml' file listing the written resources and their files,
+to feed a GitOps repository without further processing.
 `
 
 func newTemplateCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
@@ -50,6 +90,10 @@ func newTemplateCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	valueOpts := &values.Options{}
 	var extraAPIs []string
 	var showFiles []string
+	var kubeVersion string
+	var capabilitiesFile string
+	var listImages bool
+	schemas := &action.SchemaLocator{}
 
 	cmd := &cobra.Command{
 		Use:   "template [NAME] [CHART]",
@@ -63,9 +107,45 @@ func newTemplateCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 			client.ClientOnly = !validate
 			client.APIVersions = chartutil.VersionSet(extraAPIs)
 			client.IncludeCRDs = includeCrds
-			rel, err := runInstall(args, client, valueOpts, out)
+			if client.OutputDir == "" && (client.SplitByResource || client.GroupByNamespace || client.WriteIndex) {
+				return errors.New("--split-output, --group-by-namespace and --output-index require --output-dir")
+			}
+			if capabilitiesFile != "" {
+				caps, err := chartutil.LoadCapabilities(capabilitiesFile)
+				if err != nil {
+					return err
+				}
+				client.Capabilities = caps
+			}
+			if kubeVersion != "" {
+				kv, err := chartutil.ParseKubeVersion(kubeVersion)
+				if err != nil {
+					return err
+				}
+				client.KubeVersion = kv
+			}
+			if validate && (client.KubeVersion != nil || client.Capabilities != nil || schemas.Location != "") {
+				// Validate against the schema of the Kubernetes version
+				// rendered for, without a cluster.
+				kv := client.KubeVersion
+				if kv == nil && client.Capabilities != nil {
+					kv = &client.Capabilities.KubeVersion
+				}
+				if kv == nil {
+					kv = &chartutil.DefaultCapabilities.KubeVersion
+				}
+				schemas.Getters = getter.All(settings)
+				schemaFile, err := schemas.Locate(kv)
+				if err != nil {
+					return err
+				}
+				client.ClientOnly = true
+				client.ValidateManifests = true
+				client.SchemaFile = schemaFile
+			}
+			rel, err := runInstall(args, client, valueOpts, nil, out)
 			if err != nil {
-				return err
+				return reportPolicyViolations(out, output.Table, err)
 			}
 
 			var manifests bytes.Buffer
@@ -107,13 +187,19 @@ func newTemplateCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 						return fmt.Errorf("could not find template %s in chart", f)
 					}
 				}
+				manifests.Reset()
 				for _, m := range manifestsToRender {
-					fmt.Fprintf(out, "---\n%s\n", m)
+					fmt.Fprintf(&manifests, "---\n%s\n", m)
 				}
-			} else {
-				fmt.Fprintf(out, "%s", manifests.String())
 			}
 
+			if listImages {
+				for _, ref := range images.References(images.Extract(manifests.String())) {
+					fmt.Fprintln(out, ref)
+				}
+				return nil
+			}
+			fmt.Fprintf(out, "%s", manifests.String())
 			return nil
 		},
 	}
@@ -131,8 +217,17 @@ func newTemplateCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	f.BoolVar(&includeCrds, "include-crds", false, "include CRDs in the templated output")
 	f.BoolVar(&client.IsUpgrade, "is-upgrade", false, "set .Release.IsUpgrade instead of .Release.IsInstall")
 	f.StringArrayVarP(&extraAPIs, "api-versions", "a", []string{}, "Kubernetes api versions used for Capabilities.APIVersions")
+	f.StringVar(&capabilitiesFile, "capabilities-file", "", "file describing the Kubernetes version, API versions and Helm version used for Capabilities")
+	f.StringVar(&kubeVersion, "kube-version", "", "Kubernetes version used for Capabilities.KubeVersion, and to select the schema validated against with --validate")
+	f.StringVar(&schemas.Location, "schema-location", "", "path or URL of the OpenAPI schema validated against with --validate, in which {kubeVersion} is replaced by the Kubernetes version")
+	f.BoolVar(&listImages, "list-images", false, "list the container images referenced by the rendered manifests instead of the manifests")
 	f.BoolVar(&client.UseReleaseName, "release-name", false, "use release name in the output-dir path.")
+	f.BoolVar(&client.SplitByResource, "split-output", false, "write each resource to its own file in output-dir, named after its kind and name")
+	f.BoolVar(&client.GroupByNamespace, "group-by-namespace", false, "write the files to a directory per namespace in output-dir")
+	f.BoolVar(&client.WriteIndex, "output-index", false, "write an index of the written resources and their files to index.yaml in output-dir")
 	bindPostRenderFlag(cmd, &client.PostRenderer)
+	bindResolveValuesFlag(cmd, &client.ValuesResolver)
+	bindPolicyFlag(cmd, &client.Policy)
 
 	return cmd
 }
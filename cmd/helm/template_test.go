@@ -79,6 +79,27 @@ func TestTemplateCmd(t *testing.T) {
 			cmd:    fmt.Sprintf("template --api-versions helm.k8s.io/test '%s'", chartPath),
 			golden: "output/template-with-api-version.txt",
 		},
+		{
+			name:   "check kube version",
+			cmd:    fmt.Sprintf("template --kube-version 1.20 '%s'", chartPath),
+			golden: "output/template-with-kube-version.txt",
+		},
+		{
+			name:   "check capabilities file",
+			cmd:    fmt.Sprintf("template --capabilities-file testdata/capabilities.yaml '%s'", chartPath),
+			golden: "output/template-with-capabilities-file.txt",
+		},
+		{
+			name:      "check missing capabilities file",
+			cmd:       fmt.Sprintf("template --capabilities-file testdata/missing-capabilities.yaml '%s'", chartPath),
+			wantError: true,
+			golden:    "output/template-missing-capabilities-file.txt",
+		},
+		{
+			name:      "validate against a missing schema",
+			cmd:       fmt.Sprintf("template --validate --kube-version 1.20 --schema-location testdata/{kubeVersion}.json '%s'", chartPath),
+			wantError: true,
+		},
 		{
 			name:   "template with CRDs",
 			cmd:    fmt.Sprintf("template '%s' --include-crds", chartPath),
@@ -94,6 +115,33 @@ func TestTemplateCmd(t *testing.T) {
 			cmd:    fmt.Sprintf("template '%s' --show-only templates/service.yaml --show-only charts/subcharta/templates/service.yaml", chartPath),
 			golden: "output/template-show-only-multiple.txt",
 		},
+		{
+			name:   "template with list-images",
+			cmd:    fmt.Sprintf("template '%s' --list-images", "testdata/testcharts/chart-with-lib-dep"),
+			golden: "output/template-list-images.txt",
+		},
+		{
+			name:   "template with image-mapping",
+			cmd:    fmt.Sprintf("template '%s' --image-mapping testdata/image-mapping.yaml", "testdata/testcharts/chart-with-lib-dep"),
+			golden: "output/template-image-mapping.txt",
+		},
+		{
+			name:   "template with list-images and image-mapping",
+			cmd:    fmt.Sprintf("template '%s' --list-images --image-mapping testdata/image-mapping.yaml", "testdata/testcharts/alpine"),
+			golden: "output/template-list-images-mapping.txt",
+		},
+		{
+			name:      "template with a missing image-mapping",
+			cmd:       fmt.Sprintf("template '%s' --image-mapping testdata/missing-mapping.yaml", chartPath),
+			wantError: true,
+			golden:    "output/template-missing-image-mapping.txt",
+		},
+		{
+			name:      "template with split-output without output-dir",
+			cmd:       fmt.Sprintf("template '%s' --split-output", chartPath),
+			wantError: true,
+			golden:    "output/template-split-output-no-dir.txt",
+		},
 		{
 			name:   "sorted output of manifests (order of filenames, then order of objects within each YAML file)",
 			cmd:    fmt.Sprintf("template '%s'", "testdata/testcharts/object-order"),
@@ -105,3 +153,27 @@ func TestTemplateCmd(t *testing.T) {
 	}
 	runTestCmd(t, tests)
 }
+
+func TestTemplatePolicy(t *testing.T) {
+	tests := []cmdTestCase{
+		{
+			name:      "template with a violated policy",
+			cmd:       "template testdata/testcharts/alpine --policy testdata/policies/trusted-registry.rego",
+			golden:    "output/template-policy.txt",
+			wantError: true,
+		},
+		{
+			name:      "install --dry-run with a violated policy",
+			cmd:       "install policy testdata/testcharts/alpine --dry-run --policy testdata/policies/trusted-registry.rego -o json",
+			golden:    "output/install-dry-run-policy.json",
+			wantError: true,
+		},
+		{
+			name:      "template with a missing policy",
+			cmd:       "template testdata/testcharts/alpine --policy testdata/policies/missing.rego",
+			golden:    "output/template-missing-policy.txt",
+			wantError: true,
+		},
+	}
+	runTestCmd(t, tests)
+}
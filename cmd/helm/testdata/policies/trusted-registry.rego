@@ -0,0 +1,7 @@
+package helm
+
+deny[msg] {
+	c := input.spec.containers[_]
+	not startswith(c.image, "registry.example.com/")
+	msg := sprintf("container %s uses an image from an untrusted registry", [c.name])
+}
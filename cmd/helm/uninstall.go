@@ -36,6 +36,15 @@ as well as the release history, freeing it up for future use.
 
 Use the '--dry-run' flag to see which releases will be uninstalled without actually
 uninstalling them.
+
+Use '--cascade' to choose whether the dependents of the deleted resources are
+deleted in the background, in the foreground or orphaned, and '--keep-kinds' to
+leave the resources of some kinds behind, such as volumes holding state.
+
+Use '--delete-namespace' to also delete the namespace of the release, if it was
+created by 'helm install --create-namespace' and no other release is stored in
+it. The namespace is kept if any resource of the release was kept or could not
+be deleted.
 `
 
 func newUninstallCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
@@ -79,6 +88,10 @@ func newUninstallCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	f.BoolVar(&client.KeepHistory, "keep-history", false, "remove all associated resources and mark the release as deleted, but retain the release history")
 	f.DurationVar(&client.Timeout, "timeout", 300*time.Second, "time to wait for any individual Kubernetes operation (like Jobs for hooks)")
 	f.StringVar(&client.Description, "description", "", "add a custom description")
+	f.StringVar(&client.DeletionPropagation, "cascade", "background", "what happens to the dependents of deleted resources. Must be \"background\", \"foreground\" or \"orphan\"")
+	f.BoolVar(&client.DeleteNamespace, "delete-namespace", false, "delete the release namespace if the release created it and no other release, whatever its status, is stored in it")
+	f.StringSliceVar(&client.KeepKinds, "keep-kinds", []string{}, "kinds of resources to leave behind instead of deleting (can specify multiple or separate values with commas: PersistentVolumeClaim,Secret)")
+	f.StringSliceVar(&client.KindOrder, "kind-order", []string{}, "kinds that were installed before all others, in order. They are uninstalled last (can specify multiple or separate values with commas: CustomResourceDefinition,MyOperator)")
 
 	return cmd
 }
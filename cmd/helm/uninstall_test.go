@@ -57,6 +57,25 @@ func TestUninstall(t *testing.T) {
 			golden: "output/uninstall-keep-history.txt",
 			rels:   []*release.Release{release.Mock(&release.MockReleaseOptions{Name: "aeneas"})},
 		},
+		{
+			name:   "uninstall orphaning dependents",
+			cmd:    "uninstall aeneas --cascade orphan",
+			golden: "output/uninstall.txt",
+			rels:   []*release.Release{release.Mock(&release.MockReleaseOptions{Name: "aeneas"})},
+		},
+		{
+			name:      "uninstall with invalid cascade",
+			cmd:       "uninstall aeneas --cascade sideways",
+			golden:    "output/uninstall-invalid-cascade.txt",
+			rels:      []*release.Release{release.Mock(&release.MockReleaseOptions{Name: "aeneas"})},
+			wantError: true,
+		},
+		{
+			name:   "uninstall keeping kinds",
+			cmd:    "uninstall aeneas --keep-kinds Secret",
+			golden: "output/uninstall-keep-kinds.txt",
+			rels:   []*release.Release{release.Mock(&release.MockReleaseOptions{Name: "aeneas"})},
+		},
 		{
 			name:      "uninstall without release",
 			cmd:       "uninstall",
@@ -28,9 +28,11 @@ import (
 	"helm.sh/helm/v3/internal/completion"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/cli/output"
 	"helm.sh/helm/v3/pkg/cli/values"
 	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/storage/driver"
 )
 
@@ -39,8 +41,10 @@ This command upgrades a release to a new version of a chart.
 
 The upgrade arguments must be a release and chart. The chart
 argument can be either: a chart reference('example/mariadb'), a path to a chart directory,
-a packaged chart, or a fully qualified URL. For chart references, the latest
-version will be specified unless the '--version' flag is set.
+a packaged chart, a fully qualified URL, or an OCI registry reference
+('oci://example.com/charts/mariadb', requires HELM_EXPERIMENTAL_OCI to be set). For chart
+references and OCI references, the latest version will be specified unless the '--version'
+flag is set.
 
 To override values in a chart, use either the '--values' flag and pass in a file
 or use the '--set' flag and pass configuration from the command line, to force string
@@ -59,12 +63,22 @@ last (right-most) set specified. For example, if both 'bar' and 'newbar' values
 set for a key called 'foo', the 'newbar' value would take precedence:
 
     $ helm upgrade --set foo=bar --set foo=newbar redis ./redis
+
+Resources of the release that the chart no longer renders are deleted once the
+upgraded resources are applied. Each of them is reported before anything is
+applied, also with '--dry-run'. Resources with the 'helm.sh/resource-policy:
+keep' annotation, and those of the kinds given to '--prune-exclude-kinds', are
+left in the cluster instead. '--max-prune' fails the upgrade if more resources
+would be deleted, to catch a chart or values that accidentally stop rendering
+most of the release.
 `
 
 func newUpgradeCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	client := action.NewUpgrade(cfg)
 	valueOpts := &values.Options{}
 	var outfmt output.Format
+	var kubeVersion string
+	var interactive interactiveOptions
 
 	cmd := &cobra.Command{
 		Use:   "upgrade [RELEASE] [CHART]",
@@ -79,11 +93,25 @@ func newUpgradeCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 				client.Version = ">0.0.0-0"
 			}
 
-			vals, err := valueOpts.MergeValues(getter.All(settings))
+			if registry.IsOCI(args[1]) && !FeatureGateOCI.IsEnabled() {
+				return FeatureGateOCI.Error()
+			}
+
+			if kubeVersion != "" {
+				kv, err := chartutil.ParseKubeVersion(kubeVersion)
+				if err != nil {
+					return err
+				}
+				client.KubeVersion = kv
+			}
+
+			vals, sources, err := valueOpts.MergeValuesWithSources(getter.All(settings))
 			if err != nil {
 				return err
 			}
+			client.ValueSources = sources
 
+			client.ChartPathOptions.Logger = logger
 			chartPath, err := client.ChartPathOptions.LocateChart(args[1], settings)
 			if err != nil {
 				return err
@@ -109,10 +137,22 @@ func newUpgradeCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 					instClient.Namespace = client.Namespace
 					instClient.Atomic = client.Atomic
 					instClient.PostRenderer = client.PostRenderer
+					instClient.ValuesResolver = client.ValuesResolver
 					instClient.DisableOpenAPIValidation = client.DisableOpenAPIValidation
-
-					rel, err := runInstall(args, instClient, valueOpts, out)
+					instClient.ValidateManifests = client.ValidateManifests
+					instClient.SchemaFile = client.SchemaFile
+					instClient.Policy = client.Policy
+					instClient.KindOrder = client.KindOrder
+					instClient.AllowedNamespaces = client.AllowedNamespaces
+					instClient.Labels = client.Labels
+					instClient.ServerSideApplyOptions = client.ServerSideApplyOptions
+					instClient.ApplyRetryOptions = client.ApplyRetryOptions
+
+					rel, err := runInstall(args, instClient, valueOpts, &interactive, out)
 					if err != nil {
+						if client.DryRun {
+							return reportPolicyViolations(out, outfmt, err)
+						}
 						return err
 					}
 					return outfmt.Write(out, &statusPrinter{rel, settings.Debug})
@@ -130,12 +170,15 @@ func newUpgradeCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 				}
 			}
 
-			if ch.Metadata.Deprecated {
-				fmt.Fprintln(out, "WARNING: This chart is deprecated")
+			if vals, err = interactive.promptValues(args[0], ch, vals); err != nil {
+				return err
 			}
 
 			rel, err := client.Run(args[0], ch, vals)
 			if err != nil {
+				if client.DryRun {
+					err = reportPolicyViolations(out, outfmt, err)
+				}
 				return errors.Wrap(err, "UPGRADE FAILED")
 			}
 
@@ -167,6 +210,14 @@ func newUpgradeCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	f.BoolVar(&client.Force, "force", false, "force resource updates through a replacement strategy")
 	f.BoolVar(&client.DisableHooks, "no-hooks", false, "disable pre/post upgrade hooks")
 	f.BoolVar(&client.DisableOpenAPIValidation, "disable-openapi-validation", false, "if set, the upgrade process will not validate rendered templates against the Kubernetes OpenAPI Schema")
+	f.StringSliceVar(&client.KindOrder, "kind-order", []string{}, "kinds to install before all others, in order (can specify multiple or separate values with commas: CustomResourceDefinition,MyOperator)")
+	f.StringToStringVar(&client.Labels, "labels", nil, "labels to merge into those of the release. A label with an empty value is removed (can specify multiple or separate values with commas: team=payments,ticket=)")
+	f.StringSliceVar(&client.AllowedNamespaces, "allowed-namespaces", []string{}, "namespaces, other than the release namespace, the release may manage resources in. Accepts glob patterns. By default resources may be in any namespace (can specify multiple or separate values with commas: monitoring,team-*)")
+	f.BoolVar(&client.ValidateManifests, "validate-manifests", false, "validate each rendered manifest against the Kubernetes OpenAPI Schema before upgrading, reporting all unknown fields and type errors")
+	f.StringVar(&kubeVersion, "kube-version", "", "Kubernetes version to check the upgraded resources for removed and deprecated APIs against, instead of the cluster version")
+	f.StringVar(&client.SchemaFile, "openapi-schema", "", "path to an OpenAPI v2 schema used by --validate-manifests when the cluster schema is unavailable, instead of the bundled Kubernetes schema")
+	addServerSideApplyFlags(f, &client.ServerSideApplyOptions)
+	addApplyRetryFlags(f, &client.ApplyRetryOptions)
 	f.DurationVar(&client.Timeout, "timeout", 300*time.Second, "time to wait for any individual Kubernetes operation (like Jobs for hooks)")
 	f.BoolVar(&client.ResetValues, "reset-values", false, "when upgrading, reset the values to the ones built into the chart")
 	f.BoolVar(&client.ReuseValues, "reuse-values", false, "when upgrading, reuse the last release's values and merge in any overrides from the command line via --set and -f. If '--reset-values' is specified, this is ignored")
@@ -174,12 +225,19 @@ func newUpgradeCmd(cfg *action.Configuration, out io.Writer) *cobra.Command {
 	f.BoolVar(&client.Atomic, "atomic", false, "if set, upgrade process rolls back changes made in case of failed upgrade. The --wait flag will be set automatically if --atomic is used")
 	f.IntVar(&client.MaxHistory, "history-max", 10, "limit the maximum number of revisions saved per release. Use 0 for no limit")
 	f.BoolVar(&client.CleanupOnFail, "cleanup-on-fail", false, "allow deletion of new resources created in this upgrade when upgrade fails")
+	f.StringSliceVar(&client.PruneExcludeKinds, "prune-exclude-kinds", []string{}, "kinds of resources removed from the chart to leave in the cluster instead of deleting (can specify multiple or separate values with commas: PersistentVolumeClaim,Secret)")
+	f.IntVar(&client.MaxPrune, "max-prune", 0, "fail the upgrade before applying anything if more resources removed from the chart would be deleted. Use 0 for no limit")
 	f.BoolVar(&client.SubNotes, "render-subchart-notes", false, "if set, render subchart notes along with the parent")
 	f.StringVar(&client.Description, "description", "", "add a custom description")
 	addChartPathOptionsFlags(f, &client.ChartPathOptions)
+	f.BoolVar(&client.FailOnDeprecated, "fail-on-deprecated", false, "refuse deprecated charts instead of warning about them")
+	f.StringVar(&client.VerificationPolicy, "verification-policy", settings.VerificationPolicy, "refuse charts that do not satisfy the verification policy in this file, checked before rendering")
 	addValueOptionsFlags(f, valueOpts)
+	addInteractiveFlags(f, &interactive)
 	bindOutputFlag(cmd, &outfmt)
 	bindPostRenderFlag(cmd, &client.PostRenderer)
+	bindResolveValuesFlag(cmd, &client.ValuesResolver)
+	bindPolicyFlag(cmd, &client.Policy)
 
 	return cmd
 }
@@ -11,24 +11,33 @@ require (
 	github.com/containerd/containerd v1.3.2
 	github.com/cyphar/filepath-securejoin v0.2.2
 	github.com/deislabs/oras v0.8.1
+	github.com/docker/cli v0.0.0-20200130152716-5d0cf8839492
 	github.com/docker/distribution v2.7.1+incompatible
 	github.com/docker/docker v1.4.2-0.20200203170920-46ec8731fbce
 	github.com/docker/go-units v0.4.0
 	github.com/evanphx/json-patch v4.5.0+incompatible
 	github.com/gobwas/glob v0.2.3
 	github.com/gofrs/flock v0.7.1
+	github.com/google/cel-go v0.4.1
+	github.com/googleapis/gnostic v0.3.1
 	github.com/gosuri/uitable v0.0.4
+	github.com/klauspost/compress v1.10.5
 	github.com/mattn/go-shellwords v1.0.9
+	github.com/mattn/go-sqlite3 v1.14.0
 	github.com/mitchellh/copystructure v1.0.0
+	github.com/open-policy-agent/opa v0.19.2
 	github.com/opencontainers/go-digest v1.0.0-rc1
 	github.com/opencontainers/image-spec v1.0.1
 	github.com/pkg/errors v0.9.1
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/sirupsen/logrus v1.4.2
 	github.com/spf13/cobra v0.0.5
 	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.4.0
 	github.com/xeipuuv/gojsonschema v1.1.0
+	go.opentelemetry.io/otel v0.6.0
 	golang.org/x/crypto v0.0.0-20200128174031-69ecbb4d6d5d
+	google.golang.org/grpc v1.27.1
 	k8s.io/api v0.17.2
 	k8s.io/apiextensions-apiserver v0.17.2
 	k8s.io/apimachinery v0.17.2
@@ -36,6 +45,7 @@ require (
 	k8s.io/client-go v0.17.2
 	k8s.io/klog v1.0.0
 	k8s.io/kubectl v0.17.2
+	sigs.k8s.io/cli-utils v0.15.0
 	sigs.k8s.io/yaml v1.1.0
 )
 
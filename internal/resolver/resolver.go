@@ -23,26 +23,33 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Masterminds/semver/v3"
 	"github.com/pkg/errors"
 
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/helmpath"
 	"helm.sh/helm/v3/pkg/provenance"
 	"helm.sh/helm/v3/pkg/repo"
+	"helm.sh/helm/v3/pkg/versions"
 )
 
 // Resolver resolves dependencies from semantic version ranges to a particular version.
 type Resolver struct {
 	chartpath string
 	cachepath string
+	versions  versions.Resolver
 }
 
-// New creates a new resolver for a given chart and a given helm home.
-func New(chartpath, cachepath string) *Resolver {
+// New creates a new resolver for a given chart and a given helm home. The
+// version constraints of the dependencies are resolved with versionResolver,
+// or versions.Default if it is nil.
+func New(chartpath, cachepath string, versionResolver versions.Resolver) *Resolver {
+	if versionResolver == nil {
+		versionResolver = versions.Default
+	}
 	return &Resolver{
 		chartpath: chartpath,
 		cachepath: cachepath,
+		versions:  versionResolver,
 	}
 }
 
@@ -79,8 +86,7 @@ func (r *Resolver) Resolve(reqs []*chart.Dependency, repoNames map[string]string
 			}
 			continue
 		}
-		constraint, err := semver.NewConstraint(d.Version)
-		if err != nil {
+		if _, err := r.versions.Resolve(d.Version, nil); err != nil {
 			return nil, errors.Wrapf(err, "dependency %q has an invalid version/constraint format", d.Name)
 		}
 
@@ -109,24 +115,23 @@ func (r *Resolver) Resolve(reqs []*chart.Dependency, repoNames map[string]string
 			Name:       d.Name,
 			Repository: d.Repository,
 		}
-		found := false
-		// The version are already sorted and hence the first one to satisfy the constraint is used
+		var available []string
 		for _, ver := range vs {
-			v, err := semver.NewVersion(ver.Version)
-			if err != nil || len(ver.URLs) == 0 {
+			if len(ver.URLs) == 0 {
 				// Not a legit entry.
 				continue
 			}
-			if constraint.Check(v) {
-				found = true
-				locked[i].Version = v.Original()
-				break
-			}
+			available = append(available, ver.Version)
 		}
-
-		if !found {
+		resolved, err := r.versions.Resolve(d.Version, available)
+		if err != nil {
+			return nil, errors.Wrapf(err, "dependency %q has an invalid version/constraint format", d.Name)
+		}
+		if len(resolved) == 0 {
 			missing = append(missing, d.Name)
+			continue
 		}
+		locked[i].Version = resolved[0]
 	}
 	if len(missing) > 0 {
 		return nil, errors.Errorf("can't get a valid version for repositories %s. Try changing the version constraint in Chart.yaml", strings.Join(missing, ", "))
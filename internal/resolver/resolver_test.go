@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/versions"
 )
 
 func TestResolve(t *testing.T) {
@@ -115,7 +116,7 @@ func TestResolve(t *testing.T) {
 	}
 
 	repoNames := map[string]string{"alpine": "kubernetes-charts", "redis": "kubernetes-charts"}
-	r := New("testdata/chartpath", "testdata/repository")
+	r := New("testdata/chartpath", "testdata/repository", nil)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			l, err := r.Resolve(tt.req, repoNames)
@@ -155,6 +156,32 @@ func TestResolve(t *testing.T) {
 	}
 }
 
+// oldestFirst resolves versions like versions.Default, but prefers the
+// oldest versions.
+type oldestFirst struct{}
+
+func (oldestFirst) Resolve(constraint string, available []string) ([]string, error) {
+	resolved, err := versions.Default.Resolve(constraint, available)
+	for i, j := 0, len(resolved)-1; i < j; i, j = i+1, j-1 {
+		resolved[i], resolved[j] = resolved[j], resolved[i]
+	}
+	return resolved, err
+}
+
+func TestResolveWithVersionResolver(t *testing.T) {
+	req := []*chart.Dependency{
+		{Name: "alpine", Repository: "http://example.com", Version: ">=0.1.0"},
+	}
+	repoNames := map[string]string{"alpine": "kubernetes-charts"}
+	l, err := New("testdata/chartpath", "testdata/repository", oldestFirst{}).Resolve(req, repoNames)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := l.Dependencies[0].Version; v != "0.1.0" {
+		t.Errorf("expected the version picked by the resolver, 0.1.0, got %s", v)
+	}
+}
+
 func TestHashReq(t *testing.T) {
 	expect := "sha256:fb239e836325c5fa14b29d1540a13b7d3ba13151b67fe719f820e0ef6d66aaaf"
 
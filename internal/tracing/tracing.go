@@ -0,0 +1,79 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing starts the OpenTelemetry spans of Helm's core flows and
+// propagates their context to HTTP and registry requests.
+package tracing // import "helm.sh/helm/v3/internal/tracing"
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
+	"google.golang.org/grpc/codes"
+)
+
+// InstrumentationName is the name of the tracer of Helm's spans.
+const InstrumentationName = "helm.sh/helm/v3"
+
+// Start starts a span named name as a child of the span in ctx. The span is
+// started with the tracer of provider, or without a provider, with the tracer
+// of the parent span, so spans are only recorded below a recording span.
+func Start(ctx context.Context, provider trace.Provider, name string, attrs ...kv.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	tracer := trace.SpanFromContext(ctx).Tracer()
+	if provider != nil {
+		tracer = provider.Tracer(InstrumentationName)
+	}
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends span, recording err and setting an error status if err is not nil.
+func End(ctx context.Context, span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(ctx, err)
+		span.SetStatus(codes.Unknown, err.Error())
+	}
+	span.End()
+}
+
+// Inject adds the trace context of ctx to header.
+func Inject(ctx context.Context, header http.Header) {
+	trace.TraceContext{}.Inject(ctx, header)
+}
+
+// Transport returns a transport adding the trace context of each request to
+// its headers before sending it with base.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	return transport{base: base}
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+func (t transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !trace.SpanFromContext(req.Context()).SpanContext().IsValid() {
+		return t.base.RoundTrip(req)
+	}
+	// A RoundTripper must not modify the request it is given.
+	req = req.Clone(req.Context())
+	Inject(req.Context(), req.Header)
+	return t.base.RoundTrip(req)
+}
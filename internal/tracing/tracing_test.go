@@ -0,0 +1,103 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/api/trace/testtrace"
+	"google.golang.org/grpc/codes"
+)
+
+// testProvider provides the tracer recording the spans of a test.
+type testProvider struct {
+	tracer *testtrace.Tracer
+}
+
+func (p testProvider) Tracer(string) trace.Tracer {
+	return p.tracer
+}
+
+func TestStart(t *testing.T) {
+	tracer := testtrace.NewTracer()
+
+	// Without a provider or a parent span, nothing is recorded.
+	_, span := Start(context.Background(), nil, "ignored")
+	End(context.Background(), span, nil)
+	if span.SpanContext().IsValid() {
+		t.Fatal("expected a span that is not recorded")
+	}
+
+	ctx, parent := Start(context.Background(), testProvider{tracer}, "parent")
+	_, child := Start(ctx, nil, "child")
+	End(ctx, child, errors.New("failed"))
+	End(ctx, parent, nil)
+
+	spans := tracer.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if spans[1].Name() != "child" || spans[1].ParentSpanID() != spans[0].SpanContext().SpanID {
+		t.Errorf("expected child span of parent, got %q", spans[1].Name())
+	}
+	if !spans[0].Ended() || !spans[1].Ended() {
+		t.Error("expected both spans to be ended")
+	}
+	if spans[1].StatusCode() != codes.Unknown || spans[1].StatusMessage() != "failed" {
+		t.Errorf("expected error status, got %v %q", spans[1].StatusCode(), spans[1].StatusMessage())
+	}
+	if spans[0].StatusCode() != codes.OK {
+		t.Error("expected parent span without error status")
+	}
+}
+
+func TestTransport(t *testing.T) {
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	defer srv.Close()
+	client := &http.Client{Transport: Transport(http.DefaultTransport)}
+
+	req, err := http.NewRequest("GET", srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(req); err != nil {
+		t.Fatal(err)
+	}
+	if traceparent != "" {
+		t.Errorf("expected no trace context outside a span, got %q", traceparent)
+	}
+
+	ctx, span := Start(context.Background(), testProvider{testtrace.NewTracer()}, "request")
+	defer span.End()
+	if _, err := client.Do(req.WithContext(ctx)); err != nil {
+		t.Fatal(err)
+	}
+	if traceparent == "" {
+		t.Error("expected the trace context in the request headers")
+	}
+	if req.Header.Get("traceparent") != "" {
+		t.Error("expected the original request to be left unmodified")
+	}
+}
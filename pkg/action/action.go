@@ -17,23 +17,33 @@ limitations under the License.
 package action
 
 import (
+	"os"
 	"path"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/api/trace"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
-	"helm.sh/helm/v3/internal/experimental/registry"
+	helmversion "helm.sh/helm/v3/internal/version"
 	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/helmpath"
 	"helm.sh/helm/v3/pkg/kube"
+	"helm.sh/helm/v3/pkg/logging"
+	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/resolver"
 	"helm.sh/helm/v3/pkg/storage"
 	"helm.sh/helm/v3/pkg/storage/driver"
 	"helm.sh/helm/v3/pkg/time"
+	"helm.sh/helm/v3/pkg/warning"
 )
 
 // Timestamper is a function capable of producing a timestamp.Timestamper.
@@ -51,6 +61,10 @@ var (
 	errInvalidRevision = errors.New("invalid release revision")
 	// errInvalidName indicates that an invalid release name was provided
 	errInvalidName = errors.New("invalid release name, must match regex ^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])+$ and the length must not longer than 53")
+	// errServerSideApplyUnsupported indicates that the Kubernetes client cannot perform server-side apply.
+	errServerSideApplyUnsupported = errors.New("the Kubernetes client does not support server-side apply")
+	// errPropagationUnsupported indicates that the Kubernetes client cannot choose a deletion propagation policy.
+	errPropagationUnsupported = errors.New("the Kubernetes client does not support deletion propagation policies")
 )
 
 // ValidName is a regular expression for names.
@@ -82,6 +96,34 @@ type Configuration struct {
 	Capabilities *chartutil.Capabilities
 
 	Log func(string, ...interface{})
+
+	// Logger receives the structured logs of the actions and of the getters,
+	// downloaders and registry client they use. Init defaults it to a logger
+	// writing debug messages to Log.
+	Logger logging.Logger
+
+	// TracerProvider, if set, provides the tracer of the OpenTelemetry spans
+	// of the actions. Without it, spans are only recorded below a recording
+	// span in the context given to the actions.
+	TracerProvider trace.Provider
+
+	// WaitEvents, if set, is called with each problem observed while waiting
+	// for resources, such as pods failing to pull their images.
+	WaitEvents kube.WaitEventFunc
+
+	// LifecycleHooks are called at the lifecycle events of the releases
+	// installed, upgraded or rolled back.
+	LifecycleHooks []LifecycleHook
+
+	// LockHolder identifies this client in the locks it holds on the
+	// releases it installs, upgrades or rolls back. It defaults to the
+	// user, host and process of the client.
+	LockHolder string
+
+	// ExternalDriverCommand is the command, with its arguments, run by the
+	// external storage driver. Init defaults it to the fields of
+	// HELM_DRIVER_EXTERNAL_COMMAND.
+	ExternalDriverCommand []string
 }
 
 // RESTClientGetter gets the rest client
@@ -94,6 +136,22 @@ type RESTClientGetter interface {
 // DebugLog sets the logger that writes debug strings
 type DebugLog func(format string, v ...interface{})
 
+// logger returns the Logger, which is Discard if none is set.
+func (c *Configuration) logger() logging.Logger {
+	if c.Logger == nil {
+		return logging.Discard
+	}
+	return c.Logger
+}
+
+// logWarnings logs warnings, so that they are shown to users of Helm
+// that do not read them from the results of the actions.
+func (c *Configuration) logWarnings(warnings warning.Warnings) {
+	for _, w := range warnings {
+		c.logger().Warn(w.Message, "kind", w.Kind, "source", w.Source)
+	}
+}
+
 // capabilities builds a Capabilities from discovery information.
 func (c *Configuration) getCapabilities() (*chartutil.Capabilities, error) {
 	if c.Capabilities != nil {
@@ -131,6 +189,7 @@ func (c *Configuration) getCapabilities() (*chartutil.Capabilities, error) {
 			Major:   kubeVersion.Major,
 			Minor:   kubeVersion.Minor,
 		},
+		HelmVersion: helmversion.Get(),
 	}
 	return c.Capabilities, nil
 }
@@ -219,8 +278,95 @@ func (c *Configuration) recordRelease(r *release.Release) {
 	}
 }
 
+// resolveValues returns vals with its references resolved by r, or vals itself
+// if r is nil.
+func resolveValues(r resolver.Resolver, vals map[string]interface{}) (map[string]interface{}, error) {
+	if r == nil {
+		return vals, nil
+	}
+	return r.ResolveValues(vals)
+}
+
+// ServerSideApplyOptions configures how actions apply resources with
+// server-side apply.
+type ServerSideApplyOptions struct {
+	// ServerSideApply applies resources with server-side apply instead of a
+	// client-side three-way merge patch.
+	ServerSideApply bool
+	// FieldManager is recorded as the owner of the applied fields.
+	FieldManager string
+	// ConflictPolicy decides how fields owned by other managers are handled.
+	ConflictPolicy kube.ConflictPolicy
+	// IgnoreManagers restricts ConflictPolicyIgnore to these managers.
+	IgnoreManagers []string
+}
+
+func (o ServerSideApplyOptions) applyOptions(force bool, retry ApplyRetryOptions) kube.ApplyOptions {
+	return kube.ApplyOptions{
+		Force:           force,
+		ServerSideApply: o.ServerSideApply,
+		FieldManager:    o.FieldManager,
+		ConflictPolicy:  o.ConflictPolicy,
+		IgnoreManagers:  o.IgnoreManagers,
+		Retry:           retry.ApplyRetry,
+	}
+}
+
+// ApplyRetryOptions configures how actions retry creating and updating
+// resources.
+type ApplyRetryOptions struct {
+	// ApplyRetry decides how creating or updating a resource is retried
+	// after a transient error, with or without server-side apply.
+	ApplyRetry kube.RetryPolicy
+}
+
+// createResources creates resources through the Kubernetes client, using
+// server-side apply when requested and supported.
+func (c *Configuration) createResources(resources kube.ResourceList, opts kube.ApplyOptions) (*kube.Result, error) {
+	if applier, ok := c.KubeClient.(kube.Applier); ok {
+		return applier.CreateWithOptions(resources, opts)
+	}
+	if opts.ServerSideApply {
+		return nil, errServerSideApplyUnsupported
+	}
+	return c.KubeClient.Create(resources)
+}
+
+// updateResources updates resources through the Kubernetes client, using
+// server-side apply when requested and supported.
+func (c *Configuration) updateResources(current, target kube.ResourceList, opts kube.ApplyOptions) (*kube.Result, error) {
+	if applier, ok := c.KubeClient.(kube.Applier); ok {
+		return applier.UpdateWithOptions(current, target, opts)
+	}
+	if opts.ServerSideApply {
+		return nil, errServerSideApplyUnsupported
+	}
+	return c.KubeClient.Update(current, target, opts.Force)
+}
+
+// deleteResources deletes resources through the Kubernetes client with the
+// given propagation policy. An empty policy uses the client default.
+func (c *Configuration) deleteResources(resources kube.ResourceList, policy metav1.DeletionPropagation) (*kube.Result, []error) {
+	if policy == "" {
+		return c.KubeClient.Delete(resources)
+	}
+	if deleter, ok := c.KubeClient.(kube.PropagationDeleter); ok {
+		return deleter.DeleteWithPropagation(resources, policy)
+	}
+	return nil, []error{errPropagationUnsupported}
+}
+
 // InitActionConfig initializes the action configuration
+//
+// If log is nil and a Logger is set, debug messages are logged to the Logger.
 func (c *Configuration) Init(getter genericclioptions.RESTClientGetter, namespace string, helmDriver string, log DebugLog) error {
+	if log == nil && c.Logger != nil {
+		log = logging.DebugFunc(c.Logger)
+	}
+	if c.Logger == nil {
+		c.Logger = logging.FromFunc(log)
+	}
+
 	kc := kube.New(getter)
 	kc.Log = log
 
@@ -229,20 +375,56 @@ func (c *Configuration) Init(getter genericclioptions.RESTClientGetter, namespac
 		return err
 	}
 
+	encryption, err := driver.ParseEncryption(os.Getenv("HELM_DRIVER_ENCRYPTION_KEYS"))
+	if err != nil {
+		return err
+	}
+
 	var store *storage.Storage
 	switch helmDriver {
 	case "secret", "secrets", "":
 		d := driver.NewSecrets(clientset.CoreV1().Secrets(namespace))
 		d.Log = log
+		d.Encryption = encryption
+		if v := os.Getenv("HELM_DRIVER_SECRET_ZSTD"); v != "" {
+			if d.Zstd, err = strconv.ParseBool(v); err != nil {
+				return errors.Errorf("invalid HELM_DRIVER_SECRET_ZSTD %q: must be a boolean", v)
+			}
+		}
 		store = storage.Init(d)
 	case "configmap", "configmaps":
 		d := driver.NewConfigMaps(clientset.CoreV1().ConfigMaps(namespace))
 		d.Log = log
+		d.Encryption = encryption
 		store = storage.Init(d)
 	case "memory":
 		d := driver.NewMemory()
 		d.SetNamespace(namespace)
 		store = storage.Init(d)
+	case "sqlite":
+		path := os.Getenv("HELM_DRIVER_SQLITE_PATH")
+		if path == "" {
+			path = helmpath.DataPath("releases.db")
+		}
+		d, err := driver.NewSQLite(path, namespace)
+		if err != nil {
+			return err
+		}
+		d.Log = log
+		d.Encryption = encryption
+		store = storage.Init(d)
+	case "external":
+		command := c.ExternalDriverCommand
+		if len(command) == 0 {
+			command = strings.Fields(os.Getenv("HELM_DRIVER_EXTERNAL_COMMAND"))
+		}
+		if len(command) == 0 {
+			return errors.New("HELM_DRIVER_EXTERNAL_COMMAND must be set for the external driver")
+		}
+		d := driver.NewExternal(command[0], command[1:], namespace)
+		d.Log = log
+		d.Encryption = encryption
+		store = storage.Init(d)
 	default:
 		// Not sure what to do here.
 		panic("Unknown driver in HELM_DRIVER: " + helmDriver)
@@ -20,16 +20,19 @@ import (
 	"flag"
 	"io/ioutil"
 	"net/http"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	dockerauth "github.com/deislabs/oras/pkg/auth/docker"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 
-	"helm.sh/helm/v3/internal/experimental/registry"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chartutil"
 	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/storage"
 	"helm.sh/helm/v3/pkg/storage/driver"
@@ -316,3 +319,18 @@ func TestGetVersionSet(t *testing.T) {
 		t.Error("Non-existent version is reported found.")
 	}
 }
+
+func TestInitInvalidSecretZstd(t *testing.T) {
+	defer os.Setenv("HELM_DRIVER_SECRET_ZSTD", os.Getenv("HELM_DRIVER_SECRET_ZSTD"))
+	os.Setenv("HELM_DRIVER_SECRET_ZSTD", "yes")
+
+	server := "https://localhost:6443"
+	getter := genericclioptions.NewConfigFlags(false)
+	getter.APIServer = &server
+
+	var cfg Configuration
+	err := cfg.Init(getter, "default", "secret", func(string, ...interface{}) {})
+	if err == nil || !strings.Contains(err.Error(), "HELM_DRIVER_SECRET_ZSTD") {
+		t.Errorf("Expected an invalid HELM_DRIVER_SECRET_ZSTD error, got %v", err)
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+// BatchRelease is a release installed or upgraded by a Batch.
+type BatchRelease struct {
+	// Name is the name of the release.
+	Name string
+	// Chart and Values are what the release is installed or upgraded with.
+	Chart  *chart.Chart
+	Values map[string]interface{}
+	// DependsOn lists the names of the releases of the batch that have to be
+	// installed or upgraded before this one.
+	DependsOn []string
+}
+
+// BatchResult is the outcome of a release of a Batch.
+type BatchResult struct {
+	// Name is the name of the release.
+	Name string
+	// Release is the installed or upgraded release. It may be set even if
+	// the release failed.
+	Release *release.Release
+	// Upgraded is set if the release existed and was upgraded rather than
+	// installed.
+	Upgraded bool
+	// Err is why the release failed, or was skipped because a release it
+	// depends on failed.
+	Err error
+}
+
+// Batch is the action for installing and upgrading many releases at once.
+//
+// Releases that do not exist are installed, the others are upgraded. They
+// are all in the namespace of the configuration.
+type Batch struct {
+	cfg *Configuration
+
+	// Install and Upgrade hold the options releases are installed and
+	// upgraded with. The release name of Install is ignored, and it cannot
+	// be client only.
+	Install *Install
+	Upgrade *Upgrade
+	// Concurrency is the maximum number of releases installed or upgraded
+	// at once. There is no limit if it is 0.
+	Concurrency int
+}
+
+// NewBatch creates a new Batch object with the given configuration.
+func NewBatch(cfg *Configuration) *Batch {
+	return &Batch{
+		cfg:     cfg,
+		Install: NewInstall(cfg),
+		Upgrade: NewUpgrade(cfg),
+	}
+}
+
+// Run installs or upgrades releases, concurrently except that a release waits
+// for the releases it depends on. A release is skipped if one of those fails.
+//
+// There is a result for each release, in the order of releases. The error
+// lists the releases that failed, if any.
+func (b *Batch) Run(releases []BatchRelease) ([]*BatchResult, error) {
+	return b.RunWithContext(context.Background(), releases)
+}
+
+// RunWithContext is Run with a context. Releases not started yet when the
+// context is done fail with the error of the context.
+func (b *Batch) RunWithContext(ctx context.Context, releases []BatchRelease) ([]*BatchResult, error) {
+	if b.Install.ClientOnly {
+		return nil, errors.New("batch releases cannot be installed client only")
+	}
+	index, err := batchIndex(releases)
+	if err != nil {
+		return nil, err
+	}
+
+	// The configuration is shared by all releases, so set up what they would
+	// otherwise set up concurrently.
+	if err := b.cfg.KubeClient.IsReachable(); err != nil {
+		return nil, err
+	}
+	if _, err := b.cfg.getCapabilities(); err != nil {
+		return nil, err
+	}
+
+	concurrency := b.Concurrency
+	if concurrency <= 0 {
+		concurrency = len(releases)
+	}
+	workers := make(chan struct{}, concurrency)
+	done := make([]chan struct{}, len(releases))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	results := make([]*BatchResult, len(releases))
+	var wg sync.WaitGroup
+	for i, r := range releases {
+		i, r := i, r
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[i])
+			results[i] = &BatchResult{Name: r.Name}
+
+			for _, dep := range r.DependsOn {
+				<-done[index[dep]]
+				if results[index[dep]].Err != nil {
+					results[i].Err = errors.Errorf("release %s depends on %s, which failed", r.Name, dep)
+					return
+				}
+			}
+			select {
+			case workers <- struct{}{}:
+				defer func() { <-workers }()
+			case <-ctx.Done():
+				results[i].Err = ctx.Err()
+				return
+			}
+			b.release(ctx, r, results[i])
+		}()
+	}
+	wg.Wait()
+
+	var failed []string
+	for _, res := range results {
+		if res.Err != nil {
+			failed = append(failed, res.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return results, errors.Errorf("%d of %d releases failed: %s", len(failed), len(releases), strings.Join(failed, ", "))
+	}
+	return results, nil
+}
+
+// release installs r, or upgrades it if it exists, recording the outcome in
+// res.
+func (b *Batch) release(ctx context.Context, r BatchRelease, res *BatchResult) {
+	if ctx.Err() != nil {
+		res.Err = ctx.Err()
+		return
+	}
+	if h, err := b.cfg.Releases.History(r.Name); err == driver.ErrReleaseNotFound || (err == nil && len(h) == 0) {
+		b.cfg.Log("batch: installing %s", r.Name)
+		install := *b.Install
+		install.ReleaseName = r.Name
+		res.Release, res.Err = install.RunWithContext(ctx, r.Chart, r.Values)
+		return
+	}
+	b.cfg.Log("batch: upgrading %s", r.Name)
+	upgrade := *b.Upgrade
+	res.Upgraded = true
+	res.Release, res.Err = upgrade.RunWithContext(ctx, r.Name, r.Chart, r.Values)
+}
+
+// batchIndex returns the index of each release by name. It fails if names
+// are invalid or not unique, or the dependencies of the releases are unknown
+// or circular.
+func batchIndex(releases []BatchRelease) (map[string]int, error) {
+	index := make(map[string]int, len(releases))
+	for i, r := range releases {
+		if err := validateReleaseName(r.Name); err != nil {
+			return nil, errors.Errorf("release name is invalid: %s", r.Name)
+		}
+		if _, ok := index[r.Name]; ok {
+			return nil, errors.Errorf("release %s is in the batch more than once", r.Name)
+		}
+		index[r.Name] = i
+	}
+	for _, r := range releases {
+		for _, dep := range r.DependsOn {
+			if _, ok := index[dep]; !ok {
+				return nil, errors.Errorf("release %s depends on %s, which is not in the batch", r.Name, dep)
+			}
+		}
+	}
+
+	// Walk the dependencies depth first, a release found again on the path
+	// to itself being a cycle.
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make([]int, len(releases))
+	var path []string
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			start := 0
+			for path[start] != releases[i].Name {
+				start++
+			}
+			cycle := append(append([]string{}, path[start:]...), releases[i].Name)
+			return errors.Errorf("circular dependency between releases: %s", strings.Join(cycle, " -> "))
+		}
+		state[i] = visiting
+		path = append(path, releases[i].Name)
+		deps := append([]string{}, releases[i].DependsOn...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if err := visit(index[dep]); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		return nil
+	}
+	for i := range releases {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return index, nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"helm.sh/helm/v3/pkg/release"
+)
+
+func batchAction(t *testing.T) *Batch {
+	b := NewBatch(actionConfigFixture(t))
+	b.Install.Namespace = "spaced"
+	b.Upgrade.Namespace = "spaced"
+	b.Concurrency = 2
+	return b
+}
+
+func TestBatch(t *testing.T) {
+	is := assert.New(t)
+	b := batchAction(t)
+	rel := namedReleaseStub("database", release.StatusDeployed)
+	rel.Namespace = "spaced"
+	b.cfg.Releases.Create(rel)
+
+	results, err := b.Run([]BatchRelease{
+		{Name: "frontend", Chart: buildChart(), DependsOn: []string{"backend"}},
+		{Name: "backend", Chart: buildChart(), DependsOn: []string{"database"}},
+		{Name: "database", Chart: buildChart()},
+		{Name: "monitoring", Chart: buildChart()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	is.Len(results, 4)
+	for _, res := range results {
+		is.NoError(res.Err)
+		is.Equal(release.StatusDeployed, res.Release.Info.Status, res.Name)
+	}
+	is.Equal("frontend", results[0].Name)
+	is.False(results[0].Upgraded)
+	is.True(results[2].Upgraded)
+	is.Equal(2, results[2].Release.Version)
+
+	// Each release is deployed after those it depends on.
+	is.False(results[1].Release.Info.LastDeployed.Before(results[2].Release.Info.LastDeployed))
+	is.False(results[0].Release.Info.LastDeployed.Before(results[1].Release.Info.LastDeployed))
+}
+
+func TestBatch_Failure(t *testing.T) {
+	is := assert.New(t)
+	b := batchAction(t)
+
+	results, err := b.Run([]BatchRelease{
+		{Name: "frontend", Chart: buildChart(), DependsOn: []string{"backend"}},
+		{Name: "backend", Chart: buildChart(withSampleIncludingIncorrectTemplates())},
+		{Name: "monitoring", Chart: buildChart()},
+	})
+	is.EqualError(err, "2 of 3 releases failed: frontend, backend")
+	is.EqualError(results[0].Err, "release frontend depends on backend, which failed")
+	is.Nil(results[0].Release)
+	is.Error(results[1].Err)
+	is.NoError(results[2].Err)
+}
+
+func TestBatch_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		releases []BatchRelease
+		err      string
+	}{
+		{
+			"duplicate",
+			[]BatchRelease{{Name: "a"}, {Name: "a"}},
+			"release a is in the batch more than once",
+		},
+		{
+			"unknown dependency",
+			[]BatchRelease{{Name: "a", DependsOn: []string{"b"}}},
+			"release a depends on b, which is not in the batch",
+		},
+		{
+			"cycle",
+			[]BatchRelease{
+				{Name: "a", DependsOn: []string{"b"}},
+				{Name: "b", DependsOn: []string{"c"}},
+				{Name: "c", DependsOn: []string{"b"}},
+			},
+			"circular dependency between releases: b -> c -> b",
+		},
+		{
+			"invalid name",
+			[]BatchRelease{{Name: "-invalid"}},
+			"release name is invalid: -invalid",
+		},
+	}
+	for _, tt := range tests {
+		_, err := batchAction(t).Run(tt.releases)
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("%s: expected error %q, got %v", tt.name, tt.err, err)
+		}
+	}
+}
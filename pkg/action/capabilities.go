@@ -0,0 +1,76 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"helm.sh/helm/v3/pkg/warning"
+)
+
+// checkCapabilities returns a warning for each of the manifests using an API
+// version that caps do not include. APIs of the groups defined by the CRDs of
+// the chart or by the manifests are not warned about, as they are served once
+// installed.
+func checkCapabilities(ch *chart.Chart, manifests []releaseutil.Manifest, caps *chartutil.Capabilities) warning.Warnings {
+	var w warning.Warnings
+	crdGroups := make(map[string]bool)
+	for _, crd := range ch.CRDObjects() {
+		crdGroups[crdGroup(string(crd.File.Data))] = true
+	}
+	for _, m := range manifests {
+		if m.Head != nil && m.Head.Kind == "CustomResourceDefinition" {
+			crdGroups[crdGroup(m.Content)] = true
+		}
+	}
+	delete(crdGroups, "")
+
+	for _, m := range manifests {
+		if m.Head == nil || m.Head.Version == "" || caps.APIVersions.Has(m.Head.Version) {
+			continue
+		}
+		if i := strings.LastIndex(m.Head.Version, "/"); i > 0 && crdGroups[m.Head.Version[:i]] {
+			continue
+		}
+		var name string
+		if m.Head.Metadata != nil {
+			name = m.Head.Metadata.Name
+		}
+		w.Add(warning.CapabilityMismatch, m.Name, "%s %q uses %s, which is not served by Kubernetes %s",
+			m.Head.Kind, name, m.Head.Version, caps.KubeVersion.Version)
+	}
+	return w
+}
+
+// crdGroup returns the API group defined by the CRD in manifest, or "" if it
+// cannot be read.
+func crdGroup(manifest string) string {
+	var crd struct {
+		Spec struct {
+			Group string `json:"group"`
+		} `json:"spec"`
+	}
+	if err := yaml.Unmarshal([]byte(manifest), &crd); err != nil {
+		return ""
+	}
+	return crd.Spec.Group
+}
@@ -49,6 +49,9 @@ func (d *Dependency) List(chartpath string, out io.Writer) error {
 	if err != nil {
 		return err
 	}
+	for _, w := range c.Warnings {
+		fmt.Fprintf(out, "WARNING: %s\n", w)
+	}
 
 	if c.Metadata.Dependencies == nil {
 		fmt.Fprintf(out, "WARNING: no dependencies at %s\n", filepath.Join(chartpath, "charts"))
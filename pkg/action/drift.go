@@ -0,0 +1,241 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/resource"
+
+	"helm.sh/helm/v3/pkg/kube"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// Drift is the action for detecting drift between a release and the cluster.
+//
+// It compares the manifests stored with a release against the live objects.
+// Only the fields set in the manifests are compared, so fields defaulted or
+// added by the API server are not reported as drift.
+type Drift struct {
+	cfg *Configuration
+
+	// Version is the revision to compare against. Zero means the latest.
+	Version int
+}
+
+// DriftReport lists the resources of a release that differ from the cluster.
+type DriftReport struct {
+	Release   string `json:"release"`
+	Namespace string `json:"namespace"`
+	Revision  int    `json:"revision"`
+	// Added are resources of earlier revisions that are not part of this
+	// revision but still exist in the cluster.
+	Added []*ResourceDrift `json:"added,omitempty"`
+	// Removed are resources of this revision missing from the cluster.
+	Removed []*ResourceDrift `json:"removed,omitempty"`
+	// Changed are resources whose live state differs from the manifest.
+	Changed []*ResourceDrift `json:"changed,omitempty"`
+}
+
+// ResourceDrift identifies a drifted resource.
+type ResourceDrift struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// Fields are the paths of the fields that changed.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// HasDrift reports whether any drift was found.
+func (r *DriftReport) HasDrift() bool {
+	return len(r.Added)+len(r.Removed)+len(r.Changed) > 0
+}
+
+// NewDrift creates a new Drift object with the given configuration.
+func NewDrift(cfg *Configuration) *Drift {
+	return &Drift{
+		cfg: cfg,
+	}
+}
+
+// Run compares the named release against the live cluster state.
+func (d *Drift) Run(name string) (*DriftReport, error) {
+	if err := d.cfg.KubeClient.IsReachable(); err != nil {
+		return nil, err
+	}
+	fetcher, ok := d.cfg.KubeClient.(kube.Fetcher)
+	if !ok {
+		return nil, errors.New("the Kubernetes client does not support reading live resources")
+	}
+
+	rel, err := d.cfg.releaseContent(name, d.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DriftReport{
+		Release:   rel.Name,
+		Namespace: rel.Namespace,
+		Revision:  rel.Version,
+	}
+
+	desired, err := d.cfg.KubeClient.Build(bytes.NewBufferString(rel.Manifest), false)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to build kubernetes objects from release manifest")
+	}
+	live, err := fetcher.Fetch(desired)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(desired))
+	for i, info := range desired {
+		known[resourceKey(info)] = true
+		if live[i] == nil {
+			report.Removed = append(report.Removed, newResourceDrift(info, nil))
+			continue
+		}
+		fields, err := driftedFields(info.Object, live[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to compare %s", info.ObjectName())
+		}
+		if len(fields) > 0 {
+			report.Changed = append(report.Changed, newResourceDrift(info, fields))
+		}
+	}
+
+	added, err := d.leftovers(rel, known)
+	if err != nil {
+		return nil, err
+	}
+	if len(added) > 0 {
+		live, err := fetcher.Fetch(added)
+		if err != nil {
+			return nil, err
+		}
+		for i, info := range added {
+			if live[i] != nil {
+				report.Added = append(report.Added, newResourceDrift(info, nil))
+			}
+		}
+	}
+	return report, nil
+}
+
+// leftovers returns the resources of earlier revisions of rel that are not
+// in known.
+func (d *Drift) leftovers(rel *release.Release, known map[string]bool) (kube.ResourceList, error) {
+	history, err := d.cfg.Releases.History(rel.Name)
+	if err != nil {
+		return nil, err
+	}
+	var resources kube.ResourceList
+	for _, r := range history {
+		if r.Version >= rel.Version {
+			continue
+		}
+		infos, err := d.cfg.KubeClient.Build(bytes.NewBufferString(r.Manifest), false)
+		if err != nil {
+			// Older revisions may use APIs the cluster no longer serves.
+			d.cfg.Log("skipping revision %d of %s: %s", r.Version, r.Name, err)
+			continue
+		}
+		for _, info := range infos {
+			if key := resourceKey(info); !known[key] {
+				known[key] = true
+				resources = append(resources, info)
+			}
+		}
+	}
+	return resources, nil
+}
+
+// resourceKey identifies a resource by group, kind, namespace and name.
+func resourceKey(info *resource.Info) string {
+	gk := info.Mapping.GroupVersionKind.GroupKind()
+	return fmt.Sprintf("%s/%s/%s", gk.String(), info.Namespace, info.Name)
+}
+
+func newResourceDrift(info *resource.Info, fields []string) *ResourceDrift {
+	return &ResourceDrift{
+		Kind:      info.Mapping.GroupVersionKind.Kind,
+		Name:      info.Name,
+		Namespace: info.Namespace,
+		Fields:    fields,
+	}
+}
+
+// driftedFields returns the paths of the fields set in desired whose value
+// differs in live.
+func driftedFields(desired, live runtime.Object) ([]string, error) {
+	d, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return nil, err
+	}
+	l, err := runtime.DefaultUnstructuredConverter.ToUnstructured(live)
+	if err != nil {
+		return nil, err
+	}
+	// The status is owned by the cluster.
+	delete(d, "status")
+	var fields []string
+	diffFields("", d, l, &fields)
+	sort.Strings(fields)
+	return fields, nil
+}
+
+func diffFields(path string, desired, live interface{}, fields *[]string) {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			*fields = append(*fields, path)
+			return
+		}
+		for k, v := range d {
+			lv, ok := l[k]
+			if !ok {
+				if v != nil {
+					*fields = append(*fields, path+"."+k)
+				}
+				continue
+			}
+			diffFields(path+"."+k, v, lv, fields)
+		}
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			*fields = append(*fields, path)
+			return
+		}
+		for i := range d {
+			diffFields(fmt.Sprintf("%s[%d]", path, i), d[i], l[i], fields)
+		}
+	default:
+		// Compare scalars through JSON so that numbers decoded as different
+		// types still match.
+		dj, _ := json.Marshal(desired)
+		lj, _ := json.Marshal(live)
+		if !bytes.Equal(dj, lj) {
+			*fields = append(*fields, path)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/resource"
+	"sigs.k8s.io/yaml"
+
+	"helm.sh/helm/v3/pkg/kube"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+)
+
+// liveKubeClient builds resources from manifests and serves their live state
+// from a map keyed by kind and name.
+type liveKubeClient struct {
+	kubefake.PrintingKubeClient
+	live map[string]string
+}
+
+func (c *liveKubeClient) Build(r io.Reader, _ bool) (kube.ResourceList, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var resources kube.ResourceList
+	for _, doc := range releaseutil.SplitManifests(string(data)) {
+		obj, err := decodeUnstructured(doc)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, &resource.Info{
+			Name:      obj.GetName(),
+			Namespace: obj.GetNamespace(),
+			Object:    obj,
+			Mapping:   &meta.RESTMapping{GroupVersionKind: obj.GroupVersionKind()},
+		})
+	}
+	return resources, nil
+}
+
+func (c *liveKubeClient) Fetch(resources kube.ResourceList) ([]runtime.Object, error) {
+	objs := make([]runtime.Object, len(resources))
+	for i, info := range resources {
+		if doc, ok := c.live[info.Mapping.GroupVersionKind.Kind+"/"+info.Name]; ok {
+			obj, err := decodeUnstructured(doc)
+			if err != nil {
+				return nil, err
+			}
+			objs[i] = obj
+		}
+	}
+	return objs, nil
+}
+
+func decodeUnstructured(doc string) (*unstructured.Unstructured, error) {
+	// Decode numbers as int64, as the Kubernetes client does.
+	data, err := yaml.YAMLToJSON([]byte(doc))
+	if err != nil {
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{}
+	return obj, obj.UnmarshalJSON(data)
+}
+
+const driftManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  mode: fast
+  replicas: "3"
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: web
+        image: web:v1
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+`
+
+func TestDrift(t *testing.T) {
+	is := assert.New(t)
+	config := actionConfigFixture(t)
+	config.KubeClient = &liveKubeClient{
+		PrintingKubeClient: kubefake.PrintingKubeClient{Out: ioutil.Discard},
+		live: map[string]string{
+			// Defaulted and server-populated fields must not count as drift.
+			"ConfigMap/settings": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  uid: 1234
+  resourceVersion: "42"
+data:
+  mode: slow
+  replicas: "3"
+`,
+			"Deployment/web": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 2
+  revisionHistoryLimit: 10
+  template:
+    spec:
+      containers:
+      - name: web
+        image: web:v1
+        imagePullPolicy: IfNotPresent
+status:
+  replicas: 2
+`,
+			"Job/old-job": `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: old-job
+`,
+		},
+	}
+
+	old := releaseStub()
+	old.Name = "drifty"
+	old.Version = 1
+	old.Info.Status = release.StatusSuperseded
+	old.Manifest = driftManifest + `---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: old-job
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: deleted-job
+`
+	require.NoError(t, config.Releases.Create(old))
+
+	rel := releaseStub()
+	rel.Name = "drifty"
+	rel.Version = 2
+	rel.Manifest = driftManifest
+	require.NoError(t, config.Releases.Create(rel))
+
+	report, err := NewDrift(config).Run("drifty")
+	require.NoError(t, err)
+	is.True(report.HasDrift())
+	is.Equal(2, report.Revision)
+
+	require.Len(t, report.Changed, 1)
+	is.Equal("ConfigMap", report.Changed[0].Kind)
+	is.Equal([]string{".data.mode"}, report.Changed[0].Fields)
+
+	require.Len(t, report.Removed, 1)
+	is.Equal("Service", report.Removed[0].Kind)
+
+	require.Len(t, report.Added, 1)
+	is.Equal("old-job", report.Added[0].Name)
+}
+
+func TestDrift_NoDrift(t *testing.T) {
+	config := actionConfigFixture(t)
+	rel := releaseStub()
+	require.NoError(t, config.Releases.Create(rel))
+
+	report, err := NewDrift(config).Run(rel.Name)
+	require.NoError(t, err)
+	assert.False(t, report.HasDrift())
+}
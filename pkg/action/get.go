@@ -17,7 +17,19 @@ limitations under the License.
 package action
 
 import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+
 	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
 )
 
 // Get is the action for checking a given release's information.
@@ -27,8 +39,41 @@ type Get struct {
 	cfg *Configuration
 
 	Version int
+	// Filter selects the resources returned by RunManifest.
+	Filter ManifestFilter
 }
 
+// ManifestFilter selects resources of the manifest of a release. A resource
+// is selected if it matches all the fields set.
+type ManifestFilter struct {
+	// Kinds selects the resources of any of these kinds, compared
+	// case-insensitively.
+	Kinds []string
+	// Names selects the resources with any of these names.
+	Names []string
+	// Selector is a label selector, such as "app=web,tier!=db".
+	Selector string
+	// Templates selects the resources rendered from templates matching any
+	// of these patterns, such as "mychart/templates/*.yaml". Patterns have
+	// the syntax of path.Match.
+	Templates []string
+}
+
+// Resource is a resource of the manifest of a release.
+type Resource struct {
+	// Template is the path of the template the resource was rendered from.
+	Template   string            `json:"template,omitempty"`
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Name       string            `json:"name"`
+	Namespace  string            `json:"namespace,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	// Manifest is the YAML document of the resource.
+	Manifest string `json:"manifest"`
+}
+
+var sourceComment = regexp.MustCompile(`(?m)^# Source: (.+)$`)
+
 // NewGet creates a new Get object with the given configuration.
 func NewGet(cfg *Configuration) *Get {
 	return &Get{
@@ -44,3 +89,125 @@ func (g *Get) Run(name string) (*release.Release, error) {
 
 	return g.cfg.releaseContent(name, g.Version)
 }
+
+// RunManifest returns the resources of the manifest of the given release
+// selected by the filter, in the order of the manifest.
+func (g *Get) RunManifest(name string) ([]*Resource, error) {
+	rel, err := g.Run(name)
+	if err != nil {
+		return nil, err
+	}
+	return g.Filter.Apply(rel.Manifest)
+}
+
+// RunNotesDiff returns a unified diff of the notes of the given release from
+// revision compare to the revision of g, so that operators can see how the
+// guidance of a chart changed after an upgrade. The diff is empty if the
+// notes are the same.
+func (g *Get) RunNotesDiff(name string, compare int) (string, error) {
+	if compare <= 0 {
+		return "", errors.Errorf("invalid revision to compare: %d", compare)
+	}
+	rel, err := g.Run(name)
+	if err != nil {
+		return "", err
+	}
+	base, err := g.cfg.releaseContent(name, compare)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        notesLines(base),
+		B:        notesLines(rel),
+		FromFile: fmt.Sprintf("%s revision %d", name, base.Version),
+		ToFile:   fmt.Sprintf("%s revision %d", name, rel.Version),
+		Context:  3,
+	})
+}
+
+func notesLines(rel *release.Release) []string {
+	if rel.Info == nil || rel.Info.Notes == "" {
+		return nil
+	}
+	return difflib.SplitLines(strings.TrimSuffix(rel.Info.Notes, "\n"))
+}
+
+// Apply returns the resources of manifest selected by the filter.
+func (f ManifestFilter) Apply(manifest string) ([]*Resource, error) {
+	selector, err := labels.Parse(f.Selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid label selector")
+	}
+	for _, pattern := range f.Templates {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.Wrapf(err, "invalid template pattern %q", pattern)
+		}
+	}
+
+	docs := releaseutil.SplitManifests(manifest)
+	keys := make([]string, 0, len(docs))
+	for k := range docs {
+		keys = append(keys, k)
+	}
+	sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+
+	var resources []*Resource
+	for _, k := range keys {
+		var head struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+			Metadata   struct {
+				Name      string            `json:"name"`
+				Namespace string            `json:"namespace"`
+				Labels    map[string]string `json:"labels"`
+			} `json:"metadata"`
+		}
+		if err := yaml.Unmarshal([]byte(docs[k]), &head); err != nil {
+			return nil, errors.Wrap(err, "failed to parse manifest")
+		}
+		if head.Kind == "" {
+			// A document holding only comments.
+			continue
+		}
+		r := &Resource{
+			APIVersion: head.APIVersion,
+			Kind:       head.Kind,
+			Name:       head.Metadata.Name,
+			Namespace:  head.Metadata.Namespace,
+			Labels:     head.Metadata.Labels,
+			Manifest:   docs[k],
+		}
+		if m := sourceComment.FindStringSubmatch(docs[k]); m != nil {
+			r.Template = strings.TrimSpace(m[1])
+		}
+		if f.matches(r) && selector.Matches(labels.Set(r.Labels)) {
+			resources = append(resources, r)
+		}
+	}
+	return resources, nil
+}
+
+func (f ManifestFilter) matches(r *Resource) bool {
+	if len(f.Kinds) > 0 && !anyMatch(f.Kinds, func(kind string) bool { return strings.EqualFold(kind, r.Kind) }) {
+		return false
+	}
+	if len(f.Names) > 0 && !anyMatch(f.Names, func(name string) bool { return name == r.Name }) {
+		return false
+	}
+	if len(f.Templates) > 0 && !anyMatch(f.Templates, func(pattern string) bool {
+		ok, _ := path.Match(pattern, r.Template)
+		return ok
+	}) {
+		return false
+	}
+	return true
+}
+
+func anyMatch(list []string, match func(string) bool) bool {
+	for _, s := range list {
+		if match(s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"testing"
+)
+
+const filterTestManifest = `---
+# Source: web/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+---
+# Source: web/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: prod
+  labels:
+    app: web
+---
+# Source: web/charts/db/templates/statefulset.yaml
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+  labels:
+    app: db
+---
+# Source: web/templates/empty.yaml
+# Rendered nothing.
+`
+
+func TestManifestFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter ManifestFilter
+		want   []string
+	}{
+		{"all", ManifestFilter{}, []string{"Deployment/web", "Service/web", "StatefulSet/db"}},
+		{"kinds", ManifestFilter{Kinds: []string{"deployment", "StatefulSet"}}, []string{"Deployment/web", "StatefulSet/db"}},
+		{"names", ManifestFilter{Names: []string{"web"}}, []string{"Deployment/web", "Service/web"}},
+		{"selector", ManifestFilter{Selector: "app!=web"}, []string{"StatefulSet/db"}},
+		{"templates", ManifestFilter{Templates: []string{"web/templates/*.yaml"}}, []string{"Deployment/web", "Service/web"}},
+		{"all fields", ManifestFilter{Kinds: []string{"Service"}, Names: []string{"web"}, Selector: "app=web"}, []string{"Service/web"}},
+		{"no match", ManifestFilter{Kinds: []string{"Ingress"}}, nil},
+	}
+	for _, tt := range tests {
+		resources, err := tt.filter.Apply(filterTestManifest)
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		var got []string
+		for _, r := range resources {
+			got = append(got, r.Kind+"/"+r.Name)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+				break
+			}
+		}
+	}
+
+	resources, err := ManifestFilter{Names: []string{"web"}, Kinds: []string{"Service"}}.Apply(filterTestManifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := resources[0]
+	if r.Template != "web/templates/service.yaml" || r.Namespace != "prod" || r.APIVersion != "v1" || r.Labels["app"] != "web" {
+		t.Errorf("unexpected resource %+v", r)
+	}
+
+	if _, err := (ManifestFilter{Selector: "app in"}).Apply(filterTestManifest); err == nil {
+		t.Error("expected an error for an invalid selector")
+	}
+	if _, err := (ManifestFilter{Templates: []string{"["}}).Apply(filterTestManifest); err == nil {
+		t.Error("expected an error for an invalid template pattern")
+	}
+}
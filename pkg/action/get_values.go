@@ -39,22 +39,31 @@ func NewGetValues(cfg *Configuration) *GetValues {
 
 // Run executes 'helm get values' against the given release.
 func (g *GetValues) Run(name string) (map[string]interface{}, error) {
+	vals, _, err := g.RunWithSources(name)
+	return vals, err
+}
+
+// RunWithSources gets the values of the release like Run, and also returns
+// the sources recorded for the user-supplied values, keyed by their path such
+// as "image.tag". Values without a source are chart defaults, or were set
+// before sources were recorded.
+func (g *GetValues) RunWithSources(name string) (map[string]interface{}, map[string]string, error) {
 	if err := g.cfg.KubeClient.IsReachable(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	rel, err := g.cfg.releaseContent(name, g.Version)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// If the user wants all values, compute the values and return.
 	if g.AllValues {
 		cfg, err := chartutil.CoalesceValues(rel.Chart, rel.Config)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		return cfg, nil
+		return cfg, rel.ValueSources, nil
 	}
-	return rel.Config, nil
+	return rel.Config, rel.ValueSources, nil
 }
@@ -18,12 +18,14 @@ package action
 import (
 	"bytes"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 
 	"helm.sh/helm/v3/pkg/release"
 	helmtime "helm.sh/helm/v3/pkg/time"
+	"helm.sh/helm/v3/pkg/warning"
 )
 
 // execHook executes all of the hooks for the given hook event.
@@ -38,71 +40,134 @@ func (cfg *Configuration) execHook(rl *release.Release, hook release.HookEvent,
 		}
 	}
 
-	sort.Sort(hookByWeight(executingHooks))
+	return cfg.execHooks(rl, executingHooks, hook, timeout)
+}
 
-	for _, h := range executingHooks {
-		// Set default delete policy to before-hook-creation
-		if h.DeletePolicies == nil || len(h.DeletePolicies) == 0 {
-			// TODO(jlegrone): Only apply before-hook-creation delete policy to run to completion
-			//                 resources. For all other resource types update in place if a
-			//                 resource with the same name already exists and is owned by the
-			//                 current release.
-			h.DeletePolicies = []release.HookDeletePolicy{release.HookBeforeHookCreation}
-		}
+// execHooks executes the given hooks in weight order, stopping at the first
+// failure that the failure policy of its hook does not allow.
+func (cfg *Configuration) execHooks(rl *release.Release, hooks []*release.Hook, hook release.HookEvent, timeout time.Duration) error {
+	sort.Sort(hookByWeight(hooks))
 
-		if err := cfg.deleteHookByPolicy(h, release.HookBeforeHookCreation); err != nil {
+	var mu sync.Mutex
+	for _, h := range hooks {
+		if err := cfg.runHook(rl, h, hook, timeout, &mu); err != nil {
 			return err
 		}
+	}
 
-		resources, err := cfg.KubeClient.Build(bytes.NewBufferString(h.Manifest), true)
-		if err != nil {
-			return errors.Wrapf(err, "unable to build kubernetes object for %s hook %s", hook, h.Path)
-		}
+	return cfg.deleteSucceededHooks(hooks)
+}
 
-		// Record the time at which the hook was applied to the cluster
-		h.LastRun = release.HookExecution{
-			StartedAt: helmtime.Now(),
-			Phase:     release.HookPhaseRunning,
-		}
-		cfg.recordRelease(rl)
-
-		// As long as the implementation of WatchUntilReady does not panic, HookPhaseFailed or HookPhaseSucceeded
-		// should always be set by this function. If we fail to do that for any reason, then HookPhaseUnknown is
-		// the most appropriate value to surface.
-		h.LastRun.Phase = release.HookPhaseUnknown
-
-		// Create hook resources
-		if _, err := cfg.KubeClient.Create(resources); err != nil {
-			h.LastRun.CompletedAt = helmtime.Now()
-			h.LastRun.Phase = release.HookPhaseFailed
-			return errors.Wrapf(err, "warning: Hook %s %s failed", hook, h.Path)
+// runHook runs a single hook, applying its failure policy: a hook with the
+// retry policy is run again until it succeeds or runs out of retries, and the
+// failure of a hook with the warn-and-continue policy is only a warning,
+// logged and added to the warnings of the release. mu
+// guards updates to the hook executions and the stored release so that hooks
+// may run concurrently.
+func (cfg *Configuration) runHook(rl *release.Release, h *release.Hook, hook release.HookEvent, timeout time.Duration, mu *sync.Mutex) error {
+	err := cfg.runHookOnce(rl, h, hook, timeout, mu)
+	if h.FailurePolicy == release.HookRetry {
+		for retry := 1; err != nil && retry <= h.Retries; retry++ {
+			cfg.Log("%s hook %s failed, retrying (%d/%d): %s", hook, h.Path, retry, h.Retries, err)
+			// Resources left by the failed run would prevent creating the
+			// hook again, unless its delete policies already removed them.
+			if !hookHasDeletePolicy(h, release.HookFailed) && !hookHasDeletePolicy(h, release.HookBeforeHookCreation) {
+				if err := cfg.deleteHook(h); err != nil {
+					return err
+				}
+			}
+			err = cfg.runHookOnce(rl, h, hook, timeout, mu)
 		}
+	}
+	if err != nil && h.FailurePolicy == release.HookWarnAndContinue {
+		cfg.logger().Warn("hook failed, continuing as its failure policy is "+string(h.FailurePolicy), "event", hook, "hook", h.Path, "error", err)
+		mu.Lock()
+		rl.Warnings.Add(warning.HookFailed, h.Path, "%s hook failed, continuing as its failure policy is %s: %s", hook, h.FailurePolicy, err)
+		mu.Unlock()
+		return nil
+	}
+	return err
+}
+
+// runHookOnce creates the resources for a single hook and watches them until
+// they are ready, recording the outcome on the hook.
+func (cfg *Configuration) runHookOnce(rl *release.Release, h *release.Hook, hook release.HookEvent, timeout time.Duration, mu *sync.Mutex) error {
+	// Set default delete policy to before-hook-creation
+	if h.DeletePolicies == nil || len(h.DeletePolicies) == 0 {
+		// TODO(jlegrone): Only apply before-hook-creation delete policy to run to completion
+		//                 resources. For all other resource types update in place if a
+		//                 resource with the same name already exists and is owned by the
+		//                 current release.
+		h.DeletePolicies = []release.HookDeletePolicy{release.HookBeforeHookCreation}
+	}
+
+	if err := cfg.deleteHookByPolicy(h, release.HookBeforeHookCreation); err != nil {
+		return err
+	}
+
+	resources, err := cfg.KubeClient.Build(bytes.NewBufferString(h.Manifest), true)
+	if err != nil {
+		return errors.Wrapf(err, "unable to build kubernetes object for %s hook %s", hook, h.Path)
+	}
+
+	// Record the time at which the hook was applied to the cluster
+	mu.Lock()
+	h.LastRun = release.HookExecution{
+		StartedAt: helmtime.Now(),
+		Phase:     release.HookPhaseRunning,
+	}
+	cfg.recordRelease(rl)
+
+	// As long as the implementation of WatchUntilReady does not panic, HookPhaseFailed or HookPhaseSucceeded
+	// should always be set by this function. If we fail to do that for any reason, then HookPhaseUnknown is
+	// the most appropriate value to surface.
+	h.LastRun.Phase = release.HookPhaseUnknown
+	mu.Unlock()
 
-		// Watch hook resources until they have completed
-		err = cfg.KubeClient.WatchUntilReady(resources, timeout)
-		// Note the time of success/failure
+	// Create hook resources
+	if _, err := cfg.KubeClient.Create(resources); err != nil {
+		mu.Lock()
 		h.LastRun.CompletedAt = helmtime.Now()
-		// Mark hook as succeeded or failed
-		if err != nil {
-			h.LastRun.Phase = release.HookPhaseFailed
-			// If a hook is failed, check the annotation of the hook to determine whether the hook should be deleted
-			// under failed condition. If so, then clear the corresponding resource object in the hook
-			if err := cfg.deleteHookByPolicy(h, release.HookFailed); err != nil {
-				return err
-			}
+		h.LastRun.Phase = release.HookPhaseFailed
+		mu.Unlock()
+		return errors.Wrapf(err, "warning: Hook %s %s failed", hook, h.Path)
+	}
+
+	// Watch hook resources until they have completed
+	err = cfg.KubeClient.WatchUntilReady(resources, timeout)
+	// Note the time of success/failure
+	mu.Lock()
+	h.LastRun.CompletedAt = helmtime.Now()
+	// Mark hook as succeeded or failed
+	if err != nil {
+		h.LastRun.Phase = release.HookPhaseFailed
+	} else {
+		h.LastRun.Phase = release.HookPhaseSucceeded
+	}
+	mu.Unlock()
+	if err != nil {
+		// If a hook is failed, check the annotation of the hook to determine whether the hook should be deleted
+		// under failed condition. If so, then clear the corresponding resource object in the hook
+		if err := cfg.deleteHookByPolicy(h, release.HookFailed); err != nil {
 			return err
 		}
-		h.LastRun.Phase = release.HookPhaseSucceeded
+		return err
 	}
+	return nil
+}
 
-	// If all hooks are successful, check the annotation of each hook to determine whether the hook should be deleted
-	// under succeeded condition. If so, then clear the corresponding resource object in each hook
-	for _, h := range executingHooks {
+// deleteSucceededHooks is called once all hooks are successful. It checks the annotation of each hook to
+// determine whether the hook should be deleted under succeeded condition. If so, then clear the corresponding
+// resource object in each hook. Hooks whose failure was allowed by their failure policy are left alone.
+func (cfg *Configuration) deleteSucceededHooks(hooks []*release.Hook) error {
+	for _, h := range hooks {
+		if h.LastRun.Phase == release.HookPhaseFailed {
+			continue
+		}
 		if err := cfg.deleteHookByPolicy(h, release.HookSucceeded); err != nil {
 			return err
 		}
 	}
-
 	return nil
 }
 
@@ -120,20 +185,26 @@ func (x hookByWeight) Less(i, j int) bool {
 
 // deleteHookByPolicy deletes a hook if the hook policy instructs it to
 func (cfg *Configuration) deleteHookByPolicy(h *release.Hook, policy release.HookDeletePolicy) error {
+	if hookHasDeletePolicy(h, policy) {
+		return cfg.deleteHook(h)
+	}
+	return nil
+}
+
+// deleteHook deletes the resources of a hook
+func (cfg *Configuration) deleteHook(h *release.Hook) error {
 	// Never delete CustomResourceDefinitions; this could cause lots of
 	// cascading garbage collection.
 	if h.Kind == "CustomResourceDefinition" {
 		return nil
 	}
-	if hookHasDeletePolicy(h, policy) {
-		resources, err := cfg.KubeClient.Build(bytes.NewBufferString(h.Manifest), false)
-		if err != nil {
-			return errors.Wrapf(err, "unable to build kubernetes object for deleting hook %s", h.Path)
-		}
-		_, errs := cfg.KubeClient.Delete(resources)
-		if len(errs) > 0 {
-			return errors.New(joinErrors(errs))
-		}
+	resources, err := cfg.KubeClient.Build(bytes.NewBufferString(h.Manifest), false)
+	if err != nil {
+		return errors.Wrapf(err, "unable to build kubernetes object for deleting hook %s", h.Path)
+	}
+	_, errs := cfg.KubeClient.Delete(resources)
+	if len(errs) > 0 {
+		return errors.New(joinErrors(errs))
 	}
 	return nil
 }
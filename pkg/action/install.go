@@ -18,6 +18,7 @@ package action
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -29,9 +30,11 @@ import (
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/api/kv"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/cli-runtime/pkg/resource"
 
+	"helm.sh/helm/v3/internal/tracing"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/cli"
@@ -39,12 +42,17 @@ import (
 	"helm.sh/helm/v3/pkg/engine"
 	"helm.sh/helm/v3/pkg/getter"
 	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/logging"
+	"helm.sh/helm/v3/pkg/policy"
 	"helm.sh/helm/v3/pkg/postrender"
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/releaseutil"
 	"helm.sh/helm/v3/pkg/repo"
+	"helm.sh/helm/v3/pkg/resolver"
 	"helm.sh/helm/v3/pkg/storage"
 	"helm.sh/helm/v3/pkg/storage/driver"
+	"helm.sh/helm/v3/pkg/versions"
+	"helm.sh/helm/v3/pkg/warning"
 )
 
 // releaseNameMaxLen is the maximum length of a release name.
@@ -90,12 +98,65 @@ type Install struct {
 	// APIVersions allows a manual set of supported API Versions to be passed
 	// (for things like templating). These are ignored if ClientOnly is false
 	APIVersions chartutil.VersionSet
+	// KubeVersion is the Kubernetes version to render for. Like APIVersions,
+	// it is ignored if ClientOnly is false.
+	KubeVersion *chartutil.KubeVersion
+	// Capabilities are those of the cluster to render for, instead of
+	// chartutil.DefaultCapabilities, such as loaded by
+	// chartutil.LoadCapabilities. APIVersions and KubeVersion are applied on
+	// top of them. They are ignored if ClientOnly is false.
+	Capabilities *chartutil.Capabilities
 	// Used by helm template to render charts with .Release.IsUpgrade. Ignored if Dry-Run is false
 	IsUpgrade bool
 	// Used by helm template to add the release as part of OutputDir path
 	// OutputDir/<ReleaseName>
 	UseReleaseName bool
-	PostRenderer   postrender.PostRenderer
+	// OutputOptions organize the manifests written to OutputDir.
+	OutputOptions
+	PostRenderer postrender.PostRenderer
+	// ValidateManifests validates each rendered document against the OpenAPI
+	// schema of the cluster before anything is applied. SchemaFile, or else
+	// the schema bundled with Helm, is used when the cluster schema is
+	// unavailable.
+	ValidateManifests bool
+	SchemaFile        string
+	// Policy evaluates the rendered manifests, with those of the hooks,
+	// before anything is applied. Violations fail the install with a
+	// *policy.Error, also in dry-run mode.
+	Policy policy.Evaluator
+	// AllowedNamespaces lists patterns of the namespaces, other than its own,
+	// the release may manage resources in. Resources may be in any namespace
+	// if it is empty.
+	AllowedNamespaces []string
+	// Labels are attached to the release, for example to record the team
+	// owning it. They must be valid Kubernetes labels.
+	Labels map[string]string
+	// CreateNamespace creates the release namespace if it does not exist,
+	// with the labels and annotations of the namespace metadata of the
+	// chart, and NamespaceLabels and NamespaceAnnotations on top. The
+	// release records that it manages the namespace, so that uninstalling
+	// it may delete the namespace.
+	CreateNamespace      bool
+	NamespaceLabels      map[string]string
+	NamespaceAnnotations map[string]string
+	// AdoptNamespace, with CreateNamespace, adds the labels and annotations
+	// to an existing release namespace. Otherwise an existing namespace is
+	// left alone. Adopted namespaces are not deleted with the release.
+	AdoptNamespace bool
+	// ValueSources are the sources of the values the release is installed
+	// with, recorded in the release. See values.Options.MergeValuesWithSources.
+	ValueSources map[string]string
+	// KindOrder lists kinds to install before all others, in order. It
+	// overrides the helm.sh/kind-order annotation of the chart.
+	KindOrder []string
+	// NameGenerator generates the release name when GenerateName is set. It
+	// defaults to TimestampNameGenerator.
+	NameGenerator NameGenerator
+	// ValuesResolver resolves the references in the values when rendering.
+	// The release keeps the references rather than the resolved values.
+	ValuesResolver resolver.Resolver
+	ServerSideApplyOptions
+	ApplyRetryOptions
 }
 
 // ChartPathOptions captures common options used for controlling chart paths
@@ -109,6 +170,20 @@ type ChartPathOptions struct {
 	Username string // --username
 	Verify   bool   // --verify
 	Version  string // --version
+
+	// Logger receives the logs of downloading the chart.
+	Logger logging.Logger
+	// FailOnDeprecated refuses deprecated charts with a
+	// *chart.DeprecatedError rather than warning about them.
+	FailOnDeprecated bool
+	// VerificationPolicy is the file of a downloader.VerificationPolicy the
+	// chart must satisfy, if not empty. Charts that do not are refused with
+	// a *downloader.PolicyViolationError before they are loaded.
+	VerificationPolicy string
+	// VersionResolver matches Version against the versions of the chart in
+	// repository indexes and OCI registries. versions.Default is used if
+	// none is set.
+	VersionResolver versions.Resolver
 }
 
 // NewInstall creates a new Install object with the given configuration.
@@ -149,7 +224,7 @@ func (i *Install) installCRDs(crds []chart.CRD) error {
 	i.cfg.Log("Clearing discovery cache")
 	discoveryClient.Invalidate()
 	// Give time for the CRD to be recognized.
-	if err := i.cfg.KubeClient.Wait(totalItems, 60*time.Second); err != nil {
+	if err := i.cfg.waitForResources(totalItems, 60*time.Second); err != nil {
 		return err
 	}
 	// Make sure to force a rebuild of the cache.
@@ -161,6 +236,20 @@ func (i *Install) installCRDs(crds []chart.CRD) error {
 //
 // If DryRun is set to true, this will prepare the release, but not install it
 func (i *Install) Run(chrt *chart.Chart, vals map[string]interface{}) (*release.Release, error) {
+	return i.RunWithContext(context.Background(), chrt, vals)
+}
+
+// RunWithContext executes the installation, tracing it below the span in ctx.
+func (i *Install) RunWithContext(ctx context.Context, chrt *chart.Chart, vals map[string]interface{}) (*release.Release, error) {
+	ctx, span := tracing.Start(ctx, i.cfg.TracerProvider, "install",
+		kv.String("helm.release", i.ReleaseName),
+		kv.String("helm.namespace", i.Namespace))
+	rel, err := i.run(ctx, chrt, vals)
+	tracing.End(ctx, span, err)
+	return rel, err
+}
+
+func (i *Install) run(ctx context.Context, chrt *chart.Chart, vals map[string]interface{}) (*release.Release, error) {
 	// Check reachability of cluster unless in client-only mode (e.g. `helm template` without `--validate`)
 	if !i.ClientOnly {
 		if err := i.cfg.KubeClient.IsReachable(); err != nil {
@@ -168,9 +257,25 @@ func (i *Install) Run(chrt *chart.Chart, vals map[string]interface{}) (*release.
 		}
 	}
 
+	if err := validateLabels(i.Labels); err != nil {
+		return nil, err
+	}
+	if !i.DryRun && !i.ClientOnly {
+		unlock, err := i.cfg.lockRelease(i.ReleaseName, i.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
 	if err := i.availableName(); err != nil {
 		return nil, err
 	}
+	warnings := append(warning.Warnings{}, chrt.Warnings...)
+	warnings = append(warnings, chartutil.DeprecatedValues(chrt, vals)...)
+	i.cfg.logWarnings(warnings)
+	if err := checkDeprecated(i.cfg, chrt, i.FailOnDeprecated, &warnings); err != nil {
+		return nil, err
+	}
 
 	// Pre-install anything in the crd/ directory. We do this before Helm
 	// contacts the upstream server and builds the capabilities object.
@@ -186,8 +291,15 @@ func (i *Install) Run(chrt *chart.Chart, vals map[string]interface{}) (*release.
 	if i.ClientOnly {
 		// Add mock objects in here so it doesn't use Kube API server
 		// NOTE(bacongobbler): used for `helm template`
-		i.cfg.Capabilities = chartutil.DefaultCapabilities
-		i.cfg.Capabilities.APIVersions = append(i.cfg.Capabilities.APIVersions, i.APIVersions...)
+		caps := *chartutil.DefaultCapabilities
+		if i.Capabilities != nil {
+			caps = *i.Capabilities
+		}
+		caps.APIVersions = append(append(chartutil.VersionSet{}, caps.APIVersions...), i.APIVersions...)
+		if i.KubeVersion != nil {
+			caps.KubeVersion = *i.KubeVersion
+		}
+		i.cfg.Capabilities = &caps
 		i.cfg.KubeClient = &kubefake.PrintingKubeClient{Out: ioutil.Discard}
 
 		mem := driver.NewMemory()
@@ -195,6 +307,8 @@ func (i *Install) Run(chrt *chart.Chart, vals map[string]interface{}) (*release.
 		i.cfg.Releases = storage.Init(mem)
 	} else if !i.ClientOnly && len(i.APIVersions) > 0 {
 		i.cfg.Log("API Version list given outside of client only mode, this list will be ignored")
+	} else if !i.ClientOnly && i.KubeVersion != nil {
+		i.cfg.Log("Kubernetes version given outside of client only mode, it will be ignored")
 	}
 
 	if err := chartutil.ProcessDependencies(chrt, vals); err != nil {
@@ -219,15 +333,25 @@ func (i *Install) Run(chrt *chart.Chart, vals map[string]interface{}) (*release.
 		IsInstall: !isUpgrade,
 		IsUpgrade: isUpgrade,
 	}
-	valuesToRender, err := chartutil.ToRenderValues(chrt, vals, options, caps)
+	resolved, err := resolveValues(i.ValuesResolver, vals)
+	if err != nil {
+		return nil, err
+	}
+	valuesToRender, err := chartutil.ToRenderValues(chrt, resolved, options, caps)
 	if err != nil {
 		return nil, err
 	}
 
 	rel := i.createRelease(chrt, vals)
+	rel.Warnings = warnings
+	if err := i.cfg.runLifecycleHooks(LifecyclePreRender, "install", rel, i.DryRun); err != nil {
+		return nil, err
+	}
 
 	var manifestDoc *bytes.Buffer
-	rel.Hooks, manifestDoc, rel.Info.Notes, err = i.cfg.renderResources(chrt, valuesToRender, i.ReleaseName, i.OutputDir, i.SubNotes, i.UseReleaseName, i.IncludeCRDs, i.PostRenderer)
+	_, span := tracing.Start(ctx, nil, "render")
+	rel.Hooks, manifestDoc, rel.Info.Notes, err = i.cfg.renderResources(chrt, valuesToRender, i.outputWriter(), i.SubNotes, i.IncludeCRDs, i.PostRenderer, i.KindOrder, &rel.Warnings)
+	tracing.End(ctx, span, err)
 	// Even for errors, attach this if available
 	if manifestDoc != nil {
 		rel.Manifest = manifestDoc.String()
@@ -238,10 +362,28 @@ func (i *Install) Run(chrt *chart.Chart, vals map[string]interface{}) (*release.
 		// Return a release with partial data so that the client can show debugging information.
 		return rel, err
 	}
+	if err := i.cfg.runLifecycleHooks(LifecyclePostRender, "install", rel, i.DryRun); err != nil {
+		return rel, err
+	}
 
 	// Mark this release as in-progress
 	rel.SetStatus(release.StatusPendingInstall, "Initial install underway")
 
+	if i.ValidateManifests {
+		if err := i.cfg.validateManifests(rel.Manifest, i.SchemaFile); err != nil {
+			return nil, err
+		}
+	}
+	if i.Policy != nil {
+		if err := checkPolicy(i.Policy, rel); err != nil {
+			return rel, err
+		}
+	}
+	rel.Namespaces = releaseNamespaces(rel)
+	if err := checkNamespaces(rel, i.AllowedNamespaces); err != nil {
+		return rel, err
+	}
+
 	resources, err := i.cfg.KubeClient.Build(bytes.NewBufferString(rel.Manifest), !i.DisableOpenAPIValidation)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to build kubernetes objects from release manifest")
@@ -265,6 +407,19 @@ func (i *Install) Run(chrt *chart.Chart, vals map[string]interface{}) (*release.
 		return rel, nil
 	}
 
+	if err := i.cfg.runLifecycleHooks(LifecyclePreApply, "install", rel, false); err != nil {
+		return rel, err
+	}
+
+	// The namespace must exist before the release is stored in it.
+	if i.CreateNamespace {
+		managed, err := i.createNamespace(chrt)
+		if err != nil {
+			return rel, err
+		}
+		rel.ManagesNamespace = managed
+	}
+
 	// If Replace is true, we need to supercede the last release.
 	if i.Replace {
 		if err := i.replaceRelease(rel); err != nil {
@@ -291,15 +446,20 @@ func (i *Install) Run(chrt *chart.Chart, vals map[string]interface{}) (*release.
 	// At this point, we can do the install. Note that before we were detecting whether to
 	// do an update, but it's not clear whether we WANT to do an update if the re-use is set
 	// to true, since that is basically an upgrade operation.
-	if _, err := i.cfg.KubeClient.Create(resources); err != nil {
+	_, span = tracing.Start(ctx, nil, "apply", kv.Int("helm.resources", len(resources)))
+	_, err = i.cfg.createResources(resources, i.applyOptions(false, i.ApplyRetryOptions))
+	tracing.End(ctx, span, err)
+	if err != nil {
 		return i.failRelease(rel, err)
 	}
 
 	if i.Wait {
-		if err := i.cfg.KubeClient.Wait(resources, i.Timeout); err != nil {
+		_, span := tracing.Start(ctx, nil, "wait")
+		err := i.cfg.waitForResources(resources, i.Timeout)
+		tracing.End(ctx, span, err)
+		if err != nil {
 			return i.failRelease(rel, err)
 		}
-
 	}
 
 	if !i.DisableHooks {
@@ -325,6 +485,7 @@ func (i *Install) Run(chrt *chart.Chart, vals map[string]interface{}) (*release.
 		i.cfg.Log("failed to record the release: %s", err)
 	}
 
+	i.cfg.runLifecycleHooks(LifecyclePostDeploy, "install", rel, false)
 	return rel, nil
 }
 
@@ -393,7 +554,9 @@ func (i *Install) createRelease(chrt *chart.Chart, rawVals map[string]interface{
 			LastDeployed:  ts,
 			Status:        release.StatusUnknown,
 		},
-		Version: 1,
+		Version:      1,
+		Labels:       mergeLabels(nil, i.Labels),
+		ValueSources: i.ValueSources,
 	}
 }
 
@@ -430,8 +593,10 @@ func (i *Install) replaceRelease(rel *release.Release) error {
 	return i.recordRelease(last)
 }
 
-// renderResources renders the templates in a chart
-func (c *Configuration) renderResources(ch *chart.Chart, values chartutil.Values, releaseName, outputDir string, subNotes, useReleaseName, includeCrds bool, pr postrender.PostRenderer) ([]*release.Hook, *bytes.Buffer, string, error) {
+// renderResources renders the templates in a chart. The manifests are written
+// by out rather than returned if it is not nil. Problems that do not fail
+// rendering are added to w.
+func (c *Configuration) renderResources(ch *chart.Chart, values chartutil.Values, out *outputWriter, subNotes, includeCrds bool, pr postrender.PostRenderer, kindOrder []string, w *warning.Warnings) ([]*release.Hook, *bytes.Buffer, string, error) {
 	hs := []*release.Hook{}
 	b := bytes.NewBuffer(nil)
 
@@ -486,7 +651,7 @@ func (c *Configuration) renderResources(ch *chart.Chart, values chartutil.Values
 	// Sort hooks, manifests, and partials. Only hooks and manifests are returned,
 	// as partials are not used after renderer.Render. Empty manifests are also
 	// removed here.
-	hs, manifests, err := releaseutil.SortManifests(files, caps.APIVersions, releaseutil.InstallOrder)
+	hs, manifests, err := releaseutil.SortManifests(files, caps.APIVersions, releaseutil.InstallOrderWith(chartKindOrder(ch, kindOrder)))
 	if err != nil {
 		// By catching parse errors here, we can prevent bogus releases from going
 		// to Kubernetes.
@@ -501,41 +666,42 @@ func (c *Configuration) renderResources(ch *chart.Chart, values chartutil.Values
 		}
 		return hs, b, "", err
 	}
+	manifests, err = releaseutil.SortByDependencies(manifests, false)
+	if err != nil {
+		return hs, b, "", err
+	}
+	if found := checkCapabilities(ch, manifests, caps); len(found) > 0 {
+		c.logWarnings(found)
+		if w != nil {
+			*w = append(*w, found...)
+		}
+	}
 
 	// Aggregate all valid manifests into one big doc.
-	fileWritten := make(map[string]bool)
-
 	if includeCrds {
 		for _, crd := range ch.CRDObjects() {
-			if outputDir == "" {
+			if out == nil {
 				fmt.Fprintf(b, "---\n# Source: %s\n%s\n", crd.Name, string(crd.File.Data[:]))
-			} else {
-				err = writeToFile(outputDir, crd.Filename, string(crd.File.Data[:]), fileWritten[crd.Name])
-				if err != nil {
-					return hs, b, "", err
-				}
-				fileWritten[crd.Name] = true
+			} else if err := out.write(crd.Filename, string(crd.File.Data[:]), true); err != nil {
+				return hs, b, "", err
 			}
 		}
 	}
 
+	// NOTE: We do not have to worry about the post-renderer because
+	// output dir is only used by `helm template`. In the next major
+	// release, we should move this logic to template only as it is not
+	// used by install or upgrade
 	for _, m := range manifests {
-		if outputDir == "" {
+		if out == nil {
 			fmt.Fprintf(b, "---\n# Source: %s\n%s\n", m.Name, m.Content)
-		} else {
-			newDir := outputDir
-			if useReleaseName {
-				newDir = filepath.Join(outputDir, releaseName)
-			}
-			// NOTE: We do not have to worry about the post-renderer because
-			// output dir is only used by `helm template`. In the next major
-			// release, we should move this logic to template only as it is not
-			// used by install or upgrade
-			err = writeToFile(newDir, m.Name, m.Content, fileWritten[m.Name])
-			if err != nil {
-				return hs, b, "", err
-			}
-			fileWritten[m.Name] = true
+		} else if err := out.write(m.Name, m.Content, false); err != nil {
+			return hs, b, "", err
+		}
+	}
+	if out != nil {
+		if err := out.writeIndex(); err != nil {
+			return hs, b, "", err
 		}
 	}
 
@@ -549,8 +715,30 @@ func (c *Configuration) renderResources(ch *chart.Chart, values chartutil.Values
 	return hs, b, notes, nil
 }
 
-// write the <data> to <output-dir>/<name>. <append> controls if the file is created or content will be appended
-func writeToFile(outputDir string, name string, data string, append bool) error {
+// chartKindOrder returns the kinds to install first: kindOrder if given,
+// otherwise the kinds listed in the chart's helm.sh/kind-order annotation.
+func chartKindOrder(ch *chart.Chart, kindOrder []string) []string {
+	if len(kindOrder) > 0 || ch == nil || ch.Metadata == nil {
+		return kindOrder
+	}
+	return releaseutil.ParseKindOrder(ch.Metadata.Annotations[releaseutil.KindOrderAnnotation])
+}
+
+// outputWriter returns the writer of the manifests to the output directory,
+// or nil if there is none.
+func (i *Install) outputWriter() *outputWriter {
+	if i.OutputDir == "" {
+		return nil
+	}
+	var prefix string
+	if i.UseReleaseName {
+		prefix = i.ReleaseName
+	}
+	return newOutputWriter(i.OutputDir, prefix, i.Namespace, i.OutputOptions)
+}
+
+// write the <data> rendered from <source> to <output-dir>/<name>. <append> controls if the file is created or content will be appended
+func writeToFile(outputDir string, name string, source string, data string, append bool) error {
 	outfileName := strings.Join([]string{outputDir, name}, string(filepath.Separator))
 
 	err := ensureDirectoryForFile(outfileName)
@@ -565,7 +753,7 @@ func writeToFile(outputDir string, name string, data string, append bool) error
 
 	defer f.Close()
 
-	_, err = f.WriteString(fmt.Sprintf("---\n# Source: %s\n%s\n", name, data))
+	_, err = f.WriteString(fmt.Sprintf("---\n# Source: %s\n%s\n", source, data))
 
 	if err != nil {
 		return err
@@ -628,16 +816,12 @@ func (i *Install) NameAndChart(args []string) (string, string, error) {
 		return "", args[0], errors.New("must either provide a name or specify --generate-name")
 	}
 
-	base := filepath.Base(args[0])
-	if base == "." || base == "" {
-		base = "chart"
-	}
-	// if present, strip out the file extension from the name
-	if idx := strings.Index(base, "."); idx != -1 {
-		base = base[0:idx]
+	g := i.NameGenerator
+	if g == nil {
+		g = TimestampNameGenerator
 	}
-
-	return fmt.Sprintf("%s-%d", base, time.Now().Unix()), args[0], nil
+	name, err := g.GenerateName(chartBaseName(args[0]))
+	return name, args[0], err
 }
 
 // TemplateName renders a name template, returning the name or an error.
@@ -645,19 +829,42 @@ func TemplateName(nameTemplate string) (string, error) {
 	if nameTemplate == "" {
 		return "", nil
 	}
+	return renderNameTemplate(nameTemplate, nil)
+}
 
+// renderNameTemplate renders a name template with the Sprig functions and
+// the given data.
+func renderNameTemplate(nameTemplate string, data interface{}) (string, error) {
 	t, err := template.New("name-template").Funcs(sprig.TxtFuncMap()).Parse(nameTemplate)
 	if err != nil {
 		return "", err
 	}
 	var b bytes.Buffer
-	if err := t.Execute(&b, nil); err != nil {
+	if err := t.Execute(&b, data); err != nil {
 		return "", err
 	}
 
 	return b.String(), nil
 }
 
+// checkDeprecated warns that ch is deprecated or, if fail is set, refuses it.
+func checkDeprecated(cfg *Configuration, ch *chart.Chart, fail bool, w *warning.Warnings) error {
+	md := ch.Metadata
+	if md == nil || !md.Deprecated {
+		return nil
+	}
+	if fail {
+		return &chart.DeprecatedError{Chart: md}
+	}
+	keyvals := []interface{}{"chart", md.Name, "version", md.Version}
+	if md.ReplacedBy != "" {
+		keyvals = append(keyvals, "replacedBy", md.ReplacedBy)
+	}
+	cfg.logger().Warn("chart is deprecated", keyvals...)
+	w.Add(warning.Deprecated, "Chart.yaml", "%s", md.DeprecationNotice())
+	return nil
+}
+
 // CheckDependencies checks the dependencies for a chart.
 func CheckDependencies(ch *chart.Chart, reqs []*chart.Dependency) error {
 	var missing []string
@@ -689,9 +896,23 @@ OUTER:
 //
 // If 'verify' was set on ChartPathOptions, this will attempt to also verify the chart.
 func (c *ChartPathOptions) LocateChart(name string, settings *cli.EnvSettings) (string, error) {
+	return c.LocateChartWithContext(context.Background(), name, settings)
+}
+
+// LocateChartWithContext looks for a chart like LocateChart, tracing the
+// resolution and download of the chart below the span in ctx.
+func (c *ChartPathOptions) LocateChartWithContext(ctx context.Context, name string, settings *cli.EnvSettings) (string, error) {
 	name = strings.TrimSpace(name)
 	version := strings.TrimSpace(c.Version)
 
+	var policy *downloader.VerificationPolicy
+	if c.VerificationPolicy != "" {
+		var err error
+		if policy, err = downloader.LoadVerificationPolicy(c.VerificationPolicy); err != nil {
+			return "", err
+		}
+	}
+
 	if _, err := os.Stat(name); err == nil {
 		abs, err := filepath.Abs(name)
 		if err != nil {
@@ -702,6 +923,11 @@ func (c *ChartPathOptions) LocateChart(name string, settings *cli.EnvSettings) (
 				return "", err
 			}
 		}
+		if policy != nil {
+			if err := policy.VerifyFile(abs); err != nil {
+				return "", err
+			}
+		}
 		return abs, nil
 	}
 	if filepath.IsAbs(name) || strings.HasPrefix(name, ".") {
@@ -710,19 +936,26 @@ func (c *ChartPathOptions) LocateChart(name string, settings *cli.EnvSettings) (
 
 	dl := downloader.ChartDownloader{
 		Out:     os.Stdout,
+		Logger:  c.Logger,
+		Context: ctx,
 		Keyring: c.Keyring,
-		Getters: getter.All(settings),
+		// Deprecated charts are checked by the actions once loaded.
+		Deprecation: downloader.DeprecationIgnore,
+		Policy:      policy,
+		Getters:     getter.All(settings),
 		Options: []getter.Option{
 			getter.WithBasicAuth(c.Username, c.Password),
 		},
 		RepositoryConfig: settings.RepositoryConfig,
 		RepositoryCache:  settings.RepositoryCache,
+		ArchiveCache:     &downloader.ArchiveCache{Dir: filepath.Join(settings.RepositoryCache, downloader.ArchiveCacheDir)},
+		VersionResolver:  c.VersionResolver,
 	}
 	if c.Verify {
 		dl.Verify = downloader.VerifyAlways
 	}
 	if c.RepoURL != "" {
-		chartURL, err := repo.FindChartInAuthRepoURL(c.RepoURL, c.Username, c.Password, name, version,
+		chartURL, err := repo.ResolveChartInAuthRepoURL(c.VersionResolver, c.RepoURL, c.Username, c.Password, name, version,
 			c.CertFile, c.KeyFile, c.CaFile, getter.All(settings))
 		if err != nil {
 			return "", err
@@ -741,7 +974,7 @@ func (c *ChartPathOptions) LocateChart(name string, settings *cli.EnvSettings) (
 			return filename, err
 		}
 		return lname, nil
-	} else if settings.Debug {
+	} else if _, ok := errors.Cause(err).(*downloader.PolicyViolationError); ok || settings.Debug {
 		return filename, err
 	}
 
@@ -17,7 +17,9 @@ limitations under the License.
 package action
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
@@ -26,14 +28,26 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/api/trace/testtrace"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
 
 	"helm.sh/helm/v3/internal/test"
+	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/kube"
 	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/logging"
+	"helm.sh/helm/v3/pkg/policy"
 	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/resolver"
 	"helm.sh/helm/v3/pkg/storage/driver"
 	"helm.sh/helm/v3/pkg/time"
+	"helm.sh/helm/v3/pkg/warning"
 )
 
 type nameTemplateTestCase struct {
@@ -76,6 +90,38 @@ func TestInstallRelease(t *testing.T) {
 	is.Equal(rel.Info.Description, "Install complete")
 }
 
+// testTracerProvider provides the tracer recording the spans of a test.
+type testTracerProvider struct {
+	tracer *testtrace.Tracer
+}
+
+func (p testTracerProvider) Tracer(string) trace.Tracer {
+	return p.tracer
+}
+
+func TestInstallReleaseTracing(t *testing.T) {
+	is := assert.New(t)
+	tracer := testtrace.NewTracer()
+	instAction := installAction(t)
+	instAction.cfg.TracerProvider = testTracerProvider{tracer}
+	instAction.Wait = true
+	if _, err := instAction.Run(buildChart(), map[string]interface{}{}); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	var names []string
+	for _, span := range tracer.Spans() {
+		names = append(names, span.Name())
+		is.True(span.Ended(), "expected %s to be ended", span.Name())
+	}
+	is.Equal([]string{"install", "render", "apply", "wait"}, names)
+	root := tracer.Spans()[0]
+	is.Equal("test-install-release", root.Attributes()["helm.release"].AsString())
+	for _, span := range tracer.Spans()[1:] {
+		is.Equal(root.SpanContext().SpanID, span.ParentSpanID(), "expected %s to be a child of install", span.Name())
+	}
+}
+
 func TestInstallReleaseWithValues(t *testing.T) {
 	is := assert.New(t)
 	instAction := installAction(t)
@@ -286,6 +332,227 @@ func TestInstallRelease_FailedHooks(t *testing.T) {
 	is.Equal(release.StatusFailed, res.Info.Status)
 }
 
+func TestInstallRelease_HookFailurePolicy(t *testing.T) {
+	hooks := func(policy string) *chart.File {
+		return &chart.File{Name: "templates/hooks", Data: []byte(fmt.Sprintf(`kind: ConfigMap
+metadata:
+  name: test-cm
+  annotations:
+    "helm.sh/hook": post-install
+    "helm.sh/hook-delete-policy": hook-succeeded
+%s
+data:
+  name: value`, policy))}
+	}
+
+	t.Run("warn and continue", func(t *testing.T) {
+		is := assert.New(t)
+		instAction := installAction(t)
+		failer := instAction.cfg.KubeClient.(*kubefake.FailingKubeClient)
+		failer.WatchUntilReadyError = fmt.Errorf("Failed watch")
+
+		ch := buildChart()
+		ch.Templates[1] = hooks(`    "helm.sh/hook-failure-policy": warn-and-continue`)
+		res, err := instAction.Run(ch, map[string]interface{}{})
+		is.NoError(err)
+		is.Equal(release.StatusDeployed, res.Info.Status)
+		is.Equal(release.HookPhaseFailed, res.Hooks[0].LastRun.Phase)
+		is.Len(res.Warnings.Of(warning.HookFailed), 1)
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		is := assert.New(t)
+		instAction := installAction(t)
+		failer := instAction.cfg.KubeClient.(*kubefake.FailingKubeClient)
+		failer.WatchUntilReadyError = fmt.Errorf("Failed watch")
+
+		var logs []string
+		instAction.cfg.Log = func(format string, v ...interface{}) {
+			logs = append(logs, fmt.Sprintf(format, v...))
+		}
+
+		ch := buildChart()
+		ch.Templates[1] = hooks(`    "helm.sh/hook-failure-policy": retry
+    "helm.sh/hook-retries": "2"`)
+		res, err := instAction.Run(ch, map[string]interface{}{})
+		is.Error(err)
+		is.Equal(release.StatusFailed, res.Info.Status)
+		is.Equal(release.HookPhaseFailed, res.Hooks[0].LastRun.Phase)
+
+		var retries int
+		for _, l := range logs {
+			if strings.Contains(l, "retrying") {
+				retries++
+			}
+		}
+		is.Equal(2, retries)
+	})
+}
+
+// namespaceExistsKubeClient fails to create the first resources, the
+// namespace, as if it already existed
+type namespaceExistsKubeClient struct {
+	kubefake.FailingKubeClient
+	created bool
+}
+
+func (c *namespaceExistsKubeClient) Create(resources kube.ResourceList) (*kube.Result, error) {
+	if !c.created {
+		c.created = true
+		return nil, apierrors.NewAlreadyExists(schema.GroupResource{Resource: "namespaces"}, "spaced")
+	}
+	return c.FailingKubeClient.Create(resources)
+}
+
+func TestInstallRelease_CreateNamespace(t *testing.T) {
+	is := assert.New(t)
+
+	instAction := installAction(t)
+	instAction.CreateNamespace = true
+	instAction.NamespaceLabels = map[string]string{"team": "payments"}
+	ch := buildChart()
+	ch.Metadata.Namespace = &chart.NamespaceMetadata{
+		Labels:      map[string]string{"env": "prod"},
+		Annotations: map[string]string{"example.com/owner": "payments"},
+	}
+	res, err := instAction.Run(ch, map[string]interface{}{})
+	is.NoError(err)
+	is.True(res.ManagesNamespace, "expected the release to manage the namespace it created")
+
+	upAction := NewUpgrade(instAction.cfg)
+	upAction.Namespace = instAction.Namespace
+	upgraded, err := upAction.Run(res.Name, buildChart(), map[string]interface{}{})
+	is.NoError(err)
+	is.True(upgraded.ManagesNamespace, "expected upgrades to keep managing the namespace")
+
+	instAction = installAction(t)
+	instAction.CreateNamespace = true
+	instAction.NamespaceLabels = map[string]string{"team": "payments team"}
+	_, err = instAction.Run(buildChart(), map[string]interface{}{})
+	is.Error(err)
+	is.Contains(err.Error(), "invalid namespace metadata")
+
+	for _, adopt := range []bool{false, true} {
+		instAction = installAction(t)
+		instAction.cfg.KubeClient = &namespaceExistsKubeClient{FailingKubeClient: kubefake.FailingKubeClient{PrintingKubeClient: kubefake.PrintingKubeClient{Out: ioutil.Discard}}}
+		instAction.CreateNamespace = true
+		instAction.AdoptNamespace = adopt
+		res, err = instAction.Run(buildChart(), map[string]interface{}{})
+		is.NoError(err)
+		is.False(res.ManagesNamespace, "adopt: %t", adopt)
+	}
+}
+
+func TestUninstallRelease_DeleteNamespace(t *testing.T) {
+	is := assert.New(t)
+
+	instAction := installAction(t)
+	instAction.CreateNamespace = true
+	res, err := instAction.Run(buildChart(), map[string]interface{}{})
+	is.NoError(err)
+
+	other := releaseStub()
+	other.Name = "other"
+	other.Namespace = res.Namespace
+	other.Info.Status = release.StatusFailed
+	is.NoError(instAction.cfg.Releases.Create(other))
+
+	var logs []string
+	instAction.cfg.Log = func(format string, v ...interface{}) {
+		logs = append(logs, fmt.Sprintf(format, v...))
+	}
+	unAction := NewUninstall(instAction.cfg)
+	unAction.DeleteNamespace = true
+	_, err = unAction.Run(res.Name)
+	is.NoError(err)
+	is.Contains(strings.Join(logs, "\n"), "release other (failed) is still in namespace spaced")
+
+	instAction.ReleaseName = "test-install-release-2"
+	res, err = instAction.Run(buildChart(), map[string]interface{}{})
+	is.NoError(err)
+	is.True(res.ManagesNamespace)
+	logs = nil
+	unAction.KeepHistory = true
+	_, err = unAction.Run(res.Name)
+	is.NoError(err)
+	is.Contains(strings.Join(logs, "\n"), "keeping namespace spaced")
+}
+
+// namespaceDeleteKubeClient records whether the namespace spaced is deleted
+type namespaceDeleteKubeClient struct {
+	kubefake.FailingKubeClient
+	deleted bool
+}
+
+func (c *namespaceDeleteKubeClient) Build(reader io.Reader, validate bool) (kube.ResourceList, error) {
+	b, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(string(b), "kind: Namespace") {
+		return kube.ResourceList{{Name: "spaced"}}, nil
+	}
+	return c.FailingKubeClient.Build(bytes.NewReader(b), validate)
+}
+
+func (c *namespaceDeleteKubeClient) Delete(resources kube.ResourceList) (*kube.Result, []error) {
+	for _, r := range resources {
+		if r.Name == "spaced" {
+			c.deleted = true
+		}
+	}
+	return c.FailingKubeClient.Delete(resources)
+}
+
+func TestUninstallRelease_DeleteNamespaceKeptResources(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		policy      string
+		keepKinds   []string
+		deleteError error
+		deleted     bool
+		log         string
+	}{
+		{name: "deleted", deleted: true},
+		{name: "kept resource", policy: "keep", log: "keeping namespace spaced, which holds resources kept by test-install-release"},
+		{name: "kept kind", keepKinds: []string{"ConfigMap"}, log: "keeping namespace spaced, which holds resources kept by test-install-release"},
+		{name: "failed deletion", deleteError: errors.New("delete failed"), log: "keeping namespace spaced, as test-install-release was not fully uninstalled"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			is := assert.New(t)
+
+			instAction := installAction(t)
+			kubeClient := &namespaceDeleteKubeClient{FailingKubeClient: kubefake.FailingKubeClient{PrintingKubeClient: kubefake.PrintingKubeClient{Out: ioutil.Discard}}}
+			instAction.cfg.KubeClient = kubeClient
+			instAction.CreateNamespace = true
+			ch := buildChart()
+			ch.Templates = append(ch.Templates, &chart.File{
+				Name: "templates/config",
+				Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n  annotations:\n    helm.sh/resource-policy: " + tt.policy + "\n"),
+			})
+			res, err := instAction.Run(ch, map[string]interface{}{})
+			is.NoError(err)
+			is.True(res.ManagesNamespace)
+
+			var logs []string
+			instAction.cfg.Log = func(format string, v ...interface{}) {
+				logs = append(logs, fmt.Sprintf(format, v...))
+			}
+			kubeClient.DeleteError = tt.deleteError
+			unAction := NewUninstall(instAction.cfg)
+			unAction.DisableHooks = true
+			unAction.DeleteNamespace = true
+			unAction.KeepKinds = tt.keepKinds
+			_, err = unAction.Run(res.Name)
+			is.Equal(tt.deleteError != nil, err != nil, "error: %v", err)
+			is.Equal(tt.deleted, kubeClient.deleted)
+			if tt.log != "" {
+				is.Contains(strings.Join(logs, "\n"), tt.log)
+			}
+		})
+	}
+}
+
 func TestInstallRelease_ReplaceRelease(t *testing.T) {
 	is := assert.New(t)
 	instAction := installAction(t)
@@ -340,6 +607,29 @@ func TestInstallRelease_Wait(t *testing.T) {
 	is.Equal(res.Info.Status, release.StatusFailed)
 }
 
+func TestInstallRelease_WaitEvents(t *testing.T) {
+	is := assert.New(t)
+	instAction := installAction(t)
+	instAction.ReleaseName = "come-fail-away"
+	failer := instAction.cfg.KubeClient.(*kubefake.FailingKubeClient)
+	failer.WaitError = fmt.Errorf("timed out waiting for the condition")
+	failer.WaitEvents = []kube.WaitEvent{
+		{Kind: "Pod", Namespace: "spaced", Name: "web-1", Reason: "ImagePullBackOff", Message: "Back-off pulling image \"web:v9\""},
+	}
+	var seen []kube.WaitEvent
+	instAction.cfg.WaitEvents = func(e kube.WaitEvent) {
+		seen = append(seen, e)
+	}
+	instAction.Wait = true
+
+	res, err := instAction.Run(buildChart(), map[string]interface{}{})
+	is.Error(err)
+	is.Contains(err.Error(), "timed out waiting for the condition")
+	is.Contains(err.Error(), "Pod spaced/web-1: ImagePullBackOff")
+	is.Equal(failer.WaitEvents, seen)
+	is.Equal(release.StatusFailed, res.Info.Status)
+}
+
 func TestInstallRelease_Atomic(t *testing.T) {
 	is := assert.New(t)
 
@@ -381,6 +671,296 @@ func TestInstallRelease_Atomic(t *testing.T) {
 	})
 }
 
+func TestInstallRelease_ServerSideApply(t *testing.T) {
+	is := assert.New(t)
+
+	t.Run("supported client", func(t *testing.T) {
+		instAction := installAction(t)
+		instAction.ServerSideApply = true
+		instAction.FieldManager = "test-manager"
+
+		res, err := instAction.Run(buildChart(), map[string]interface{}{})
+		is.NoError(err)
+		is.Equal(release.StatusDeployed, res.Info.Status)
+	})
+
+	t.Run("unsupported client", func(t *testing.T) {
+		instAction := installAction(t)
+		instAction.ReleaseName = "no-apply"
+		instAction.cfg.KubeClient = struct{ kube.Interface }{instAction.cfg.KubeClient}
+		instAction.ServerSideApply = true
+
+		res, err := instAction.Run(buildChart(), map[string]interface{}{})
+		is.Error(err)
+		is.Contains(err.Error(), "server-side apply")
+		is.Equal(release.StatusFailed, res.Info.Status)
+	})
+}
+
+func TestInstallRelease_ValidateManifests(t *testing.T) {
+	is := assert.New(t)
+	instAction := installAction(t)
+	instAction.ValidateManifests = true
+	instAction.SchemaFile = "testdata/openapi.json"
+
+	chrt := buildChart()
+	chrt.Templates = []*chart.File{
+		{Name: "templates/good.yaml", Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: good\ndata:\n  key: value\n")},
+		{Name: "templates/bad.yaml", Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: bad\ndatta:\n  key: value\n")},
+	}
+
+	_, err := instAction.Run(chrt, map[string]interface{}{})
+	is.Error(err)
+	is.Contains(err.Error(), "hello/templates/bad.yaml (ConfigMap bad)")
+	is.Contains(err.Error(), `unknown field "datta"`)
+	is.NotContains(err.Error(), "good.yaml")
+
+	chrt.Templates = chrt.Templates[:1]
+	instAction.ReleaseName = "valid-manifests"
+	_, err = instAction.Run(chrt, map[string]interface{}{})
+	is.NoError(err)
+}
+
+// denyKinds is a policy denying resources of some kinds.
+type denyKinds []string
+
+func (d denyKinds) Evaluate(resources []policy.Resource) ([]policy.Violation, error) {
+	var violations []policy.Violation
+	for _, r := range resources {
+		for _, kind := range d {
+			if r.Object["kind"] == kind {
+				violations = append(violations, policy.Violation{Policy: "deny-kinds", Message: kind + " is denied", Source: r.Source, Kind: kind})
+			}
+		}
+	}
+	return violations, nil
+}
+
+func TestInstallRelease_Policy(t *testing.T) {
+	is := assert.New(t)
+	instAction := installAction(t)
+	instAction.Policy = denyKinds{"Secret"}
+
+	chrt := buildChart()
+	chrt.Templates = append(chrt.Templates, &chart.File{
+		Name: "templates/secret.yaml", Data: []byte("apiVersion: v1\nkind: Secret\nmetadata:\n  name: denied\n"),
+	})
+	_, err := instAction.Run(chrt, map[string]interface{}{})
+	perr, ok := err.(*policy.Error)
+	if !ok {
+		t.Fatalf("expected a policy error, got %v", err)
+	}
+	is.Len(perr.Violations, 1)
+	is.Equal("hello/templates/secret.yaml", perr.Violations[0].Source)
+	_, err = instAction.cfg.Releases.Get(instAction.ReleaseName, 1)
+	is.Error(err, "expected no release to be stored")
+
+	// hooks are evaluated too
+	instAction.Policy = denyKinds{"ConfigMap"}
+	instAction.DryRun = true
+	_, err = instAction.Run(buildChart(), map[string]interface{}{})
+	if _, ok := err.(*policy.Error); !ok {
+		t.Fatalf("expected a policy error for the hook, got %v", err)
+	}
+
+	instAction.Policy = denyKinds{"Deployment"}
+	_, err = instAction.Run(buildChart(), map[string]interface{}{})
+	is.NoError(err)
+}
+
+func TestInstallRelease_Namespaces(t *testing.T) {
+	is := assert.New(t)
+	instAction := installAction(t)
+
+	chrt := buildChart()
+	chrt.Templates = append(chrt.Templates, &chart.File{
+		Name: "templates/monitor.yaml", Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: monitor\n  namespace: monitoring\n"),
+	})
+	res, err := instAction.Run(chrt, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	is.Equal([]string{"monitoring", "spaced"}, res.Namespaces)
+	rel, err := instAction.cfg.Releases.Get(res.Name, res.Version)
+	is.NoError(err)
+	is.Equal([]string{"monitoring", "spaced"}, rel.Namespaces)
+
+	instAction = installAction(t)
+	instAction.AllowedNamespaces = []string{"kube-*"}
+	_, err = instAction.Run(chrt, map[string]interface{}{})
+	is.EqualError(err, `release "test-install-release" manages resources in namespaces that are not allowed: monitoring`)
+
+	instAction = installAction(t)
+	instAction.AllowedNamespaces = []string{"kube-*", "monitor*"}
+	_, err = instAction.Run(chrt, map[string]interface{}{})
+	is.NoError(err)
+}
+
+func TestInstallRelease_Labels(t *testing.T) {
+	is := assert.New(t)
+	instAction := installAction(t)
+	instAction.Labels = map[string]string{"team": "payments", "example.com/ticket": "OPS-123"}
+	res, err := instAction.Run(buildChart(), map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	rel, err := instAction.cfg.Releases.Get(res.Name, res.Version)
+	is.NoError(err)
+	is.Equal(instAction.Labels, rel.Labels)
+
+	instAction = installAction(t)
+	instAction.Labels = map[string]string{"status": "ok", "team": "pay ments"}
+	_, err = instAction.Run(buildChart(), map[string]interface{}{})
+	is.Error(err)
+	is.Contains(err.Error(), "status: label is reserved by Helm")
+	is.Contains(err.Error(), "team: a valid label must be")
+}
+
+func TestInstallRelease_ValueSources(t *testing.T) {
+	is := assert.New(t)
+	instAction := installAction(t)
+	instAction.ValueSources = map[string]string{"name": "--set"}
+	res, err := instAction.Run(buildChart(), map[string]interface{}{"name": "value"})
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	is.Equal(instAction.ValueSources, res.ValueSources)
+
+	getAction := NewGetValues(instAction.cfg)
+	vals, sources, err := getAction.RunWithSources(res.Name)
+	is.NoError(err)
+	is.Equal(map[string]interface{}{"name": "value"}, vals)
+	is.Equal(map[string]string{"name": "--set"}, sources)
+}
+
+func TestInstallRelease_Deprecated(t *testing.T) {
+	is := assert.New(t)
+	instAction := installAction(t)
+	var logs bytes.Buffer
+	instAction.cfg.Logger = logging.New(&logs, logging.LevelWarn)
+	chrt := buildChart()
+	chrt.Metadata.Deprecated = true
+	chrt.Metadata.ReplacedBy = "repo/successor"
+	_, err := instAction.Run(chrt, map[string]interface{}{})
+	is.NoError(err)
+	is.Contains(logs.String(), "chart is deprecated")
+	is.Contains(logs.String(), "replacedBy=repo/successor")
+
+	instAction = installAction(t)
+	instAction.FailOnDeprecated = true
+	_, err = instAction.Run(chrt, map[string]interface{}{})
+	is.IsType(&chart.DeprecatedError{}, err)
+	is.EqualError(err, "chart hello 0.1.0 is deprecated, it is replaced by repo/successor")
+}
+
+func TestInstallRelease_ClientOnlyCapabilities(t *testing.T) {
+	is := assert.New(t)
+	instAction := installAction(t)
+	instAction.ClientOnly = true
+	instAction.Capabilities = &chartutil.Capabilities{
+		KubeVersion: chartutil.KubeVersion{Version: "v1.21.0", Major: "1", Minor: "21"},
+		APIVersions: chartutil.VersionSet{"v1", "example.com/v1"},
+	}
+	instAction.APIVersions = chartutil.VersionSet{"other.example.com/v1"}
+
+	chrt := buildChart()
+	chrt.Templates = []*chart.File{
+		{Name: "templates/configmap.yaml", Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: caps\ndata:\n  version: {{ .Capabilities.KubeVersion.Version }}\n  example: {{ .Capabilities.APIVersions.Has \"example.com/v1\" | quote }}\n  other: {{ .Capabilities.APIVersions.Has \"other.example.com/v1\" | quote }}\n  apps: {{ .Capabilities.APIVersions.Has \"apps/v1\" | quote }}\n")},
+	}
+	res, err := instAction.Run(chrt, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	is.Contains(res.Manifest, "version: v1.21.0")
+	is.Contains(res.Manifest, `example: "true"`)
+	is.Contains(res.Manifest, `other: "true"`)
+	is.Contains(res.Manifest, `apps: "false"`)
+}
+
+func TestInstallRelease_Warnings(t *testing.T) {
+	is := assert.New(t)
+	instAction := installAction(t)
+	instAction.ClientOnly = true
+	instAction.Capabilities = &chartutil.Capabilities{
+		KubeVersion: chartutil.KubeVersion{Version: "v1.21.0", Major: "1", Minor: "21"},
+		APIVersions: chartutil.VersionSet{"v1"},
+	}
+
+	chrt := buildChart()
+	chrt.Schema = []byte(`{"properties": {"legacy": {"deprecated": true}}}`)
+	chrt.Warnings.Add(warning.IgnoredFile, "charts/_disabled", "skipped")
+	chrt.Templates = []*chart.File{
+		{Name: "templates/configmap.yaml", Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n")},
+		{Name: "templates/deployment.yaml", Data: []byte("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\n")},
+		{Name: "templates/crd.yaml", Data: []byte("apiVersion: v1\nkind: CustomResourceDefinition\nmetadata:\n  name: widgets.example.com\nspec:\n  group: example.com\n")},
+		{Name: "templates/widget.yaml", Data: []byte("apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: widget\n")},
+	}
+	res, err := instAction.Run(chrt, map[string]interface{}{"legacy": true})
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	is.Equal(warning.Warnings{
+		{Kind: warning.IgnoredFile, Source: "charts/_disabled", Message: "skipped"},
+		{Kind: warning.Deprecated, Source: "legacy", Message: "value is deprecated"},
+		{Kind: warning.CapabilityMismatch, Source: "hello/templates/deployment.yaml", Message: `Deployment "app" uses apps/v1, which is not served by Kubernetes v1.21.0`},
+	}, res.Warnings)
+}
+
+func TestInstallRelease_ClientOnlyKubeVersion(t *testing.T) {
+	is := assert.New(t)
+	instAction := installAction(t)
+	instAction.ClientOnly = true
+	instAction.KubeVersion = &chartutil.KubeVersion{Version: "v1.17.0", Major: "1", Minor: "17"}
+	instAction.ValidateManifests = true
+	instAction.SchemaFile = "testdata/openapi.json"
+
+	chrt := buildChart()
+	chrt.Templates = []*chart.File{
+		{Name: "templates/configmap.yaml", Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: kube\ndata:\n  version: {{ .Capabilities.KubeVersion.Version }}\n")},
+	}
+	res, err := instAction.Run(chrt, map[string]interface{}{})
+	is.NoError(err)
+	is.Contains(res.Manifest, "version: v1.17.0")
+	is.Equal("v1.16.0", chartutil.DefaultCapabilities.KubeVersion.Version)
+
+	chrt.Templates = append(chrt.Templates, &chart.File{
+		Name: "templates/bad.yaml", Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: bad\ndatta:\n  key: value\n"),
+	})
+	instAction.ReleaseName = "kube-version-invalid"
+	_, err = instAction.Run(chrt, map[string]interface{}{})
+	is.Error(err)
+	is.Contains(err.Error(), `unknown field "datta"`)
+}
+
+func TestInstallRelease_ValuesResolver(t *testing.T) {
+	is := assert.New(t)
+	instAction := installAction(t)
+	instAction.ValuesResolver = resolver.Providers{
+		"test": resolver.ProviderFunc(func(ref string) (interface{}, error) {
+			return "resolved-" + ref, nil
+		}),
+	}
+
+	chrt := buildChart()
+	chrt.Templates = []*chart.File{
+		{Name: "templates/secret.yaml", Data: []byte("apiVersion: v1\nkind: Secret\nmetadata:\n  name: db\nstringData:\n  password: {{ .Values.password }}\n")},
+	}
+	vals := map[string]interface{}{
+		"password": map[string]interface{}{"valueFrom": "test:db"},
+	}
+	res, err := instAction.Run(chrt, vals)
+	is.NoError(err)
+	is.Contains(res.Manifest, "password: resolved-db")
+	// The release keeps the reference rather than the secret.
+	is.Equal(vals, res.Config)
+
+	instAction.ReleaseName = "values-resolver-unknown"
+	vals["password"] = map[string]interface{}{"valueFrom": "vault:db"}
+	_, err = instAction.Run(chrt, vals)
+	is.Error(err)
+	is.Contains(err.Error(), `no provider for references of scheme "vault"`)
+}
+
 func TestNameTemplate(t *testing.T) {
 	testCases := []nameTemplateTestCase{
 		// Just a straight up nop please
@@ -486,6 +1066,109 @@ func TestInstallReleaseOutputDir(t *testing.T) {
 	is.True(os.IsNotExist(err))
 }
 
+func TestInstallOutputDirSplitByResource(t *testing.T) {
+	is := assert.New(t)
+	instAction := installAction(t)
+	dir, err := ioutil.TempDir("", "output-dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	instAction.OutputDir = dir
+	instAction.SplitByResource = true
+	instAction.GroupByNamespace = true
+	instAction.WriteIndex = true
+
+	chrt := buildChart(withSampleTemplates(), withMultipleManifestTemplate())
+	chrt.Templates = append(chrt.Templates, &chart.File{
+		Name: "templates/other",
+		Data: []byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: other\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: other\n  namespace: other\n"),
+	})
+	if _, err := instAction.Run(chrt, map[string]interface{}{}); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	for _, name := range []string{"spaced/role_schedule-agents.yaml", "spaced/rolebinding_schedule-agents.yaml", "other/configmap_other.yaml", "_cluster/namespace_other.yaml"} {
+		_, err = os.Stat(filepath.Join(dir, name))
+		is.NoError(err, name)
+	}
+	// Documents without a kind and a name are written to files named after
+	// their templates.
+	_, err = os.Stat(filepath.Join(dir, "spaced/_.yaml"))
+	is.True(os.IsNotExist(err), "expected no spaced/_.yaml, got %v", err)
+	data, err := ioutil.ReadFile(filepath.Join(dir, "spaced/hello/templates/goodbye"))
+	is.NoError(err)
+	is.Equal("---\n# Source: hello/templates/goodbye\ngoodbye: world\n", string(data))
+	data, err = ioutil.ReadFile(filepath.Join(dir, "other/configmap_other.yaml"))
+	is.NoError(err)
+	is.Equal("---\n# Source: hello/templates/other\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: other\n  namespace: other\n", string(data))
+
+	data, err = ioutil.ReadFile(filepath.Join(dir, OutputIndexFile))
+	is.NoError(err)
+	var index OutputIndex
+	is.NoError(yaml.Unmarshal(data, &index))
+	is.Contains(index.Resources, OutputResource{
+		Path:       "other/configmap_other.yaml",
+		Source:     "hello/templates/other",
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Name:       "other",
+		Namespace:  "other",
+	})
+	is.Contains(index.Resources, OutputResource{
+		Path:       "_cluster/namespace_other.yaml",
+		Source:     "hello/templates/other",
+		APIVersion: "v1",
+		Kind:       "Namespace",
+		Name:       "other",
+	})
+	is.Contains(index.Resources, OutputResource{
+		Path:      "spaced/hello/templates/goodbye",
+		Source:    "hello/templates/goodbye",
+		Namespace: "spaced",
+	})
+	is.Contains(index.Resources, OutputResource{
+		Path:       "spaced/role_schedule-agents.yaml",
+		Source:     "hello/templates/rbac",
+		APIVersion: "rbac.authorization.k8s.io/v1",
+		Kind:       "Role",
+		Name:       "schedule-agents",
+		Namespace:  "spaced",
+	})
+}
+
+func TestInstallOutputDirEscape(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		manifest string
+	}{
+		{"namespace", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: escape\n  namespace: ../../x\n"},
+		{"name", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: ../../x\n"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			instAction := installAction(t)
+			dir, err := ioutil.TempDir("", "output-dir")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			instAction.OutputDir = filepath.Join(dir, "a", "b")
+			instAction.SplitByResource = true
+			instAction.GroupByNamespace = true
+
+			chrt := buildChart()
+			chrt.Templates = append(chrt.Templates, &chart.File{
+				Name: "templates/escape",
+				Data: []byte(tt.manifest),
+			})
+			_, err = instAction.Run(chrt, map[string]interface{}{})
+			assert.Error(t, err)
+			_, err = os.Stat(filepath.Join(dir, "x"))
+			assert.True(t, os.IsNotExist(err), "file written outside of the output directory")
+		})
+	}
+}
+
 func TestInstallOutputDirWithReleaseName(t *testing.T) {
 	is := assert.New(t)
 	instAction := installAction(t)
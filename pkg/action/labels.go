@@ -0,0 +1,84 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// reservedLabels are the labels Helm sets on release records itself.
+var reservedLabels = map[string]bool{
+	"name":       true,
+	"owner":      true,
+	"status":     true,
+	"version":    true,
+	"createdAt":  true,
+	"modifiedAt": true,
+	"chunks":     true,
+}
+
+// validateLabels checks that labels are valid Kubernetes labels that Helm
+// does not reserve for itself.
+func validateLabels(labels map[string]string) error {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var problems []string
+	for _, k := range keys {
+		if reservedLabels[k] {
+			problems = append(problems, k+": label is reserved by Helm")
+			continue
+		}
+		for _, msg := range validation.IsQualifiedName(k) {
+			problems = append(problems, k+": "+msg)
+		}
+		for _, msg := range validation.IsValidLabelValue(labels[k]) {
+			problems = append(problems, k+": "+msg)
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Errorf("invalid release labels:\n- %s", strings.Join(problems, "\n- "))
+	}
+	return nil
+}
+
+// mergeLabels returns current overridden by labels. Labels with an empty
+// value are removed.
+func mergeLabels(current, labels map[string]string) map[string]string {
+	merged := make(map[string]string, len(current)+len(labels))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k, v := range labels {
+		if v == "" {
+			delete(merged, k)
+		} else {
+			merged[k] = v
+		}
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
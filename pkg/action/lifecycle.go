@@ -0,0 +1,99 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// LifecycleEvent is a point in the life of a release at which the
+// lifecycle hooks of a Configuration are called.
+type LifecycleEvent string
+
+const (
+	// LifecyclePreRender is before the chart of a release is rendered.
+	LifecyclePreRender LifecycleEvent = "pre-render"
+	// LifecyclePostRender is after the chart of a release is rendered.
+	LifecyclePostRender LifecycleEvent = "post-render"
+	// LifecyclePreApply is before the resources of a release are applied to
+	// the cluster.
+	LifecyclePreApply LifecycleEvent = "pre-apply"
+	// LifecyclePostDeploy is after a release is deployed.
+	LifecyclePostDeploy LifecycleEvent = "post-deploy"
+)
+
+// LifecycleContext describes the release a lifecycle hook is called for.
+type LifecycleContext struct {
+	Event LifecycleEvent `json:"event"`
+	// Action is the action changing the release: install, upgrade or
+	// rollback.
+	Action    string          `json:"action"`
+	DryRun    bool            `json:"dryRun,omitempty"`
+	Name      string          `json:"name"`
+	Namespace string          `json:"namespace"`
+	Revision  int             `json:"revision"`
+	Chart     *chart.Metadata `json:"chart,omitempty"`
+	// Values are the values supplied by the user.
+	Values map[string]interface{} `json:"values,omitempty"`
+	// Manifest is the rendered manifest. It is empty for pre-render.
+	Manifest string `json:"manifest,omitempty"`
+	// Status is the status of the release.
+	Status release.Status `json:"status,omitempty"`
+}
+
+// LifecycleHook is called at each lifecycle event of the releases changed
+// through a Configuration.
+//
+// An error returned before the release is applied aborts the action. Errors
+// returned for post-deploy are logged, as the release is already deployed.
+type LifecycleHook func(ctx *LifecycleContext) error
+
+// runLifecycleHooks calls the lifecycle hooks for the event on rel.
+func (c *Configuration) runLifecycleHooks(event LifecycleEvent, action string, rel *release.Release, dryRun bool) error {
+	if len(c.LifecycleHooks) == 0 {
+		return nil
+	}
+	ctx := &LifecycleContext{
+		Event:     event,
+		Action:    action,
+		DryRun:    dryRun,
+		Name:      rel.Name,
+		Namespace: rel.Namespace,
+		Revision:  rel.Version,
+		Values:    rel.Config,
+		Manifest:  rel.Manifest,
+	}
+	if rel.Chart != nil {
+		ctx.Chart = rel.Chart.Metadata
+	}
+	if rel.Info != nil {
+		ctx.Status = rel.Info.Status
+	}
+	for _, hook := range c.LifecycleHooks {
+		if err := hook(ctx); err != nil {
+			if event == LifecyclePostDeploy {
+				c.Log("%s hook failed for %s: %s", event, rel.Name, err)
+				continue
+			}
+			return errors.Wrapf(err, "%s hook failed", event)
+		}
+	}
+	return nil
+}
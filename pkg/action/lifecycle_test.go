@@ -0,0 +1,140 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// recordLifecycle returns a lifecycle hook recording the contexts it is
+// called with, failing for the event fail.
+func recordLifecycle(calls *[]LifecycleContext, fail LifecycleEvent) LifecycleHook {
+	return func(ctx *LifecycleContext) error {
+		*calls = append(*calls, *ctx)
+		if ctx.Event == fail {
+			return fmt.Errorf("%s denied", ctx.Event)
+		}
+		return nil
+	}
+}
+
+func lifecycleEvents(calls []LifecycleContext) []LifecycleEvent {
+	events := make([]LifecycleEvent, len(calls))
+	for i, call := range calls {
+		events[i] = call.Event
+	}
+	return events
+}
+
+func TestLifecycleHooks_Install(t *testing.T) {
+	is := assert.New(t)
+	instAction := installAction(t)
+	var calls []LifecycleContext
+	instAction.cfg.LifecycleHooks = []LifecycleHook{recordLifecycle(&calls, "")}
+
+	_, err := instAction.Run(buildChart(), map[string]interface{}{"name": "value"})
+	require.NoError(t, err)
+
+	is.Equal([]LifecycleEvent{LifecyclePreRender, LifecyclePostRender, LifecyclePreApply, LifecyclePostDeploy}, lifecycleEvents(calls))
+	is.Equal("install", calls[0].Action)
+	is.Equal("test-install-release", calls[0].Name)
+	is.Equal("spaced", calls[0].Namespace)
+	is.Equal("hello", calls[0].Chart.Name)
+	is.Equal("value", calls[0].Values["name"])
+	is.Empty(calls[0].Manifest)
+	is.Contains(calls[1].Manifest, "hello")
+	is.Equal(release.StatusDeployed, calls[3].Status)
+}
+
+func TestLifecycleHooks_InstallDryRun(t *testing.T) {
+	instAction := installAction(t)
+	instAction.DryRun = true
+	var calls []LifecycleContext
+	instAction.cfg.LifecycleHooks = []LifecycleHook{recordLifecycle(&calls, "")}
+
+	_, err := instAction.Run(buildChart(), map[string]interface{}{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []LifecycleEvent{LifecyclePreRender, LifecyclePostRender}, lifecycleEvents(calls))
+	assert.True(t, calls[0].DryRun)
+}
+
+func TestLifecycleHooks_PreApplyAborts(t *testing.T) {
+	instAction := installAction(t)
+	var calls []LifecycleContext
+	instAction.cfg.LifecycleHooks = []LifecycleHook{recordLifecycle(&calls, LifecyclePreApply)}
+
+	_, err := instAction.Run(buildChart(), map[string]interface{}{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pre-apply hook failed: pre-apply denied")
+
+	_, err = instAction.cfg.Releases.Get(instAction.ReleaseName, 1)
+	assert.Error(t, err, "expected the release not to be stored")
+}
+
+func TestLifecycleHooks_PostDeployLogged(t *testing.T) {
+	instAction := installAction(t)
+	var calls []LifecycleContext
+	instAction.cfg.LifecycleHooks = []LifecycleHook{recordLifecycle(&calls, LifecyclePostDeploy)}
+
+	rel, err := instAction.Run(buildChart(), map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, release.StatusDeployed, rel.Info.Status)
+}
+
+func TestLifecycleHooks_Upgrade(t *testing.T) {
+	is := assert.New(t)
+	upAction := upgradeAction(t)
+	rel := releaseStub()
+	rel.Info.Status = release.StatusDeployed
+	require.NoError(t, upAction.cfg.Releases.Create(rel))
+
+	var calls []LifecycleContext
+	upAction.cfg.LifecycleHooks = []LifecycleHook{recordLifecycle(&calls, "")}
+
+	_, err := upAction.Run(rel.Name, buildChart(), map[string]interface{}{})
+	require.NoError(t, err)
+
+	is.Equal([]LifecycleEvent{LifecyclePreRender, LifecyclePostRender, LifecyclePreApply, LifecyclePostDeploy}, lifecycleEvents(calls))
+	is.Equal("upgrade", calls[0].Action)
+	is.Equal(2, calls[0].Revision)
+	is.Equal(release.StatusDeployed, calls[3].Status)
+}
+
+func TestLifecycleHooks_UpgradePostRenderAborts(t *testing.T) {
+	upAction := upgradeAction(t)
+	rel := releaseStub()
+	rel.Info.Status = release.StatusDeployed
+	require.NoError(t, upAction.cfg.Releases.Create(rel))
+
+	var calls []LifecycleContext
+	upAction.cfg.LifecycleHooks = []LifecycleHook{recordLifecycle(&calls, LifecyclePostRender)}
+
+	_, err := upAction.Run(rel.Name, buildChart(), map[string]interface{}{})
+	require.Error(t, err)
+	assert.Equal(t, []LifecycleEvent{LifecyclePreRender, LifecyclePostRender}, lifecycleEvents(calls))
+
+	last, err := upAction.cfg.Releases.Last(rel.Name)
+	require.NoError(t, err)
+	assert.Equal(t, 1, last.Version)
+}
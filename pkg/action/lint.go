@@ -25,6 +25,7 @@ import (
 	"github.com/pkg/errors"
 
 	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/deprecation"
 	"helm.sh/helm/v3/pkg/lint"
 	"helm.sh/helm/v3/pkg/lint/support"
 )
@@ -36,12 +37,23 @@ type Lint struct {
 	Strict        bool
 	Namespace     string
 	WithSubcharts bool
+	// KubeVersion is the Kubernetes version to render the charts for and to
+	// check them for deprecated and removed APIs against, if set.
+	KubeVersion *chartutil.KubeVersion
+	// Fix corrects the fixable findings of chart directories before linting
+	// them. Packaged charts are linted unchanged.
+	Fix bool
 }
 
 type LintResult struct {
 	TotalChartsLinted int
 	Messages          []support.Message
 	Errors            []error
+	// Deprecations are the resources using APIs deprecated or removed in
+	// the Kubernetes version linted for.
+	Deprecations []*deprecation.Finding
+	// Fixes are the changes made to the charts when fixing them.
+	Fixes []support.Fix
 }
 
 // NewLint creates a new Lint object with the given configuration.
@@ -57,7 +69,18 @@ func (l *Lint) Run(paths []string, vals map[string]interface{}) *LintResult {
 	}
 	result := &LintResult{}
 	for _, path := range paths {
-		linter, err := lintChart(path, vals, l.Namespace, l.Strict)
+		if l.Fix {
+			if fi, err := os.Stat(path); err == nil && fi.IsDir() {
+				fixes, err := lint.Fix(path)
+				result.Fixes = append(result.Fixes, fixes...)
+				if err != nil {
+					result.Errors = append(result.Errors, errors.Wrapf(err, "unable to fix chart %s", path))
+					continue
+				}
+			}
+		}
+
+		linter, err := lintChart(path, vals, l.Namespace, l.KubeVersion, l.Strict)
 		if err != nil {
 			result.Errors = append(result.Errors, err)
 			continue
@@ -69,12 +92,15 @@ func (l *Lint) Run(paths []string, vals map[string]interface{}) *LintResult {
 			if msg.Severity >= lowestTolerance {
 				result.Errors = append(result.Errors, msg.Err)
 			}
+			if f, ok := msg.Err.(*deprecation.Finding); ok {
+				result.Deprecations = append(result.Deprecations, f)
+			}
 		}
 	}
 	return result
 }
 
-func lintChart(path string, vals map[string]interface{}, namespace string, strict bool) (support.Linter, error) {
+func lintChart(path string, vals map[string]interface{}, namespace string, kubeVersion *chartutil.KubeVersion, strict bool) (support.Linter, error) {
 	var chartPath string
 	linter := support.Linter{}
 
@@ -113,5 +139,5 @@ func lintChart(path string, vals map[string]interface{}, namespace string, stric
 		return linter, errors.Wrap(err, "unable to check Chart.yaml file in chart")
 	}
 
-	return lint.All(chartPath, vals, namespace, strict), nil
+	return lint.AllWithKubeVersion(chartPath, vals, namespace, kubeVersion, strict), nil
 }
@@ -20,8 +20,12 @@ import (
 	"path"
 	"regexp"
 
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/releaseutil"
+	"helm.sh/helm/v3/pkg/storage/driver"
 )
 
 // ListStates represents zero or more status codes that a list item may have set
@@ -78,6 +82,30 @@ func (s ListStates) FromName(str string) ListStates {
 	return ListUnknown
 }
 
+// statuses returns the names of the statuses in s, or nil if s includes
+// unknown statuses, which cannot be listed by name.
+func (s ListStates) statuses() []string {
+	if s&ListUnknown != 0 {
+		return nil
+	}
+	var names []string
+	for _, status := range []release.Status{
+		release.StatusDeployed,
+		release.StatusUninstalled,
+		release.StatusUninstalling,
+		release.StatusPendingInstall,
+		release.StatusPendingUpgrade,
+		release.StatusPendingRollback,
+		release.StatusSuperseded,
+		release.StatusFailed,
+	} {
+		if s&s.FromName(status.String()) != 0 {
+			names = append(names, status.String())
+		}
+	}
+	return names
+}
+
 // ListAll is a convenience for enabling all list filters
 const ListAll = ListDeployed | ListUninstalled | ListUninstalling | ListPendingInstall | ListPendingRollback | ListPendingUpgrade | ListSuperseded | ListFailed
 
@@ -118,7 +146,10 @@ type List struct {
 	// Offset is the starting index for the Run() call
 	Offset int
 	// Filter is a filter that is applied to the results
-	Filter       string
+	Filter string
+	// Selector is a Kubernetes label selector the labels of the latest
+	// revision of releases must match, such as "team=payments,tier!=test".
+	Selector     string
 	Short        bool
 	Uninstalled  bool
 	Superseded   bool
@@ -126,12 +157,20 @@ type List struct {
 	Deployed     bool
 	Failed       bool
 	Pending      bool
+	// PageSize is the number of release records read from storage at a
+	// time. Zero reads them all at once.
+	PageSize int
 }
 
+// defaultListPageSize is the default number of release records read from
+// storage at a time.
+const defaultListPageSize = 500
+
 // NewList constructs a new *List
 func NewList(cfg *Configuration) *List {
 	return &List{
 		StateMask: ListDeployed | ListFailed,
+		PageSize:  defaultListPageSize,
 		cfg:       cfg,
 	}
 }
@@ -151,25 +190,53 @@ func (l *List) Run() ([]*release.Release, error) {
 		}
 	}
 
-	results, err := l.cfg.Releases.List(func(rel *release.Release) bool {
-		// Skip anything that the mask doesn't cover
-		currentStatus := l.StateMask.FromName(rel.Info.Status.String())
-		if l.StateMask&currentStatus == 0 {
-			return false
+	selector := labels.Everything()
+	if l.Selector != "" {
+		var err error
+		selector, err = labels.Parse(l.Selector)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid selector")
 		}
+	}
 
-		// Skip anything that doesn't match the filter.
-		if filter != nil && !filter.MatchString(rel.Name) {
-			return false
+	// Read the releases a page at a time, letting storage filter them by
+	// status, and only keep the latest revision of each release.
+	latest := make(map[string]*release.Release)
+	opts := driver.ListOptions{Statuses: l.StateMask.statuses(), Limit: l.PageSize}
+	for {
+		page, next, err := l.cfg.Releases.ListPage(opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, rel := range page {
+			// Skip anything that the mask doesn't cover
+			currentStatus := l.StateMask.FromName(rel.Info.Status.String())
+			if l.StateMask&currentStatus == 0 {
+				continue
+			}
+
+			// Skip anything that doesn't match the filter.
+			if filter != nil && !filter.MatchString(rel.Name) {
+				continue
+			}
+			keepLatest(latest, rel)
+		}
+		if next == "" {
+			break
 		}
-		return true
-	})
+		opts.Continue = next
+	}
 
-	if results == nil {
-		return results, nil
+	if len(latest) == 0 {
+		return nil, nil
 	}
 
-	results = filterList(results)
+	results := make([]*release.Release, 0, len(latest))
+	for _, rel := range latest {
+		if selector.Matches(labels.Set(rel.Labels)) {
+			results = append(results, rel)
+		}
+	}
 
 	// Unfortunately, we have to sort before truncating, which can incur substantial overhead
 	l.sort(results)
@@ -190,7 +257,7 @@ func (l *List) Run() ([]*release.Release, error) {
 	}
 	results = results[l.Offset:last]
 
-	return results, err
+	return results, nil
 }
 
 // sort is an in-place sort where order is based on the value of a.Sort
@@ -223,12 +290,7 @@ func filterList(releases []*release.Release) []*release.Release {
 	latestReleases := make(map[string]*release.Release)
 
 	for _, rls := range releases {
-		name, namespace := rls.Name, rls.Namespace
-		key := path.Join(namespace, name)
-		if latestRelease, exists := latestReleases[key]; exists && latestRelease.Version > rls.Version {
-			continue
-		}
-		latestReleases[key] = rls
+		keepLatest(latestReleases, rls)
 	}
 
 	var list = make([]*release.Release, 0, len(latestReleases))
@@ -238,6 +300,16 @@ func filterList(releases []*release.Release) []*release.Release {
 	return list
 }
 
+// keepLatest records rls in latest, keyed by namespace and name, unless a
+// later revision of it is already recorded.
+func keepLatest(latest map[string]*release.Release, rls *release.Release) {
+	key := path.Join(rls.Namespace, rls.Name)
+	if latestRelease, exists := latest[key]; exists && latestRelease.Version > rls.Version {
+		return
+	}
+	latest[key] = rls
+}
+
 // setStateMask calculates the state mask based on parameters.
 func (l *List) SetStateMask() {
 	if l.All {
@@ -188,6 +188,30 @@ func TestList_StateMask(t *testing.T) {
 	is.Len(res, 3)
 }
 
+func TestList_PageSize(t *testing.T) {
+	is := assert.New(t)
+	lister := newListFixture(t)
+	lister.PageSize = 1
+	makeMeSomeReleases(lister.cfg.Releases, t)
+	two, err := lister.cfg.Releases.Get("two", 2)
+	is.NoError(err)
+	two.SetStatus(release.StatusSuperseded, "superseded")
+	is.NoError(lister.cfg.Releases.Update(two))
+
+	res, err := lister.Run()
+	is.NoError(err)
+	is.Len(res, 2)
+	is.Equal("one", res[0].Name)
+	is.Equal("three", res[1].Name)
+}
+
+func TestListStatesStatuses(t *testing.T) {
+	is := assert.New(t)
+	is.Equal([]string{"deployed", "failed"}, (ListDeployed | ListFailed).statuses())
+	is.Len(ListAll.statuses(), 8)
+	is.Nil((ListDeployed | ListUnknown).statuses())
+}
+
 func TestList_Filter(t *testing.T) {
 	is := assert.New(t)
 	lister := newListFixture(t)
@@ -200,6 +224,40 @@ func TestList_Filter(t *testing.T) {
 	is.Equal("three", res[0].Name)
 }
 
+func TestList_Selector(t *testing.T) {
+	is := assert.New(t)
+	lister := newListFixture(t)
+	one := namedReleaseStub("one", release.StatusDeployed)
+	one.Labels = map[string]string{"team": "payments", "tier": "test"}
+	two := namedReleaseStub("two", release.StatusDeployed)
+	two.Labels = map[string]string{"team": "payments"}
+	three := namedReleaseStub("three", release.StatusDeployed)
+	for _, rel := range []*release.Release{one, two, three} {
+		is.NoError(lister.cfg.Releases.Create(rel))
+	}
+
+	lister.Selector = "team=payments"
+	res, err := lister.Run()
+	is.NoError(err)
+	is.Len(res, 2)
+
+	lister.Selector = "team=payments,tier!=test"
+	res, err = lister.Run()
+	is.NoError(err)
+	is.Len(res, 1)
+	is.Equal("two", res[0].Name)
+
+	lister.Selector = "!team"
+	res, err = lister.Run()
+	is.NoError(err)
+	is.Len(res, 1)
+	is.Equal("three", res[0].Name)
+
+	lister.Selector = "team in ("
+	_, err = lister.Run()
+	is.Error(err)
+}
+
 func TestList_FilterFailsCompile(t *testing.T) {
 	is := assert.New(t)
 	lister := newListFixture(t)
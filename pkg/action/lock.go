@@ -0,0 +1,58 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+)
+
+// lockTTLMargin is how long the lease on a release outlasts the timeout of
+// the operation holding it. The lease is renewed until the lock is released,
+// however long the operation runs. Should the client die without releasing
+// the lock, other clients can take it over once its lease has expired.
+const lockTTLMargin = 5 * time.Minute
+
+// lockRelease locks the release name while it is installed, upgraded or
+// rolled back, so that other clients fail instead of changing the release
+// at the same time. It returns a function releasing the lock. Invalid names
+// are not locked and are left to the caller to report.
+func (c *Configuration) lockRelease(name string, timeout time.Duration) (func(), error) {
+	if validateReleaseName(name) != nil {
+		return func() {}, nil
+	}
+	return c.Releases.Lock(name, c.lockHolder(), timeout+lockTTLMargin)
+}
+
+// lockHolder returns LockHolder, or identifies the user, host and process
+// of the client if it is not set.
+func (c *Configuration) lockHolder() string {
+	if c.LockHolder != "" {
+		return c.LockHolder
+	}
+	username := "unknown"
+	if u, err := user.Current(); err == nil {
+		username = u.Username
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s@%s (pid %d)", username, host, os.Getpid())
+}
@@ -0,0 +1,135 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+func TestUpgradeRelease_Locked(t *testing.T) {
+	is := assert.New(t)
+	req := require.New(t)
+
+	upAction := upgradeAction(t)
+	upAction.cfg.LockHolder = "alice@laptop"
+	rel := releaseStub()
+	rel.Name = "locked-release"
+	rel.Info.Status = release.StatusDeployed
+	req.NoError(upAction.cfg.Releases.Create(rel))
+
+	unlock, err := upAction.cfg.Releases.Lock(rel.Name, "bob@desktop", time.Minute)
+	req.NoError(err)
+
+	_, err = upAction.Run(rel.Name, buildChart(), map[string]interface{}{})
+	req.Error(err)
+	is.IsType(&driver.LockedError{}, err)
+	is.Contains(err.Error(), `release "locked-release" is locked by bob@desktop since `)
+	history, err := upAction.cfg.Releases.History(rel.Name)
+	req.NoError(err)
+	is.Len(history, 1)
+
+	// The lock is released once the other client is done.
+	unlock()
+	res, err := upAction.Run(rel.Name, buildChart(), map[string]interface{}{})
+	req.NoError(err)
+	is.Equal(2, res.Version)
+
+	// The upgrade released its lock.
+	unlock, err = upAction.cfg.Releases.Lock(rel.Name, "bob@desktop", time.Minute)
+	req.NoError(err)
+	unlock()
+}
+
+func TestInstallRelease_Locked(t *testing.T) {
+	req := require.New(t)
+
+	instAction := installAction(t)
+	unlock, err := instAction.cfg.Releases.Lock(instAction.ReleaseName, "bob@desktop", time.Minute)
+	req.NoError(err)
+	defer unlock()
+
+	_, err = instAction.Run(buildChart(), map[string]interface{}{})
+	req.Error(err)
+	assert.IsType(t, &driver.LockedError{}, err)
+
+	// Dry runs do not change the release and are not locked out.
+	instAction.DryRun = true
+	_, err = instAction.Run(buildChart(), map[string]interface{}{})
+	req.NoError(err)
+}
+
+func TestRollback_Locked(t *testing.T) {
+	req := require.New(t)
+
+	config := actionConfigFixture(t)
+	rel := releaseStub()
+	rel.Info.Status = release.StatusDeployed
+	req.NoError(config.Releases.Create(rel))
+	unlock, err := config.Releases.Lock(rel.Name, "bob@desktop", time.Minute)
+	req.NoError(err)
+	defer unlock()
+
+	err = NewRollback(config).Run(rel.Name)
+	req.Error(err)
+	assert.IsType(t, &driver.LockedError{}, err)
+}
+
+// lockCounter counts the locks taken on the releases of a driver.
+type lockCounter struct {
+	*driver.Memory
+	locks int
+}
+
+func (l *lockCounter) LockRelease(name string, lock driver.Lock) error {
+	l.locks++
+	return l.Memory.LockRelease(name, lock)
+}
+
+func TestUpgradeRelease_AtomicLockedOnce(t *testing.T) {
+	is := assert.New(t)
+	req := require.New(t)
+
+	upAction := upgradeAction(t)
+	counter := &lockCounter{Memory: driver.NewMemory()}
+	upAction.cfg.Releases = storage.Init(counter)
+	rel := releaseStub()
+	rel.Info.Status = release.StatusDeployed
+	req.NoError(upAction.cfg.Releases.Create(rel))
+
+	failer := upAction.cfg.KubeClient.(*kubefake.FailingKubeClient)
+	failer.WatchUntilReadyError = errors.New("arming key removed")
+	upAction.Atomic = true
+	_, err := upAction.Run(rel.Name, buildChart(), map[string]interface{}{})
+	req.Error(err)
+	is.Contains(err.Error(), "has been rolled back")
+
+	// The rollback runs under the lock of the upgrade, which is released.
+	is.Equal(1, counter.locks)
+	unlock, err := upAction.cfg.Releases.Lock(rel.Name, "bob@desktop", time.Minute)
+	req.NoError(err)
+	unlock()
+}
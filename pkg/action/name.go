@@ -0,0 +1,152 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// NameGenerator generates the names of releases installed with GenerateName.
+type NameGenerator interface {
+	// GenerateName returns a release name for the chart named chartName.
+	GenerateName(chartName string) (string, error)
+}
+
+// NameGeneratorFunc adapts a function to a NameGenerator.
+type NameGeneratorFunc func(chartName string) (string, error)
+
+// GenerateName calls f(chartName).
+func (f NameGeneratorFunc) GenerateName(chartName string) (string, error) {
+	return f(chartName)
+}
+
+// TimestampNameGenerator names releases after the chart and the current Unix
+// time, such as "mariadb-1600000000". It is the default NameGenerator.
+var TimestampNameGenerator NameGenerator = NameGeneratorFunc(func(chartName string) (string, error) {
+	return fmt.Sprintf("%s-%d", chartName, time.Now().Unix()), nil
+})
+
+// TemplateNameGenerator names releases by rendering a template like
+// TemplateName, which can also refer to the chart name as .Chart, such as
+//
+//	{{ .Chart }}-{{ randAlphaNum 5 | lower }}
+type TemplateNameGenerator struct {
+	Template string
+}
+
+// GenerateName renders the template for the chart named chartName.
+func (g *TemplateNameGenerator) GenerateName(chartName string) (string, error) {
+	return renderNameTemplate(g.Template, map[string]interface{}{"Chart": chartName})
+}
+
+// WordListNameGenerator names releases by picking a random word from each of
+// its lists, such as an adjective and a noun, joined by Separator.
+type WordListNameGenerator struct {
+	Lists [][]string
+	// Separator joins the words. It defaults to "-".
+	Separator string
+}
+
+var (
+	nameRandMu sync.Mutex
+	nameRand   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// GenerateName picks the words of a name. The chart name is not used.
+func (g *WordListNameGenerator) GenerateName(string) (string, error) {
+	sep := g.Separator
+	if sep == "" {
+		sep = "-"
+	}
+	words := make([]string, 0, len(g.Lists))
+	nameRandMu.Lock()
+	defer nameRandMu.Unlock()
+	for _, list := range g.Lists {
+		if len(list) == 0 {
+			return "", errors.New("cannot generate a name from an empty word list")
+		}
+		words = append(words, list[nameRand.Intn(len(list))])
+	}
+	return strings.Join(words, sep), nil
+}
+
+// NameRules applies naming conventions to the names of another generator.
+type NameRules struct {
+	// Generator generates the names the rules are applied to. It defaults to
+	// TimestampNameGenerator.
+	Generator NameGenerator
+	// Prefix and Suffix are added to the generated names.
+	Prefix string
+	Suffix string
+	// MaxLength is the maximum length of names, including the prefix and
+	// suffix. It defaults to, and cannot exceed, the maximum release name
+	// length of 53. Longer generated names are shortened from the start,
+	// keeping the end that usually makes them unique.
+	MaxLength int
+}
+
+// GenerateName generates a name for the chart named chartName and applies
+// the rules to it, failing if the result is not a valid release name.
+func (r *NameRules) GenerateName(chartName string) (string, error) {
+	g := r.Generator
+	if g == nil {
+		g = TimestampNameGenerator
+	}
+	name, err := g.GenerateName(chartName)
+	if err != nil {
+		return "", err
+	}
+
+	maxLen := r.MaxLength
+	if maxLen <= 0 || maxLen > releaseNameMaxLen {
+		maxLen = releaseNameMaxLen
+	}
+	room := maxLen - len(r.Prefix) - len(r.Suffix)
+	if room < 1 {
+		return "", errors.Errorf("prefix %q and suffix %q leave no room for a name of at most %d characters", r.Prefix, r.Suffix, maxLen)
+	}
+	if len(name) > room {
+		name = strings.TrimLeft(name[len(name)-room:], "-_.")
+	}
+
+	name = r.Prefix + name + r.Suffix
+	if !ValidName.MatchString(name) {
+		return "", errors.Errorf("generated release name %q is invalid", name)
+	}
+	return name, nil
+}
+
+// chartBaseName returns the name of the chart at the path or reference chrt,
+// without the extension of a packaged chart, for naming releases.
+func chartBaseName(chrt string) string {
+	base := filepath.Base(chrt)
+	if base == "." || base == "" {
+		base = "chart"
+	}
+	// if present, strip out the file extension from the name
+	if idx := strings.Index(base, "."); idx != -1 {
+		base = base[0:idx]
+	}
+	return base
+}
@@ -0,0 +1,124 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateNameGenerator(t *testing.T) {
+	is := assert.New(t)
+
+	g := &TemplateNameGenerator{Template: "{{ .Chart }}-{{ \"PROD\" | lower }}"}
+	name, err := g.GenerateName("mariadb")
+	is.NoError(err)
+	is.Equal("mariadb-prod", name)
+
+	g.Template = "{{ .Chart"
+	_, err = g.GenerateName("mariadb")
+	is.Error(err)
+}
+
+func TestWordListNameGenerator(t *testing.T) {
+	is := assert.New(t)
+
+	g := &WordListNameGenerator{Lists: [][]string{{"brave"}, {"otter", "otter"}}}
+	name, err := g.GenerateName("mariadb")
+	is.NoError(err)
+	is.Equal("brave-otter", name)
+
+	g.Separator = "_"
+	name, err = g.GenerateName("mariadb")
+	is.NoError(err)
+	is.Equal("brave_otter", name)
+
+	g.Lists = append(g.Lists, nil)
+	_, err = g.GenerateName("mariadb")
+	is.Error(err)
+}
+
+func TestNameRules(t *testing.T) {
+	fixed := func(name string) NameGenerator {
+		return NameGeneratorFunc(func(string) (string, error) { return name, nil })
+	}
+
+	tests := []struct {
+		name    string
+		rules   NameRules
+		expect  string
+		wantErr bool
+	}{
+		{
+			name:   "prefix and suffix",
+			rules:  NameRules{Generator: fixed("mariadb-1"), Prefix: "team-", Suffix: "-dev"},
+			expect: "team-mariadb-1-dev",
+		},
+		{
+			name:   "shortened from the start",
+			rules:  NameRules{Generator: fixed("mariadb-1600000000"), Prefix: "t-", MaxLength: 13},
+			expect: "t-1600000000",
+		},
+		{
+			name:   "capped at the release name length",
+			rules:  NameRules{Generator: fixed(strings.Repeat("a", 60)), MaxLength: 100},
+			expect: strings.Repeat("a", releaseNameMaxLen),
+		},
+		{
+			name:    "no room",
+			rules:   NameRules{Generator: fixed("mariadb"), Prefix: "prefix-", MaxLength: 7},
+			wantErr: true,
+		},
+		{
+			name:    "invalid",
+			rules:   NameRules{Generator: fixed("Maria DB")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		name, err := tt.rules.GenerateName("mariadb")
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error, got name %q", tt.name, name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.name, err)
+		} else if name != tt.expect {
+			t.Errorf("%s: expected name %q, got %q", tt.name, tt.expect, name)
+		}
+	}
+}
+
+func TestNameAndChartNameGenerator(t *testing.T) {
+	is := assert.New(t)
+	instAction := installAction(t)
+	instAction.ReleaseName = ""
+	instAction.GenerateName = true
+	instAction.NameGenerator = &NameRules{
+		Generator: &TemplateNameGenerator{Template: "{{ .Chart }}-release"},
+		Prefix:    "team-",
+	}
+
+	name, chrt, err := instAction.NameAndChart([]string{"./charts/mariadb-7.3.0.tgz"})
+	is.NoError(err)
+	is.Equal("team-mariadb-7-release", name)
+	is.Equal("./charts/mariadb-7.3.0.tgz", chrt)
+}
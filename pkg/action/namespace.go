@@ -0,0 +1,153 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/kube"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// createNamespace creates the release namespace with the labels and
+// annotations of the chart and of the install, overriding those of the chart
+// or removing them if empty.
+// An existing namespace is left alone unless AdoptNamespace is set, in which
+// case the labels and annotations are added to it. It reports whether the
+// release created the namespace; adopted namespaces are never deleted with
+// the release.
+func (i *Install) createNamespace(chrt *chart.Chart) (bool, error) {
+	var labels, annotations map[string]string
+	if md := chrt.Metadata; md != nil && md.Namespace != nil {
+		labels, annotations = md.Namespace.Labels, md.Namespace.Annotations
+	}
+	labels = mergeLabels(labels, i.NamespaceLabels)
+	annotations = mergeLabels(annotations, i.NamespaceAnnotations)
+
+	resources, err := i.cfg.namespaceResources(i.Namespace, labels, annotations)
+	if err != nil {
+		return false, err
+	}
+	if _, err := i.cfg.KubeClient.Create(resources); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return false, errors.Wrapf(err, "failed to create namespace %s", i.Namespace)
+		}
+		if !i.AdoptNamespace {
+			i.cfg.Log("namespace %s already exists, leaving it alone", i.Namespace)
+			return false, nil
+		}
+		i.cfg.Log("adopting existing namespace %s", i.Namespace)
+		if _, err := i.cfg.KubeClient.Update(resources, resources, false); err != nil {
+			return false, errors.Wrapf(err, "failed to adopt namespace %s", i.Namespace)
+		}
+		return false, nil
+	}
+	i.cfg.Log("created namespace %s", i.Namespace)
+	return true, nil
+}
+
+// deleteNamespace deletes the namespace of rel if the release created it and
+// no record of another release, whatever its status, is stored in it.
+func (u *Uninstall) deleteNamespace(rel *release.Release) error {
+	if !rel.ManagesNamespace {
+		u.cfg.Log("uninstall: namespace %s was not created by %s, leaving it alone", rel.Namespace, rel.Name)
+		return nil
+	}
+	releases, err := u.cfg.Releases.ListReleases()
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the releases in namespace %s", rel.Namespace)
+	}
+	for _, r := range releases {
+		if r.Name != rel.Name && r.Namespace == rel.Namespace {
+			u.cfg.Log("uninstall: release %s (%s) is still in namespace %s, leaving it alone", r.Name, r.Info.Status, rel.Namespace)
+			return nil
+		}
+	}
+	resources, err := u.cfg.namespaceResources(rel.Namespace, nil, nil)
+	if err != nil {
+		return err
+	}
+	if _, errs := u.cfg.KubeClient.Delete(resources); len(errs) > 0 {
+		return errors.Wrapf(errors.New(joinErrors(errs)), "failed to delete namespace %s", rel.Namespace)
+	}
+	return nil
+}
+
+// namespaceResources builds the resources of a namespace
+func (c *Configuration) namespaceResources(name string, labels, annotations map[string]string) (kube.ResourceList, error) {
+	if err := validateNamespaceMetadata(labels, annotations); err != nil {
+		return nil, err
+	}
+	ns := &v1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+	}
+	buf, err := yaml.Marshal(ns)
+	if err != nil {
+		return nil, err
+	}
+	return c.KubeClient.Build(bytes.NewBuffer(buf), true)
+}
+
+// validateNamespaceMetadata checks that labels and annotations are valid
+// Kubernetes labels and annotations
+func validateNamespaceMetadata(labels, annotations map[string]string) error {
+	var problems []string
+	for _, k := range sortedStringKeys(labels) {
+		for _, msg := range validation.IsQualifiedName(k) {
+			problems = append(problems, "label "+k+": "+msg)
+		}
+		for _, msg := range validation.IsValidLabelValue(labels[k]) {
+			problems = append(problems, "label "+k+": "+msg)
+		}
+	}
+	for _, k := range sortedStringKeys(annotations) {
+		for _, msg := range validation.IsQualifiedName(k) {
+			problems = append(problems, "annotation "+k+": "+msg)
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Errorf("invalid namespace metadata:\n- %s", strings.Join(problems, "\n- "))
+	}
+	return nil
+}
+
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,99 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+)
+
+// releaseNamespaces returns the namespaces the resources and hooks of rel are
+// in, sorted. Resources without a namespace in their manifest are in the
+// namespace of the release.
+func releaseNamespaces(rel *release.Release) []string {
+	seen := map[string]bool{rel.Namespace: true}
+	manifests := []string{rel.Manifest}
+	for _, h := range rel.Hooks {
+		manifests = append(manifests, h.Manifest)
+	}
+	for _, m := range manifests {
+		for _, doc := range releaseutil.SplitManifests(m) {
+			var head struct {
+				Metadata struct {
+					Namespace string `json:"namespace"`
+				} `json:"metadata"`
+			}
+			if err := yaml.Unmarshal([]byte(doc), &head); err != nil || head.Metadata.Namespace == "" {
+				continue
+			}
+			seen[head.Metadata.Namespace] = true
+		}
+	}
+
+	namespaces := make([]string, 0, len(seen))
+	for ns := range seen {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// checkNamespaces fails if rel manages resources in namespaces other than its
+// own that match none of the allowed patterns, in the syntax of path.Match.
+// Without any pattern resources may be in any namespace.
+func checkNamespaces(rel *release.Release, allowed []string) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+	var denied []string
+	for _, ns := range rel.Namespaces {
+		if ns == rel.Namespace {
+			continue
+		}
+		ok, err := matchNamespace(allowed, ns)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			denied = append(denied, ns)
+		}
+	}
+	if len(denied) > 0 {
+		return errors.Errorf("release %q manages resources in namespaces that are not allowed: %s", rel.Name, strings.Join(denied, ", "))
+	}
+	return nil
+}
+
+func matchNamespace(patterns []string, ns string) (bool, error) {
+	for _, p := range patterns {
+		ok, err := path.Match(p, ns)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid namespace pattern %q", p)
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,214 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	pathvalidation "k8s.io/apimachinery/pkg/api/validation/path"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+
+	"helm.sh/helm/v3/pkg/releaseutil"
+)
+
+// OutputIndexFile is the name of the index written to the output directory.
+const OutputIndexFile = "index.yaml"
+
+// ClusterScopedDir is the directory cluster-scoped resources are written to
+// when grouping the output by namespace.
+const ClusterScopedDir = "_cluster"
+
+// clusterScopedKinds are the well-known kinds of cluster-scoped resources.
+var clusterScopedKinds = map[string]bool{
+	"APIService":                     true,
+	"ClusterRole":                    true,
+	"ClusterRoleBinding":             true,
+	"CustomResourceDefinition":       true,
+	"IngressClass":                   true,
+	"MutatingWebhookConfiguration":   true,
+	"Namespace":                      true,
+	"PersistentVolume":               true,
+	"PodSecurityPolicy":              true,
+	"PriorityClass":                  true,
+	"RuntimeClass":                   true,
+	"StorageClass":                   true,
+	"ValidatingWebhookConfiguration": true,
+}
+
+// OutputOptions organize the manifests written to an output directory. By
+// default the manifests rendered from a template are written to a file named
+// after the template.
+type OutputOptions struct {
+	// SplitByResource writes each resource to its own file, named after its
+	// kind and name such as deployment_web.yaml. Resources lacking a kind or
+	// a name are written to the file named after their template.
+	SplitByResource bool
+	// GroupByNamespace writes the files to a directory named after the
+	// namespace of their resources. Resources without a namespace are in the
+	// namespace of the release, and well-known cluster-scoped resources are
+	// written to the ClusterScopedDir directory.
+	GroupByNamespace bool
+	// WriteIndex writes an OutputIndex of the written files to the
+	// OutputIndexFile file of the output directory.
+	WriteIndex bool
+}
+
+// OutputIndex lists the resources written to an output directory.
+type OutputIndex struct {
+	Resources []OutputResource `json:"resources"`
+}
+
+// OutputResource is a resource written to an output directory.
+type OutputResource struct {
+	// Path is the path of the file holding the resource, relative to the
+	// output directory and slash-separated.
+	Path string `json:"path"`
+	// Source is the template or CRD file the resource is rendered from.
+	Source     string `json:"source"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+}
+
+// outputWriter writes rendered manifests to an output directory.
+type outputWriter struct {
+	dir string
+	// prefix is the directory of the rendered templates within dir.
+	prefix    string
+	namespace string
+	opts      OutputOptions
+	written   map[string]bool
+	index     OutputIndex
+}
+
+func newOutputWriter(dir, prefix, namespace string, opts OutputOptions) *outputWriter {
+	return &outputWriter{
+		dir:       dir,
+		prefix:    prefix,
+		namespace: namespace,
+		opts:      opts,
+		written:   make(map[string]bool),
+	}
+}
+
+// write writes the manifests rendered from the file source. CRDs are
+// written outside of the prefix directory.
+func (w *outputWriter) write(source, content string, crd bool) error {
+	prefix := w.prefix
+	if crd {
+		prefix = ""
+	}
+	docs := releaseutil.SplitManifests(content)
+	keys := make([]string, 0, len(docs))
+	for k := range docs {
+		keys = append(keys, k)
+	}
+	sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+
+	if !w.opts.SplitByResource && !w.opts.GroupByNamespace {
+		name := path.Join(prefix, source)
+		for _, k := range keys {
+			w.addToIndex(name, source, docs[k])
+		}
+		return w.writeFile(name, source, content)
+	}
+	for _, k := range keys {
+		res := w.addToIndex("", source, docs[k])
+		name := source
+		if w.opts.SplitByResource && res.Kind != "" && res.Name != "" {
+			if msgs := pathvalidation.IsValidPathSegmentName(res.Name); len(msgs) > 0 {
+				return errors.Errorf("invalid name %q of %s in %s: %s", res.Name, res.Kind, source, strings.Join(msgs, ", "))
+			}
+			name = fmt.Sprintf("%s_%s.yaml", strings.ToLower(res.Kind), res.Name)
+		}
+		if w.opts.GroupByNamespace {
+			ns := res.Namespace
+			if ns == "" {
+				ns = ClusterScopedDir
+			} else if msgs := validation.IsDNS1123Label(ns); len(msgs) > 0 {
+				return errors.Errorf("invalid namespace %q of %s %q in %s: %s", ns, res.Kind, res.Name, source, strings.Join(msgs, ", "))
+			}
+			name = path.Join(ns, name)
+		}
+		res.Path = path.Join(prefix, name)
+		if err := w.writeFile(res.Path, source, docs[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addToIndex adds the resource in doc, written to name, to the index.
+func (w *outputWriter) addToIndex(name, source, doc string) *OutputResource {
+	var head struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+		Metadata   struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+	}
+	// Rendered manifests are already known to parse.
+	_ = yaml.Unmarshal([]byte(doc), &head)
+	res := OutputResource{
+		Path:       name,
+		Source:     source,
+		APIVersion: head.APIVersion,
+		Kind:       head.Kind,
+		Name:       head.Metadata.Name,
+		Namespace:  head.Metadata.Namespace,
+	}
+	if res.Namespace == "" && !clusterScopedKinds[res.Kind] {
+		res.Namespace = w.namespace
+	}
+	w.index.Resources = append(w.index.Resources, res)
+	return &w.index.Resources[len(w.index.Resources)-1]
+}
+
+// writeFile writes data rendered from source to the file name, appending to
+// it if it was already written. Names that leave the output directory are
+// refused.
+func (w *outputWriter) writeFile(name, source, data string) error {
+	if clean := path.Clean(name); path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return errors.Errorf("refusing to write %s, rendered from %s, outside of the output directory", name, source)
+	}
+	if err := writeToFile(w.dir, filepath.FromSlash(name), source, data, w.written[name]); err != nil {
+		return err
+	}
+	w.written[name] = true
+	return nil
+}
+
+// writeIndex writes the index if requested.
+func (w *outputWriter) writeIndex() error {
+	if !w.opts.WriteIndex {
+		return nil
+	}
+	data, err := yaml.Marshal(w.index)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(w.dir, OutputIndexFile), data, 0644)
+}
@@ -17,9 +17,13 @@ limitations under the License.
 package action
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 	"syscall"
 
 	"github.com/Masterminds/semver/v3"
@@ -30,6 +34,7 @@ import (
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/provenance"
+	"helm.sh/helm/v3/pkg/sbom"
 )
 
 // Package is the action for packaging a chart.
@@ -43,6 +48,11 @@ type Package struct {
 	AppVersion       string
 	Destination      string
 	DependencyUpdate bool
+	// SBOM is the format of an SBOM of the chart written next to the
+	// archive, if not empty.
+	SBOM sbom.Format
+	// Out, if set, receives the warnings found loading the chart.
+	Out io.Writer
 
 	RepositoryConfig string
 	RepositoryCache  string
@@ -59,6 +69,11 @@ func (p *Package) Run(path string, vals map[string]interface{}) (string, error)
 	if err != nil {
 		return "", err
 	}
+	if p.Out != nil {
+		for _, w := range ch.Warnings {
+			fmt.Fprintf(p.Out, "WARNING: %s\n", w)
+		}
+	}
 
 	// If version is set, modify the version.
 	if p.Version != "" {
@@ -95,12 +110,46 @@ func (p *Package) Run(path string, vals map[string]interface{}) (string, error)
 	}
 
 	if p.Sign {
-		err = p.Clearsign(name)
+		if err := p.Clearsign(name); err != nil {
+			return name, err
+		}
+	}
+
+	if p.SBOM != "" {
+		err = writeSBOM(name, p.SBOM)
 	}
 
 	return name, err
 }
 
+// writeSBOM writes the SBOM of the chart archive at path next to it, named
+// after the archive with the extension of the format.
+func writeSBOM(path string, format sbom.Format) error {
+	archive, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	data, err := chartSBOM(archive, format)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(strings.TrimSuffix(path, ".tgz")+format.Extension(), data, 0644)
+}
+
+// chartSBOM returns the SBOM of a chart archive in the given format.
+func chartSBOM(archive []byte, format sbom.Format) ([]byte, error) {
+	ch, err := loader.LoadArchive(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	doc, err := sbom.Describe(ch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate SBOM")
+	}
+	doc.Chart.Digest = fmt.Sprintf("sha256:%x", sha256.Sum256(archive))
+	return doc.Encode(format)
+}
+
 func setVersion(ch *chart.Chart, ver string) error {
 	// Verify that version is a Version, and error out if it is not.
 	if _, err := semver.NewVersion(ver); err != nil {
@@ -0,0 +1,82 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/cli-runtime/pkg/resource"
+
+	"helm.sh/helm/v3/pkg/kube"
+)
+
+// PruneOptions control what happens to the resources of a release that an
+// upgrade no longer renders. Such resources are deleted once the upgraded
+// resources are applied, unless they are excluded.
+type PruneOptions struct {
+	// PruneExcludeKinds lists kinds whose removed resources are left in the
+	// cluster instead of being deleted, such as PersistentVolumeClaim.
+	// Resources with the helm.sh/resource-policy: keep annotation are always
+	// left in the cluster.
+	PruneExcludeKinds []string
+	// MaxPrune fails the upgrade before anything is applied if more
+	// resources would be deleted, to catch charts or values that
+	// accidentally stop rendering most of a release. Zero means no limit.
+	MaxPrune int
+}
+
+// planPrune splits the resources of current that target no longer contains
+// into those to delete and those to keep, and reports them. It returns the
+// resources to pass as the current resources when updating, which omit the
+// resources to keep so that they are not deleted.
+func (c *Configuration) planPrune(current, target kube.ResourceList, opts PruneOptions) (kube.ResourceList, error) {
+	var prune, keep kube.ResourceList
+	for _, info := range current.Difference(target) {
+		kind := info.Mapping.GroupVersionKind.Kind
+		switch {
+		case containsString(opts.PruneExcludeKinds, kind):
+			c.logger().Warn("keeping resource removed from the chart", "kind", kind, "name", info.Name, "namespace", info.Namespace, "reason", "excluded kind")
+			keep = append(keep, info)
+		case hasKeepPolicy(info):
+			c.logger().Warn("keeping resource removed from the chart", "kind", kind, "name", info.Name, "namespace", info.Namespace, "reason", resourcePolicyAnno)
+			keep = append(keep, info)
+		default:
+			c.logger().Warn("deleting resource removed from the chart", "kind", kind, "name", info.Name, "namespace", info.Namespace)
+			prune = append(prune, info)
+		}
+	}
+
+	if opts.MaxPrune > 0 && len(prune) > opts.MaxPrune {
+		return nil, errors.Errorf("upgrade would delete %d resources removed from the chart, more than the limit of %d", len(prune), opts.MaxPrune)
+	}
+	if len(keep) == 0 {
+		return current, nil
+	}
+	return current.Difference(keep), nil
+}
+
+// hasKeepPolicy reports whether the resource has the keep resource policy.
+func hasKeepPolicy(info *resource.Info) bool {
+	accessor, err := meta.Accessor(info.Object)
+	if err != nil {
+		return false
+	}
+	policy := accessor.GetAnnotations()[resourcePolicyAnno]
+	return strings.ToLower(strings.TrimSpace(policy)) == keepPolicy
+}
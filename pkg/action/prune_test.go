@@ -0,0 +1,117 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/kube"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/logging"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// pruneKubeClient records the resources an update deletes.
+type pruneKubeClient struct {
+	liveKubeClient
+	deleted []string
+}
+
+func (c *pruneKubeClient) UpdateWithOptions(original, target kube.ResourceList, _ kube.ApplyOptions) (*kube.Result, error) {
+	res := &kube.Result{Updated: target, Deleted: original.Difference(target)}
+	for _, info := range res.Deleted {
+		c.deleted = append(c.deleted, info.Mapping.GroupVersionKind.Kind+"/"+info.Name)
+	}
+	return res, nil
+}
+
+const pruneManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: kept
+  annotations:
+    helm.sh/resource-policy: keep
+---
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: data
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+`
+
+func pruneUpgradeAction(t *testing.T) (*Upgrade, *pruneKubeClient, *bytes.Buffer) {
+	upAction := upgradeAction(t)
+	client := &pruneKubeClient{liveKubeClient: liveKubeClient{
+		PrintingKubeClient: kubefake.PrintingKubeClient{Out: ioutil.Discard},
+	}}
+	upAction.cfg.KubeClient = client
+	var logs bytes.Buffer
+	upAction.cfg.Logger = logging.New(&logs, logging.LevelWarn)
+
+	rel := releaseStub()
+	rel.Name = "pruned"
+	rel.Info.Status = release.StatusDeployed
+	rel.Manifest = pruneManifest
+	require.NoError(t, upAction.cfg.Releases.Create(rel))
+	return upAction, client, &logs
+}
+
+func pruneChart() *chart.Chart {
+	chrt := buildChart()
+	chrt.Templates = []*chart.File{
+		{Name: "templates/settings.yaml", Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n")},
+	}
+	return chrt
+}
+
+func TestUpgradeRelease_Prune(t *testing.T) {
+	is := assert.New(t)
+	upAction, client, logs := pruneUpgradeAction(t)
+	upAction.PruneExcludeKinds = []string{"PersistentVolumeClaim"}
+
+	_, err := upAction.Run("pruned", pruneChart(), map[string]interface{}{})
+	is.NoError(err)
+	is.Equal([]string{"Deployment/web"}, client.deleted)
+	is.Contains(logs.String(), "deleting resource removed from the chart kind=Deployment name=web")
+	is.Contains(logs.String(), "keeping resource removed from the chart kind=ConfigMap name=kept namespace=\"\" reason=helm.sh/resource-policy")
+	is.Contains(logs.String(), "keeping resource removed from the chart kind=PersistentVolumeClaim name=data namespace=\"\" reason=\"excluded kind\"")
+}
+
+func TestUpgradeRelease_MaxPrune(t *testing.T) {
+	is := assert.New(t)
+	upAction, client, _ := pruneUpgradeAction(t)
+	upAction.MaxPrune = 1
+
+	_, err := upAction.Run("pruned", pruneChart(), map[string]interface{}{})
+	is.EqualError(err, "upgrade would delete 2 resources removed from the chart, more than the limit of 1")
+	is.Empty(client.deleted)
+}
@@ -58,6 +58,7 @@ func (p *Pull) Run(chartRef string) (string, error) {
 
 	c := downloader.ChartDownloader{
 		Out:     &out,
+		Logger:  p.Logger,
 		Keyring: p.Keyring,
 		Verify:  downloader.VerifyNever,
 		Getters: getter.All(p.Settings),
@@ -67,8 +68,12 @@ func (p *Pull) Run(chartRef string) (string, error) {
 		},
 		RepositoryConfig: p.Settings.RepositoryConfig,
 		RepositoryCache:  p.Settings.RepositoryCache,
+		VersionResolver:  p.VersionResolver,
 	}
 
+	if p.FailOnDeprecated {
+		c.Deprecation = downloader.DeprecationFail
+	}
 	if p.Verify {
 		c.Verify = downloader.VerifyAlways
 	} else if p.VerifyLater {
@@ -88,7 +93,7 @@ func (p *Pull) Run(chartRef string) (string, error) {
 	}
 
 	if p.RepoURL != "" {
-		chartURL, err := repo.FindChartInAuthRepoURL(p.RepoURL, p.Username, p.Password, chartRef, p.Version, p.CertFile, p.KeyFile, p.CaFile, getter.All(p.Settings))
+		chartURL, err := repo.ResolveChartInAuthRepoURL(p.VersionResolver, p.RepoURL, p.Username, p.Password, chartRef, p.Version, p.CertFile, p.KeyFile, p.CaFile, getter.All(p.Settings))
 		if err != nil {
 			return out.String(), err
 		}
@@ -0,0 +1,109 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/sbom"
+)
+
+// Push is the action for pushing a chart to a registry.
+//
+// It provides the implementation of 'helm push'.
+type Push struct {
+	cfg *Configuration
+
+	// Sign, Key and Keyring sign a chart directory when it is packaged.
+	Sign    bool
+	Key     string
+	Keyring string
+	// SBOM is the format of an SBOM of the chart attached to it once
+	// pushed, if not empty.
+	SBOM sbom.Format
+}
+
+// NewPush creates a new Push object with the given configuration.
+func NewPush(cfg *Configuration) *Push {
+	return &Push{
+		cfg: cfg,
+	}
+}
+
+// Run pushes the chart at path to the registry reference ref and returns the
+// digest of the pushed manifest.
+//
+// A chart directory is packaged first. A packaged chart is pushed along with
+// its provenance file, the archive path plus ".prov", if there is one.
+// With SBOM set, an SBOM of the chart is generated and attached to the
+// pushed chart.
+func (p *Push) Run(path, ref string) (string, error) {
+	r, err := registry.ParseReference(strings.TrimPrefix(ref, registry.OCIScheme+"://"))
+	if err != nil {
+		return "", err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if fi.IsDir() {
+		dest, err := ioutil.TempDir("", "helm-push-")
+		if err != nil {
+			return "", err
+		}
+		defer os.RemoveAll(dest)
+		pkg := NewPackage()
+		pkg.Sign = p.Sign
+		pkg.Key = p.Key
+		pkg.Keyring = p.Keyring
+		pkg.Destination = dest
+		if path, err = pkg.Run(path, nil); err != nil {
+			return "", errors.Wrap(err, "failed to package chart")
+		}
+	} else if p.Sign {
+		return "", errors.New("only chart directories can be signed when pushed, sign the package with 'helm package --sign'")
+	}
+
+	archive, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	prov, err := ioutil.ReadFile(path + ".prov")
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	digest, err := p.cfg.RegistryClient.PushChartArchive(r, archive, prov)
+	if err != nil || p.SBOM == "" {
+		return digest, err
+	}
+
+	data, err := chartSBOM(archive, p.SBOM)
+	if err != nil {
+		return digest, err
+	}
+	subject := &registry.Reference{Repo: r.Repo, Digest: digest}
+	if _, err := p.cfg.RegistryClient.PushSBOM(subject, p.SBOM.MediaType(), data); err != nil {
+		return digest, errors.Wrap(err, "failed to attach SBOM")
+	}
+	return digest, nil
+}
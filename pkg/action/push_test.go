@@ -0,0 +1,43 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"testing"
+)
+
+func TestPushErrors(t *testing.T) {
+	chartPath := "../../cmd/helm/testdata/testcharts/signtest-0.1.0.tgz"
+
+	tests := []struct {
+		name string
+		path string
+		ref  string
+		sign bool
+	}{
+		{"invalid reference", chartPath, "oci://localhost:5000/a:b:c", false},
+		{"missing chart", "testdata/missing-0.1.0.tgz", "oci://localhost:5000/missing", false},
+		{"signing a packaged chart", chartPath, "oci://localhost:5000/signtest", true},
+	}
+	for _, tt := range tests {
+		push := NewPush(actionConfigFixture(t))
+		push.Sign = tt.sign
+		if _, err := push.Run(tt.path, tt.ref); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
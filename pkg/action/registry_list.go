@@ -0,0 +1,38 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+// RegistryList lists the registries with stored credentials.
+type RegistryList struct {
+	cfg *Configuration
+}
+
+// NewRegistryList creates a new RegistryList object with the given configuration.
+func NewRegistryList(cfg *Configuration) *RegistryList {
+	return &RegistryList{
+		cfg: cfg,
+	}
+}
+
+// Run executes the registry list operation
+func (a *RegistryList) Run() ([]*registry.Login, error) {
+	return a.cfg.RegistryClient.Logins()
+}
@@ -18,6 +18,8 @@ package action
 
 import (
 	"io"
+
+	"github.com/pkg/errors"
 )
 
 // RegistryLogout performs a registry login operation.
@@ -36,3 +38,20 @@ func NewRegistryLogout(cfg *Configuration) *RegistryLogout {
 func (a *RegistryLogout) Run(out io.Writer, hostname string) error {
 	return a.cfg.RegistryClient.Logout(hostname)
 }
+
+// RunAll logs out of every registry with stored credentials
+func (a *RegistryLogout) RunAll(out io.Writer) error {
+	logins, err := a.cfg.RegistryClient.Logins()
+	if err != nil {
+		return err
+	}
+	if len(logins) == 0 {
+		return errors.New("not logged in to any registry")
+	}
+	for _, login := range logins {
+		if err := a.cfg.RegistryClient.Logout(login.Host); err != nil {
+			return errors.Wrapf(err, "failed to logout from %s", login.Host)
+		}
+	}
+	return nil
+}
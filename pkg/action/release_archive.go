@@ -0,0 +1,218 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+)
+
+// releaseArchiveDir is the directory holding the revisions in a release
+// archive. Each revision is stored as JSON in
+// releases/NAMESPACE/NAME/NAME.vVERSION.json.
+const releaseArchiveDir = "releases"
+
+// ReleaseExport is the action for exporting release histories.
+//
+// It provides the implementation of 'helm release export'.
+type ReleaseExport struct {
+	cfg *Configuration
+
+	// All exports every release in storage rather than the named ones.
+	All bool
+}
+
+// NewReleaseExport creates a new ReleaseExport object with the given configuration.
+func NewReleaseExport(cfg *Configuration) *ReleaseExport {
+	return &ReleaseExport{
+		cfg: cfg,
+	}
+}
+
+// Run writes the complete histories of the named releases to out as a
+// gzipped tar archive, and returns the revisions written.
+func (e *ReleaseExport) Run(out io.Writer, names ...string) ([]*release.Release, error) {
+	var rels []*release.Release
+	if e.All {
+		all, err := e.cfg.Releases.ListReleases()
+		if err != nil {
+			return nil, err
+		}
+		rels = all
+	} else {
+		if len(names) == 0 {
+			return nil, errMissingRelease
+		}
+		for _, name := range names {
+			if err := validateReleaseName(name); err != nil {
+				return nil, errors.Errorf("release name is invalid: %s", name)
+			}
+			h, err := e.cfg.Releases.History(name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to get history of %s", name)
+			}
+			rels = append(rels, h...)
+		}
+	}
+	sortReleases(rels)
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	for _, rel := range rels {
+		data, err := json.Marshal(rel)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to encode %s revision %d", rel.Name, rel.Version)
+		}
+		name := path.Join(releaseArchiveDir, rel.Namespace, rel.Name, fmt.Sprintf("%s.v%d.json", rel.Name, rel.Version))
+		if err := tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(data)),
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return rels, gz.Close()
+}
+
+// ReleaseImport is the action for importing release histories.
+//
+// It provides the implementation of 'helm release import'.
+type ReleaseImport struct {
+	cfg *Configuration
+
+	// Namespace, if set, is the namespace the releases are imported into.
+	Namespace string
+	// Force replaces revisions that already exist in storage.
+	Force bool
+}
+
+// NewReleaseImport creates a new ReleaseImport object with the given configuration.
+func NewReleaseImport(cfg *Configuration) *ReleaseImport {
+	return &ReleaseImport{
+		cfg: cfg,
+	}
+}
+
+// Run stores the revisions of the archive read from in, as written by
+// ReleaseExport, and returns them. Revisions keep their version and status.
+//
+// Nothing is stored if the archive cannot be read, or if a revision already
+// exists and Force is not set.
+func (i *ReleaseImport) Run(in io.Reader) ([]*release.Release, error) {
+	rels, err := readReleaseArchive(in)
+	if err != nil {
+		return nil, err
+	}
+
+	exists := make([]bool, len(rels))
+	for n, rel := range rels {
+		if i.Namespace != "" {
+			rel.Namespace = i.Namespace
+		}
+		if _, err := i.cfg.Releases.Get(rel.Name, rel.Version); err == nil {
+			if !i.Force {
+				return nil, errors.Errorf("revision %d of release %s already exists", rel.Version, rel.Name)
+			}
+			exists[n] = true
+		}
+	}
+
+	for n, rel := range rels {
+		i.cfg.Log("importing revision %d of %s", rel.Version, rel.Name)
+		if exists[n] {
+			err = i.cfg.Releases.Update(rel)
+		} else {
+			err = i.cfg.Releases.Create(rel)
+		}
+		if err != nil {
+			return rels[:n], errors.Wrapf(err, "unable to import revision %d of %s", rel.Version, rel.Name)
+		}
+	}
+	return rels, nil
+}
+
+// readReleaseArchive reads the revisions of a release archive.
+func readReleaseArchive(in io.Reader) ([]*release.Release, error) {
+	gz, err := gzip.NewReader(in)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read release archive")
+	}
+	defer gz.Close()
+
+	var rels []*release.Release
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read release archive")
+		}
+		if hdr.Typeflag != tar.TypeReg || !strings.HasPrefix(hdr.Name, releaseArchiveDir+"/") || path.Ext(hdr.Name) != ".json" {
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read %s", hdr.Name)
+		}
+		rel := &release.Release{}
+		if err := json.Unmarshal(data, rel); err != nil {
+			return nil, errors.Wrapf(err, "unable to decode %s", hdr.Name)
+		}
+		if err := validateReleaseName(rel.Name); err != nil || rel.Version <= 0 || rel.Info == nil {
+			return nil, errors.Errorf("%s does not hold a valid release", hdr.Name)
+		}
+		rels = append(rels, rel)
+	}
+	if len(rels) == 0 {
+		return nil, errors.New("release archive holds no releases")
+	}
+	sortReleases(rels)
+	return rels, nil
+}
+
+// sortReleases sorts releases by namespace, name and version.
+func sortReleases(rels []*release.Release) {
+	releaseutil.SortByRevision(rels)
+	sort.SliceStable(rels, func(i, j int) bool {
+		if rels[i].Namespace != rels[j].Namespace {
+			return rels[i].Namespace < rels[j].Namespace
+		}
+		return rels[i].Name < rels[j].Name
+	})
+}
@@ -0,0 +1,66 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"helm.sh/helm/v3/pkg/release"
+)
+
+func TestReleaseExportImport(t *testing.T) {
+	is := assert.New(t)
+	src := actionConfigFixture(t)
+	for v := 1; v <= 3; v++ {
+		rel := releaseStub()
+		rel.Version = v
+		if v < 3 {
+			rel.Info.Status = release.StatusSuperseded
+		}
+		require.NoError(t, src.Releases.Create(rel))
+	}
+
+	var buf bytes.Buffer
+	exported, err := NewReleaseExport(src).Run(&buf, "angry-panda")
+	require.NoError(t, err)
+	is.Len(exported, 3)
+
+	dst := actionConfigFixture(t)
+	imp := NewReleaseImport(dst)
+	imp.Namespace = "restored"
+	imported, err := imp.Run(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	is.Len(imported, 3)
+
+	h, err := dst.Releases.History("angry-panda")
+	require.NoError(t, err)
+	is.Len(h, 3)
+	last, err := dst.Releases.Last("angry-panda")
+	require.NoError(t, err)
+	is.Equal(3, last.Version)
+	is.Equal(release.StatusDeployed, last.Info.Status)
+	is.Equal("restored", last.Namespace)
+
+	_, err = NewReleaseImport(dst).Run(bytes.NewReader(buf.Bytes()))
+	is.Error(err)
+	_, err = NewReleaseImport(dst).Run(bytes.NewBufferString("not an archive"))
+	is.Error(err)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// ReleasePush is the action for pushing rendered releases to a registry.
+//
+// It provides the implementation of 'helm release push'.
+type ReleasePush struct {
+	cfg *Configuration
+
+	// Version is the revision to push. Zero means the latest.
+	Version int
+	// AllValues bundles the computed values of the release rather than
+	// only the user-supplied ones.
+	AllValues bool
+}
+
+// NewReleasePush creates a new ReleasePush object with the given configuration.
+func NewReleasePush(cfg *Configuration) *ReleasePush {
+	return &ReleasePush{
+		cfg: cfg,
+	}
+}
+
+// Run pushes the rendered manifest, hooks and values of the named release to
+// the registry reference ref, and returns the digest of the pushed manifest.
+// Without a tag, ref is tagged with the release name and revision, such as
+// "myrelease-3". The bundle is built from the stored release, so the same
+// revision always pushes the same digest.
+func (p *ReleasePush) Run(name, ref string) (string, error) {
+	r, err := registry.ParseReference(strings.TrimPrefix(ref, registry.OCIScheme+"://"))
+	if err != nil {
+		return "", err
+	}
+	rel, err := p.cfg.releaseContent(name, p.Version)
+	if err != nil {
+		return "", err
+	}
+	if r.Tag == "" {
+		r.Tag = fmt.Sprintf("%s-%d", rel.Name, rel.Version)
+	}
+	bundle, err := releaseBundle(rel, p.AllValues)
+	if err != nil {
+		return "", err
+	}
+	return p.cfg.RegistryClient.PushReleaseBundle(r, bundle)
+}
+
+// releaseBundle builds the bundle of a rendered release
+func releaseBundle(rel *release.Release, allValues bool) (*registry.ReleaseBundle, error) {
+	vals := rel.Config
+	if allValues {
+		var err error
+		if vals, err = chartutil.CoalesceValues(rel.Chart, rel.Config); err != nil {
+			return nil, err
+		}
+	}
+	values, err := yaml.Marshal(vals)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to encode the values of %s", rel.Name)
+	}
+
+	bundle := &registry.ReleaseBundle{
+		Metadata: registry.ReleaseBundleMetadata{
+			Name:      rel.Name,
+			Namespace: rel.Namespace,
+			Revision:  rel.Version,
+		},
+		Manifest: []byte(rel.Manifest),
+		Values:   values,
+	}
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		md := rel.Chart.Metadata
+		bundle.Metadata.Chart = md.Name
+		bundle.Metadata.ChartVersion = md.Version
+		bundle.Metadata.AppVersion = md.AppVersion
+	}
+	if rel.Info != nil {
+		bundle.Metadata.Created = rel.Info.LastDeployed.Time
+	}
+	if len(rel.Hooks) > 0 {
+		var hooks bytes.Buffer
+		for _, h := range rel.Hooks {
+			fmt.Fprintf(&hooks, "---\n# Source: %s\n%s\n", h.Path, h.Manifest)
+		}
+		bundle.Hooks = hooks.Bytes()
+	}
+	return bundle, nil
+}
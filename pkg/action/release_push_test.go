@@ -0,0 +1,69 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseBundle(t *testing.T) {
+	is := assert.New(t)
+	rel := releaseStub()
+	rel.Manifest = "apiVersion: v1\nkind: ConfigMap\n"
+
+	bundle, err := releaseBundle(rel, false)
+	require.NoError(t, err)
+	is.Equal("angry-panda", bundle.Metadata.Name)
+	is.Equal(1, bundle.Metadata.Revision)
+	is.Equal("hello", bundle.Metadata.Chart)
+	is.Equal("0.1.0", bundle.Metadata.ChartVersion)
+	is.Equal(rel.Info.LastDeployed.Time, bundle.Metadata.Created)
+	is.Equal(rel.Manifest, string(bundle.Manifest))
+	is.Equal("name: value\n", string(bundle.Values))
+	is.Equal(2, strings.Count(string(bundle.Hooks), "# Source: "))
+
+	again, err := releaseBundle(rel, false)
+	require.NoError(t, err)
+	is.Equal(bundle, again, "expected the same release to bundle the same")
+
+	rel.Chart.Values = map[string]interface{}{"replicas": 2}
+	bundle, err = releaseBundle(rel, true)
+	require.NoError(t, err)
+	is.Equal("name: value\nreplicas: 2\n", string(bundle.Values))
+
+	rel.Hooks = nil
+	bundle, err = releaseBundle(rel, false)
+	require.NoError(t, err)
+	is.Nil(bundle.Hooks)
+}
+
+func TestReleasePushErrors(t *testing.T) {
+	cfg := actionConfigFixture(t)
+	require.NoError(t, cfg.Releases.Create(releaseStub()))
+
+	push := NewReleasePush(cfg)
+	if _, err := push.Run("angry-panda", "oci://localhost:5000/a:b:c"); err == nil {
+		t.Error("expected an error for an invalid reference")
+	}
+	if _, err := push.Run("missing", "oci://localhost:5000/bundles/missing"); err == nil {
+		t.Error("expected an error for a missing release")
+	}
+}
@@ -0,0 +1,37 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+// ReleaseReencrypt is the action for re-encrypting stored releases.
+//
+// It provides the implementation of 'helm release reencrypt'.
+type ReleaseReencrypt struct {
+	cfg *Configuration
+}
+
+// NewReleaseReencrypt creates a new ReleaseReencrypt object with the given configuration.
+func NewReleaseReencrypt(cfg *Configuration) *ReleaseReencrypt {
+	return &ReleaseReencrypt{
+		cfg: cfg,
+	}
+}
+
+// Run writes back every revision in storage, so that they are encrypted with
+// the current key after a key rotation, and returns the number written.
+func (r *ReleaseReencrypt) Run() (int, error) {
+	return r.cfg.Releases.Reencrypt()
+}
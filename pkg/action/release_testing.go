@@ -17,14 +17,19 @@ limitations under the License.
 package action
 
 import (
+	"encoding/xml"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
 
 	"helm.sh/helm/v3/pkg/release"
+	helmtime "helm.sh/helm/v3/pkg/time"
 )
 
 // ReleaseTesting is the action for testing a release.
@@ -35,6 +40,31 @@ type ReleaseTesting struct {
 	Timeout time.Duration
 	// Used for fetching logs from test pods
 	Namespace string
+	// Parallel runs all selected tests at once instead of one at a time in
+	// weight order. Timeout applies to each test individually.
+	Parallel bool
+	// Filters restricts which tests are run. Tests are selected by name with
+	// the "name" key and excluded by name with the "!name" key.
+	Filters map[string][]string
+	// Selector is a label selector that tests must match to be run.
+	Selector string
+}
+
+// TestReport summarizes the outcome of the tests of a release.
+type TestReport struct {
+	Release   string        `json:"release"`
+	Namespace string        `json:"namespace"`
+	Revision  int           `json:"revision"`
+	Tests     []*TestResult `json:"tests"`
+}
+
+// TestResult describes the outcome of a single test.
+type TestResult struct {
+	Name        string            `json:"name"`
+	Phase       release.HookPhase `json:"phase"`
+	Skipped     bool              `json:"skipped,omitempty"`
+	StartedAt   helmtime.Time     `json:"started_at,omitempty"`
+	CompletedAt helmtime.Time     `json:"completed_at,omitempty"`
 }
 
 // NewReleaseTesting creates a new ReleaseTesting object with the given configuration.
@@ -60,7 +90,12 @@ func (r *ReleaseTesting) Run(name string) (*release.Release, error) {
 		return rel, err
 	}
 
-	if err := r.cfg.execHook(rel, release.HookTest, r.Timeout); err != nil {
+	tests, err := r.selectTests(rel)
+	if err != nil {
+		return rel, err
+	}
+
+	if err := r.execTests(rel, tests); err != nil {
 		r.cfg.Releases.Update(rel)
 		return rel, err
 	}
@@ -68,6 +103,120 @@ func (r *ReleaseTesting) Run(name string) (*release.Release, error) {
 	return rel, r.cfg.Releases.Update(rel)
 }
 
+// Report summarizes the last run of the tests in the given release. Tests
+// that were not selected by the filters, or did not run, are reported as
+// skipped.
+func (r *ReleaseTesting) Report(rel *release.Release) (*TestReport, error) {
+	tests, err := r.selectTests(rel)
+	if err != nil {
+		return nil, err
+	}
+	selected := make(map[*release.Hook]bool, len(tests))
+	for _, h := range tests {
+		selected[h] = true
+	}
+
+	report := &TestReport{
+		Release:   rel.Name,
+		Namespace: rel.Namespace,
+		Revision:  rel.Version,
+		Tests:     []*TestResult{},
+	}
+	for _, h := range rel.Hooks {
+		if !hookHasEvent(h, release.HookTest) {
+			continue
+		}
+		report.Tests = append(report.Tests, &TestResult{
+			Name:        h.Name,
+			Phase:       h.LastRun.Phase,
+			Skipped:     !selected[h] || h.LastRun.StartedAt.IsZero(),
+			StartedAt:   h.LastRun.StartedAt,
+			CompletedAt: h.LastRun.CompletedAt,
+		})
+	}
+	return report, nil
+}
+
+// selectTests returns the test hooks of a release matching the filters and
+// selector.
+func (r *ReleaseTesting) selectTests(rel *release.Release) ([]*release.Hook, error) {
+	selector := labels.Everything()
+	if r.Selector != "" {
+		var err error
+		selector, err = labels.Parse(r.Selector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid test selector %q", r.Selector)
+		}
+	}
+
+	var tests []*release.Hook
+	for _, h := range rel.Hooks {
+		if !hookHasEvent(h, release.HookTest) {
+			continue
+		}
+		if names := r.Filters["name"]; len(names) > 0 && !containsString(names, h.Name) {
+			continue
+		}
+		if containsString(r.Filters["!name"], h.Name) {
+			continue
+		}
+		if r.Selector != "" {
+			var obj struct {
+				Metadata struct {
+					Labels map[string]string `json:"labels"`
+				} `json:"metadata"`
+			}
+			if err := yaml.Unmarshal([]byte(h.Manifest), &obj); err != nil {
+				return nil, errors.Wrapf(err, "unable to parse labels of test %s", h.Name)
+			}
+			if !selector.Matches(labels.Set(obj.Metadata.Labels)) {
+				continue
+			}
+		}
+		tests = append(tests, h)
+	}
+	return tests, nil
+}
+
+// execTests runs the given tests, either sequentially in weight order or all
+// at once.
+func (r *ReleaseTesting) execTests(rel *release.Release, tests []*release.Hook) error {
+	// Clear previous executions so tests that do not run this time are not
+	// mistaken for fresh results.
+	for _, h := range tests {
+		h.LastRun = release.HookExecution{}
+	}
+
+	if !r.Parallel {
+		return r.cfg.execHooks(rel, tests, release.HookTest, r.Timeout)
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs = make([]error, len(tests))
+	)
+	for i, h := range tests {
+		wg.Add(1)
+		go func(i int, h *release.Hook) {
+			defer wg.Done()
+			errs[i] = r.cfg.runHook(rel, h, release.HookTest, r.Timeout, &mu)
+		}(i, h)
+	}
+	wg.Wait()
+
+	var failed []error
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.New(joinErrors(failed))
+	}
+	return r.cfg.deleteSucceededHooks(tests)
+}
+
 // GetPodLogs will write the logs for all test pods in the given release into
 // the given writer. These can be immediately output to the user or captured for
 // other uses
@@ -77,23 +226,111 @@ func (r *ReleaseTesting) GetPodLogs(out io.Writer, rel *release.Release) error {
 		return errors.Wrap(err, "unable to get kubernetes client to fetch pod logs")
 	}
 
-	for _, h := range rel.Hooks {
-		for _, e := range h.Events {
-			if e == release.HookTest {
-				req := client.CoreV1().Pods(r.Namespace).GetLogs(h.Name, &v1.PodLogOptions{})
-				logReader, err := req.Stream()
-				if err != nil {
-					return errors.Wrapf(err, "unable to get pod logs for %s", h.Name)
-				}
-
-				fmt.Fprintf(out, "POD LOGS: %s\n", h.Name)
-				_, err = io.Copy(out, logReader)
-				fmt.Fprintln(out)
-				if err != nil {
-					return errors.Wrapf(err, "unable to write pod logs for %s", h.Name)
-				}
-			}
+	tests, err := r.selectTests(rel)
+	if err != nil {
+		return err
+	}
+
+	for _, h := range tests {
+		req := client.CoreV1().Pods(r.Namespace).GetLogs(h.Name, &v1.PodLogOptions{})
+		logReader, err := req.Stream()
+		if err != nil {
+			return errors.Wrapf(err, "unable to get pod logs for %s", h.Name)
+		}
+
+		fmt.Fprintf(out, "POD LOGS: %s\n", h.Name)
+		_, err = io.Copy(out, logReader)
+		fmt.Fprintln(out)
+		if err != nil {
+			return errors.Wrapf(err, "unable to write pod logs for %s", h.Name)
 		}
 	}
 	return nil
 }
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit writes the report to out as a JUnit XML test suite.
+func (t *TestReport) WriteJUnit(out io.Writer) error {
+	suite := junitTestSuite{
+		Name: fmt.Sprintf("%s.%s", t.Namespace, t.Release),
+	}
+	var total time.Duration
+	for _, res := range t.Tests {
+		tc := junitTestCase{
+			Name:      res.Name,
+			Classname: suite.Name,
+		}
+		switch {
+		case res.Skipped:
+			tc.Skipped = &junitMessage{Message: "test was not run"}
+			suite.Skipped++
+		case res.Phase != release.HookPhaseSucceeded:
+			tc.Failure = &junitMessage{Message: fmt.Sprintf("test finished in phase %s", res.Phase)}
+			suite.Failures++
+		}
+		if !res.Skipped && !res.CompletedAt.IsZero() {
+			d := res.CompletedAt.Sub(res.StartedAt)
+			tc.Time = formatSeconds(d)
+			total += d
+		} else {
+			tc.Time = formatSeconds(0)
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	suite.Tests = len(suite.TestCases)
+	suite.Time = formatSeconds(total)
+
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(out)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suite); err != nil {
+		return errors.Wrap(err, "unable to write JUnit report")
+	}
+	_, err := fmt.Fprintln(out)
+	return err
+}
+
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+func hookHasEvent(h *release.Hook, event release.HookEvent) bool {
+	for _, e := range h.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+var manifestWithLabeledTestHook = `kind: Pod
+metadata:
+  name: labeled-test
+  labels:
+    suite: smoke
+  annotations:
+    "helm.sh/hook": test
+`
+
+func releaseTestingFixture(t *testing.T) (*ReleaseTesting, *release.Release) {
+	t.Helper()
+	client := NewReleaseTesting(actionConfigFixture(t))
+	rel := releaseStub()
+	rel.Hooks = append(rel.Hooks, &release.Hook{
+		Name:     "labeled-test",
+		Kind:     "Pod",
+		Path:     "labeled-test",
+		Manifest: manifestWithLabeledTestHook,
+		Events:   []release.HookEvent{release.HookTest},
+	})
+	if err := client.cfg.Releases.Create(rel); err != nil {
+		t.Fatal(err)
+	}
+	return client, rel
+}
+
+func TestReleaseTesting_Filters(t *testing.T) {
+	is := assert.New(t)
+	req := require.New(t)
+
+	client, rel := releaseTestingFixture(t)
+	client.Filters = map[string][]string{"!name": {"finding-nemo"}}
+
+	res, err := client.Run(rel.Name)
+	req.NoError(err)
+
+	report, err := client.Report(res)
+	req.NoError(err)
+	req.Len(report.Tests, 2)
+	is.Equal("finding-nemo", report.Tests[0].Name)
+	is.True(report.Tests[0].Skipped)
+	is.Equal("labeled-test", report.Tests[1].Name)
+	is.False(report.Tests[1].Skipped)
+	is.Equal(release.HookPhaseSucceeded, report.Tests[1].Phase)
+}
+
+func TestReleaseTesting_Selector(t *testing.T) {
+	is := assert.New(t)
+	req := require.New(t)
+
+	client, rel := releaseTestingFixture(t)
+	client.Filters = map[string][]string{"name": {"labeled-test"}}
+	client.Selector = "suite=smoke"
+
+	tests, err := client.selectTests(rel)
+	req.NoError(err)
+	req.Len(tests, 1)
+	is.Equal("labeled-test", tests[0].Name)
+
+	client.Selector = "suite=slow"
+	tests, err = client.selectTests(rel)
+	req.NoError(err)
+	is.Len(tests, 0)
+
+	client.Selector = "suite in ("
+	_, err = client.selectTests(rel)
+	is.Error(err)
+}
+
+func TestReleaseTesting_Parallel(t *testing.T) {
+	is := assert.New(t)
+	req := require.New(t)
+
+	client, rel := releaseTestingFixture(t)
+	client.Parallel = true
+	failer := client.cfg.KubeClient.(*kubefake.FailingKubeClient)
+	failer.WatchUntilReadyError = fmt.Errorf("test pod failed")
+
+	res, err := client.Run(rel.Name)
+	req.Error(err)
+	is.Contains(err.Error(), "test pod failed")
+
+	report, err := client.Report(res)
+	req.NoError(err)
+	for _, r := range report.Tests {
+		is.False(r.Skipped)
+		is.Equal(release.HookPhaseFailed, r.Phase)
+	}
+
+	var out bytes.Buffer
+	req.NoError(report.WriteJUnit(&out))
+	is.Contains(out.String(), `<testsuite name=".angry-panda" tests="2" failures="2" skipped="0"`)
+	is.Contains(out.String(), `<failure message="test finished in phase Failed"></failure>`)
+}
@@ -19,6 +19,9 @@ package action
 import (
 	"strings"
 
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"helm.sh/helm/v3/pkg/releaseutil"
 )
 
@@ -31,8 +34,15 @@ const resourcePolicyAnno = "helm.sh/resource-policy"
 //   during an uninstallRelease action.
 const keepPolicy = "keep"
 
-func filterManifestsToKeep(manifests []releaseutil.Manifest) (keep, remaining []releaseutil.Manifest) {
+// filterManifestsToKeep splits manifests into those kept by the keep
+// resource policy, or because their kind is one of keepKinds, and the
+// remaining ones.
+func filterManifestsToKeep(manifests []releaseutil.Manifest, keepKinds []string) (keep, remaining []releaseutil.Manifest) {
 	for _, m := range manifests {
+		if containsString(keepKinds, m.Head.Kind) {
+			keep = append(keep, m)
+			continue
+		}
 		if m.Head.Metadata == nil || m.Head.Metadata.Annotations == nil || len(m.Head.Metadata.Annotations) == 0 {
 			remaining = append(remaining, m)
 			continue
@@ -52,3 +62,19 @@ func filterManifestsToKeep(manifests []releaseutil.Manifest) (keep, remaining []
 	}
 	return keep, remaining
 }
+
+// parseDeletionPropagation converts the name of a deletion propagation policy
+// as given on the command line. An empty name selects the default policy.
+func parseDeletionPropagation(name string) (metav1.DeletionPropagation, error) {
+	switch strings.ToLower(name) {
+	case "":
+		return "", nil
+	case "background":
+		return metav1.DeletePropagationBackground, nil
+	case "foreground":
+		return metav1.DeletePropagationForeground, nil
+	case "orphan":
+		return metav1.DeletePropagationOrphan, nil
+	}
+	return "", errors.Errorf("invalid deletion propagation %q, must be one of background, foreground or orphan", name)
+}
@@ -42,6 +42,11 @@ type Rollback struct {
 	Recreate      bool // will (if true) recreate pods after a rollback.
 	Force         bool // will (if true) force resource upgrade through uninstall/recreate if needed
 	CleanupOnFail bool
+	// MaxHistory limits the number of revisions saved per release. When it
+	// is 0 the MaxHistory of the storage applies.
+	MaxHistory int
+	ServerSideApplyOptions
+	ApplyRetryOptions
 }
 
 // NewRollback creates a new Rollback object with the given configuration.
@@ -57,6 +62,19 @@ func (r *Rollback) Run(name string) error {
 		return err
 	}
 
+	if !r.DryRun {
+		unlock, err := r.cfg.lockRelease(name, r.Timeout)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+	return r.run(name)
+}
+
+// run rolls back the release name without locking it, for callers that hold
+// its lock already.
+func (r *Rollback) run(name string) error {
 	r.cfg.Log("preparing rollback of %s", name)
 	currentRelease, targetRelease, err := r.prepareRollback(name)
 	if err != nil {
@@ -64,8 +82,15 @@ func (r *Rollback) Run(name string) error {
 	}
 
 	if !r.DryRun {
+		if err := r.cfg.runLifecycleHooks(LifecyclePreApply, "rollback", targetRelease, false); err != nil {
+			return err
+		}
 		r.cfg.Log("creating rolled back release for %s", name)
-		if err := r.cfg.Releases.Create(targetRelease); err != nil {
+		maxHistory := r.cfg.Releases.MaxHistory
+		if r.MaxHistory > 0 {
+			maxHistory = r.MaxHistory
+		}
+		if err := r.cfg.Releases.CreateWithMaxHistory(targetRelease, maxHistory); err != nil {
 			return err
 		}
 	}
@@ -80,6 +105,7 @@ func (r *Rollback) Run(name string) error {
 		if err := r.cfg.Releases.Update(targetRelease); err != nil {
 			return err
 		}
+		r.cfg.runLifecycleHooks(LifecyclePostDeploy, "rollback", targetRelease, false)
 	}
 	return nil
 }
@@ -114,10 +140,11 @@ func (r *Rollback) prepareRollback(name string) (*release.Release, *release.Rele
 
 	// Store a new release object with previous release's configuration
 	targetRelease := &release.Release{
-		Name:      name,
-		Namespace: currentRelease.Namespace,
-		Chart:     previousRelease.Chart,
-		Config:    previousRelease.Config,
+		Name:         name,
+		Namespace:    currentRelease.Namespace,
+		Chart:        previousRelease.Chart,
+		Config:       previousRelease.Config,
+		ValueSources: previousRelease.ValueSources,
 		Info: &release.Info{
 			FirstDeployed: currentRelease.Info.FirstDeployed,
 			LastDeployed:  helmtime.Now(),
@@ -127,9 +154,12 @@ func (r *Rollback) prepareRollback(name string) (*release.Release, *release.Rele
 			// message here, and only override it later if we experience failure.
 			Description: fmt.Sprintf("Rollback to %d", previousVersion),
 		},
-		Version:  currentRelease.Version + 1,
-		Manifest: previousRelease.Manifest,
-		Hooks:    previousRelease.Hooks,
+		Version:          currentRelease.Version + 1,
+		Manifest:         previousRelease.Manifest,
+		Hooks:            previousRelease.Hooks,
+		Namespaces:       previousRelease.Namespaces,
+		Labels:           currentRelease.Labels,
+		ManagesNamespace: currentRelease.ManagesNamespace,
 	}
 
 	return currentRelease, targetRelease, nil
@@ -159,7 +189,7 @@ func (r *Rollback) performRollback(currentRelease, targetRelease *release.Releas
 		r.cfg.Log("rollback hooks disabled for %s", targetRelease.Name)
 	}
 
-	results, err := r.cfg.KubeClient.Update(current, target, r.Force)
+	results, err := r.cfg.updateResources(current, target, r.applyOptions(r.Force, r.ApplyRetryOptions))
 
 	if err != nil {
 		msg := fmt.Sprintf("Rollback %q failed: %s", targetRelease.Name, err)
@@ -195,7 +225,7 @@ func (r *Rollback) performRollback(currentRelease, targetRelease *release.Releas
 	}
 
 	if r.Wait {
-		if err := r.cfg.KubeClient.Wait(target, r.Timeout); err != nil {
+		if err := r.cfg.waitForResources(target, r.Timeout); err != nil {
 			targetRelease.SetStatus(release.StatusFailed, fmt.Sprintf("Release %q failed: %s", targetRelease.Name, err.Error()))
 			r.cfg.recordRelease(currentRelease)
 			r.cfg.recordRelease(targetRelease)
@@ -0,0 +1,106 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/helmpath"
+)
+
+// DefaultSchemaLocation is where the OpenAPI schemas of Kubernetes releases
+// are published.
+const DefaultSchemaLocation = "https://raw.githubusercontent.com/kubernetes/kubernetes/{kubeVersion}/api/openapi-spec/swagger.json"
+
+// SchemaLocator locates the OpenAPI schema of a Kubernetes version, for
+// validating rendered manifests without access to a cluster.
+type SchemaLocator struct {
+	// Location is the path or URL of the schema, in which "{kubeVersion}"
+	// is replaced by the Kubernetes version, such as "v1.22.0". It defaults
+	// to DefaultSchemaLocation.
+	Location string
+	// Getters fetch schemas by URL.
+	Getters getter.Providers
+	// CacheDir is where fetched schemas are kept, so that validation works
+	// offline once the schema of a version has been fetched. It defaults to
+	// the "schemas" directory of the Helm cache.
+	CacheDir string
+}
+
+// Locate returns the path of the schema of Kubernetes version kubeVersion,
+// fetching it into the cache if the location is a URL.
+func (s *SchemaLocator) Locate(kubeVersion *chartutil.KubeVersion) (string, error) {
+	location := s.Location
+	if location == "" {
+		location = DefaultSchemaLocation
+	}
+	location = strings.Replace(location, "{kubeVersion}", kubeVersion.Version, -1)
+
+	u, err := url.Parse(location)
+	if err != nil || u.Scheme == "" || filepath.VolumeName(location) != "" {
+		if _, err := os.Stat(location); err != nil {
+			return "", errors.Wrapf(err, "no OpenAPI schema for Kubernetes %s", kubeVersion)
+		}
+		return location, nil
+	}
+
+	cacheDir := s.CacheDir
+	if cacheDir == "" {
+		cacheDir = helmpath.CachePath("schemas")
+	}
+	cached := filepath.Join(cacheDir, fmt.Sprintf("%x.json", sha256.Sum256([]byte(location))))
+	if _, err := os.Stat(cached); err == nil {
+		return cached, nil
+	}
+
+	g, err := s.Getters.ByScheme(u.Scheme)
+	if err != nil {
+		return "", err
+	}
+	data, err := g.Get(location)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to fetch the OpenAPI schema for Kubernetes %s", kubeVersion)
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return "", err
+	}
+	// Write the schema atomically, so that an interrupted download is not
+	// mistaken for a cached schema.
+	tmp, err := ioutil.TempFile(cacheDir, "schema-")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data.Bytes()); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	return cached, os.Rename(tmp.Name(), cached)
+}
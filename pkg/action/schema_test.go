@@ -0,0 +1,91 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/getter"
+)
+
+func TestSchemaLocatorPath(t *testing.T) {
+	kv := &chartutil.KubeVersion{Version: "v1.17.0", Major: "1", Minor: "17"}
+	s := &SchemaLocator{Location: "testdata/{kubeVersion}/openapi.json"}
+	if _, err := s.Locate(kv); err == nil {
+		t.Error("expected an error for a missing schema")
+	}
+
+	s.Location = "testdata/openapi.json"
+	path, err := s.Locate(kv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != "testdata/openapi.json" {
+		t.Errorf("expected the schema path, got %s", path)
+	}
+}
+
+func TestSchemaLocatorURL(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "helm-schemas-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cacheDir)
+
+	var requested string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		http.ServeFile(w, r, "testdata/openapi.json")
+	}))
+
+	kv := &chartutil.KubeVersion{Version: "v1.17.0", Major: "1", Minor: "17"}
+	s := &SchemaLocator{
+		Location: srv.URL + "/{kubeVersion}/swagger.json",
+		Getters:  getter.All(cli.New()),
+		CacheDir: cacheDir,
+	}
+	path, err := s.Locate(kv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requested != "/v1.17.0/swagger.json" {
+		t.Errorf("expected the schema of v1.17.0 to be fetched, got %s", requested)
+	}
+	expect, err := ioutil.ReadFile("testdata/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ioutil.ReadFile(path); err != nil || string(got) != string(expect) {
+		t.Errorf("unexpected cached schema %q: %v", got, err)
+	}
+
+	// The cached schema is used once the server is gone.
+	srv.Close()
+	cached, err := s.Locate(kv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached != path {
+		t.Errorf("expected the cached schema %s, got %s", path, cached)
+	}
+}
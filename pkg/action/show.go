@@ -17,14 +17,17 @@ limitations under the License.
 package action
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 
+	"github.com/pkg/errors"
 	"sigs.k8s.io/yaml"
 
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/registry"
 )
 
 type ShowOutputFormat string
@@ -34,6 +37,7 @@ const (
 	ShowChart  ShowOutputFormat = "chart"
 	ShowValues ShowOutputFormat = "values"
 	ShowReadme ShowOutputFormat = "readme"
+	ShowCRDs   ShowOutputFormat = "crds"
 )
 
 var readmeFileNames = []string{"readme.md", "readme.txt", "readme"}
@@ -59,11 +63,55 @@ func NewShow(output ShowOutputFormat) *Show {
 
 // Run executes 'helm show' against the given release.
 func (s *Show) Run(chartpath string) (string, error) {
-	var out strings.Builder
 	chrt, err := loader.Load(chartpath)
 	if err != nil {
 		return "", err
 	}
+	return s.show(chrt)
+}
+
+// RunOCI executes 'helm show' against a chart in a registry, referenced as
+// oci://host/path/name[:tag], without storing it in the local cache.
+//
+// Without a tag, the highest version matching Version is shown. Only the
+// chart config is pulled to show the Chart.yaml.
+func (s *Show) RunOCI(client *registry.Client, name string) (string, error) {
+	if s.Verify {
+		return "", errors.Errorf("provenance verification is not supported for OCI chart %q", name)
+	}
+	ref, err := registry.ParseReference(strings.TrimPrefix(name, registry.OCIScheme+"://"))
+	if err != nil {
+		return "", err
+	}
+	if s.VersionResolver != nil {
+		client = client.WithVersionResolver(s.VersionResolver)
+	}
+	ref, err = client.ResolveChartVersion(ref, s.Version)
+	if err != nil {
+		return "", err
+	}
+
+	if s.OutputFormat == ShowChart {
+		metadata, err := client.FetchChartMetadata(ref)
+		if err != nil {
+			return "", err
+		}
+		return s.show(&chart.Chart{Metadata: metadata})
+	}
+	archive, err := client.FetchChartArchive(ref)
+	if err != nil {
+		return "", err
+	}
+	chrt, err := loader.LoadArchive(bytes.NewReader(archive))
+	if err != nil {
+		return "", err
+	}
+	return s.show(chrt)
+}
+
+// show formats the information of chrt.
+func (s *Show) show(chrt *chart.Chart) (string, error) {
+	var out strings.Builder
 	cf, err := yaml.Marshal(chrt.Metadata)
 	if err != nil {
 		return "", err
@@ -94,6 +142,15 @@ func (s *Show) Run(chartpath string) (string, error) {
 		}
 		fmt.Fprintf(&out, "%s\n", readme.Data)
 	}
+
+	if s.OutputFormat == ShowCRDs {
+		for i, crd := range chrt.CRDObjects() {
+			if i > 0 {
+				fmt.Fprintln(&out, "---")
+			}
+			fmt.Fprintf(&out, "%s\n", crd.File.Data)
+		}
+	}
 	return out.String(), nil
 }
 
@@ -20,6 +20,8 @@ import (
 	"io/ioutil"
 	"strings"
 	"testing"
+
+	"helm.sh/helm/v3/pkg/chart"
 )
 
 func TestShow(t *testing.T) {
@@ -74,3 +76,32 @@ func TestShow(t *testing.T) {
 		t.Errorf("expected empty values buffer, got %s", output)
 	}
 }
+
+func TestShowCRDs(t *testing.T) {
+	client := NewShow(ShowCRDs)
+
+	output, err := client.show(&chart.Chart{
+		Metadata: &chart.Metadata{Name: "crds"},
+		Files: []*chart.File{
+			{Name: "crds/a.yaml", Data: []byte("kind: CustomResourceDefinition\nname: a")},
+			{Name: "crds/b.yaml", Data: []byte("kind: CustomResourceDefinition\nname: b")},
+			{Name: "README.md", Data: []byte("readme")},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := "kind: CustomResourceDefinition\nname: a\n---\nkind: CustomResourceDefinition\nname: b\n"
+	if output != expect {
+		t.Errorf("Expected\n%q\nGot\n%q\n", expect, output)
+	}
+}
+
+func TestShowOCIVerify(t *testing.T) {
+	client := NewShow(ShowChart)
+	client.Verify = true
+	if _, err := client.RunOCI(nil, "oci://localhost:5000/charts/alpine"); err == nil {
+		t.Error("expected an error verifying an OCI chart")
+	}
+}
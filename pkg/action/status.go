@@ -17,7 +17,13 @@ limitations under the License.
 package action
 
 import (
+	"bytes"
+
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/pkg/kube"
 	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/time"
 )
 
 // Status is the action for checking the deployment status of releases.
@@ -29,6 +35,39 @@ type Status struct {
 	Version int
 }
 
+// StatusReport is the status of a release along with the health of its live
+// resources.
+type StatusReport struct {
+	Name         string         `json:"name"`
+	Namespace    string         `json:"namespace"`
+	Revision     int            `json:"revision"`
+	Status       release.Status `json:"status"`
+	LastDeployed time.Time      `json:"last_deployed,omitempty"`
+	Description  string         `json:"description,omitempty"`
+	// Healthy reports whether all resources are Current.
+	Healthy   bool              `json:"healthy"`
+	Resources []*ResourceHealth `json:"resources"`
+	Hooks     []*HookResult     `json:"hooks,omitempty"`
+	Notes     string            `json:"notes,omitempty"`
+}
+
+// ResourceHealth is the health of a resource of a release.
+type ResourceHealth struct {
+	Kind      string              `json:"kind"`
+	Name      string              `json:"name"`
+	Namespace string              `json:"namespace,omitempty"`
+	Status    kube.ResourceStatus `json:"status"`
+	Message   string              `json:"message,omitempty"`
+}
+
+// HookResult is the result of the last run of a hook of a release.
+type HookResult struct {
+	Name    string                `json:"name"`
+	Kind    string                `json:"kind"`
+	Events  []release.HookEvent   `json:"events"`
+	LastRun release.HookExecution `json:"last_run"`
+}
+
 // NewStatus creates a new Status object with the given configuration.
 func NewStatus(cfg *Configuration) *Status {
 	return &Status{
@@ -44,3 +83,57 @@ func (s *Status) Run(name string) (*release.Release, error) {
 
 	return s.cfg.releaseContent(name, s.Version)
 }
+
+// RunReport returns the status of the given release along with the health of
+// its live resources.
+func (s *Status) RunReport(name string) (*StatusReport, error) {
+	rel, err := s.Run(name)
+	if err != nil {
+		return nil, err
+	}
+	fetcher, ok := s.cfg.KubeClient.(kube.Fetcher)
+	if !ok {
+		return nil, errors.New("the Kubernetes client does not support reading live resources")
+	}
+
+	report := &StatusReport{
+		Name:         rel.Name,
+		Namespace:    rel.Namespace,
+		Revision:     rel.Version,
+		Status:       rel.Info.Status,
+		LastDeployed: rel.Info.LastDeployed,
+		Description:  rel.Info.Description,
+		Healthy:      true,
+		Resources:    []*ResourceHealth{},
+		Notes:        rel.Info.Notes,
+	}
+	for _, h := range rel.Hooks {
+		report.Hooks = append(report.Hooks, &HookResult{Name: h.Name, Kind: h.Kind, Events: h.Events, LastRun: h.LastRun})
+	}
+
+	resources, err := s.cfg.KubeClient.Build(bytes.NewBufferString(rel.Manifest), false)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to build kubernetes objects from release manifest")
+	}
+	live, err := fetcher.Fetch(resources)
+	if err != nil {
+		return nil, err
+	}
+	for i, info := range resources {
+		status, message, err := kube.ComputeStatus(live[i])
+		if err != nil {
+			status, message = kube.StatusUnknown, err.Error()
+		}
+		report.Resources = append(report.Resources, &ResourceHealth{
+			Kind:      info.Mapping.GroupVersionKind.Kind,
+			Name:      info.Name,
+			Namespace: info.Namespace,
+			Status:    status,
+			Message:   message,
+		})
+		if status != kube.StatusCurrent {
+			report.Healthy = false
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"helm.sh/helm/v3/pkg/kube"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+)
+
+func TestStatusReport(t *testing.T) {
+	is := assert.New(t)
+	config := actionConfigFixture(t)
+	config.KubeClient = &liveKubeClient{
+		PrintingKubeClient: kubefake.PrintingKubeClient{Out: ioutil.Discard},
+		live: map[string]string{
+			"ConfigMap/settings": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n",
+			"Deployment/web": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 2
+status:
+  replicas: 2
+  updatedReplicas: 2
+  readyReplicas: 1
+  availableReplicas: 2
+`,
+		},
+	}
+	rel := releaseStub()
+	rel.Manifest = driftManifest
+	rel.Info.Notes = "Thanks for installing"
+	require.NoError(t, config.Releases.Create(rel))
+
+	report, err := NewStatus(config).RunReport(rel.Name)
+	require.NoError(t, err)
+	is.Equal(rel.Name, report.Name)
+	is.Equal("Thanks for installing", report.Notes)
+	is.False(report.Healthy)
+	is.Len(report.Hooks, len(rel.Hooks))
+	is.ElementsMatch([]*ResourceHealth{
+		{Kind: "ConfigMap", Name: "settings", Status: kube.StatusCurrent, Message: "Resource is always ready"},
+		{Kind: "Deployment", Name: "web", Status: kube.StatusInProgress, Message: "Ready: 1/2"},
+		{Kind: "Service", Name: "web", Status: kube.StatusNotFound, Message: "Resource not found"},
+	}, report.Resources)
+}
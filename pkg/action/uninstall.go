@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/releaseutil"
@@ -38,6 +39,23 @@ type Uninstall struct {
 	KeepHistory  bool
 	Timeout      time.Duration
 	Description  string
+	// KindOrder lists the kinds that were installed before all others. They
+	// are uninstalled last, in reverse order. It overrides the
+	// helm.sh/kind-order annotation of the chart.
+	KindOrder []string
+	// DeletionPropagation decides what happens to the dependents of the
+	// deleted resources: "background" (the default), "foreground" or
+	// "orphan".
+	DeletionPropagation string
+	// KeepKinds lists kinds whose resources are left behind, as if they had
+	// the helm.sh/resource-policy: keep annotation.
+	KeepKinds []string
+	// DeleteNamespace deletes the release namespace after the resources of
+	// the release, if the release created it and no other release, whatever
+	// its status, is stored in it. The namespace is kept along with the
+	// history of the release if KeepHistory is set, and along with the
+	// resources the release kept or failed to delete.
+	DeleteNamespace bool
 }
 
 // NewUninstall creates a new Uninstall object with the given configuration.
@@ -66,6 +84,11 @@ func (u *Uninstall) Run(name string) (*release.UninstallReleaseResponse, error)
 		return nil, errors.Errorf("uninstall: Release name is invalid: %s", name)
 	}
 
+	propagation, err := parseDeletionPropagation(u.DeletionPropagation)
+	if err != nil {
+		return nil, err
+	}
+
 	rels, err := u.cfg.Releases.History(name)
 	if err != nil {
 		return nil, errors.Wrapf(err, "uninstall: Release not loaded: %s", name)
@@ -109,7 +132,7 @@ func (u *Uninstall) Run(name string) (*release.UninstallReleaseResponse, error)
 		u.cfg.Log("uninstall: Failed to store updated release: %s", err)
 	}
 
-	kept, errs := u.deleteRelease(rel)
+	kept, errs := u.deleteRelease(rel, propagation)
 	res.Info = kept
 
 	if !u.DisableHooks {
@@ -132,6 +155,19 @@ func (u *Uninstall) Run(name string) (*release.UninstallReleaseResponse, error)
 			errs = append(errs, errors.Wrap(err, "uninstall: Failed to purge the release"))
 		}
 
+		if u.DeleteNamespace {
+			switch {
+			case kept != "":
+				u.cfg.Log("uninstall: keeping namespace %s, which holds resources kept by %s", rel.Namespace, name)
+			case len(errs) > 0:
+				u.cfg.Log("uninstall: keeping namespace %s, as %s was not fully uninstalled", rel.Namespace, name)
+			default:
+				if err := u.deleteNamespace(rel); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+
 		// Return the errors that occurred while deleting the release, if any
 		if len(errs) > 0 {
 			return res, errors.Errorf("uninstallation completed with %d error(s): %s", len(errs), joinErrors(errs))
@@ -140,6 +176,9 @@ func (u *Uninstall) Run(name string) (*release.UninstallReleaseResponse, error)
 		return res, nil
 	}
 
+	if u.DeleteNamespace {
+		u.cfg.Log("uninstall: keeping namespace %s, which holds the history of %s", rel.Namespace, name)
+	}
 	if err := u.cfg.Releases.Update(rel); err != nil {
 		u.cfg.Log("uninstall: Failed to store updated release: %s", err)
 	}
@@ -168,14 +207,17 @@ func joinErrors(errs []error) string {
 }
 
 // deleteRelease deletes the release and returns manifests that were kept in the deletion process
-func (u *Uninstall) deleteRelease(rel *release.Release) (string, []error) {
+func (u *Uninstall) deleteRelease(rel *release.Release, propagation metav1.DeletionPropagation) (string, []error) {
 	caps, err := u.cfg.getCapabilities()
 	if err != nil {
 		return rel.Manifest, []error{errors.Wrap(err, "could not get apiVersions from Kubernetes")}
 	}
 
 	manifests := releaseutil.SplitManifests(rel.Manifest)
-	_, files, err := releaseutil.SortManifests(manifests, caps.APIVersions, releaseutil.UninstallOrder)
+	_, files, err := releaseutil.SortManifests(manifests, caps.APIVersions, releaseutil.UninstallOrderWith(chartKindOrder(rel.Chart, u.KindOrder)))
+	if err == nil {
+		files, err = releaseutil.SortByDependencies(files, true)
+	}
 	if err != nil {
 		// We could instead just delete everything in no particular order.
 		// FIXME: One way to delete at this point would be to try a label-based
@@ -184,12 +226,15 @@ func (u *Uninstall) deleteRelease(rel *release.Release) (string, []error) {
 		return rel.Manifest, []error{errors.Wrap(err, "corrupted release record. You must manually delete the resources")}
 	}
 
-	filesToKeep, filesToDelete := filterManifestsToKeep(files)
+	filesToKeep, filesToDelete := filterManifestsToKeep(files, u.KeepKinds)
 	var kept string
 	for _, f := range filesToKeep {
 		kept += f.Name + "\n"
 	}
 
+	if len(rel.Namespaces) > 1 {
+		u.cfg.Log("uninstall: deleting resources of %s in namespaces %s", rel.Name, strings.Join(rel.Namespaces, ", "))
+	}
 	var builder strings.Builder
 	for _, file := range filesToDelete {
 		builder.WriteString("\n---\n" + file.Content)
@@ -199,6 +244,6 @@ func (u *Uninstall) deleteRelease(rel *release.Release) (string, []error) {
 		return "", []error{errors.Wrap(err, "unable to build kubernetes objects for delete")}
 	}
 
-	_, errs := u.cfg.KubeClient.Delete(resources)
+	_, errs := u.cfg.deleteResources(resources, propagation)
 	return kept, errs
 }
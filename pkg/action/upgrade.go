@@ -18,20 +18,27 @@ package action
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/api/kv"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/resource"
 
+	"helm.sh/helm/v3/internal/tracing"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/deprecation"
 	"helm.sh/helm/v3/pkg/kube"
+	"helm.sh/helm/v3/pkg/policy"
 	"helm.sh/helm/v3/pkg/postrender"
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/releaseutil"
+	"helm.sh/helm/v3/pkg/resolver"
+	"helm.sh/helm/v3/pkg/warning"
 )
 
 // Upgrade is the action for upgrading releases.
@@ -62,6 +69,40 @@ type Upgrade struct {
 	Description              string
 	PostRenderer             postrender.PostRenderer
 	DisableOpenAPIValidation bool
+	// ValidateManifests validates each rendered document against the OpenAPI
+	// schema of the cluster before anything is applied. SchemaFile, or else
+	// the schema bundled with Helm, is used when the cluster schema is
+	// unavailable.
+	ValidateManifests bool
+	SchemaFile        string
+	// Policy evaluates the rendered manifests, with those of the hooks,
+	// before anything is applied. Violations fail the upgrade with a
+	// *policy.Error, also in dry-run mode.
+	Policy policy.Evaluator
+	// AllowedNamespaces lists patterns of the namespaces, other than its own,
+	// the release may manage resources in. Resources may be in any namespace
+	// if it is empty.
+	AllowedNamespaces []string
+	// Labels are merged into the labels of the release. Labels with an empty
+	// value are removed from it.
+	Labels map[string]string
+	// ValueSources are the sources of the values the release is upgraded
+	// with, recorded in the release along with the sources of reused values.
+	// See values.Options.MergeValuesWithSources.
+	ValueSources map[string]string
+	// KindOrder lists kinds to install before all others, in order. It
+	// overrides the helm.sh/kind-order annotation of the chart.
+	KindOrder []string
+	// KubeVersion is the Kubernetes version to check the upgraded resources
+	// for removed and deprecated APIs against, instead of the version of the
+	// cluster.
+	KubeVersion *chartutil.KubeVersion
+	// ValuesResolver resolves the references in the values when rendering.
+	// The release keeps the references rather than the resolved values.
+	ValuesResolver resolver.Resolver
+	ServerSideApplyOptions
+	ApplyRetryOptions
+	PruneOptions
 }
 
 // NewUpgrade creates a new Upgrade object with the given configuration.
@@ -73,6 +114,21 @@ func NewUpgrade(cfg *Configuration) *Upgrade {
 
 // Run executes the upgrade on the given release.
 func (u *Upgrade) Run(name string, chart *chart.Chart, vals map[string]interface{}) (*release.Release, error) {
+	return u.RunWithContext(context.Background(), name, chart, vals)
+}
+
+// RunWithContext executes the upgrade on the given release, tracing it below
+// the span in ctx.
+func (u *Upgrade) RunWithContext(ctx context.Context, name string, chart *chart.Chart, vals map[string]interface{}) (*release.Release, error) {
+	ctx, span := tracing.Start(ctx, u.cfg.TracerProvider, "upgrade",
+		kv.String("helm.release", name),
+		kv.String("helm.namespace", u.Namespace))
+	rel, err := u.run(ctx, name, chart, vals)
+	tracing.End(ctx, span, err)
+	return rel, err
+}
+
+func (u *Upgrade) run(ctx context.Context, name string, chart *chart.Chart, vals map[string]interface{}) (*release.Release, error) {
 	// Make sure if Atomic is set, that wait is set as well. This makes it so
 	// the user doesn't have to specify both
 	u.Wait = u.Wait || u.Atomic
@@ -80,16 +136,21 @@ func (u *Upgrade) Run(name string, chart *chart.Chart, vals map[string]interface
 	if err := validateReleaseName(name); err != nil {
 		return nil, errors.Errorf("release name is invalid: %s", name)
 	}
+	if !u.DryRun {
+		unlock, err := u.cfg.lockRelease(name, u.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
 	u.cfg.Log("preparing upgrade for %s", name)
-	currentRelease, upgradedRelease, err := u.prepareUpgrade(name, chart, vals)
+	currentRelease, upgradedRelease, err := u.prepareUpgrade(ctx, name, chart, vals)
 	if err != nil {
 		return nil, err
 	}
 
-	u.cfg.Releases.MaxHistory = u.MaxHistory
-
 	u.cfg.Log("performing update for %s", name)
-	res, err := u.performUpgrade(currentRelease, upgradedRelease)
+	res, err := u.performUpgrade(ctx, currentRelease, upgradedRelease)
 	if err != nil {
 		return res, err
 	}
@@ -99,6 +160,7 @@ func (u *Upgrade) Run(name string, chart *chart.Chart, vals map[string]interface
 		if err := u.cfg.Releases.Update(upgradedRelease); err != nil {
 			return res, err
 		}
+		u.cfg.runLifecycleHooks(LifecyclePostDeploy, "upgrade", upgradedRelease, false)
 	}
 
 	return res, nil
@@ -117,10 +179,19 @@ func validateReleaseName(releaseName string) error {
 }
 
 // prepareUpgrade builds an upgraded release for an upgrade operation.
-func (u *Upgrade) prepareUpgrade(name string, chart *chart.Chart, vals map[string]interface{}) (*release.Release, *release.Release, error) {
+func (u *Upgrade) prepareUpgrade(ctx context.Context, name string, chart *chart.Chart, vals map[string]interface{}) (*release.Release, *release.Release, error) {
 	if chart == nil {
 		return nil, nil, errMissingChart
 	}
+	if err := validateLabels(u.Labels); err != nil {
+		return nil, nil, err
+	}
+	warnings := append(warning.Warnings{}, chart.Warnings...)
+	warnings = append(warnings, chartutil.DeprecatedValues(chart, vals)...)
+	u.cfg.logWarnings(warnings)
+	if err := checkDeprecated(u.cfg, chart, u.FailOnDeprecated, &warnings); err != nil {
+		return nil, nil, err
+	}
 
 	// finds the deployed release with the given name
 	currentRelease, err := u.cfg.Releases.Deployed(name)
@@ -129,6 +200,7 @@ func (u *Upgrade) prepareUpgrade(name string, chart *chart.Chart, vals map[strin
 	}
 
 	// determine if values will be reused
+	sources := u.valueSources(currentRelease, vals)
 	vals, err = u.reuseValues(chart, currentRelease, vals)
 	if err != nil {
 		return nil, nil, err
@@ -159,12 +231,11 @@ func (u *Upgrade) prepareUpgrade(name string, chart *chart.Chart, vals map[strin
 	if err != nil {
 		return nil, nil, err
 	}
-	valuesToRender, err := chartutil.ToRenderValues(chart, vals, options, caps)
+	resolved, err := resolveValues(u.ValuesResolver, vals)
 	if err != nil {
 		return nil, nil, err
 	}
-
-	hooks, manifestDoc, notesTxt, err := u.cfg.renderResources(chart, valuesToRender, "", "", u.SubNotes, false, false, u.PostRenderer)
+	valuesToRender, err := chartutil.ToRenderValues(chart, resolved, options, caps)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -181,19 +252,86 @@ func (u *Upgrade) prepareUpgrade(name string, chart *chart.Chart, vals map[strin
 			Status:        release.StatusPendingUpgrade,
 			Description:   "Preparing upgrade", // This should be overwritten later.
 		},
-		Version:  revision,
-		Manifest: manifestDoc.String(),
-		Hooks:    hooks,
+		Version:          revision,
+		Labels:           mergeLabels(currentRelease.Labels, u.Labels),
+		ManagesNamespace: currentRelease.ManagesNamespace,
+		ValueSources:     sources,
+		Warnings:         warnings,
 	}
+	if err := u.cfg.runLifecycleHooks(LifecyclePreRender, "upgrade", upgradedRelease, u.DryRun); err != nil {
+		return nil, nil, err
+	}
+
+	_, span := tracing.Start(ctx, nil, "render")
+	hooks, manifestDoc, notesTxt, err := u.cfg.renderResources(chart, valuesToRender, nil, u.SubNotes, false, u.PostRenderer, u.KindOrder, &upgradedRelease.Warnings)
+	tracing.End(ctx, span, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	upgradedRelease.Manifest = manifestDoc.String()
+	upgradedRelease.Hooks = hooks
 
 	if len(notesTxt) > 0 {
 		upgradedRelease.Info.Notes = notesTxt
 	}
+	if err := u.cfg.runLifecycleHooks(LifecyclePostRender, "upgrade", upgradedRelease, u.DryRun); err != nil {
+		return currentRelease, upgradedRelease, err
+	}
+	if u.ValidateManifests {
+		if err := u.cfg.validateManifests(upgradedRelease.Manifest, u.SchemaFile); err != nil {
+			return currentRelease, upgradedRelease, err
+		}
+	}
+	if u.Policy != nil {
+		if err := checkPolicy(u.Policy, upgradedRelease); err != nil {
+			return currentRelease, upgradedRelease, err
+		}
+	}
+	upgradedRelease.Namespaces = releaseNamespaces(upgradedRelease)
+	if err := checkNamespaces(upgradedRelease, u.AllowedNamespaces); err != nil {
+		return currentRelease, upgradedRelease, err
+	}
+	if err := u.checkDeprecations(upgradedRelease, caps); err != nil {
+		return currentRelease, upgradedRelease, err
+	}
 	err = validateManifest(u.cfg.KubeClient, manifestDoc.Bytes(), !u.DisableOpenAPIValidation)
 	return currentRelease, upgradedRelease, err
 }
 
-func (u *Upgrade) performUpgrade(originalRelease, upgradedRelease *release.Release) (*release.Release, error) {
+// checkDeprecations fails if the resources or hooks of rel use APIs removed
+// in the Kubernetes version upgraded to, and logs those using deprecated APIs.
+func (u *Upgrade) checkDeprecations(rel *release.Release, caps *chartutil.Capabilities) error {
+	kubeVersion := caps.KubeVersion
+	if u.KubeVersion != nil {
+		kubeVersion = *u.KubeVersion
+	}
+	findings, err := deprecation.Check(rel.Manifest, "", kubeVersion.Version)
+	if err != nil {
+		return err
+	}
+	for _, h := range rel.Hooks {
+		hf, err := deprecation.Check(h.Manifest, h.Path, kubeVersion.Version)
+		if err != nil {
+			return err
+		}
+		findings = append(findings, hf...)
+	}
+
+	for _, f := range findings {
+		if !f.Removed {
+			u.cfg.logger().Warn("resource uses a deprecated API",
+				"template", f.Template, "kind", f.Kind, "name", f.Name,
+				"apiVersion", f.APIVersion, "replacement", f.Replacement, "removedIn", f.RemovedIn)
+			rel.Warnings.Add(warning.Deprecated, f.Template, "%s", f.Error())
+		}
+	}
+	if removed := findings.Removed(); len(removed) > 0 {
+		return errors.Wrapf(removed, "upgrade uses APIs removed in Kubernetes %s", kubeVersion.Version)
+	}
+	return nil
+}
+
+func (u *Upgrade) performUpgrade(ctx context.Context, originalRelease, upgradedRelease *release.Release) (*release.Release, error) {
 	current, err := u.cfg.KubeClient.Build(bytes.NewBufferString(originalRelease.Manifest), false)
 	if err != nil {
 		return upgradedRelease, errors.Wrap(err, "unable to build kubernetes objects from current release manifest")
@@ -220,6 +358,13 @@ func (u *Upgrade) performUpgrade(originalRelease, upgradedRelease *release.Relea
 		return nil, errors.Wrap(err, "rendered manifests contain a new resource that already exists. Unable to continue with update")
 	}
 
+	// Report the resources removed from the chart before anything is
+	// applied, also in dry-run mode.
+	current, err = u.cfg.planPrune(current, target, u.PruneOptions)
+	if err != nil {
+		return nil, err
+	}
+
 	if u.DryRun {
 		u.cfg.Log("dry run for %s", upgradedRelease.Name)
 		if len(u.Description) > 0 {
@@ -230,8 +375,12 @@ func (u *Upgrade) performUpgrade(originalRelease, upgradedRelease *release.Relea
 		return upgradedRelease, nil
 	}
 
+	if err := u.cfg.runLifecycleHooks(LifecyclePreApply, "upgrade", upgradedRelease, false); err != nil {
+		return upgradedRelease, err
+	}
+
 	u.cfg.Log("creating upgraded release for %s", upgradedRelease.Name)
-	if err := u.cfg.Releases.Create(upgradedRelease); err != nil {
+	if err := u.cfg.Releases.CreateWithMaxHistory(upgradedRelease, u.MaxHistory); err != nil {
 		return nil, err
 	}
 
@@ -244,7 +393,9 @@ func (u *Upgrade) performUpgrade(originalRelease, upgradedRelease *release.Relea
 		u.cfg.Log("upgrade hooks disabled for %s", upgradedRelease.Name)
 	}
 
-	results, err := u.cfg.KubeClient.Update(current, target, u.Force)
+	_, span := tracing.Start(ctx, nil, "apply", kv.Int("helm.resources", len(target)))
+	results, err := u.cfg.updateResources(current, target, u.applyOptions(u.Force, u.ApplyRetryOptions))
+	tracing.End(ctx, span, err)
 	if err != nil {
 		u.cfg.recordRelease(originalRelease)
 		return u.failRelease(upgradedRelease, results.Created, err)
@@ -261,7 +412,10 @@ func (u *Upgrade) performUpgrade(originalRelease, upgradedRelease *release.Relea
 	}
 
 	if u.Wait {
-		if err := u.cfg.KubeClient.Wait(target, u.Timeout); err != nil {
+		_, span := tracing.Start(ctx, nil, "wait")
+		err := u.cfg.waitForResources(target, u.Timeout)
+		tracing.End(ctx, span, err)
+		if err != nil {
 			u.cfg.recordRelease(originalRelease)
 			return u.failRelease(upgradedRelease, results.Created, err)
 		}
@@ -336,7 +490,9 @@ func (u *Upgrade) failRelease(rel *release.Release, created kube.ResourceList, e
 		rollin.Recreate = u.Recreate
 		rollin.Force = u.Force
 		rollin.Timeout = u.Timeout
-		if rollErr := rollin.Run(rel.Name); rollErr != nil {
+		rollin.MaxHistory = u.MaxHistory
+		// The release is still locked by the upgrade.
+		if rollErr := rollin.run(rel.Name); rollErr != nil {
 			return rel, errors.Wrapf(rollErr, "an error occurred while rolling back the release. original upgrade error: %s", err)
 		}
 		return rel, errors.Wrapf(err, "release %s failed, and has been rolled back due to atomic being set", rel.Name)
@@ -384,6 +540,56 @@ func (u *Upgrade) reuseValues(chart *chart.Chart, current *release.Release, newV
 	return newVals, nil
 }
 
+// valueSources returns the sources of the values of the upgraded release,
+// including those of the values reuseValues copies from the current release.
+func (u *Upgrade) valueSources(current *release.Release, newVals map[string]interface{}) map[string]string {
+	switch {
+	case u.ResetValues:
+		return u.ValueSources
+	case u.ReuseValues:
+		return mergeValueSources(current.ValueSources, u.ValueSources)
+	case len(newVals) == 0 && len(current.Config) > 0:
+		return current.ValueSources
+	}
+	return u.ValueSources
+}
+
+// mergeValueSources returns the sources of the current values overridden by
+// new values. The sources of current values below or above the path of a new
+// value are dropped, as the new value replaces them.
+func mergeValueSources(current, sources map[string]string) map[string]string {
+	if len(current) == 0 {
+		return sources
+	}
+	merged := make(map[string]string, len(current)+len(sources))
+	for path, source := range current {
+		replaced := false
+		for newPath := range sources {
+			if isValuePathPrefix(newPath, path) || isValuePathPrefix(path, newPath) {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged[path] = source
+		}
+	}
+	for path, source := range sources {
+		merged[path] = source
+	}
+	return merged
+}
+
+// isValuePathPrefix reports whether the value at path is or is within the
+// value at prefix, such as "image" for "image.tag" or "hosts" for "hosts[0]".
+func isValuePathPrefix(prefix, path string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	rest := path[len(prefix):]
+	return rest == "" || rest[0] == '.' || rest[0] == '['
+}
+
 func validateManifest(c kube.Interface, manifest []byte, openAPIValidation bool) error {
 	_, err := c.Build(bytes.NewReader(manifest), openAPIValidation)
 	return err
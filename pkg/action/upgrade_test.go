@@ -17,15 +17,18 @@ limitations under the License.
 package action
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
 	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/logging"
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/time"
 )
@@ -253,3 +256,105 @@ func TestUpgradeRelease_ReuseValues(t *testing.T) {
 		is.Equal(expectedValues, updatedRes.Config)
 	})
 }
+
+func TestUpgradeRelease_ValueSources(t *testing.T) {
+	is := assert.New(t)
+	upAction := upgradeAction(t)
+
+	rel := releaseStub()
+	rel.Name = "sources"
+	rel.Info.Status = release.StatusDeployed
+	rel.Config = map[string]interface{}{
+		"name":  "value",
+		"image": map[string]interface{}{"tag": "1.0"},
+		"hosts": []interface{}{"a.example.com"},
+	}
+	rel.ValueSources = map[string]string{
+		"name":      "values.yaml",
+		"image.tag": "--set",
+		"hosts[0]":  "values.yaml",
+	}
+	is.NoError(upAction.cfg.Releases.Create(rel))
+
+	upAction.ReuseValues = true
+	upAction.ValueSources = map[string]string{
+		"image.tag": "values-prod.yaml",
+		"hosts":     "values-prod.yaml",
+	}
+	newValues := map[string]interface{}{
+		"image": map[string]interface{}{"tag": "2.0"},
+		"hosts": []interface{}{},
+	}
+	res, err := upAction.Run(rel.Name, buildChart(), newValues)
+	is.NoError(err)
+	is.Equal(map[string]string{
+		"name":      "values.yaml",
+		"image.tag": "values-prod.yaml",
+		"hosts":     "values-prod.yaml",
+	}, res.ValueSources)
+
+	// Values copied from the current release keep their sources.
+	upAction = upgradeAction(t)
+	rel = releaseStub()
+	rel.Name = "sources"
+	rel.Info.Status = release.StatusDeployed
+	rel.Config = map[string]interface{}{"name": "value"}
+	rel.ValueSources = map[string]string{"name": "values.yaml"}
+	is.NoError(upAction.cfg.Releases.Create(rel))
+	res, err = upAction.Run(rel.Name, buildChart(), map[string]interface{}{})
+	is.NoError(err)
+	is.Equal(rel.ValueSources, res.ValueSources)
+}
+
+func TestUpgradeRelease_Labels(t *testing.T) {
+	is := assert.New(t)
+	req := require.New(t)
+
+	upAction := upgradeAction(t)
+	rel := releaseStub()
+	rel.Name = "labeled"
+	rel.Info.Status = release.StatusDeployed
+	rel.Labels = map[string]string{"team": "payments", "ticket": "OPS-1"}
+	upAction.cfg.Releases.Create(rel)
+
+	upAction.Labels = map[string]string{"ticket": "", "pipeline": "build-42"}
+	res, err := upAction.Run(rel.Name, buildChart(), map[string]interface{}{})
+	req.NoError(err)
+	is.Equal(map[string]string{"team": "payments", "pipeline": "build-42"}, res.Labels)
+
+	upAction.Labels = map[string]string{"owner": "me"}
+	_, err = upAction.Run(rel.Name, buildChart(), map[string]interface{}{})
+	is.EqualError(err, "invalid release labels:\n- owner: label is reserved by Helm")
+}
+
+func TestUpgradeRelease_DeprecatedAPIs(t *testing.T) {
+	is := assert.New(t)
+	req := require.New(t)
+
+	upAction := upgradeAction(t)
+	var logs bytes.Buffer
+	upAction.cfg.Logger = logging.New(&logs, logging.LevelWarn)
+	rel := releaseStub()
+	rel.Name = "legacy"
+	rel.Info.Status = release.StatusDeployed
+	upAction.cfg.Releases.Create(rel)
+
+	ch := buildChart(func(opts *chartOptions) {
+		opts.Templates = append(opts.Templates, &chart.File{
+			Name: "templates/deployment.yaml",
+			Data: []byte("apiVersion: extensions/v1beta1\nkind: Deployment\nmetadata:\n  name: legacy\n"),
+		})
+	})
+
+	// The API is removed in the Kubernetes version of the cluster.
+	_, err := upAction.Run(rel.Name, ch, map[string]interface{}{})
+	req.Error(err)
+	is.Contains(err.Error(), "upgrade uses APIs removed in Kubernetes v1.16.0")
+	is.Contains(err.Error(), `hello/templates/deployment.yaml: Deployment "legacy" uses extensions/v1beta1, which is removed in Kubernetes v1.16; use apps/v1 instead`)
+
+	// The API is only deprecated in the Kubernetes version checked against.
+	upAction.KubeVersion = &chartutil.KubeVersion{Version: "v1.15.0", Major: "1", Minor: "15"}
+	_, err = upAction.Run(rel.Name, ch, map[string]interface{}{})
+	req.NoError(err)
+	is.Contains(logs.String(), "[warn] resource uses a deprecated API template=hello/templates/deployment.yaml kind=Deployment name=legacy apiVersion=extensions/v1beta1 replacement=apps/v1 removedIn=1.16")
+}
@@ -18,12 +18,19 @@ package action
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/kubectl/pkg/util/openapi"
+	"sigs.k8s.io/yaml"
 
 	"helm.sh/helm/v3/pkg/kube"
+	"helm.sh/helm/v3/pkg/policy"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
 )
 
 func existingResourceConflict(resources kube.ResourceList) error {
@@ -46,3 +53,82 @@ func existingResourceConflict(resources kube.ResourceList) error {
 	})
 	return err
 }
+
+// validateManifests validates each document of manifest against the OpenAPI
+// schema of the cluster, falling back to the schema in schemaFile, or to the
+// schema bundled with Helm, when the cluster schema is unavailable. All
+// problems are reported at once, grouped by the template they come from.
+func (c *Configuration) validateManifests(manifest, schemaFile string) error {
+	schema, err := c.openAPISchema(schemaFile)
+	if err != nil {
+		return err
+	}
+
+	manifests := releaseutil.SplitManifests(manifest)
+	keys := make([]string, 0, len(manifests))
+	for k := range manifests {
+		keys = append(keys, k)
+	}
+	sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+
+	var problems []string
+	for _, k := range keys {
+		doc := manifests[k]
+		errs := kube.ValidateManifest(schema, []byte(doc))
+		if len(errs) == 0 {
+			continue
+		}
+		name := manifestSource(doc)
+		var head releaseutil.SimpleHead
+		if err := yaml.Unmarshal([]byte(doc), &head); err == nil && head.Metadata != nil {
+			name = fmt.Sprintf("%s (%s %s)", name, head.Kind, head.Metadata.Name)
+		}
+		for _, e := range errs {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, e))
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Errorf("manifests do not match the OpenAPI schema:\n- %s", strings.Join(problems, "\n- "))
+	}
+	return nil
+}
+
+// checkPolicy evaluates the manifest of rel, with those of its hooks, against
+// the policies of p.
+func checkPolicy(p policy.Evaluator, rel *release.Release) error {
+	var b strings.Builder
+	b.WriteString(rel.Manifest)
+	for _, h := range rel.Hooks {
+		fmt.Fprintf(&b, "\n---\n# Source: %s\n%s\n", h.Path, h.Manifest)
+	}
+	return policy.Check(p, b.String())
+}
+
+// openAPISchema returns the schema served by the cluster or, if that cannot
+// be retrieved, the schema stored in schemaFile, falling back to the schema
+// bundled with Helm.
+func (c *Configuration) openAPISchema(schemaFile string) (openapi.Resources, error) {
+	if getter, ok := c.KubeClient.(kube.SchemaGetter); ok {
+		schema, err := getter.OpenAPISchema()
+		if err == nil {
+			return schema, nil
+		}
+		c.Log("unable to get the OpenAPI schema from the cluster: %s", err)
+	}
+	if schemaFile != "" {
+		return kube.LoadOpenAPISchema(schemaFile)
+	}
+	c.Log("validating manifests against the bundled OpenAPI schema")
+	return kube.BundledOpenAPISchema()
+}
+
+// manifestSource returns the template a rendered document came from.
+func manifestSource(doc string) string {
+	const prefix = "# Source: "
+	for _, line := range strings.Split(doc, "\n") {
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, prefix))
+		}
+	}
+	return "manifest"
+}
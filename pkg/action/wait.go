@@ -0,0 +1,55 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package action
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/pkg/kube"
+)
+
+// maxWaitEvents is the number of most recent wait events included in the
+// error returned when a wait fails.
+const maxWaitEvents = 5
+
+// waitForResources waits for resources to become ready. When the Kubernetes
+// client can report why resources are not progressing, the problems are
+// logged, passed to WaitEvents and summarized in the error of a failed wait.
+func (c *Configuration) waitForResources(resources kube.ResourceList, timeout time.Duration) error {
+	waiter, ok := c.KubeClient.(kube.EventWaiter)
+	if !ok {
+		return c.KubeClient.Wait(resources, timeout)
+	}
+	var events []string
+	err := waiter.WaitWithEvents(resources, timeout, func(e kube.WaitEvent) {
+		c.Log("waiting: %s", e)
+		events = append(events, e.String())
+		if c.WaitEvents != nil {
+			c.WaitEvents(e)
+		}
+	})
+	if err != nil && len(events) > 0 {
+		if len(events) > maxWaitEvents {
+			events = events[len(events)-maxWaitEvents:]
+		}
+		return errors.Wrapf(err, "resources not ready (%s)", strings.Join(events, "; "))
+	}
+	return err
+}
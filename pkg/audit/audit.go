@@ -0,0 +1,202 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit // import "helm.sh/helm/v3/pkg/audit"
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Sources of the credentials of a request.
+const (
+	// CredentialsNone is a request sent without credentials.
+	CredentialsNone = "none"
+	// CredentialsBasicAuth is a request sent with the username and password
+	// set for it, such as those of a repository.
+	CredentialsBasicAuth = "basic-auth"
+	// CredentialsProvider is a request sent with credentials looked up by a
+	// credential provider, such as a plugin.
+	CredentialsProvider = "credential-provider"
+	// CredentialsRegistryConfig is a request sent with credentials stored by
+	// 'helm registry login'.
+	CredentialsRegistryConfig = "registry-config"
+	// CredentialsClientCertificate is a request authenticated by a TLS client
+	// certificate only.
+	CredentialsClientCertificate = "client-certificate"
+)
+
+// Event is an outbound request.
+type Event struct {
+	Time time.Time `json:"time"`
+	// Component is the part of Helm that sent the request, such as
+	// "getter/http" or "registry".
+	Component string `json:"component"`
+	Method    string `json:"method"`
+	// URL is the URL or reference requested, without user information and
+	// query values, which may hold secrets.
+	URL string `json:"url"`
+	// Status is the status code of the response, or zero if there was none.
+	Status int `json:"status,omitempty"`
+	// Bytes is the number of bytes of the response body read.
+	Bytes int64 `json:"bytes"`
+	// Credentials is the source of the credentials sent with the request.
+	Credentials string        `json:"credentials"`
+	Duration    time.Duration `json:"duration"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// Auditor receives the events of the outbound requests. It is called by
+// concurrent requests at once.
+type Auditor interface {
+	Audit(e Event)
+}
+
+// Func is an Auditor calling a function.
+type Func func(e Event)
+
+// Audit calls f.
+func (f Func) Audit(e Event) {
+	f(e)
+}
+
+// Discard is an Auditor that discards all events.
+var Discard Auditor = Func(func(Event) {})
+
+var (
+	defaultMu      sync.RWMutex
+	defaultAuditor = Discard
+)
+
+// Default returns the Auditor used when none is set.
+func Default() Auditor {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultAuditor
+}
+
+// SetDefault sets the Auditor used when none is set. It applies to the
+// getters and registry clients created afterwards.
+func SetDefault(a Auditor) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if a == nil {
+		a = Discard
+	}
+	defaultAuditor = a
+}
+
+// NewJSONAuditor returns an Auditor writing the events to w as JSON, one
+// per line.
+func NewJSONAuditor(w io.Writer) Auditor {
+	var mu sync.Mutex
+	enc := json.NewEncoder(w)
+	return Func(func(e Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		enc.Encode(e)
+	})
+}
+
+// RedactURL returns rawurl without its user information and with its query
+// values replaced, so that it holds no secrets.
+func RedactURL(rawurl string) string {
+	u, err := url.Parse(rawurl)
+	if err != nil || u.Scheme == "" {
+		// A reference rather than a URL.
+		return rawurl
+	}
+	u.User = nil
+	if u.RawQuery != "" {
+		q := u.Query()
+		for k := range q {
+			q[k] = []string{"redacted"}
+		}
+		u.RawQuery = q.Encode()
+	}
+	return u.String()
+}
+
+// Transport returns a transport auditing the requests it sends to a. The
+// credentials function returns the source of the credentials of a request.
+// The event of a request is sent once its response body is read or closed.
+func Transport(rt http.RoundTripper, a Auditor, component string, credentials func(*http.Request) string) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &transport{rt: rt, auditor: a, component: component, credentials: credentials}
+}
+
+type transport struct {
+	rt          http.RoundTripper
+	auditor     Auditor
+	component   string
+	credentials func(*http.Request) string
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	e := Event{
+		Time:        time.Now(),
+		Component:   t.component,
+		Method:      req.Method,
+		URL:         RedactURL(req.URL.String()),
+		Credentials: t.credentials(req),
+	}
+	resp, err := t.rt.RoundTrip(req)
+	if err != nil {
+		e.Duration = time.Since(e.Time)
+		e.Error = err.Error()
+		t.auditor.Audit(e)
+		return resp, err
+	}
+	e.Status = resp.StatusCode
+	resp.Body = &body{ReadCloser: resp.Body, event: e, auditor: t.auditor}
+	return resp, nil
+}
+
+// body counts the bytes read from a response body and sends the event of
+// its request once it is read or closed.
+type body struct {
+	io.ReadCloser
+	event   Event
+	auditor Auditor
+	once    sync.Once
+}
+
+func (b *body) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.event.Bytes += int64(n)
+	if err == io.EOF {
+		b.audit()
+	}
+	return n, err
+}
+
+func (b *body) Close() error {
+	b.audit()
+	return b.ReadCloser.Close()
+}
+
+func (b *body) audit() {
+	b.once.Do(func() {
+		b.event.Duration = time.Since(b.event.Time)
+		b.auditor.Audit(b.event)
+	})
+}
@@ -0,0 +1,26 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package audit records the outbound requests of the Helm SDK.
+
+An Auditor receives an Event for every request the getters and the registry
+client send, with the URL or reference requested, the method, the response
+status, the number of bytes received and the source of the credentials used.
+Credentials themselves are never recorded. The getters and registry clients
+use the Auditor they are given, or else the Default one, which discards the
+events until it is replaced with SetDefault.
+*/
+package audit // import "helm.sh/helm/v3/pkg/audit"
@@ -18,6 +18,8 @@ package chart
 import (
 	"path/filepath"
 	"strings"
+
+	"helm.sh/helm/v3/pkg/warning"
 )
 
 // APIVersionV1 is the API version number for version 1.
@@ -47,6 +49,9 @@ type Chart struct {
 	// Files are miscellaneous files in a chart archive,
 	// e.g. README, LICENSE, etc.
 	Files []*File `json:"files"`
+	// Warnings are the problems found loading the chart and its
+	// dependencies that did not stop it from loading.
+	Warnings warning.Warnings `json:"-"`
 
 	parent       *Chart
 	dependencies []*Chart
@@ -21,3 +21,13 @@ type ValidationError string
 func (v ValidationError) Error() string {
 	return "validation: " + string(v)
 }
+
+// DeprecatedError is returned when a deprecated chart is refused.
+type DeprecatedError struct {
+	// Chart is the metadata of the deprecated chart.
+	Chart *Metadata
+}
+
+func (e *DeprecatedError) Error() string {
+	return e.Chart.DeprecationNotice()
+}
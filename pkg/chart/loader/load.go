@@ -18,7 +18,6 @@ package loader
 
 import (
 	"bytes"
-	"log"
 	"os"
 	"path/filepath"
 	"strings"
@@ -27,6 +26,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/warning"
 )
 
 // ChartLoader loads a chart.
@@ -109,7 +109,7 @@ func LoadFiles(files []*BufferedFile) (*chart.Chart, error) {
 				c.Metadata = new(chart.Metadata)
 			}
 			if c.Metadata.APIVersion != chart.APIVersionV1 {
-				log.Printf("Warning: Dependencies are handled in Chart.yaml since apiVersion \"v2\". We recommend migrating dependencies to Chart.yaml.")
+				c.Warnings.Add(warning.Deprecated, f.Name, "dependencies are handled in Chart.yaml since apiVersion \"v2\", we recommend migrating dependencies to Chart.yaml")
 			}
 			if err := yaml.Unmarshal(f.Data, c.Metadata); err != nil {
 				return c, errors.Wrap(err, "cannot load requirements.yaml")
@@ -152,6 +152,7 @@ func LoadFiles(files []*BufferedFile) (*chart.Chart, error) {
 		var err error
 		switch {
 		case strings.IndexAny(n, "_.") == 0:
+			c.Warnings.Add(warning.IgnoredFile, "charts/"+n, "skipped as its name starts with %q", n[:1])
 			continue
 		case filepath.Ext(n) == ".tgz":
 			file := files[0]
@@ -178,6 +179,12 @@ func LoadFiles(files []*BufferedFile) (*chart.Chart, error) {
 		if err != nil {
 			return c, errors.Wrapf(err, "error unpacking %s in %s", n, c.Name())
 		}
+		for _, w := range sc.Warnings {
+			if w.Source != "" {
+				w.Source = "charts/" + n + "/" + w.Source
+			}
+			c.Warnings = append(c.Warnings, w)
+		}
 		c.AddDependency(sc)
 	}
 
@@ -224,6 +224,29 @@ func TestLoadV2WithReqs(t *testing.T) {
 	verifyDependenciesLock(t, c)
 }
 
+func TestLoadFilesWarnings(t *testing.T) {
+	c, err := LoadFiles([]*BufferedFile{
+		{Name: "Chart.yaml", Data: []byte("apiVersion: v2\nname: frobnitz\nversion: 1.2.3\n")},
+		{Name: "requirements.yaml", Data: []byte("dependencies: []\n")},
+		{Name: "charts/_disabled/Chart.yaml", Data: []byte("apiVersion: v2\nname: disabled\nversion: 0.1.0\n")},
+	})
+	if err != nil {
+		t.Fatalf("Failed to load files: %s", err)
+	}
+	expect := []string{
+		`requirements.yaml: dependencies are handled in Chart.yaml since apiVersion "v2", we recommend migrating dependencies to Chart.yaml`,
+		`charts/_disabled: skipped as its name starts with "_"`,
+	}
+	if len(c.Warnings) != len(expect) {
+		t.Fatalf("Expected %d warnings, got %v", len(expect), c.Warnings)
+	}
+	for i, w := range c.Warnings {
+		if w.String() != expect[i] {
+			t.Errorf("Expected warning %q, got %q", expect[i], w.String())
+		}
+	}
+}
+
 func TestLoadInvalidArchive(t *testing.T) {
 	tmpdir, err := ioutil.TempDir("", "helm-test-")
 	if err != nil {
@@ -15,6 +15,8 @@ limitations under the License.
 
 package chart
 
+import "fmt"
+
 // Maintainer describes a Chart maintainer.
 type Maintainer struct {
 	// Name is a user name or organization name
@@ -25,6 +27,15 @@ type Maintainer struct {
 	URL string `json:"url,omitempty"`
 }
 
+// NamespaceMetadata describes the namespace a chart is installed in, when
+// Helm creates it.
+type NamespaceMetadata struct {
+	// Labels are set on the namespace
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are set on the namespace
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
 // Metadata for a Chart file. This models the structure of a Chart.yaml file.
 type Metadata struct {
 	// The name of the chart
@@ -53,6 +64,9 @@ type Metadata struct {
 	AppVersion string `json:"appVersion,omitempty"`
 	// Whether or not this chart is deprecated
 	Deprecated bool `json:"deprecated,omitempty"`
+	// Where the chart replacing a deprecated chart can be found: a chart
+	// reference (repo/chart), a URL or an OCI reference (oci://...).
+	ReplacedBy string `json:"replacedBy,omitempty"`
 	// Annotations are additional mappings uninterpreted by Helm,
 	// made available for inspection by other applications.
 	Annotations map[string]string `json:"annotations,omitempty"`
@@ -62,6 +76,9 @@ type Metadata struct {
 	Dependencies []*Dependency `json:"dependencies,omitempty"`
 	// Specifies the chart type: application or library
 	Type string `json:"type,omitempty"`
+	// Namespace describes the namespace the chart is installed in, when
+	// Helm creates it
+	Namespace *NamespaceMetadata `json:"namespace,omitempty"`
 }
 
 // Validate checks the metadata for known issues, returning an error if metadata is not correct
@@ -85,6 +102,19 @@ func (md *Metadata) Validate() error {
 	return nil
 }
 
+// DeprecationNotice returns a notice that the chart is deprecated, pointing
+// to its replacement if known, or "" if the chart is not deprecated.
+func (md *Metadata) DeprecationNotice() string {
+	if md == nil || !md.Deprecated {
+		return ""
+	}
+	notice := fmt.Sprintf("chart %s %s is deprecated", md.Name, md.Version)
+	if md.ReplacedBy != "" {
+		notice += fmt.Sprintf(", it is replaced by %s", md.ReplacedBy)
+	}
+	return notice
+}
+
 func isValidChartType(in string) bool {
 	switch in {
 	case "", "application", "library":
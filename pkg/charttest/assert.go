@@ -0,0 +1,180 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package charttest // import "helm.sh/helm/v3/pkg/charttest"
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// Assertion asserts on the documents it selects. Every assertion set in it
+// must hold for every selected document.
+type Assertion struct {
+	Selector
+	// Equal asserts that a field has a value.
+	Equal *FieldValue `json:"equal,omitempty"`
+	// NotEqual asserts that a field does not have a value.
+	NotEqual *FieldValue `json:"notEqual,omitempty"`
+	// Matches asserts that a field matches a regular expression.
+	Matches *FieldPattern `json:"matches,omitempty"`
+	// Exists asserts that the field selected by a path exists.
+	Exists string `json:"exists,omitempty"`
+	// NotExists asserts that the field selected by a path does not exist.
+	NotExists string `json:"notExists,omitempty"`
+	// Count asserts on the number of selected documents.
+	Count *int `json:"count,omitempty"`
+	// MatchSnapshot asserts that the selected documents are those of the
+	// snapshot of the test. It only applies to tests of suites.
+	MatchSnapshot bool `json:"matchSnapshot,omitempty"`
+	// FailedTemplate asserts that rendering fails with an error containing
+	// it. It only applies to tests of suites.
+	FailedTemplate string `json:"failedTemplate,omitempty"`
+}
+
+// FieldValue is the value of the field selected by a JSONPath expression.
+type FieldValue struct {
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// FieldPattern is a regular expression the field selected by a JSONPath
+// expression matches.
+type FieldPattern struct {
+	Path    string `json:"path"`
+	Pattern string `json:"pattern"`
+}
+
+// Check checks the assertion a against the documents of o.
+func (o *Output) Check(a Assertion) error {
+	docs := o.Select(a.Selector)
+	if a.Count != nil {
+		if len(docs) != *a.Count {
+			return errors.Errorf("expected %d documents, got %d", *a.Count, len(docs))
+		}
+	} else if len(docs) == 0 {
+		return errors.Errorf("no document rendered for %s", a.Selector)
+	}
+
+	var re *regexp.Regexp
+	if a.Matches != nil {
+		var err error
+		if re, err = regexp.Compile(a.Matches.Pattern); err != nil {
+			return errors.Wrapf(err, "invalid pattern %q", a.Matches.Pattern)
+		}
+	}
+	for _, doc := range docs {
+		if a.Equal != nil {
+			if err := checkValue(doc, a.Equal.Path, func(v interface{}) bool { return equal(v, a.Equal.Value) }, "to equal %v", a.Equal.Value); err != nil {
+				return err
+			}
+		}
+		if a.NotEqual != nil {
+			if err := checkValue(doc, a.NotEqual.Path, func(v interface{}) bool { return !equal(v, a.NotEqual.Value) }, "not to equal %v", a.NotEqual.Value); err != nil {
+				return err
+			}
+		}
+		if re != nil {
+			match := func(v interface{}) bool {
+				s, ok := v.(string)
+				return ok && re.MatchString(s)
+			}
+			if err := checkValue(doc, a.Matches.Path, match, "to match %q", a.Matches.Pattern); err != nil {
+				return err
+			}
+		}
+		if a.Exists != "" {
+			if _, err := doc.Get(a.Exists); err != nil {
+				return errors.Errorf("%s: expected %s to exist", doc, a.Exists)
+			}
+		}
+		if a.NotExists != "" {
+			if _, err := doc.Get(a.NotExists); err == nil {
+				return errors.Errorf("%s: expected %s not to exist", doc, a.NotExists)
+			}
+		}
+	}
+	return nil
+}
+
+// checkValue checks that every value of the field of doc selected by path
+// satisfies ok.
+func checkValue(doc Document, path string, ok func(interface{}) bool, format string, args ...interface{}) error {
+	values, err := doc.Get(path)
+	if err != nil {
+		return errors.Wrap(err, doc.String())
+	}
+	for _, v := range values {
+		if !ok(v) {
+			return errors.Errorf("%s: expected %s %s, got %v", doc, path, fmt.Sprintf(format, args...), v)
+		}
+	}
+	return nil
+}
+
+// String describes the documents selected by s.
+func (s Selector) String() string {
+	var parts []string
+	if s.Template != "" {
+		parts = append(parts, "template "+s.Template)
+	}
+	if s.Kind != "" {
+		parts = append(parts, "kind "+s.Kind)
+	}
+	if s.Name != "" {
+		parts = append(parts, "name "+s.Name)
+	}
+	if len(parts) == 0 {
+		return "the chart"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// MatchSnapshot compares docs with the snapshot in the file filename. The
+// snapshot is written if it does not exist yet or if update is set.
+func MatchSnapshot(docs []Document, filename string, update bool) error {
+	var b bytes.Buffer
+	for _, doc := range docs {
+		data, err := yaml.Marshal(doc.Object)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "---\n# Source: %s\n%s", doc.Template, data)
+	}
+
+	snapshot, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) || update {
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return err
+		}
+		return ioutil.WriteFile(filename, b.Bytes(), 0644)
+	}
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(snapshot, b.Bytes()) {
+		return errors.Errorf("documents do not match the snapshot %s:\n%s", filename, b.String())
+	}
+	return nil
+}
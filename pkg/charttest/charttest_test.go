@@ -0,0 +1,174 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package charttest // import "helm.sh/helm/v3/pkg/charttest"
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+)
+
+func TestRender(t *testing.T) {
+	ch, err := loader.Load("testdata/web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := Render(ch, map[string]interface{}{"replicaCount": 3}, Options{ReleaseName: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Partials and notes are not documents.
+	if len(out.Documents) != 2 {
+		t.Fatalf("expected 2 documents, got %v", out.Documents)
+	}
+
+	docs := out.Select(Selector{Kind: "Deployment"})
+	if len(docs) != 1 {
+		t.Fatalf("expected 1 deployment, got %d", len(docs))
+	}
+	if docs[0].Template != "templates/deployment.yaml" || docs[0].Name() != "test-web" {
+		t.Errorf("unexpected deployment %s", docs[0])
+	}
+	values, err := docs[0].Get("{.spec.template.spec.containers[*].image}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 1 || values[0] != "nginx:1.19" {
+		t.Errorf("unexpected images %v", values)
+	}
+	if _, err := docs[0].Get(".spec.strategy"); err == nil {
+		t.Error("expected an error for a missing field")
+	}
+
+	count := 1
+	checks := []struct {
+		assertion Assertion
+		wantErr   string
+	}{
+		{Assertion{Selector: Selector{Kind: "Deployment"}, Equal: &FieldValue{".spec.replicas", 3}}, ""},
+		{Assertion{Selector: Selector{Kind: "Deployment"}, Equal: &FieldValue{".spec.replicas", 2}}, "expected .spec.replicas to equal 2, got 3"},
+		{Assertion{Selector: Selector{Kind: "Deployment"}, NotEqual: &FieldValue{".spec.replicas", 2}}, ""},
+		{Assertion{Selector: Selector{Name: "test-web"}, Matches: &FieldPattern{".metadata.name", "^test-"}}, ""},
+		{Assertion{Selector: Selector{Kind: "Service"}, Exists: ".spec.ports"}, ""},
+		{Assertion{Selector: Selector{Kind: "Service"}, NotExists: ".spec.ports"}, "expected .spec.ports not to exist"},
+		{Assertion{Selector: Selector{Template: "templates/service.yaml"}, Count: &count}, ""},
+		{Assertion{Selector: Selector{Kind: "Ingress"}, Exists: ".spec"}, "no document rendered for kind Ingress"},
+	}
+	for _, c := range checks {
+		err := out.Check(c.assertion)
+		if c.wantErr == "" && err != nil {
+			t.Errorf("unexpected error: %s", err)
+		} else if c.wantErr != "" && (err == nil || !strings.Contains(err.Error(), c.wantErr)) {
+			t.Errorf("expected error %q, got %v", c.wantErr, err)
+		}
+	}
+}
+
+func TestRenderDisabledSubchart(t *testing.T) {
+	sub := &chart.Chart{
+		Metadata:  &chart.Metadata{Name: "sub", Version: "1.0.0"},
+		Templates: []*chart.File{{Name: "templates/configmap.yaml", Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: sub\n")}},
+	}
+	ch := &chart.Chart{
+		Metadata: &chart.Metadata{
+			Name:         "parent",
+			Version:      "1.0.0",
+			Dependencies: []*chart.Dependency{{Name: "sub", Version: "1.0.0", Condition: "sub.enabled"}},
+		},
+		Values: map[string]interface{}{"sub": map[string]interface{}{"enabled": true}},
+	}
+	ch.AddDependency(sub)
+
+	out, err := Render(ch, map[string]interface{}{"sub": map[string]interface{}{"enabled": false}}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Documents) != 0 {
+		t.Errorf("expected no documents with the subchart disabled, got %v", out.Documents)
+	}
+
+	// Disabling the subchart for one render does not remove it for others.
+	out, err = Render(ch, nil, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Documents) != 1 {
+		t.Errorf("expected the document of the subchart, got %v", out.Documents)
+	}
+	if len(ch.Dependencies()) != 1 {
+		t.Errorf("expected the chart to keep its subchart, got %d", len(ch.Dependencies()))
+	}
+}
+
+func TestMatchSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "charttest-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "snapshot.yaml")
+
+	doc := Document{Template: "templates/cm.yaml", Object: map[string]interface{}{"kind": "ConfigMap"}}
+	changed := Document{Template: "templates/cm.yaml", Object: map[string]interface{}{"kind": "Secret"}}
+	if err := MatchSnapshot([]Document{doc}, filename, false); err != nil {
+		t.Fatalf("failed to write the snapshot: %s", err)
+	}
+	if err := MatchSnapshot([]Document{doc}, filename, false); err != nil {
+		t.Errorf("expected the snapshot to match: %s", err)
+	}
+	if err := MatchSnapshot([]Document{changed}, filename, false); err == nil {
+		t.Error("expected the snapshot not to match")
+	}
+	if err := MatchSnapshot([]Document{changed}, filename, true); err != nil {
+		t.Errorf("failed to update the snapshot: %s", err)
+	}
+	if err := MatchSnapshot([]Document{changed}, filename, false); err != nil {
+		t.Errorf("expected the updated snapshot to match: %s", err)
+	}
+}
+
+func TestSuites(t *testing.T) {
+	ch, err := loader.Load("testdata/web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	suites, err := LoadSuites("testdata/web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(suites) != 2 {
+		t.Fatalf("expected 2 suites, got %d", len(suites))
+	}
+
+	var failed []string
+	for _, s := range suites {
+		for _, r := range s.Run(ch, false) {
+			if !r.Passed() {
+				failed = append(failed, r.Test)
+				t.Logf("%s: %s: %v", r.Suite, r.Test, r.Errors)
+			}
+		}
+	}
+	if len(failed) != 1 || failed[0] != "fails" {
+		t.Errorf("expected only the test 'fails' to fail, got %v", failed)
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package charttest tests the manifests rendered from charts.
+
+Tests render a chart with the values and release options they set, select
+the rendered documents by template, kind and name, and assert on the values
+of their fields, selected with JSONPath, or compare them with a snapshot.
+
+Tests are written in Go, with Render and the methods of Output, or in YAML
+suites such as
+
+	suite: deployment
+	templates:
+	- templates/deployment.yaml
+	tests:
+	- it: sets the number of replicas
+	  set:
+	    replicaCount: 3
+	  asserts:
+	  - kind: Deployment
+	    equal:
+	      path: .spec.replicas
+	      value: 3
+	  - matchSnapshot: true
+
+which LoadSuites loads from the tests directory of a chart and Suite.Run
+runs.
+*/
+package charttest // import "helm.sh/helm/v3/pkg/charttest"
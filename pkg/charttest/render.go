@@ -0,0 +1,258 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package charttest // import "helm.sh/helm/v3/pkg/charttest"
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/copystructure"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/releaseutil"
+)
+
+// Options are the release a chart is rendered for.
+type Options struct {
+	// ReleaseName defaults to "release-name".
+	ReleaseName string
+	// Namespace defaults to "default".
+	Namespace string
+	// Revision defaults to 1.
+	Revision int
+	// IsUpgrade renders the chart for an upgrade rather than an install.
+	IsUpgrade bool
+	// Capabilities default to chartutil.DefaultCapabilities.
+	Capabilities *chartutil.Capabilities
+	// Strict fails rendering on missing values.
+	Strict bool
+}
+
+// Output is the manifests rendered from a chart.
+type Output struct {
+	Documents []Document
+}
+
+// Document is a rendered manifest.
+type Document struct {
+	// Template is the path of the template the document is rendered from,
+	// relative to the chart, such as templates/deployment.yaml or
+	// charts/sub/templates/service.yaml.
+	Template string
+	// Object is the content of the document.
+	Object map[string]interface{}
+}
+
+// Selector selects rendered documents. Empty fields select every document.
+type Selector struct {
+	Template string `json:"template,omitempty"`
+	Kind     string `json:"kind,omitempty"`
+	Name     string `json:"name,omitempty"`
+}
+
+// Render renders the templates of ch with vals, which override the values of
+// the chart. ch is left unchanged, so that it can be rendered again.
+func Render(ch *chart.Chart, vals map[string]interface{}, opts Options) (*Output, error) {
+	if opts.ReleaseName == "" {
+		opts.ReleaseName = "release-name"
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = "default"
+	}
+	if opts.Revision == 0 {
+		opts.Revision = 1
+	}
+	// Processing dependencies removes disabled subcharts and imports values,
+	// so it changes a copy of the chart.
+	ch, err := copyChart(ch)
+	if err != nil {
+		return nil, err
+	}
+	if err := chartutil.ProcessDependencies(ch, vals); err != nil {
+		return nil, err
+	}
+	values, err := chartutil.ToRenderValues(ch, vals, chartutil.ReleaseOptions{
+		Name:      opts.ReleaseName,
+		Namespace: opts.Namespace,
+		Revision:  opts.Revision,
+		IsInstall: !opts.IsUpgrade,
+		IsUpgrade: opts.IsUpgrade,
+	}, opts.Capabilities)
+	if err != nil {
+		return nil, err
+	}
+	files, err := engine.Engine{Strict: opts.Strict}.Render(ch, values)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	out := &Output{}
+	for _, name := range names {
+		base := path.Base(name)
+		if strings.HasPrefix(base, "_") || strings.HasSuffix(base, "NOTES.txt") {
+			continue
+		}
+		// Rendered templates are named after the top-level chart.
+		template := strings.SplitN(name, "/", 2)[1]
+		docs := releaseutil.SplitManifests(files[name])
+		keys := make([]string, 0, len(docs))
+		for k := range docs {
+			keys = append(keys, k)
+		}
+		sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+		for _, k := range keys {
+			var obj map[string]interface{}
+			if err := yaml.Unmarshal([]byte(docs[k]), &obj); err != nil {
+				return nil, errors.Wrapf(err, "cannot parse the manifest rendered from %s", template)
+			}
+			if len(obj) == 0 {
+				continue
+			}
+			out.Documents = append(out.Documents, Document{Template: template, Object: obj})
+		}
+	}
+	return out, nil
+}
+
+// Select returns the documents selected by sel.
+func (o *Output) Select(sel Selector) []Document {
+	var docs []Document
+	for _, doc := range o.Documents {
+		if sel.Template != "" && doc.Template != sel.Template {
+			continue
+		}
+		if sel.Kind != "" && doc.Kind() != sel.Kind {
+			continue
+		}
+		if sel.Name != "" && doc.Name() != sel.Name {
+			continue
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// Kind returns the kind of the document.
+func (d Document) Kind() string {
+	kind, _ := d.Object["kind"].(string)
+	return kind
+}
+
+// Name returns the name of the document.
+func (d Document) Name() string {
+	metadata, _ := d.Object["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	return name
+}
+
+// Get returns the values of the fields of the document selected by the
+// JSONPath expression path, such as .spec.template.spec.containers[0].image.
+// The braces around the expression may be left out. It returns an error if
+// no field is selected.
+func (d Document) Get(path string) ([]interface{}, error) {
+	if !strings.HasPrefix(path, "{") {
+		path = "{" + path + "}"
+	}
+	jp := jsonpath.New("charttest")
+	if err := jp.Parse(path); err != nil {
+		return nil, errors.Wrapf(err, "invalid path %s", path)
+	}
+	results, err := jp.FindResults(d.Object)
+	if err != nil {
+		return nil, err
+	}
+	var values []interface{}
+	for _, result := range results {
+		for _, v := range result {
+			values = append(values, v.Interface())
+		}
+	}
+	if len(values) == 0 {
+		return nil, errors.Errorf("%s is not found", path)
+	}
+	return values, nil
+}
+
+// String returns the template, kind and name of the document.
+func (d Document) String() string {
+	return fmt.Sprintf("%s %s/%s", d.Template, d.Kind(), d.Name())
+}
+
+// equal reports whether the values a and b, either parsed from YAML or
+// given in Go, are equal once both are converted to JSON types.
+func equal(a, b interface{}) bool {
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+func normalize(v interface{}) interface{} {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out interface{}
+	if err := yaml.Unmarshal(data, &out); err != nil {
+		return v
+	}
+	return out
+}
+
+// copyChart returns a copy of ch and its subcharts whose dependencies, values
+// and metadata can be changed without changing ch.
+func copyChart(ch *chart.Chart) (*chart.Chart, error) {
+	out := *ch
+	if ch.Metadata != nil {
+		md := *ch.Metadata
+		if ch.Metadata.Dependencies != nil {
+			md.Dependencies = make([]*chart.Dependency, len(ch.Metadata.Dependencies))
+			for i, dep := range ch.Metadata.Dependencies {
+				d := *dep
+				md.Dependencies[i] = &d
+			}
+		}
+		out.Metadata = &md
+	}
+	if ch.Values != nil {
+		values, err := copystructure.Copy(ch.Values)
+		if err != nil {
+			return nil, err
+		}
+		out.Values = values.(map[string]interface{})
+	}
+	deps := make([]*chart.Chart, 0, len(ch.Dependencies()))
+	for _, dep := range ch.Dependencies() {
+		d, err := copyChart(dep)
+		if err != nil {
+			return nil, err
+		}
+		deps = append(deps, d)
+	}
+	out.SetDependencies(deps...)
+	return &out, nil
+}
@@ -0,0 +1,262 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package charttest // import "helm.sh/helm/v3/pkg/charttest"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+// TestsDir is the directory of a chart holding its test suites, in files
+// named *_test.yaml.
+const TestsDir = "tests"
+
+// SnapshotDir is the directory, next to the test suites, holding their
+// snapshots.
+const SnapshotDir = "__snapshot__"
+
+// Suite is a set of tests of a chart, defined in YAML.
+type Suite struct {
+	Name string `json:"suite"`
+	// Templates restricts the documents the tests assert on to those
+	// rendered from these templates, relative to the chart.
+	Templates []string `json:"templates,omitempty"`
+	// Values are values files, relative to the suite, and Set values keyed
+	// by their dotted path, that apply to every test.
+	Values []string               `json:"values,omitempty"`
+	Set    map[string]interface{} `json:"set,omitempty"`
+	// Release is the release the chart is rendered for.
+	Release Release `json:"release,omitempty"`
+	// Capabilities are the capabilities the chart is rendered for, in the
+	// format of chartutil.ParseCapabilities. Their API versions are added
+	// to the default ones.
+	Capabilities json.RawMessage `json:"capabilities,omitempty"`
+	Tests        []Test          `json:"tests"`
+
+	// filename is the file the suite is loaded from.
+	filename string
+}
+
+// Release is the release a suite renders a chart for.
+type Release struct {
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Revision  int    `json:"revision,omitempty"`
+	Upgrade   bool   `json:"upgrade,omitempty"`
+}
+
+// Test renders a chart and asserts on the rendered documents.
+type Test struct {
+	Name string `json:"it"`
+	// Values and Set add to the values of the suite.
+	Values  []string               `json:"values,omitempty"`
+	Set     map[string]interface{} `json:"set,omitempty"`
+	Asserts []Assertion            `json:"asserts"`
+}
+
+// Result is the result of a test.
+type Result struct {
+	Suite string
+	Test  string
+	// Errors are the failed assertions of the test.
+	Errors []error
+}
+
+// Passed reports whether every assertion of the test held.
+func (r Result) Passed() bool {
+	return len(r.Errors) == 0
+}
+
+// LoadSuite loads a suite from the file filename.
+func LoadSuite(filename string) (*Suite, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	s := &Suite{}
+	if err := yaml.UnmarshalStrict(data, s); err != nil {
+		return nil, errors.Wrapf(err, "cannot load test suite %s", filename)
+	}
+	if s.Name == "" {
+		s.Name = strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	}
+	s.filename = filename
+	return s, nil
+}
+
+// LoadSuites loads the suites in the TestsDir directory of the chart
+// directory dir.
+func LoadSuites(dir string) ([]*Suite, error) {
+	files, err := filepath.Glob(filepath.Join(dir, TestsDir, "*_test.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	suites := make([]*Suite, 0, len(files))
+	for _, file := range files {
+		s, err := LoadSuite(file)
+		if err != nil {
+			return nil, err
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
+
+// Run runs the tests of the suite against ch. Snapshots are written if they
+// do not exist yet or if updateSnapshots is set.
+func (s *Suite) Run(ch *chart.Chart, updateSnapshots bool) []Result {
+	results := make([]Result, 0, len(s.Tests))
+	for _, t := range s.Tests {
+		result := Result{Suite: s.Name, Test: t.Name}
+		if err := s.run(ch, t, updateSnapshots, &result); err != nil {
+			result.Errors = append(result.Errors, err)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// run runs the test t, adding its failed assertions to result. It returns
+// an error if the test cannot be run.
+func (s *Suite) run(ch *chart.Chart, t Test, updateSnapshots bool, result *Result) error {
+	vals := map[string]interface{}{}
+	for _, layer := range []struct {
+		files []string
+		set   map[string]interface{}
+	}{{s.Values, s.Set}, {t.Values, t.Set}} {
+		for _, file := range layer.files {
+			fileVals, err := chartutil.ReadValuesFile(s.path(file))
+			if err != nil {
+				return err
+			}
+			vals = chartutil.CoalesceTables(fileVals, vals)
+		}
+		vals = chartutil.CoalesceTables(expandPaths(layer.set), vals)
+	}
+
+	opts := Options{
+		ReleaseName: s.Release.Name,
+		Namespace:   s.Release.Namespace,
+		Revision:    s.Release.Revision,
+		IsUpgrade:   s.Release.Upgrade,
+	}
+	if len(s.Capabilities) > 0 {
+		caps, err := chartutil.ParseCapabilities(s.Capabilities)
+		if err != nil {
+			return err
+		}
+		caps.APIVersions = append(append(chartutil.VersionSet{}, chartutil.DefaultCapabilities.APIVersions...), caps.APIVersions...)
+		opts.Capabilities = caps
+	}
+
+	out, renderErr := Render(ch, vals, opts)
+	if renderErr == nil && len(s.Templates) > 0 {
+		var docs []Document
+		for _, doc := range out.Documents {
+			if containsString(s.Templates, doc.Template) {
+				docs = append(docs, doc)
+			}
+		}
+		out.Documents = docs
+	}
+
+	snapshots := 0
+	for i, a := range t.Asserts {
+		var err error
+		switch {
+		case a.FailedTemplate != "":
+			if renderErr == nil {
+				err = errors.Errorf("expected rendering to fail with %q", a.FailedTemplate)
+			} else if !strings.Contains(renderErr.Error(), a.FailedTemplate) {
+				err = errors.Errorf("expected rendering to fail with %q, got %q", a.FailedTemplate, renderErr)
+			}
+		case renderErr != nil:
+			return renderErr
+		case a.MatchSnapshot:
+			snapshots++
+			err = MatchSnapshot(out.Select(a.Selector), s.snapshotFile(t.Name, snapshots), updateSnapshots)
+		default:
+			err = out.Check(a)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, errors.Wrapf(err, "assertion %d", i+1))
+		}
+	}
+	return nil
+}
+
+// path returns the path of file, relative to the suite.
+func (s *Suite) path(file string) string {
+	if filepath.IsAbs(file) || s.filename == "" {
+		return file
+	}
+	return filepath.Join(filepath.Dir(s.filename), file)
+}
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+
+// snapshotFile returns the file of the nth snapshot of the test named name.
+func (s *Suite) snapshotFile(name string, n int) string {
+	base := strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if n > 1 {
+		base = fmt.Sprintf("%s-%d", base, n)
+	}
+	suite := strings.TrimSuffix(filepath.Base(s.filename), filepath.Ext(s.filename))
+	if s.filename == "" {
+		suite = s.Name
+	}
+	return s.path(filepath.Join(SnapshotDir, suite, base+".yaml"))
+}
+
+// expandPaths turns values keyed by their dotted path, such as image.tag,
+// into nested maps.
+func expandPaths(set map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, v := range set {
+		parts := strings.Split(k, ".")
+		m := out
+		for _, p := range parts[:len(parts)-1] {
+			next, ok := m[p].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				m[p] = next
+			}
+			m = next
+		}
+		m[parts[len(parts)-1]] = v
+	}
+	return out
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
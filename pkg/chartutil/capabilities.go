@@ -16,10 +16,21 @@ limitations under the License.
 package chartutil
 
 import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+
+	helmversion "helm.sh/helm/v3/internal/version"
 )
 
 var (
@@ -34,6 +45,7 @@ var (
 			Minor:   "16",
 		},
 		APIVersions: DefaultVersionSet,
+		HelmVersion: helmversion.Get(),
 	}
 )
 
@@ -43,6 +55,85 @@ type Capabilities struct {
 	KubeVersion KubeVersion
 	// APIversions are supported Kubernetes API versions.
 	APIVersions VersionSet
+	// HelmVersion is the build information for this helm version
+	HelmVersion helmversion.BuildInfo
+}
+
+// capabilitiesFile is the format of a file describing capabilities.
+type capabilitiesFile struct {
+	KubeVersion string   `json:"kubeVersion"`
+	APIVersions []string `json:"apiVersions"`
+	HelmVersion string   `json:"helmVersion"`
+}
+
+// ParseCapabilities parses the capabilities of a Kubernetes cluster, to
+// render for it without contacting it.
+//
+// The capabilities are either YAML, such as
+//
+//	kubeVersion: v1.22.3
+//	apiVersions:
+//	- v1
+//	- apps/v1
+//	- monitoring.coreos.com/v1
+//	helmVersion: v3.7.0
+//
+// or, if they are not a YAML mapping, the output of 'kubectl api-versions', one
+// API version per line. Invalid YAML is an error. Unlike those of
+// DefaultCapabilities, the API versions are only those listed. The Kubernetes
+// and Helm versions default to those of DefaultCapabilities.
+func ParseCapabilities(data []byte) (*Capabilities, error) {
+	caps := &Capabilities{
+		KubeVersion: DefaultCapabilities.KubeVersion,
+		APIVersions: VersionSet{},
+		HelmVersion: DefaultCapabilities.HelmVersion,
+	}
+
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if _, ok := doc.(map[string]interface{}); !ok {
+		// A list of API versions such as 'kubectl api-versions' prints.
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			if v := strings.TrimSpace(scanner.Text()); v != "" {
+				caps.APIVersions = append(caps.APIVersions, v)
+			}
+		}
+		return caps, scanner.Err()
+	}
+
+	var f capabilitiesFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	caps.APIVersions = append(caps.APIVersions, f.APIVersions...)
+	if f.KubeVersion != "" {
+		kv, err := ParseKubeVersion(f.KubeVersion)
+		if err != nil {
+			return nil, err
+		}
+		caps.KubeVersion = *kv
+	}
+	if f.HelmVersion != "" {
+		if _, err := semver.NewVersion(f.HelmVersion); err != nil {
+			return nil, errors.Wrapf(err, "invalid Helm version %q", f.HelmVersion)
+		}
+		caps.HelmVersion.Version = f.HelmVersion
+	}
+	return caps, nil
+}
+
+// LoadCapabilities reads the capabilities of a Kubernetes cluster from a file.
+// See ParseCapabilities for its format.
+func LoadCapabilities(filename string) (*Capabilities, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	caps, err := ParseCapabilities(data)
+	return caps, errors.Wrapf(err, "cannot load capabilities from %s", filename)
 }
 
 // KubeVersion is the Kubernetes version.
@@ -52,6 +143,19 @@ type KubeVersion struct {
 	Minor   string // Kubernetes minor version
 }
 
+// ParseKubeVersion parses a Kubernetes version such as "v1.22.0" or "1.22".
+func ParseKubeVersion(version string) (*KubeVersion, error) {
+	sv, err := semver.NewVersion(version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid Kubernetes version %q", version)
+	}
+	return &KubeVersion{
+		Version: "v" + sv.String(),
+		Major:   fmt.Sprint(sv.Major()),
+		Minor:   fmt.Sprint(sv.Minor()),
+	}, nil
+}
+
 // String implements fmt.Stringer
 func (kv *KubeVersion) String() string { return kv.Version }
 
@@ -58,3 +58,41 @@ func TestDefaultCapabilities(t *testing.T) {
 		t.Errorf("Expected default KubeVersion.Minor to be 16, got %q", kv.Minor)
 	}
 }
+
+func TestParseCapabilities(t *testing.T) {
+	caps, err := ParseCapabilities([]byte("kubeVersion: 1.22.3\napiVersions:\n- v1\n- apps/v1\nhelmVersion: v3.7.0\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caps.KubeVersion.Version != "v1.22.3" || caps.KubeVersion.Minor != "22" {
+		t.Errorf("Expected KubeVersion v1.22.3, got %+v", caps.KubeVersion)
+	}
+	if len(caps.APIVersions) != 2 || !caps.APIVersions.Has("apps/v1") {
+		t.Errorf("Expected API versions v1 and apps/v1, got %v", caps.APIVersions)
+	}
+	if caps.HelmVersion.Version != "v3.7.0" {
+		t.Errorf("Expected HelmVersion v3.7.0, got %q", caps.HelmVersion.Version)
+	}
+
+	// The output of kubectl api-versions
+	caps, err = ParseCapabilities([]byte("apps/v1\nbatch/v1\nv1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(caps.APIVersions) != 3 || !caps.APIVersions.Has("batch/v1") {
+		t.Errorf("Expected API versions apps/v1, batch/v1 and v1, got %v", caps.APIVersions)
+	}
+	if caps.KubeVersion != DefaultCapabilities.KubeVersion {
+		t.Errorf("Expected default KubeVersion, got %+v", caps.KubeVersion)
+	}
+
+	if _, err := ParseCapabilities([]byte("kubeVersion: latest\n")); err == nil {
+		t.Error("Expected an error for an invalid Kubernetes version")
+	}
+	if _, err := ParseCapabilities([]byte("kubeVersion: 1.22.3\napiVersions: v1\n")); err == nil {
+		t.Error("Expected an error for invalid API versions")
+	}
+	if _, err := ParseCapabilities([]byte("kubeVersion: [1.22.3\n")); err == nil {
+		t.Error("Expected an error for invalid YAML")
+	}
+}
@@ -18,7 +18,9 @@ package chartutil
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -26,6 +28,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/warning"
 )
 
 // ValidateAgainstSchema checks that values does not violate the structure laid out in schema
@@ -85,3 +88,61 @@ func ValidateAgainstSingleSchema(values Values, schemaJSON []byte) error {
 
 	return nil
 }
+
+// DeprecatedValues returns a warning for each of values that the schemas of
+// chrt or its dependencies mark as deprecated with the "deprecated" keyword.
+// values should be the values given by the user rather than the coalesced
+// values, so that the defaults of the chart are not warned about. Schemas
+// that are not valid JSON are ignored, as they fail validation.
+func DeprecatedValues(chrt *chart.Chart, values map[string]interface{}) warning.Warnings {
+	var w warning.Warnings
+	deprecatedValues(chrt, values, "", &w)
+	return w
+}
+
+func deprecatedValues(chrt *chart.Chart, values map[string]interface{}, prefix string, w *warning.Warnings) {
+	if chrt.Schema != nil {
+		var schema map[string]interface{}
+		if err := json.Unmarshal(chrt.Schema, &schema); err == nil {
+			deprecatedProperties(schema, values, prefix, w)
+		}
+	}
+	for _, subchart := range chrt.Dependencies() {
+		if subchartValues, ok := values[subchart.Name()].(map[string]interface{}); ok {
+			deprecatedValues(subchart, subchartValues, prefix+subchart.Name()+".", w)
+		}
+	}
+}
+
+func deprecatedProperties(schema, values map[string]interface{}, prefix string, w *warning.Warnings) {
+	properties, _ := schema["properties"].(map[string]interface{})
+	for _, name := range sortedKeys(properties) {
+		value, ok := values[name]
+		if !ok {
+			continue
+		}
+		property, ok := properties[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if deprecated, _ := property["deprecated"].(bool); deprecated {
+			if description, _ := property["description"].(string); description != "" {
+				w.Add(warning.Deprecated, prefix+name, "value is deprecated: %s", description)
+			} else {
+				w.Add(warning.Deprecated, prefix+name, "value is deprecated")
+			}
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			deprecatedProperties(property, nested, prefix+name+".", w)
+		}
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
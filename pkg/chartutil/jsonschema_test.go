@@ -141,3 +141,53 @@ func TestValidateAgainstSchemaNegative(t *testing.T) {
 		t.Errorf("Error string :\n`%s`\ndoes not match expected\n`%s`", errString, expectedErrString)
 	}
 }
+
+func TestDeprecatedValues(t *testing.T) {
+	subchart := &chart.Chart{
+		Metadata: &chart.Metadata{
+			Name: "subchart",
+		},
+		Schema: []byte(`{"properties": {"age": {"type": "integer", "deprecated": true}}}`),
+	}
+	chrt := &chart.Chart{
+		Metadata: &chart.Metadata{
+			Name: "chrt",
+		},
+		Schema: []byte(`{
+  "properties": {
+    "image": {
+      "properties": {
+        "name": {"deprecated": true, "description": "use image.repository"},
+        "tag": {"deprecated": true}
+      }
+    },
+    "name": {"type": "string"}
+  }
+}`),
+	}
+	chrt.AddDependency(subchart)
+
+	vals := map[string]interface{}{
+		"name": "John",
+		"image": map[string]interface{}{
+			"name": "nginx",
+		},
+		"subchart": map[string]interface{}{
+			"age": 25,
+		},
+	}
+
+	expected := []string{
+		"image.name: value is deprecated: use image.repository",
+		"subchart.age: value is deprecated",
+	}
+	warnings := DeprecatedValues(chrt, vals)
+	if len(warnings) != len(expected) {
+		t.Fatalf("Expected %d warnings, got %v", len(expected), warnings)
+	}
+	for i, w := range warnings {
+		if w.String() != expected[i] {
+			t.Errorf("Expected warning %q, got %q", expected[i], w.String())
+		}
+	}
+}
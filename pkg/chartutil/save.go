@@ -21,6 +21,7 @@ import (
 	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"time"
@@ -115,29 +116,40 @@ func Save(c *chart.Chart, outDir string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	err = writeArchive(f, c)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(filename)
+	}
+	return filename, err
+}
 
+// Write writes the chart to w as a gzipped tar archive, as Save does to a
+// file, so that archives can be built without writing to disk.
+func Write(c *chart.Chart, w io.Writer) error {
+	if err := c.Validate(); err != nil {
+		return errors.Wrap(err, "chart validation")
+	}
+	return writeArchive(w, c)
+}
+
+func writeArchive(w io.Writer, c *chart.Chart) error {
 	// Wrap in gzip writer
-	zipper := gzip.NewWriter(f)
+	zipper := gzip.NewWriter(w)
 	zipper.Header.Extra = headerBytes
 	zipper.Header.Comment = "Helm"
 
 	// Wrap in tar writer
 	twriter := tar.NewWriter(zipper)
-	rollback := false
-	defer func() {
-		twriter.Close()
-		zipper.Close()
-		f.Close()
-		if rollback {
-			os.Remove(filename)
-		}
-	}()
-
 	if err := writeTarContents(twriter, c, ""); err != nil {
-		rollback = true
-		return filename, err
+		return err
+	}
+	if err := twriter.Close(); err != nil {
+		return err
 	}
-	return filename, nil
+	return zipper.Close()
 }
 
 func writeTarContents(out *tar.Writer, c *chart.Chart, prefix string) error {
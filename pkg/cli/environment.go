@@ -28,12 +28,14 @@ import (
 	"strconv"
 	"sync"
 
+	units "github.com/docker/go-units"
 	"github.com/spf13/pflag"
 
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"helm.sh/helm/v3/pkg/helmpath"
 	"helm.sh/helm/v3/pkg/kube"
+	"helm.sh/helm/v3/pkg/transfer"
 )
 
 // EnvSettings describes all of the environment settings.
@@ -50,12 +52,26 @@ type EnvSettings struct {
 	Debug bool
 	// RegistryConfig is the path to the registry config file.
 	RegistryConfig string
+	// RegistryCache is the path to the registry cache directory.
+	RegistryCache string
 	// RepositoryConfig is the path to the repositories file.
 	RepositoryConfig string
 	// RepositoryCache is the path to the repository cache directory.
 	RepositoryCache string
 	// PluginsDirectory is the path to the plugins directory.
 	PluginsDirectory string
+	// MaxConcurrentDownloads is the number of downloads run at once, or
+	// unlimited if it is zero.
+	MaxConcurrentDownloads int
+	// DownloadBandwidth is the number of bytes per second shared by all
+	// downloads, or unlimited if it is zero.
+	DownloadBandwidth int64
+	// AuditLog is the file the outbound requests are recorded to, as JSON
+	// lines, if it is not empty.
+	AuditLog string
+	// VerificationPolicy is the file of the verification policy charts are
+	// checked against when installed or upgraded, if it is not empty.
+	VerificationPolicy string
 }
 
 func New() *EnvSettings {
@@ -65,10 +81,20 @@ func New() *EnvSettings {
 		KubeContext:      os.Getenv("HELM_KUBECONTEXT"),
 		PluginsDirectory: envOr("HELM_PLUGINS", helmpath.DataPath("plugins")),
 		RegistryConfig:   envOr("HELM_REGISTRY_CONFIG", helmpath.ConfigPath("registry.json")),
+		RegistryCache:    envOr("HELM_REGISTRY_CACHE", helmpath.CachePath("registry", "cache")),
 		RepositoryConfig: envOr("HELM_REPOSITORY_CONFIG", helmpath.ConfigPath("repositories.yaml")),
 		RepositoryCache:  envOr("HELM_REPOSITORY_CACHE", helmpath.CachePath("repository")),
+		AuditLog:         os.Getenv("HELM_AUDIT_LOG"),
+
+		VerificationPolicy: os.Getenv("HELM_VERIFICATION_POLICY"),
 	}
 	env.Debug, _ = strconv.ParseBool(os.Getenv("HELM_DEBUG"))
+	env.MaxConcurrentDownloads, _ = strconv.Atoi(os.Getenv("HELM_MAX_CONCURRENT_DOWNLOADS"))
+	// The bandwidth is in bytes per second, with an optional binary unit
+	// such as 512k or 10MiB.
+	if bandwidth, err := units.RAMInBytes(os.Getenv("HELM_DOWNLOAD_BANDWIDTH")); err == nil {
+		env.DownloadBandwidth = bandwidth
+	}
 	return &env
 }
 
@@ -96,10 +122,16 @@ func (s *EnvSettings) EnvVars() map[string]string {
 		"HELM_DEBUG":             fmt.Sprint(s.Debug),
 		"HELM_PLUGINS":           s.PluginsDirectory,
 		"HELM_REGISTRY_CONFIG":   s.RegistryConfig,
+		"HELM_REGISTRY_CACHE":    s.RegistryCache,
 		"HELM_REPOSITORY_CACHE":  s.RepositoryCache,
 		"HELM_REPOSITORY_CONFIG": s.RepositoryConfig,
 		"HELM_NAMESPACE":         s.Namespace(),
 		"HELM_KUBECONTEXT":       s.KubeContext,
+
+		"HELM_MAX_CONCURRENT_DOWNLOADS": strconv.Itoa(s.MaxConcurrentDownloads),
+		"HELM_DOWNLOAD_BANDWIDTH":       strconv.FormatInt(s.DownloadBandwidth, 10),
+		"HELM_AUDIT_LOG":                s.AuditLog,
+		"HELM_VERIFICATION_POLICY":      s.VerificationPolicy,
 	}
 
 	if s.KubeConfig != "" {
@@ -109,6 +141,14 @@ func (s *EnvSettings) EnvVars() map[string]string {
 	return envvars
 }
 
+// TransferLimits returns the limits of the downloads set in the environment.
+func (s *EnvSettings) TransferLimits() transfer.Limits {
+	return transfer.Limits{
+		Concurrency: s.MaxConcurrentDownloads,
+		Bandwidth:   s.DownloadBandwidth,
+	}
+}
+
 //Namespace gets the namespace from the configuration
 func (s *EnvSettings) Namespace() string {
 	if s.namespace != "" {
@@ -22,6 +22,8 @@ import (
 	"testing"
 
 	"github.com/spf13/pflag"
+
+	"helm.sh/helm/v3/pkg/transfer"
 )
 
 func TestEnvSettings(t *testing.T) {
@@ -35,6 +37,7 @@ func TestEnvSettings(t *testing.T) {
 		// expected values
 		ns, kcontext string
 		debug        bool
+		limits       transfer.Limits
 	}{
 		{
 			name: "defaults",
@@ -59,6 +62,12 @@ func TestEnvSettings(t *testing.T) {
 			ns:      "myns",
 			debug:   true,
 		},
+		{
+			name:    "with download limits set",
+			envvars: map[string]string{"HELM_MAX_CONCURRENT_DOWNLOADS": "4", "HELM_DOWNLOAD_BANDWIDTH": "10MiB"},
+			ns:      "default",
+			limits:  transfer.Limits{Concurrency: 4, Bandwidth: 10 * 1024 * 1024},
+		},
 	}
 
 	for _, tt := range tests {
@@ -84,6 +93,9 @@ func TestEnvSettings(t *testing.T) {
 			if settings.KubeContext != tt.kcontext {
 				t.Errorf("expected kube-context %q, got %q", tt.kcontext, settings.KubeContext)
 			}
+			if settings.TransferLimits() != tt.limits {
+				t.Errorf("expected download limits %+v, got %+v", tt.limits, settings.TransferLimits())
+			}
 		})
 	}
 }
@@ -17,9 +17,11 @@ limitations under the License.
 package values
 
 import (
+	"fmt"
 	"io/ioutil"
 	"net/url"
 	"os"
+	"reflect"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -29,17 +31,96 @@ import (
 	"helm.sh/helm/v3/pkg/strvals"
 )
 
+// ListMergeStrategy is how a list in a values file is merged with the list
+// set at the same key by the values files before it.
+type ListMergeStrategy string
+
+const (
+	// ListReplace replaces the earlier list. It is the default.
+	ListReplace ListMergeStrategy = "replace"
+	// ListAppend appends the items of the list to the earlier list.
+	ListAppend ListMergeStrategy = "append"
+	// ListMergeByKey merges the maps of the list into the maps of the earlier
+	// list with the same value at the merge key, and appends the others.
+	ListMergeByKey ListMergeStrategy = "merge-by-key"
+)
+
+// DefaultMergeKey is the key identifying the items of lists merged with
+// ListMergeByKey, unless another is set.
+const DefaultMergeKey = "name"
+
+// Source names for the values set on the command line, as reported by
+// MergeValuesWithSources.
+const (
+	SourceSet       = "--set"
+	SourceSetString = "--set-string"
+	SourceSetFile   = "--set-file"
+)
+
 type Options struct {
 	ValueFiles   []string
 	StringValues []string
 	Values       []string
 	FileValues   []string
+
+	// NullDeletes makes a null value delete the key from the merged values,
+	// so that the chart default applies instead of the value set by an
+	// earlier file. By default, null values are kept, and remove the key
+	// from the chart defaults too.
+	NullDeletes bool
+	// ListMerge is how lists of later values files are merged with those of
+	// earlier files. It defaults to ListReplace.
+	ListMerge ListMergeStrategy
+	// MergeKey identifies list items merged with ListMergeByKey. It defaults
+	// to DefaultMergeKey.
+	MergeKey string
+	// Decrypter decrypts the values files it detects as encrypted. It
+	// defaults to SOPS.
+	Decrypter Decrypter
+	// DisableDecryption reads encrypted values files as is.
+	DisableDecryption bool
 }
 
 // MergeValues merges values from files specified via -f/--values and directly
 // via --set, --set-string, or --set-file, marshaling them to YAML
 func (opts *Options) MergeValues(p getter.Providers) (map[string]interface{}, error) {
+	base, _, err := opts.merge(p, false)
+	return base, err
+}
+
+// MergeValuesWithSources merges values like MergeValues, and also returns the
+// source of each merged value, keyed by its path such as "image.tag" or
+// "hosts[0]". The source is the values file that last changed the value, or
+// one of the Source constants for values set on the command line.
+func (opts *Options) MergeValuesWithSources(p getter.Providers) (map[string]interface{}, map[string]string, error) {
+	return opts.merge(p, true)
+}
+
+func (opts *Options) merge(p getter.Providers, withSources bool) (map[string]interface{}, map[string]string, error) {
+	if err := opts.validate(); err != nil {
+		return nil, nil, err
+	}
+
 	base := map[string]interface{}{}
+	var sources, leaves map[string]string
+	if withSources {
+		sources = map[string]string{}
+		leaves = map[string]string{}
+	}
+	// track records the source of the values changed since the last call.
+	track := func(source string) {
+		if !withSources {
+			return
+		}
+		current := map[string]string{}
+		flatten("", base, current)
+		for path, v := range current {
+			if prev, ok := leaves[path]; !ok || prev != v {
+				sources[path] = source
+			}
+		}
+		leaves = current
+	}
 
 	// User specified a values files via -f/--values
 	for _, filePath := range opts.ValueFiles {
@@ -47,28 +128,37 @@ func (opts *Options) MergeValues(p getter.Providers) (map[string]interface{}, er
 
 		bytes, err := readFile(filePath, p)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if bytes, err = opts.decrypt(filePath, bytes); err != nil {
+			return nil, nil, err
 		}
 
 		if err := yaml.Unmarshal(bytes, &currentMap); err != nil {
-			return nil, errors.Wrapf(err, "failed to parse %s", filePath)
+			return nil, nil, errors.Wrapf(err, "failed to parse %s", filePath)
 		}
 		// Merge with the previous map
-		base = mergeMaps(base, currentMap)
+		base = opts.mergeMaps(base, currentMap)
+		track(filePath)
 	}
 
 	// User specified a value via --set
 	for _, value := range opts.Values {
 		if err := strvals.ParseInto(value, base); err != nil {
-			return nil, errors.Wrap(err, "failed parsing --set data")
+			return nil, nil, errors.Wrap(err, "failed parsing --set data")
 		}
+		if err := opts.deleteNulls(base, value); err != nil {
+			return nil, nil, err
+		}
+		track(SourceSet)
 	}
 
 	// User specified a value via --set-string
 	for _, value := range opts.StringValues {
 		if err := strvals.ParseIntoString(value, base); err != nil {
-			return nil, errors.Wrap(err, "failed parsing --set-string data")
+			return nil, nil, errors.Wrap(err, "failed parsing --set-string data")
 		}
+		track(SourceSetString)
 	}
 
 	// User specified a value via --set-file
@@ -78,32 +168,154 @@ func (opts *Options) MergeValues(p getter.Providers) (map[string]interface{}, er
 			return string(bytes), err
 		}
 		if err := strvals.ParseIntoFile(value, base, reader); err != nil {
-			return nil, errors.Wrap(err, "failed parsing --set-file data")
+			return nil, nil, errors.Wrap(err, "failed parsing --set-file data")
 		}
+		track(SourceSetFile)
 	}
 
-	return base, nil
+	if withSources {
+		// Drop the sources of values replaced since.
+		for path := range sources {
+			if _, ok := leaves[path]; !ok {
+				delete(sources, path)
+			}
+		}
+	}
+	return base, sources, nil
 }
 
-func mergeMaps(a, b map[string]interface{}) map[string]interface{} {
+func (opts *Options) validate() error {
+	switch opts.ListMerge {
+	case "", ListReplace, ListAppend, ListMergeByKey:
+		return nil
+	}
+	return errors.Errorf("unknown list merge strategy %q, expected %q, %q or %q", opts.ListMerge, ListReplace, ListAppend, ListMergeByKey)
+}
+
+func (opts *Options) mergeMaps(a, b map[string]interface{}) map[string]interface{} {
 	out := make(map[string]interface{}, len(a))
 	for k, v := range a {
 		out[k] = v
 	}
 	for k, v := range b {
+		if v == nil && opts.NullDeletes {
+			delete(out, k)
+			continue
+		}
 		if v, ok := v.(map[string]interface{}); ok {
 			if bv, ok := out[k]; ok {
 				if bv, ok := bv.(map[string]interface{}); ok {
-					out[k] = mergeMaps(bv, v)
+					out[k] = opts.mergeMaps(bv, v)
 					continue
 				}
 			}
 		}
+		if v, ok := v.([]interface{}); ok {
+			if bv, ok := out[k].([]interface{}); ok {
+				out[k] = opts.mergeLists(bv, v)
+				continue
+			}
+		}
 		out[k] = v
 	}
 	return out
 }
 
+func (opts *Options) mergeLists(a, b []interface{}) []interface{} {
+	switch opts.ListMerge {
+	case ListAppend:
+		return append(append([]interface{}{}, a...), b...)
+	case ListMergeByKey:
+		key := opts.MergeKey
+		if key == "" {
+			key = DefaultMergeKey
+		}
+		out := append([]interface{}{}, a...)
+	items:
+		for _, item := range b {
+			if m, ok := item.(map[string]interface{}); ok && m[key] != nil {
+				for i, existing := range out {
+					if em, ok := existing.(map[string]interface{}); ok && reflect.DeepEqual(em[key], m[key]) {
+						out[i] = opts.mergeMaps(em, m)
+						continue items
+					}
+				}
+			}
+			out = append(out, item)
+		}
+		return out
+	}
+	return b
+}
+
+// deleteNulls deletes the keys set to null by the --set value from base, if
+// null values delete keys.
+func (opts *Options) deleteNulls(base map[string]interface{}, value string) error {
+	if !opts.NullDeletes {
+		return nil
+	}
+	set, err := strvals.Parse(value)
+	if err != nil {
+		return errors.Wrap(err, "failed parsing --set data")
+	}
+	deleteNullKeys(base, set)
+	return nil
+}
+
+func deleteNullKeys(dst, set map[string]interface{}) {
+	for k, v := range set {
+		if v == nil {
+			delete(dst, k)
+		} else if sm, ok := v.(map[string]interface{}); ok {
+			if dm, ok := dst[k].(map[string]interface{}); ok {
+				deleteNullKeys(dm, sm)
+			}
+		}
+	}
+}
+
+// flatten adds the leaf values of v to out, keyed by their path below prefix.
+func flatten(prefix string, v interface{}, out map[string]string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && prefix != "" {
+			out[prefix] = "{}"
+		}
+		for k, item := range v {
+			path := k
+			if prefix != "" {
+				path = prefix + "." + k
+			}
+			flatten(path, item, out)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			out[prefix] = "[]"
+		}
+		for i, item := range v {
+			flatten(fmt.Sprintf("%s[%d]", prefix, i), item, out)
+		}
+	default:
+		out[prefix] = fmt.Sprintf("%#v", v)
+	}
+}
+
+// decrypt returns the decrypted content of the values file read from filePath
+// if it is encrypted, and data otherwise.
+func (opts *Options) decrypt(filePath string, data []byte) ([]byte, error) {
+	if opts.DisableDecryption {
+		return data, nil
+	}
+	d := opts.Decrypter
+	if d == nil {
+		d = SOPS{}
+	}
+	if !d.Encrypted(data) {
+		return data, nil
+	}
+	return d.Decrypt(filePath, data)
+}
+
 // readFile load a file from stdin, the local directory, or a remote file with a url.
 func readFile(filePath string, p getter.Providers) ([]byte, error) {
 	if strings.TrimSpace(filePath) == "-" {
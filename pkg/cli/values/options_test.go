@@ -17,8 +17,13 @@ limitations under the License.
 package values
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+
+	"sigs.k8s.io/yaml"
 )
 
 func TestMergeValues(t *testing.T) {
@@ -43,25 +48,25 @@ func TestMergeValues(t *testing.T) {
 		"testing": "fun",
 	}
 
-	testMap := mergeMaps(flatMap, nestedMap)
+	testMap := (&Options{}).mergeMaps(flatMap, nestedMap)
 	equal := reflect.DeepEqual(testMap, nestedMap)
 	if !equal {
 		t.Errorf("Expected a nested map to overwrite a flat value. Expected: %v, got %v", nestedMap, testMap)
 	}
 
-	testMap = mergeMaps(nestedMap, flatMap)
+	testMap = (&Options{}).mergeMaps(nestedMap, flatMap)
 	equal = reflect.DeepEqual(testMap, flatMap)
 	if !equal {
 		t.Errorf("Expected a flat value to overwrite a map. Expected: %v, got %v", flatMap, testMap)
 	}
 
-	testMap = mergeMaps(nestedMap, anotherNestedMap)
+	testMap = (&Options{}).mergeMaps(nestedMap, anotherNestedMap)
 	equal = reflect.DeepEqual(testMap, anotherNestedMap)
 	if !equal {
 		t.Errorf("Expected a nested map to overwrite another nested map. Expected: %v, got %v", anotherNestedMap, testMap)
 	}
 
-	testMap = mergeMaps(anotherFlatMap, anotherNestedMap)
+	testMap = (&Options{}).mergeMaps(anotherFlatMap, anotherNestedMap)
 	expectedMap := map[string]interface{}{
 		"testing": "fun",
 		"foo":     "bar",
@@ -75,3 +80,148 @@ func TestMergeValues(t *testing.T) {
 		t.Errorf("Expected a map with different keys to merge properly with another map. Expected: %v, got %v", expectedMap, testMap)
 	}
 }
+
+func writeValues(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestMergeValuesStrategies(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-values-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	base := writeValues(t, dir, "base.yaml", `
+image: {repository: nginx, tag: "1.0"}
+hosts: [a.example.com]
+containers:
+- {name: web, port: 80}
+- {name: sidecar, port: 9000}
+`)
+	prod := writeValues(t, dir, "prod.yaml", `
+image: {tag: null}
+hosts: [b.example.com]
+containers:
+- {name: web, port: 8080}
+- {name: metrics, port: 9090}
+`)
+
+	tests := []struct {
+		name   string
+		opts   Options
+		expect string
+	}{
+		{
+			name: "defaults",
+			opts: Options{},
+			expect: `containers:
+- name: web
+  port: 8080
+- name: metrics
+  port: 9090
+hosts:
+- b.example.com
+image:
+  repository: nginx
+  tag: null
+`,
+		},
+		{
+			name: "append and null deletes",
+			opts: Options{ListMerge: ListAppend, NullDeletes: true},
+			expect: `containers:
+- name: web
+  port: 80
+- name: sidecar
+  port: 9000
+- name: web
+  port: 8080
+- name: metrics
+  port: 9090
+hosts:
+- a.example.com
+- b.example.com
+image:
+  repository: nginx
+`,
+		},
+		{
+			name: "merge by key",
+			opts: Options{ListMerge: ListMergeByKey},
+			expect: `containers:
+- name: web
+  port: 8080
+- name: sidecar
+  port: 9000
+- name: metrics
+  port: 9090
+hosts:
+- a.example.com
+- b.example.com
+image:
+  repository: nginx
+  tag: null
+`,
+		},
+	}
+	for _, tt := range tests {
+		opts := tt.opts
+		opts.ValueFiles = []string{base, prod}
+		vals, err := opts.MergeValues(nil)
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		out, err := yaml.Marshal(vals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != tt.expect {
+			t.Errorf("%s: expected\n%s\ngot\n%s", tt.name, tt.expect, out)
+		}
+	}
+
+	opts := Options{ValueFiles: []string{base}, ListMerge: "zip"}
+	if _, err := opts.MergeValues(nil); err == nil {
+		t.Error("expected an error for an unknown list merge strategy")
+	}
+}
+
+func TestMergeValuesWithSources(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-values-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	base := writeValues(t, dir, "base.yaml", "image: {repository: nginx, tag: \"1.0\"}\nreplicas: 1\nhosts: [a.example.com]\n")
+	prod := writeValues(t, dir, "prod.yaml", "replicas: 3\nhosts: [b.example.com]\n")
+
+	opts := Options{
+		ValueFiles:   []string{base, prod},
+		Values:       []string{"image.tag=1.2.3,debug=null"},
+		StringValues: []string{"replicas=5"},
+		NullDeletes:  true,
+	}
+	vals, sources, err := opts.MergeValuesWithSources(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := vals["debug"]; ok {
+		t.Error("expected debug=null to be deleted")
+	}
+	expect := map[string]string{
+		"image.repository": base,
+		"image.tag":        SourceSet,
+		"replicas":         SourceSetString,
+		"hosts[0]":         prod,
+	}
+	if !reflect.DeepEqual(sources, expect) {
+		t.Errorf("expected sources %v, got %v", expect, sources)
+	}
+}
@@ -0,0 +1,274 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package values
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Prompter asks for the values described by the values schema of a chart.
+type Prompter struct {
+	// In is where the answers are read from.
+	In io.Reader
+	// Out is where the questions are written to.
+	Out io.Writer
+
+	r   *bufio.Reader
+	eof bool
+}
+
+// Prompt walks schema, the values.schema.json of a chart, and asks for the
+// value of each property with its description, default and allowed values.
+// It returns the values answered.
+//
+// defaults are the values of the chart, shown as defaults instead of those of
+// the schema. Properties already set in vals are not asked for. An empty
+// answer keeps the default, unless the property is required and has none.
+// Lists of scalars are answered as comma-separated items, and properties of
+// other types are skipped.
+func (p *Prompter) Prompt(schema []byte, defaults, vals map[string]interface{}) (map[string]interface{}, error) {
+	var s map[string]interface{}
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return nil, errors.Wrap(err, "failed to parse values schema")
+	}
+	p.r = bufio.NewReader(p.In)
+	p.eof = false
+	answers := map[string]interface{}{}
+	if err := p.walk("", s, defaults, vals, answers); err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+func (p *Prompter) walk(path string, schema, defaults, vals, answers map[string]interface{}) error {
+	props, _ := schema["properties"].(map[string]interface{})
+	required := map[string]bool{}
+	if list, ok := schema["required"].([]interface{}); ok {
+		for _, r := range list {
+			if name, ok := r.(string); ok {
+				required[name] = true
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		prop, ok := props[k].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key := k
+		if path != "" {
+			key = path + "." + k
+		}
+		if schemaType(prop) == "object" {
+			sub := map[string]interface{}{}
+			if err := p.walk(key, prop, childMap(defaults, k), childMap(vals, k), sub); err != nil {
+				return err
+			}
+			if len(sub) > 0 {
+				answers[k] = sub
+			}
+			continue
+		}
+		if _, ok := vals[k]; ok {
+			continue
+		}
+		def, hasDefault := defaults[k]
+		if !hasDefault {
+			def, hasDefault = prop["default"]
+		}
+		v, err := p.ask(key, prop, def, hasDefault, required[k])
+		if err != nil {
+			return err
+		}
+		if v != nil {
+			answers[k] = v
+		}
+	}
+	return nil
+}
+
+// ask asks for the value of the property at path, and returns nil if the
+// default is kept.
+func (p *Prompter) ask(path string, prop map[string]interface{}, def interface{}, hasDefault, required bool) (interface{}, error) {
+	t := schemaType(prop)
+	itemType := ""
+	if t == "array" {
+		items, _ := prop["items"].(map[string]interface{})
+		itemType = schemaType(items)
+		if itemType == "object" || itemType == "array" {
+			return nil, nil
+		}
+	}
+
+	question := path
+	if enum, ok := prop["enum"].([]interface{}); ok {
+		question += fmt.Sprintf(" (one of %s)", joinValues(enum))
+	} else if t == "array" {
+		question += " (comma-separated)"
+	}
+	if hasDefault && def != nil {
+		question += fmt.Sprintf(" [%s]", formatValue(def))
+	}
+	if desc, ok := prop["description"].(string); ok && desc != "" {
+		fmt.Fprintf(p.Out, "# %s\n", desc)
+	}
+
+	for {
+		fmt.Fprintf(p.Out, "%s: ", question)
+		answer, err := p.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if answer == "" {
+			if hasDefault || !required {
+				return nil, nil
+			}
+			if p.eof {
+				return nil, errors.Errorf("no value for required value %s", path)
+			}
+			fmt.Fprintln(p.Out, "A value is required.")
+			continue
+		}
+
+		v, err := parseAnswer(answer, t, itemType)
+		if err == nil {
+			err = checkEnum(v, prop)
+		}
+		if err != nil {
+			if p.eof {
+				return nil, errors.Wrapf(err, "invalid value for %s", path)
+			}
+			fmt.Fprintf(p.Out, "Invalid value: %s\n", err)
+			continue
+		}
+		return v, nil
+	}
+}
+
+// readLine reads an answer. Once the input is exhausted, answers are empty.
+func (p *Prompter) readLine() (string, error) {
+	if p.eof {
+		fmt.Fprintln(p.Out)
+		return "", nil
+	}
+	line, err := p.r.ReadString('\n')
+	if err == io.EOF {
+		p.eof = true
+		if line == "" {
+			fmt.Fprintln(p.Out)
+		}
+	} else if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// schemaType returns the type of a schema, ignoring "null" in type lists.
+func schemaType(schema map[string]interface{}) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		for _, item := range t {
+			if s, ok := item.(string); ok && s != "null" {
+				return s
+			}
+		}
+	}
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	return ""
+}
+
+func childMap(m map[string]interface{}, key string) map[string]interface{} {
+	child, _ := m[key].(map[string]interface{})
+	return child
+}
+
+func parseAnswer(answer, t, itemType string) (interface{}, error) {
+	switch t {
+	case "integer":
+		return strconv.ParseInt(answer, 10, 64)
+	case "number":
+		return strconv.ParseFloat(answer, 64)
+	case "boolean":
+		switch strings.ToLower(answer) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		return strconv.ParseBool(answer)
+	case "array":
+		var list []interface{}
+		for _, item := range strings.Split(answer, ",") {
+			v, err := parseAnswer(strings.TrimSpace(item), itemType, "")
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	}
+	return answer, nil
+}
+
+func checkEnum(v interface{}, prop map[string]interface{}) error {
+	enum, ok := prop["enum"].([]interface{})
+	if !ok {
+		return nil
+	}
+	for _, e := range enum {
+		if formatValue(e) == formatValue(v) {
+			return nil
+		}
+	}
+	return errors.Errorf("%s is not one of %s", formatValue(v), joinValues(enum))
+}
+
+func joinValues(values []interface{}) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = formatValue(v)
+	}
+	return strings.Join(s, ", ")
+}
+
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case []interface{}:
+		return joinValues(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package values
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const promptSchema = `{
+  "type": "object",
+  "required": ["name"],
+  "properties": {
+    "name": {"type": "string", "description": "Name of the application"},
+    "replicas": {"type": "integer", "default": 1},
+    "debug": {"type": "boolean"},
+    "hosts": {"type": "array", "items": {"type": "string"}},
+    "sidecars": {"type": "array", "items": {"type": "object"}},
+    "service": {
+      "type": "object",
+      "properties": {
+        "type": {"type": "string", "enum": ["ClusterIP", "NodePort", "LoadBalancer"]},
+        "port": {"type": ["integer", "null"]}
+      }
+    }
+  }
+}`
+
+func TestPrompt(t *testing.T) {
+	// Questions are asked in alphabetical order: debug, hosts, name,
+	// replicas and service.type, as service.port is set.
+	in := strings.Join([]string{
+		"yes",
+		"a.example.com, b.example.com",
+		"", // name is required
+		"web",
+		"", // replicas keeps its default
+		"Ingress",
+		"NodePort",
+	}, "\n") + "\n"
+	out := bytes.NewBuffer(nil)
+	p := &Prompter{In: strings.NewReader(in), Out: out}
+
+	defaults := map[string]interface{}{
+		"service": map[string]interface{}{"type": "ClusterIP"},
+	}
+	vals := map[string]interface{}{
+		"service": map[string]interface{}{"port": 8080},
+	}
+	got, err := p.Prompt([]byte(promptSchema), defaults, vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"debug":   true,
+		"hosts":   []interface{}{"a.example.com", "b.example.com"},
+		"name":    "web",
+		"service": map[string]interface{}{"type": "NodePort"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	for _, s := range []string{
+		"# Name of the application\nname: A value is required.\nname: ",
+		"replicas [1]: ",
+		"service.type (one of ClusterIP, NodePort, LoadBalancer) [ClusterIP]: Invalid value: Ingress is not one of ClusterIP, NodePort, LoadBalancer\n",
+	} {
+		if !strings.Contains(out.String(), s) {
+			t.Errorf("expected the output to contain %q, got:\n%s", s, out)
+		}
+	}
+	if strings.Contains(out.String(), "service.port") || strings.Contains(out.String(), "sidecars") {
+		t.Errorf("expected no question for set values and lists of objects, got:\n%s", out)
+	}
+}
+
+func TestPromptEOF(t *testing.T) {
+	p := &Prompter{In: strings.NewReader("yes\n"), Out: bytes.NewBuffer(nil)}
+	if _, err := p.Prompt([]byte(promptSchema), nil, nil); err == nil || err.Error() != "no value for required value name" {
+		t.Errorf("expected an error for the missing required value, got %v", err)
+	}
+
+	p = &Prompter{In: strings.NewReader("no\n\nweb"), Out: bytes.NewBuffer(nil)}
+	got, err := p.Prompt([]byte(promptSchema), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, map[string]interface{}{"debug": false, "name": "web"}) {
+		t.Errorf("unexpected values %v", got)
+	}
+}
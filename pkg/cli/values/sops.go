@@ -0,0 +1,106 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package values
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// Decrypter decrypts encrypted values files.
+type Decrypter interface {
+	// Encrypted reports whether data, the content of a values file, is
+	// encrypted.
+	Encrypted(data []byte) bool
+	// Decrypt returns the decrypted content of the values file read from
+	// path.
+	Decrypt(path string, data []byte) ([]byte, error)
+}
+
+// SOPS decrypts values files encrypted with SOPS. It runs the sops command,
+// so files encrypted with any of the SOPS backends, such as age, PGP or a
+// cloud KMS, are decrypted with the keys sops is configured with.
+type SOPS struct {
+	// Command is the sops executable. It defaults to "sops", looked up in
+	// the PATH.
+	Command string
+	// Env is added to the environment sops runs in, for instance to set
+	// SOPS_AGE_KEY_FILE.
+	Env []string
+}
+
+// sopsMetadata is the metadata SOPS adds to the files it encrypts.
+type sopsMetadata struct {
+	SOPS *struct {
+		MAC     string `json:"mac"`
+		Version string `json:"version"`
+	} `json:"sops"`
+}
+
+// Encrypted reports whether data holds the metadata of a SOPS-encrypted file.
+func (s SOPS) Encrypted(data []byte) bool {
+	var m sopsMetadata
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return false
+	}
+	return m.SOPS != nil && m.SOPS.MAC != ""
+}
+
+// Decrypt decrypts data with sops.
+//
+// data is decrypted from a temporary file, so that values files read from
+// stdin or a URL are decrypted alike.
+func (s SOPS) Decrypt(path string, data []byte) ([]byte, error) {
+	command := s.Command
+	if command == "" {
+		command = "sops"
+	}
+	format := "yaml"
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		format = "json"
+	}
+
+	dir, err := ioutil.TempDir("", "helm-sops-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	tmp := filepath.Join(dir, "values."+format)
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(command, "--decrypt", "--input-type", format, "--output-type", format, tmp)
+	cmd.Env = append(os.Environ(), s.Env...)
+	stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.Errorf("failed to decrypt %s: %s", path, msg)
+		}
+		return nil, errors.Wrapf(err, "failed to decrypt %s", path)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package values
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const encryptedValues = `password: ENC[AES256_GCM,data:Tr7o1Q==,iv:1=,tag:2=,type:str]
+sops:
+    age:
+        - recipient: age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p
+    lastmodified: "2021-01-01T00:00:00Z"
+    mac: ENC[AES256_GCM,data:3=,iv:4=,tag:5=,type:str]
+    version: 3.7.1
+`
+
+func TestSOPSEncrypted(t *testing.T) {
+	tests := []struct {
+		data string
+		want bool
+	}{
+		{encryptedValues, true},
+		{`{"password": "ENC[...]", "sops": {"mac": "ENC[...]", "version": "3.7.1"}}`, true},
+		{"password: plain\n", false},
+		{"sops:\n  enabled: true\n", false},
+		{"not: [yaml\n", false},
+	}
+	for _, tt := range tests {
+		if got := (SOPS{}).Encrypted([]byte(tt.data)); got != tt.want {
+			t.Errorf("Encrypted(%q) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestMergeValuesDecrypt(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("TODO: refactor this test to work on windows")
+	}
+
+	dir, err := ioutil.TempDir("", "helm-values-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The fake sops prints its arguments and the key file it was given.
+	sops := filepath.Join(dir, "sops")
+	script := "#!/bin/sh\necho \"args: $*\"\necho \"key: $SOPS_AGE_KEY_FILE\"\n"
+	if err := ioutil.WriteFile(sops, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	failing := filepath.Join(dir, "failing-sops")
+	if err := ioutil.WriteFile(failing, []byte("#!/bin/sh\necho 'no key to decrypt' >&2\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	secrets := filepath.Join(dir, "secrets.yaml")
+	if err := ioutil.WriteFile(secrets, []byte(encryptedValues), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &Options{
+		ValueFiles: []string{secrets},
+		Decrypter:  SOPS{Command: sops, Env: []string{"SOPS_AGE_KEY_FILE=keys.txt"}},
+	}
+	vals, err := opts.MergeValues(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(vals["args"].(string), "--decrypt --input-type yaml --output-type yaml ") {
+		t.Errorf("unexpected sops arguments %q", vals["args"])
+	}
+	if vals["key"] != "keys.txt" {
+		t.Errorf("expected the environment to be passed to sops, got %q", vals["key"])
+	}
+
+	opts.DisableDecryption = true
+	vals, err = opts.MergeValues(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := vals["sops"]; !ok {
+		t.Errorf("expected the encrypted values, got %v", vals)
+	}
+
+	opts = &Options{ValueFiles: []string{secrets}, Decrypter: SOPS{Command: failing}}
+	_, err = opts.MergeValues(nil)
+	if err == nil || err.Error() != "failed to decrypt "+secrets+": no key to decrypt" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	// Files that are not encrypted are read as is.
+	plain := filepath.Join(dir, "values.yaml")
+	if err := ioutil.WriteFile(plain, []byte("password: plain\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	opts.ValueFiles = []string{plain}
+	vals, err = opts.MergeValues(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(vals, map[string]interface{}{"password": "plain"}) {
+		t.Errorf("unexpected values %v", vals)
+	}
+}
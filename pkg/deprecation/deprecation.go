@@ -0,0 +1,214 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deprecation // import "helm.sh/helm/v3/pkg/deprecation"
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// API is the API version of a kind that is deprecated in a version of
+// Kubernetes, and possibly removed in a later version.
+type API struct {
+	APIVersion string
+	Kind       string
+	// DeprecatedIn is the Kubernetes version deprecating the API.
+	DeprecatedIn string
+	// RemovedIn is the Kubernetes version removing the API, empty if it is
+	// not scheduled for removal.
+	RemovedIn string
+	// Replacement is the API version to use instead, empty if there is none.
+	Replacement string
+}
+
+// APIs are the known deprecated Kubernetes APIs.
+var APIs = []API{
+	{"extensions/v1beta1", "DaemonSet", "1.9", "1.16", "apps/v1"},
+	{"extensions/v1beta1", "Deployment", "1.9", "1.16", "apps/v1"},
+	{"extensions/v1beta1", "ReplicaSet", "1.9", "1.16", "apps/v1"},
+	{"extensions/v1beta1", "NetworkPolicy", "1.9", "1.16", "networking.k8s.io/v1"},
+	{"extensions/v1beta1", "PodSecurityPolicy", "1.10", "1.16", "policy/v1beta1"},
+	{"extensions/v1beta1", "Ingress", "1.14", "1.22", "networking.k8s.io/v1"},
+	{"apps/v1beta1", "Deployment", "1.9", "1.16", "apps/v1"},
+	{"apps/v1beta1", "StatefulSet", "1.9", "1.16", "apps/v1"},
+	{"apps/v1beta2", "DaemonSet", "1.9", "1.16", "apps/v1"},
+	{"apps/v1beta2", "Deployment", "1.9", "1.16", "apps/v1"},
+	{"apps/v1beta2", "ReplicaSet", "1.9", "1.16", "apps/v1"},
+	{"apps/v1beta2", "StatefulSet", "1.9", "1.16", "apps/v1"},
+	{"networking.k8s.io/v1beta1", "Ingress", "1.19", "1.22", "networking.k8s.io/v1"},
+	{"networking.k8s.io/v1beta1", "IngressClass", "1.19", "1.22", "networking.k8s.io/v1"},
+	{"apiextensions.k8s.io/v1beta1", "CustomResourceDefinition", "1.16", "1.22", "apiextensions.k8s.io/v1"},
+	{"admissionregistration.k8s.io/v1beta1", "MutatingWebhookConfiguration", "1.16", "1.22", "admissionregistration.k8s.io/v1"},
+	{"admissionregistration.k8s.io/v1beta1", "ValidatingWebhookConfiguration", "1.16", "1.22", "admissionregistration.k8s.io/v1"},
+	{"apiregistration.k8s.io/v1beta1", "APIService", "1.19", "1.22", "apiregistration.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", "ClusterRole", "1.17", "1.22", "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", "ClusterRoleBinding", "1.17", "1.22", "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", "Role", "1.17", "1.22", "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", "RoleBinding", "1.17", "1.22", "rbac.authorization.k8s.io/v1"},
+	{"scheduling.k8s.io/v1beta1", "PriorityClass", "1.14", "1.22", "scheduling.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "CSIDriver", "1.19", "1.22", "storage.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "CSINode", "1.17", "1.22", "storage.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "StorageClass", "1.19", "1.22", "storage.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "VolumeAttachment", "1.19", "1.22", "storage.k8s.io/v1"},
+	{"certificates.k8s.io/v1beta1", "CertificateSigningRequest", "1.19", "1.22", "certificates.k8s.io/v1"},
+	{"coordination.k8s.io/v1beta1", "Lease", "1.14", "1.22", "coordination.k8s.io/v1"},
+	{"batch/v1beta1", "CronJob", "1.21", "1.25", "batch/v1"},
+	{"discovery.k8s.io/v1beta1", "EndpointSlice", "1.21", "1.25", "discovery.k8s.io/v1"},
+	{"events.k8s.io/v1beta1", "Event", "1.19", "1.25", "events.k8s.io/v1"},
+	{"autoscaling/v2beta1", "HorizontalPodAutoscaler", "1.22", "1.25", "autoscaling/v2"},
+	{"autoscaling/v2beta2", "HorizontalPodAutoscaler", "1.23", "1.26", "autoscaling/v2"},
+	{"node.k8s.io/v1beta1", "RuntimeClass", "1.20", "1.25", "node.k8s.io/v1"},
+	{"policy/v1beta1", "PodDisruptionBudget", "1.21", "1.25", "policy/v1"},
+	{"policy/v1beta1", "PodSecurityPolicy", "1.21", "1.25", ""},
+}
+
+// Finding is a resource using an API that is deprecated or removed in the
+// Kubernetes version it was checked against.
+type Finding struct {
+	// Template is the template the resource was rendered from, if known.
+	Template   string `json:"template,omitempty"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	APIVersion string `json:"apiVersion"`
+	// Replacement is the API version to use instead, if there is one.
+	Replacement  string `json:"replacement,omitempty"`
+	DeprecatedIn string `json:"deprecatedIn"`
+	RemovedIn    string `json:"removedIn,omitempty"`
+	// Removed is whether the API is removed, rather than only deprecated, in
+	// the Kubernetes version checked against.
+	Removed bool `json:"removed"`
+}
+
+// Error describes the finding, so it can be reported as an error.
+func (f *Finding) Error() string {
+	status := "deprecated in Kubernetes v" + f.DeprecatedIn
+	if f.Removed {
+		status = "removed in Kubernetes v" + f.RemovedIn
+	} else if f.RemovedIn != "" {
+		status += " and removed in v" + f.RemovedIn
+	}
+	msg := fmt.Sprintf("%s %q uses %s, which is %s", f.Kind, f.Name, f.APIVersion, status)
+	if f.Replacement != "" {
+		msg += "; use " + f.Replacement + " instead"
+	}
+	return msg
+}
+
+// Findings are the findings of a check, reported together as an error.
+type Findings []*Finding
+
+// Error lists the findings, one per line.
+func (fs Findings) Error() string {
+	msgs := make([]string, 0, len(fs))
+	for _, f := range fs {
+		msg := f.Error()
+		if f.Template != "" {
+			msg = f.Template + ": " + msg
+		}
+		msgs = append(msgs, msg)
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Removed returns the findings of removed APIs.
+func (fs Findings) Removed() Findings {
+	var removed Findings
+	for _, f := range fs {
+		if f.Removed {
+			removed = append(removed, f)
+		}
+	}
+	return removed
+}
+
+var (
+	docSep      = regexp.MustCompile(`(?m)^---\s*$`)
+	sourceMatch = regexp.MustCompile(`(?m)^# Source: (.+)$`)
+)
+
+// Check returns the resources of manifest, a stream of YAML documents, that
+// use APIs deprecated or removed in Kubernetes version kubeVersion.
+//
+// Each resource is attributed to the template named in the "# Source:"
+// comment of its document, as Helm renders them, or else to template.
+func Check(manifest, template, kubeVersion string) (Findings, error) {
+	v, err := semver.NewVersion(kubeVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid Kubernetes version %q", kubeVersion)
+	}
+
+	var findings Findings
+	for _, doc := range docSep.Split(manifest, -1) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		var res struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+			Metadata   struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		}
+		if err := yaml.Unmarshal([]byte(doc), &res); err != nil {
+			// Invalid documents are reported by other checks.
+			continue
+		}
+		api, ok := lookup(res.APIVersion, res.Kind)
+		if !ok || !reached(v, api.DeprecatedIn) {
+			continue
+		}
+		source := template
+		if m := sourceMatch.FindStringSubmatch(doc); m != nil {
+			source = strings.TrimSpace(m[1])
+		}
+		findings = append(findings, &Finding{
+			Template:     source,
+			Kind:         res.Kind,
+			Name:         res.Metadata.Name,
+			APIVersion:   res.APIVersion,
+			Replacement:  api.Replacement,
+			DeprecatedIn: api.DeprecatedIn,
+			RemovedIn:    api.RemovedIn,
+			Removed:      api.RemovedIn != "" && reached(v, api.RemovedIn),
+		})
+	}
+	return findings, nil
+}
+
+func lookup(apiVersion, kind string) (API, bool) {
+	for _, api := range APIs {
+		if api.APIVersion == apiVersion && api.Kind == kind {
+			return api, true
+		}
+	}
+	return API{}, false
+}
+
+// reached returns whether v is at or after the Kubernetes minor version
+// given as "major.minor".
+func reached(v *semver.Version, version string) bool {
+	target := semver.MustParse(version)
+	if v.Major() != target.Major() {
+		return v.Major() > target.Major()
+	}
+	return v.Minor() >= target.Minor()
+}
@@ -0,0 +1,97 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deprecation
+
+import (
+	"strings"
+	"testing"
+)
+
+const manifest = `---
+# Source: web/templates/deployment.yaml
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: web
+---
+# Source: web/templates/ingress.yaml
+apiVersion: networking.k8s.io/v1beta1
+kind: Ingress
+metadata:
+  name: web
+---
+# Source: web/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+`
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		kubeVersion string
+		want        []string
+	}{
+		{"v1.8.0", nil},
+		{"v1.16.0", []string{
+			"web/templates/deployment.yaml: Deployment \"web\" uses apps/v1beta2, which is removed in Kubernetes v1.16; use apps/v1 instead",
+		}},
+		{"1.19", []string{
+			"web/templates/deployment.yaml: Deployment \"web\" uses apps/v1beta2, which is removed in Kubernetes v1.16; use apps/v1 instead",
+			"web/templates/ingress.yaml: Ingress \"web\" uses networking.k8s.io/v1beta1, which is deprecated in Kubernetes v1.19 and removed in v1.22; use networking.k8s.io/v1 instead",
+		}},
+		{"v1.22.3-gke.100", []string{
+			"web/templates/deployment.yaml: Deployment \"web\" uses apps/v1beta2, which is removed in Kubernetes v1.16; use apps/v1 instead",
+			"web/templates/ingress.yaml: Ingress \"web\" uses networking.k8s.io/v1beta1, which is removed in Kubernetes v1.22; use networking.k8s.io/v1 instead",
+		}},
+	}
+	for _, tt := range tests {
+		findings, err := Check(manifest, "", tt.kubeVersion)
+		if err != nil {
+			t.Fatalf("%s: %s", tt.kubeVersion, err)
+		}
+		var got []string
+		for _, f := range findings {
+			got = append(got, (Findings{f}).Error())
+		}
+		if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s: expected findings\n%s\ngot\n%s", tt.kubeVersion, strings.Join(tt.want, "\n"), strings.Join(got, "\n"))
+		}
+	}
+}
+
+func TestCheckTemplate(t *testing.T) {
+	tmpl := "apiVersion: policy/v1beta1\nkind: PodSecurityPolicy\nmetadata:\n  name: restricted\n"
+	findings, err := Check(tmpl, "templates/psp.yaml", "v1.25.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one finding, got %v", findings)
+	}
+	f := findings[0]
+	if f.Template != "templates/psp.yaml" || !f.Removed || f.Replacement != "" {
+		t.Errorf("unexpected finding %+v", f)
+	}
+	if len(findings.Removed()) != 1 {
+		t.Errorf("expected the finding to be removed")
+	}
+
+	if _, err := Check(tmpl, "", "latest"); err == nil {
+		t.Error("expected an error for an invalid Kubernetes version")
+	}
+}
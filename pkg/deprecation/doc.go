@@ -0,0 +1,24 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package deprecation detects Kubernetes APIs that are deprecated or removed in
+a version of Kubernetes.
+
+Helm charts often outlive the API versions their templates were written
+against. Check reports the resources of rendered manifests using such API
+versions, along with the API version replacing them.
+*/
+package deprecation // import "helm.sh/helm/v3/pkg/deprecation"
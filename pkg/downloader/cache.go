@@ -0,0 +1,101 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package downloader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ArchiveCacheDir is the directory of the archive cache within the
+// repository cache.
+const ArchiveCacheDir = "archives"
+
+// ArchiveCache is a content-addressed store of the chart archives downloaded
+// from HTTP repositories. Archives are keyed by the SHA-256 digests the
+// repository indexes list for them, so that a chart version is downloaded
+// once however many times it is installed or used as a dependency, as the
+// registry cache does for charts in OCI registries.
+type ArchiveCache struct {
+	// Dir is the directory the archives are stored in.
+	Dir string
+}
+
+// Get returns the archive with the given digest, and whether it is cached.
+// Archives whose content no longer matches their digest are removed.
+func (c *ArchiveCache) Get(digest string) ([]byte, bool) {
+	path, err := c.path(digest)
+	if err != nil {
+		return nil, false
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != filepath.Base(path) {
+		os.Remove(path)
+		return nil, false
+	}
+	return data, true
+}
+
+// Put stores an archive under its digest. It fails if the archive does not
+// have the given digest.
+func (c *ArchiveCache) Put(digest string, archive []byte) error {
+	path, err := c.path(digest)
+	if err != nil {
+		return err
+	}
+	if sum := sha256.Sum256(archive); hex.EncodeToString(sum[:]) != filepath.Base(path) {
+		return errors.Errorf("archive does not match digest %s", digest)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// The archive is written to a temporary file and renamed so that
+	// concurrent downloads never read a partial archive.
+	f, err := ioutil.TempFile(filepath.Dir(path), ".archive-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(archive); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// path returns the file of the archive with the given digest, which is hex
+// encoded with an optional sha256: prefix as found in repository indexes.
+func (c *ArchiveCache) path(digest string) (string, error) {
+	sum := strings.ToLower(strings.TrimPrefix(digest, "sha256:"))
+	if b, err := hex.DecodeString(sum); err != nil || len(b) != sha256.Size {
+		return "", errors.Errorf("invalid archive digest %q", digest)
+	}
+	return filepath.Join(c.Dir, "sha256", sum), nil
+}
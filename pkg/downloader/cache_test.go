@@ -0,0 +1,124 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package downloader
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"helm.sh/helm/v3/internal/test/ensure"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/repo/repotest"
+)
+
+func TestArchiveCache(t *testing.T) {
+	c := &ArchiveCache{Dir: ensure.TempDir(t)}
+	archive := []byte("chart archive")
+	digest := fmt.Sprintf("%x", sha256.Sum256(archive))
+
+	if _, ok := c.Get(digest); ok {
+		t.Fatal("expected an empty cache")
+	}
+	if err := c.Put(digest, archive); err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range []string{digest, "sha256:" + digest} {
+		if data, ok := c.Get(d); !ok || !bytes.Equal(data, archive) {
+			t.Errorf("expected %s to be cached, got %q", d, data)
+		}
+	}
+
+	if err := c.Put(digest, []byte("other archive")); err == nil {
+		t.Error("expected an error for an archive not matching its digest")
+	}
+	if err := c.Put("../../etc/passwd", archive); err == nil {
+		t.Error("expected an error for an invalid digest")
+	}
+
+	// Corrupted archives are dropped.
+	if err := ioutil.WriteFile(filepath.Join(c.Dir, "sha256", digest), []byte("corrupted"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get(digest); ok {
+		t.Error("expected a corrupted archive not to be returned")
+	}
+	if _, err := os.Stat(filepath.Join(c.Dir, "sha256", digest)); !os.IsNotExist(err) {
+		t.Errorf("expected the corrupted archive to be removed, got %v", err)
+	}
+}
+
+func TestDownloadTo_ArchiveCache(t *testing.T) {
+	dest := ensure.TempDir(t)
+
+	srv, err := repotest.NewTempServer("testdata/*.tgz*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+	if err := srv.CreateIndex(); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.LinkIndices(); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := &ArchiveCache{Dir: ensure.TempDir(t)}
+	c := ChartDownloader{
+		Out:              os.Stderr,
+		RepositoryConfig: filepath.Join(srv.Root(), "repositories.yaml"),
+		RepositoryCache:  srv.Root(),
+		ArchiveCache:     cache,
+		Getters: getter.All(&cli.EnvSettings{
+			RepositoryConfig: filepath.Join(srv.Root(), "repositories.yaml"),
+			RepositoryCache:  srv.Root(),
+		}),
+	}
+	if _, _, err := c.DownloadTo("test/signtest", "0.1.0", dest); err != nil {
+		t.Fatal(err)
+	}
+	archive, err := ioutil.ReadFile("testdata/signtest-0.1.0.tgz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get(fmt.Sprintf("%x", sha256.Sum256(archive))); !ok {
+		t.Fatal("expected the downloaded archive to be cached")
+	}
+
+	// The cached archive is used once the repository is gone, whether the
+	// chart is referred to by name or by URL.
+	srv.Stop()
+	os.Remove(filepath.Join(dest, "signtest-0.1.0.tgz"))
+	for _, ref := range []string{"test/signtest", srv.URL() + "/signtest-0.1.0.tgz"} {
+		where, _, err := c.DownloadTo(ref, "0.1.0", dest)
+		if err != nil {
+			t.Fatalf("%s: %s", ref, err)
+		}
+		data, err := ioutil.ReadFile(where)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, archive) {
+			t.Errorf("%s: expected the cached archive", ref)
+		}
+	}
+}
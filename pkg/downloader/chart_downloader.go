@@ -16,6 +16,8 @@ limitations under the License.
 package downloader
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -25,12 +27,20 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/api/kv"
+	"sigs.k8s.io/yaml"
 
+	"helm.sh/helm/v3/internal/tracing"
 	"helm.sh/helm/v3/internal/urlutil"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/getter"
 	"helm.sh/helm/v3/pkg/helmpath"
+	"helm.sh/helm/v3/pkg/logging"
 	"helm.sh/helm/v3/pkg/provenance"
+	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/repo"
+	"helm.sh/helm/v3/pkg/versions"
 )
 
 // VerificationStrategy describes a strategy for determining whether to verify a chart.
@@ -51,6 +61,21 @@ const (
 	VerifyLater
 )
 
+// DeprecationStrategy describes what to do when a downloaded chart is
+// deprecated.
+type DeprecationStrategy int
+
+const (
+	// DeprecationWarn writes a warning, pointing to the replacement of the
+	// chart if known.
+	DeprecationWarn DeprecationStrategy = iota
+	// DeprecationFail fails the download with a *chart.DeprecatedError.
+	DeprecationFail
+	// DeprecationIgnore downloads deprecated charts silently, for callers
+	// that check the chart themselves once loaded.
+	DeprecationIgnore
+)
+
 // ErrNoOwnerRepo indicates that a given chart URL can't be found in any repos.
 var ErrNoOwnerRepo = errors.New("could not find a repo containing the given URL")
 
@@ -60,16 +85,42 @@ var ErrNoOwnerRepo = errors.New("could not find a repo containing the given URL"
 type ChartDownloader struct {
 	// Out is the location to write warning and info messages.
 	Out io.Writer
+	// Logger receives the logs of the download, and is passed on to the getters.
+	Logger logging.Logger
+	// Context carries the trace the spans of resolving and downloading charts
+	// are recorded in, and is passed on to the getters.
+	Context context.Context
 	// Verify indicates what verification strategy to use.
 	Verify VerificationStrategy
 	// Keyring is the keyring file used for verification.
 	Keyring string
+	// Deprecation indicates what to do with deprecated charts.
+	Deprecation DeprecationStrategy
+	// Policy refuses charts that do not satisfy it, if set. The chart file
+	// is removed and a *PolicyViolationError returned.
+	Policy *VerificationPolicy
+	// RegistryClient fetches the signatures and provenance of charts pulled
+	// from OCI registries when checking them against the Policy. A client
+	// with the default configuration is used if none is set.
+	RegistryClient *registry.Client
 	// Getter collection for the operation
 	Getters getter.Providers
 	// Options provide parameters to be passed along to the Getter being initialized.
 	Options          []getter.Option
 	RepositoryConfig string
 	RepositoryCache  string
+	// ArchiveCache stores the archives of charts from HTTP repositories, and
+	// provides them instead of downloading them again, if set. Only charts
+	// whose digest is listed in a repository index are cached.
+	ArchiveCache *ArchiveCache
+	// VersionResolver matches version constraints against the versions listed
+	// in repository indexes and the tags of OCI repositories. versions.Default
+	// is used if none is set.
+	VersionResolver versions.Resolver
+
+	// digest is the digest of the chart archive listed in the repository
+	// index the chart was resolved with, if any.
+	digest string
 }
 
 // DownloadTo retrieves a chart. Depending on the settings, it may also download a provenance file.
@@ -84,7 +135,16 @@ type ChartDownloader struct {
 // Returns a string path to the location where the file was downloaded and a verification
 // (if provenance was verified), or an error if something bad happened.
 func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *provenance.Verification, error) {
-	u, err := c.ResolveChartVersion(ref, version)
+	ctx, span := tracing.Start(c.context(), nil, "download",
+		kv.String("helm.chart", ref),
+		kv.String("helm.version", version))
+	destfile, ver, err := c.downloadTo(ctx, ref, version, dest)
+	tracing.End(ctx, span, err)
+	return destfile, ver, err
+}
+
+func (c *ChartDownloader) downloadTo(ctx context.Context, ref, version, dest string) (string, *provenance.Verification, error) {
+	u, err := c.resolve(ctx, ref, version)
 	if err != nil {
 		return "", nil, err
 	}
@@ -94,21 +154,56 @@ func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *proven
 		return "", nil, err
 	}
 
-	data, err := g.Get(u.String(), c.Options...)
+	c.logger().Debug("downloading chart", "url", u, "dest", dest)
+	opts := append([]getter.Option{getter.WithLogger(c.logger()), getter.WithContext(ctx)}, c.Options...)
+
+	var data *bytes.Buffer
+	var r *registry.CacheRefSummary
+	name := filepath.Base(u.Path)
+	if og, ok := g.(*getter.OCIGetter); ok {
+		data, r, err = og.GetWithDetails(u.String(), opts...)
+		if err == nil {
+			name = fmt.Sprintf("%s-%s.tgz", r.Chart.Metadata.Name, r.Chart.Metadata.Version)
+		}
+	} else if archive, ok := c.cachedArchive(); ok {
+		c.logger().Debug("using cached chart archive", "url", u, "digest", c.digest)
+		data = bytes.NewBuffer(archive)
+	} else {
+		data, err = g.Get(u.String(), opts...)
+		if err == nil {
+			c.cacheArchive(data.Bytes())
+		}
+	}
 	if err != nil {
 		return "", nil, err
 	}
+	if err := c.checkDeprecation(data.Bytes()); err != nil {
+		return "", nil, err
+	}
 
-	name := filepath.Base(u.Path)
 	destfile := filepath.Join(dest, name)
 	if err := ioutil.WriteFile(destfile, data.Bytes(), 0644); err != nil {
 		return destfile, nil, err
 	}
+	if c.Policy != nil {
+		if err := c.checkPolicy(ctx, g, u, destfile, r); err != nil {
+			os.Remove(destfile)
+			os.Remove(destfile + ".prov")
+			return "", nil, err
+		}
+	}
 
 	// If provenance is requested, verify it.
 	ver := &provenance.Verification{}
+	if c.Verify > VerifyNever && u.Scheme == registry.OCIScheme {
+		if c.Verify == VerifyAlways {
+			return destfile, ver, errors.Errorf("provenance verification is not supported for OCI chart %q", ref)
+		}
+		fmt.Fprintf(c.Out, "WARNING: Verification not supported for OCI chart %s\n", ref)
+		return destfile, ver, nil
+	}
 	if c.Verify > VerifyNever {
-		body, err := g.Get(u.String() + ".prov")
+		body, err := g.Get(u.String()+".prov", getter.WithLogger(c.logger()), getter.WithContext(ctx))
 		if err != nil {
 			if c.Verify == VerifyAlways {
 				return destfile, ver, errors.Errorf("failed to fetch provenance %q", u.String()+".prov")
@@ -133,6 +228,116 @@ func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *proven
 	return destfile, ver, nil
 }
 
+// cachedArchive returns the archive of the resolved chart from the archive
+// cache, if it is there.
+func (c *ChartDownloader) cachedArchive() ([]byte, bool) {
+	if c.ArchiveCache == nil || c.digest == "" {
+		return nil, false
+	}
+	return c.ArchiveCache.Get(c.digest)
+}
+
+// cacheArchive stores the downloaded archive of the resolved chart in the
+// archive cache. Failing to cache it does not fail the download.
+func (c *ChartDownloader) cacheArchive(archive []byte) {
+	if c.ArchiveCache == nil || c.digest == "" {
+		return
+	}
+	if err := c.ArchiveCache.Put(c.digest, archive); err != nil {
+		c.logger().Debug("not caching chart archive", "digest", c.digest, "error", err)
+	}
+}
+
+// checkPolicy checks the chart downloaded from u to destfile against the
+// policy. r is the summary of the reference of charts pulled from an OCI
+// registry, and nil for others.
+func (c *ChartDownloader) checkPolicy(ctx context.Context, g getter.Getter, u *url.URL, destfile string, r *registry.CacheRefSummary) error {
+	if r == nil {
+		if c.Policy.Keyring != "" {
+			// Charts without provenance are refused by the policy.
+			if body, err := g.Get(u.String()+".prov", getter.WithLogger(c.logger()), getter.WithContext(ctx)); err == nil {
+				if err := ioutil.WriteFile(destfile+".prov", body.Bytes(), 0644); err != nil {
+					return err
+				}
+			}
+		}
+		return c.Policy.verify(u.String(), destfile, nil)
+	}
+
+	client := c.RegistryClient
+	if client == nil {
+		var err error
+		if client, err = registry.NewClient(); err != nil {
+			return err
+		}
+	}
+	client = client.WithContext(ctx)
+	ref := &registry.Reference{Repo: r.Repo, Digest: r.Manifest.Digest.String()}
+	if c.Policy.Keyring != "" {
+		if prov, err := client.FetchChartProvenance(ref); err == nil {
+			if err := ioutil.WriteFile(destfile+".prov", prov, 0644); err != nil {
+				return err
+			}
+		}
+	}
+	return c.Policy.verify(u.String(), destfile, &ociChart{
+		digest: ref.Digest,
+		signatures: func() ([]provenance.CosignSignature, error) {
+			_, sigs, err := client.FetchCosignSignatures(ref)
+			return sigs, err
+		},
+	})
+}
+
+// checkDeprecation warns about or refuses the chart archive if the chart is
+// deprecated, according to the deprecation strategy.
+func (c *ChartDownloader) checkDeprecation(archive []byte) error {
+	if c.Deprecation == DeprecationIgnore {
+		return nil
+	}
+	md, err := archiveMetadata(archive)
+	if err != nil || !md.Deprecated {
+		// Archives that cannot be read fail once they are loaded.
+		return nil
+	}
+	if c.Deprecation == DeprecationFail {
+		return &chart.DeprecatedError{Chart: md}
+	}
+	fmt.Fprintf(c.Out, "WARNING: %s\n", md.DeprecationNotice())
+	return nil
+}
+
+// archiveMetadata reads the Chart.yaml file of a chart archive.
+func archiveMetadata(archive []byte) (*chart.Metadata, error) {
+	files, err := loader.LoadArchiveFiles(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if f.Name == "Chart.yaml" {
+			md := new(chart.Metadata)
+			return md, yaml.Unmarshal(f.Data, md)
+		}
+	}
+	return nil, errors.New("Chart.yaml file is missing")
+}
+
+// logger returns the Logger, which is Discard if none is set.
+func (c *ChartDownloader) logger() logging.Logger {
+	if c.Logger == nil {
+		return logging.Discard
+	}
+	return c.Logger
+}
+
+// context returns the Context, which is context.Background if none is set.
+func (c *ChartDownloader) context() context.Context {
+	if c.Context == nil {
+		return context.Background()
+	}
+	return c.Context
+}
+
 // ResolveChartVersion resolves a chart reference to a URL.
 //
 // It returns the URL and sets the ChartDownloader's Options that can fetch
@@ -143,17 +348,43 @@ func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *proven
 // A version is a SemVer string (1.2.3-beta.1+f334a6789).
 //
 //	- For fully qualified URLs, the version will be ignored (since URLs aren't versioned)
+//	- For OCI references (oci://), the version is passed along to the Getter, which
+//	  resolves it against the registry's tags unless the reference pins a tag
 //	- For a chart reference
 //		* If version is non-empty, this will return the URL for that version
 //		* If version is empty, this will return the URL for the latest version
 //		* If no version can be found, an error is returned
 func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, error) {
+	return c.resolve(c.context(), ref, version)
+}
+
+// resolve resolves a chart reference to a URL, tracing it below the span in
+// ctx.
+func (c *ChartDownloader) resolve(ctx context.Context, ref, version string) (*url.URL, error) {
+	ctx, span := tracing.Start(ctx, nil, "resolve",
+		kv.String("helm.chart", ref),
+		kv.String("helm.version", version))
+	u, err := c.resolveChartVersion(ref, version)
+	tracing.End(ctx, span, err)
+	return u, err
+}
+
+func (c *ChartDownloader) resolveChartVersion(ref, version string) (*url.URL, error) {
+	c.digest = ""
 	u, err := url.Parse(ref)
 	if err != nil {
 		return nil, errors.Errorf("invalid chart URL format: %s", ref)
 	}
 	c.Options = append(c.Options, getter.WithURL(ref))
 
+	if u.Scheme == registry.OCIScheme {
+		c.Options = append(c.Options, getter.WithVersion(version))
+		if c.VersionResolver != nil {
+			c.Options = append(c.Options, getter.WithVersionResolver(c.VersionResolver))
+		}
+		return u, nil
+	}
+
 	rf, err := loadRepoConfig(c.RepositoryConfig)
 	if err != nil {
 		return u, err
@@ -166,7 +397,7 @@ func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, er
 		// we want to find the repo in case we have special SSL cert config
 		// for that repo.
 
-		rc, err := c.scanReposForURL(ref, rf)
+		rc, cv, err := c.scanReposForURL(ref, rf)
 		if err != nil {
 			// If there is no special config, return the default HTTP client and
 			// swallow the error.
@@ -178,6 +409,7 @@ func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, er
 
 		// If we get here, we don't need to go through the next phase of looking
 		// up the URL. We have it already. So we just set the parameters and return.
+		c.digest = cv.Digest
 		c.Options = append(
 			c.Options,
 			getter.WithURL(rc.URL),
@@ -225,7 +457,7 @@ func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, er
 		return u, errors.Wrap(err, "no cached repo found. (try 'helm repo update')")
 	}
 
-	cv, err := i.Get(chartName, version)
+	cv, err := i.Resolve(c.VersionResolver, chartName, version)
 	if err != nil {
 		return u, errors.Wrapf(err, "chart %q matching %s not found in %s index. (try 'helm repo update')", chartName, version, r.Config.Name)
 	}
@@ -233,6 +465,7 @@ func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, er
 	if len(cv.URLs) == 0 {
 		return u, errors.Errorf("chart %q has no downloadable URLs", ref)
 	}
+	c.digest = cv.Digest
 
 	// TODO: Seems that picking first URL is not fully correct
 	u, err = url.Parse(cv.URLs[0])
@@ -316,7 +549,8 @@ func pickChartRepositoryConfigByName(name string, cfgs []*repo.Entry) (*repo.Ent
 //
 // This will attempt to find the given URL in all of the known repositories files.
 //
-// If the URL is found, this will return the repo entry that contained that URL.
+// If the URL is found, this will return the repo entry that contained that URL,
+// along with the chart version it is a URL of.
 //
 // If all of the repos are checked, but the URL is not found, an ErrNoOwnerRepo
 // error is returned.
@@ -330,33 +564,33 @@ func pickChartRepositoryConfigByName(name string, cfgs []*repo.Entry) (*repo.Ent
 // The same URL can technically exist in two or more repositories. This algorithm
 // will return the first one it finds. Order is determined by the order of repositories
 // in the repositories.yaml file.
-func (c *ChartDownloader) scanReposForURL(u string, rf *repo.File) (*repo.Entry, error) {
+func (c *ChartDownloader) scanReposForURL(u string, rf *repo.File) (*repo.Entry, *repo.ChartVersion, error) {
 	// FIXME: This is far from optimal. Larger installations and index files will
 	// incur a performance hit for this type of scanning.
 	for _, rc := range rf.Repositories {
 		r, err := repo.NewChartRepository(rc, c.Getters)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		idxFile := filepath.Join(c.RepositoryCache, helmpath.CacheIndexFile(r.Config.Name))
 		i, err := repo.LoadIndexFile(idxFile)
 		if err != nil {
-			return nil, errors.Wrap(err, "no cached repo found. (try 'helm repo update')")
+			return nil, nil, errors.Wrap(err, "no cached repo found. (try 'helm repo update')")
 		}
 
 		for _, entry := range i.Entries {
 			for _, ver := range entry {
 				for _, dl := range ver.URLs {
 					if urlutil.Equal(u, dl) {
-						return rc, nil
+						return rc, ver, nil
 					}
 				}
 			}
 		}
 	}
 	// This means that there is no repo file for the given URL.
-	return nil, ErrNoOwnerRepo
+	return nil, nil, ErrNoOwnerRepo
 }
 
 func loadRepoConfig(file string) (*repo.File, error) {
@@ -16,12 +16,17 @@ limitations under the License.
 package downloader
 
 import (
+	"bytes"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"helm.sh/helm/v3/internal/test/ensure"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/getter"
 	"helm.sh/helm/v3/pkg/repo"
@@ -135,7 +140,7 @@ func TestResolveChartOpts(t *testing.T) {
 			continue
 		}
 
-		if *(got.(*getter.HTTPGetter)) != *(expect.(*getter.HTTPGetter)) {
+		if !reflect.DeepEqual(got, expect) {
 			t.Errorf("%s: expected %s, got %s", tt.name, expect, got)
 		}
 	}
@@ -270,6 +275,54 @@ func TestDownloadTo_VerifyLater(t *testing.T) {
 	}
 }
 
+func TestCheckDeprecation(t *testing.T) {
+	dir := ensure.TempDir(t)
+	path, err := chartutil.Save(&chart.Chart{
+		Metadata: &chart.Metadata{
+			APIVersion: chart.APIVersionV2,
+			Name:       "old",
+			Version:    "1.0.0",
+			Deprecated: true,
+			ReplacedBy: "oci://example.com/charts/new",
+		},
+	}, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	c := ChartDownloader{Out: &out}
+	if err := c.checkDeprecation(archive); err != nil {
+		t.Fatal(err)
+	}
+	if expect := "WARNING: chart old 1.0.0 is deprecated, it is replaced by oci://example.com/charts/new\n"; out.String() != expect {
+		t.Errorf("Expected %q, got %q", expect, out.String())
+	}
+
+	out.Reset()
+	c.Deprecation = DeprecationIgnore
+	if err := c.checkDeprecation(archive); err != nil || out.Len() != 0 {
+		t.Errorf("Expected deprecated chart to be ignored, got %v and %q", err, out.String())
+	}
+
+	c.Deprecation = DeprecationFail
+	if _, ok := c.checkDeprecation(archive).(*chart.DeprecatedError); !ok {
+		t.Error("Expected deprecated chart to be refused")
+	}
+
+	signtest, err := ioutil.ReadFile("testdata/signtest-0.1.0.tgz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.checkDeprecation(signtest); err != nil {
+		t.Errorf("Expected chart that is not deprecated to be accepted, got %v", err)
+	}
+}
+
 func TestScanReposForURL(t *testing.T) {
 	c := ChartDownloader{
 		Out:              os.Stderr,
@@ -288,7 +341,7 @@ func TestScanReposForURL(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	entry, err := c.scanReposForURL(u, rf)
+	entry, cv, err := c.scanReposForURL(u, rf)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -296,10 +349,13 @@ func TestScanReposForURL(t *testing.T) {
 	if entry.Name != "testing" {
 		t.Errorf("Unexpected repo %q for URL %q", entry.Name, u)
 	}
+	if cv.Name != "alpine" || cv.Version != "0.2.0" {
+		t.Errorf("Unexpected chart %s-%s for URL %q", cv.Name, cv.Version, u)
+	}
 
 	// A lookup failure should produce an ErrNoOwnerRepo
 	u = "https://no.such.repo/foo/bar-1.23.4.tgz"
-	if _, err = c.scanReposForURL(u, rf); err != ErrNoOwnerRepo {
+	if _, _, err = c.scanReposForURL(u, rf); err != ErrNoOwnerRepo {
 		t.Fatalf("expected ErrNoOwnerRepo, got %v", err)
 	}
 }
@@ -0,0 +1,377 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package downloader
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/internal/resolver"
+	"helm.sh/helm/v3/internal/urlutil"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/repo"
+)
+
+// DependencyNode is a chart in a dependency graph.
+type DependencyNode struct {
+	Name string `json:"name"`
+	// Version is the version of the chart the dependency resolved to.
+	Version string `json:"version"`
+	// Constraint is the version constraint the chart is required with.
+	Constraint string `json:"constraint,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	// Digest is the SHA-256 digest of the chart archive, if the chart is
+	// not an unpacked directory.
+	Digest    string   `json:"digest,omitempty"`
+	Alias     string   `json:"alias,omitempty"`
+	Condition string   `json:"condition,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	// Enabled reports whether the dependency is enabled by the default
+	// values of the charts requiring it.
+	Enabled      bool              `json:"enabled"`
+	Dependencies []*DependencyNode `json:"dependencies,omitempty"`
+}
+
+// Graph resolves the transitive dependencies of the chart at ChartPath, a
+// chart directory or archive, and returns the chart as the root of the graph.
+//
+// Dependencies found in the charts/ directory of the chart requiring them are
+// used as they are. Others are resolved like Update does, using the version
+// locked in Chart.lock if there is one, and downloaded to a temporary
+// directory to read their own dependencies. Nothing is written to the chart.
+func (m *Manager) Graph() (*DependencyNode, error) {
+	c, err := loader.Load(m.ChartPath)
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := ioutil.TempDir("", "helm-dependency-graph-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	g := &graphBuilder{m: m, tmp: tmp}
+	if g.repos, err = m.loadChartRepositories(); err != nil && !os.IsNotExist(errors.Cause(err)) {
+		return nil, err
+	}
+
+	root := &DependencyNode{
+		Name:    c.Name(),
+		Version: c.Metadata.Version,
+		Enabled: true,
+	}
+	dir := ""
+	if fi, err := os.Stat(m.ChartPath); err == nil && fi.IsDir() {
+		dir = m.ChartPath
+	} else if root.Digest, err = fileDigest(m.ChartPath); err != nil {
+		return nil, err
+	}
+	tags, _ := chartutil.Values(c.Values).Table("tags")
+	if err := g.build(root, c, dir, tags, nil); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+type graphBuilder struct {
+	m     *Manager
+	tmp   string
+	repos map[string]*repo.ChartRepository
+}
+
+// build adds the dependencies of c to node. dir is the directory of c if it
+// is unpacked, and parents are the names of the charts requiring c.
+func (g *graphBuilder) build(node *DependencyNode, c *chart.Chart, dir string, tags chartutil.Values, parents []string) error {
+	parents = append(parents, c.Name())
+	var lock map[string]string
+	if c.Lock != nil {
+		lock = map[string]string{}
+		for _, d := range c.Lock.Dependencies {
+			lock[d.Name+"|"+d.Repository] = d.Version
+		}
+	}
+
+	for _, d := range c.Metadata.Dependencies {
+		dep := &DependencyNode{
+			Name:       d.Name,
+			Constraint: d.Version,
+			Repository: d.Repository,
+			Alias:      d.Alias,
+			Condition:  d.Condition,
+			Tags:       d.Tags,
+			Enabled:    node.Enabled && dependencyEnabled(d, c.Values, tags),
+		}
+		node.Dependencies = append(node.Dependencies, dep)
+		for _, p := range parents {
+			if p == d.Name {
+				return errors.Errorf("dependency cycle: %s requires %s", strings.Join(parents, " -> "), d.Name)
+			}
+		}
+
+		sub, subDir, err := g.vendored(c, dir, d, dep)
+		if err != nil {
+			return err
+		}
+		if sub == nil {
+			version := d.Version
+			if v, ok := lock[d.Name+"|"+d.Repository]; ok {
+				version = v
+			}
+			if sub, subDir, err = g.fetch(dir, d, version, dep); err != nil {
+				return errors.Wrapf(err, "failed to resolve dependency %s of %s", d.Name, c.Name())
+			}
+		}
+		dep.Version = sub.Metadata.Version
+		if err := g.build(dep, sub, subDir, tags, parents); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// vendored returns the dependency d of c found in its charts/ directory, and
+// the directory of the dependency if it is unpacked.
+func (g *graphBuilder) vendored(c *chart.Chart, dir string, d *chart.Dependency, node *DependencyNode) (*chart.Chart, string, error) {
+	for _, sub := range c.Dependencies() {
+		if sub.Name() != d.Name || !chartutil.IsCompatibleRange(d.Version, sub.Metadata.Version) {
+			continue
+		}
+		if dir == "" {
+			return sub, "", nil
+		}
+		archive := filepath.Join(dir, "charts", fmt.Sprintf("%s-%s.tgz", sub.Name(), sub.Metadata.Version))
+		if _, err := os.Stat(archive); err == nil {
+			digest, err := fileDigest(archive)
+			node.Digest = digest
+			return sub, "", err
+		}
+		if subDir := filepath.Join(dir, "charts", sub.Name()); isDir(subDir) {
+			return sub, subDir, nil
+		}
+		return sub, "", nil
+	}
+	return nil, "", nil
+}
+
+// fetch loads the dependency d at the given version or constraint from its
+// repository, and returns it with its directory if it is a local directory.
+func (g *graphBuilder) fetch(dir string, d *chart.Dependency, version string, node *DependencyNode) (*chart.Chart, string, error) {
+	switch {
+	case d.Repository == "":
+		return nil, "", errors.New("the chart is missing from the charts/ directory")
+	case strings.HasPrefix(d.Repository, "file://"):
+		if dir == "" {
+			return nil, "", errors.Errorf("%s cannot be resolved relative to a chart archive", d.Repository)
+		}
+		path, err := resolver.GetLocalPath(d.Repository, dir)
+		if err != nil {
+			return nil, "", err
+		}
+		c, err := loader.Load(path)
+		if err != nil {
+			return nil, "", err
+		}
+		if isDir(path) {
+			return c, path, nil
+		}
+		node.Digest, err = fileDigest(path)
+		return c, "", err
+	}
+
+	ref, username, password := "", "", ""
+	if registry.IsOCI(d.Repository) {
+		ref = strings.TrimSuffix(d.Repository, "/") + "/" + d.Name
+	} else {
+		var err error
+		if ref, username, password, err = g.chartURL(d, version); err != nil {
+			return nil, "", err
+		}
+		version = ""
+	}
+
+	dest, err := ioutil.TempDir(g.tmp, d.Name+"-")
+	if err != nil {
+		return nil, "", err
+	}
+	dl := ChartDownloader{
+		Out:              ioutil.Discard,
+		Logger:           g.m.logger(),
+		Verify:           g.m.Verify,
+		Keyring:          g.m.Keyring,
+		RepositoryConfig: g.m.RepositoryConfig,
+		RepositoryCache:  g.m.RepositoryCache,
+		VersionResolver:  g.m.VersionResolver,
+		Getters:          g.m.Getters,
+		Options:          []getter.Option{getter.WithPool(g.m.pool())},
+	}
+	if username != "" || password != "" {
+		dl.Options = append(dl.Options, getter.WithBasicAuth(username, password))
+	}
+	archive, _, err := dl.DownloadTo(ref, version, dest)
+	if err != nil {
+		return nil, "", err
+	}
+	if node.Digest, err = fileDigest(archive); err != nil {
+		return nil, "", err
+	}
+	c, err := loader.Load(archive)
+	return c, "", err
+}
+
+// chartURL returns the URL of the version of the dependency d in its
+// repository the version resolver of the manager picks for the constraint.
+func (g *graphBuilder) chartURL(d *chart.Dependency, constraint string) (url, username, password string, err error) {
+	repoURL := d.Repository
+	if name := strings.TrimPrefix(strings.TrimPrefix(d.Repository, "@"), "alias:"); name != d.Repository {
+		cr, ok := g.repos[name]
+		if !ok {
+			return "", "", "", errors.Errorf("no repository named %q, add it with 'helm repo add'", name)
+		}
+		repoURL = cr.Config.URL
+	}
+	versionResolver := g.m.versionResolver()
+	if _, err := versionResolver.Resolve(constraint, nil); err != nil {
+		return "", "", "", err
+	}
+	for _, cr := range g.repos {
+		if !urlutil.Equal(repoURL, cr.Config.URL) {
+			continue
+		}
+		versions, err := findEntryByName(d.Name, cr)
+		if err != nil {
+			return "", "", "", errors.Errorf("chart %s not found in %s", d.Name, repoURL)
+		}
+		byVersion := make(map[string]*repo.ChartVersion, len(versions))
+		var available []string
+		for _, ver := range versions {
+			if len(ver.URLs) == 0 {
+				continue
+			}
+			byVersion[ver.Version] = ver
+			available = append(available, ver.Version)
+		}
+		resolved, err := versionResolver.Resolve(constraint, available)
+		if err != nil {
+			return "", "", "", err
+		}
+		if len(resolved) > 0 {
+			url, err := normalizeURL(repoURL, byVersion[resolved[0]].URLs[0])
+			return url, cr.Config.Username, cr.Config.Password, err
+		}
+		return "", "", "", errors.Errorf("no version of chart %s in %s satisfies %s", d.Name, repoURL, constraint)
+	}
+	url, err = repo.ResolveChartInAuthRepoURL(g.m.VersionResolver, repoURL, "", "", d.Name, constraint, "", "", "", g.m.Getters)
+	return url, "", "", err
+}
+
+// dependencyEnabled reports whether the condition and tags of d enable it,
+// like chartutil.ProcessDependencies does, given the values of the chart
+// requiring it and the tags of the root chart.
+func dependencyEnabled(d *chart.Dependency, vals map[string]interface{}, tags chartutil.Values) bool {
+	for _, cond := range strings.Split(strings.TrimSpace(d.Condition), ",") {
+		if cond == "" {
+			continue
+		}
+		if v, err := chartutil.Values(vals).PathValue(cond); err == nil {
+			if b, ok := v.(bool); ok {
+				return b
+			}
+		}
+	}
+	var hasTrue, hasFalse bool
+	for _, tag := range d.Tags {
+		if b, ok := tags[tag].(bool); ok {
+			hasTrue = hasTrue || b
+			hasFalse = hasFalse || !b
+		}
+	}
+	return hasTrue || !hasFalse
+}
+
+func fileDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", h.Sum(nil)), nil
+}
+
+func isDir(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir()
+}
+
+// WriteDOT writes the graph rooted at n in the DOT language of Graphviz. Each
+// chart version is a node, and disabled dependencies are dashed edges.
+func (n *DependencyNode) WriteDOT(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "digraph dependencies {"); err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	var walk func(n *DependencyNode) error
+	walk = func(n *DependencyNode) error {
+		id := n.Name + "@" + n.Version
+		if seen[id] {
+			return nil
+		}
+		seen[id] = true
+		if _, err := fmt.Fprintf(w, "\t%q [label=%q];\n", id, n.Name+"\n"+n.Version); err != nil {
+			return err
+		}
+		for _, d := range n.Dependencies {
+			var attrs []string
+			if d.Alias != "" {
+				attrs = append(attrs, fmt.Sprintf("label=%q", d.Alias))
+			}
+			if !d.Enabled {
+				attrs = append(attrs, "style=dashed")
+			}
+			suffix := ""
+			if len(attrs) > 0 {
+				suffix = " [" + strings.Join(attrs, ", ") + "]"
+			}
+			if _, err := fmt.Fprintf(w, "\t%q -> %q%s;\n", id, d.Name+"@"+d.Version, suffix); err != nil {
+				return err
+			}
+			if err := walk(d); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(n); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
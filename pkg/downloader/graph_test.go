@@ -0,0 +1,162 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package downloader
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/repo/repotest"
+)
+
+func TestGraph(t *testing.T) {
+	srv, err := repotest.NewTempServer("testdata/*.tgz*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+	if err := srv.LinkIndices(); err != nil {
+		t.Fatal(err)
+	}
+	dir := func(p ...string) string {
+		return filepath.Join(append([]string{srv.Root()}, p...)...)
+	}
+
+	dep := &chart.Chart{
+		Metadata: &chart.Metadata{
+			Name:       "dep-chart",
+			Version:    "0.2.0",
+			APIVersion: "v2",
+			Dependencies: []*chart.Dependency{
+				{Name: "local-subchart", Version: "^0.1.0", Repository: "@test", Alias: "other"},
+			},
+		},
+	}
+	parent := &chart.Chart{
+		Metadata: &chart.Metadata{
+			Name:       "parent-chart",
+			Version:    "0.1.0",
+			APIVersion: "v2",
+			Dependencies: []*chart.Dependency{
+				{Name: "local-subchart", Version: "0.1.0", Repository: srv.URL(), Condition: "sub.enabled"},
+				{Name: "dep-chart", Version: "0.2.0", Repository: "file://../dep-chart"},
+			},
+		},
+		Raw: []*chart.File{
+			{Name: chartutil.ValuesfileName, Data: []byte("sub:\n  enabled: false\n")},
+		},
+	}
+	for _, c := range []*chart.Chart{dep, parent} {
+		if err := chartutil.SaveDir(c, dir()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := &Manager{
+		ChartPath: dir("parent-chart"),
+		Out:       bytes.NewBuffer(nil),
+		Getters: getter.Providers{getter.Provider{
+			Schemes: []string{"http", "https"},
+			New:     getter.NewHTTPGetter,
+		}},
+		RepositoryConfig: dir("repositories.yaml"),
+		RepositoryCache:  dir(),
+	}
+	root, err := m.Graph()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest, err := fileDigest("testdata/local-subchart-0.1.0.tgz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.Name != "parent-chart" || root.Version != "0.1.0" || root.Digest != "" || !root.Enabled {
+		t.Errorf("unexpected root %+v", root)
+	}
+	if len(root.Dependencies) != 2 {
+		t.Fatalf("expected 2 dependencies, got %d", len(root.Dependencies))
+	}
+	sub := root.Dependencies[0]
+	if sub.Name != "local-subchart" || sub.Version != "0.1.0" || sub.Digest != digest || sub.Enabled {
+		t.Errorf("unexpected dependency %+v", sub)
+	}
+	depNode := root.Dependencies[1]
+	if depNode.Name != "dep-chart" || depNode.Version != "0.2.0" || depNode.Digest != "" || !depNode.Enabled {
+		t.Errorf("unexpected dependency %+v", depNode)
+	}
+	if len(depNode.Dependencies) != 1 {
+		t.Fatalf("expected 1 dependency of dep-chart, got %d", len(depNode.Dependencies))
+	}
+	other := depNode.Dependencies[0]
+	if other.Name != "local-subchart" || other.Alias != "other" || other.Version != "0.1.0" || other.Digest != digest || !other.Enabled {
+		t.Errorf("unexpected dependency %+v", other)
+	}
+
+	var b strings.Builder
+	if err := root.WriteDOT(&b); err != nil {
+		t.Fatal(err)
+	}
+	expect := `digraph dependencies {
+	"parent-chart@0.1.0" [label="parent-chart\n0.1.0"];
+	"parent-chart@0.1.0" -> "local-subchart@0.1.0" [style=dashed];
+	"local-subchart@0.1.0" [label="local-subchart\n0.1.0"];
+	"parent-chart@0.1.0" -> "dep-chart@0.2.0";
+	"dep-chart@0.2.0" [label="dep-chart\n0.2.0"];
+	"dep-chart@0.2.0" -> "local-subchart@0.1.0" [label="other"];
+}
+`
+	if b.String() != expect {
+		t.Errorf("expected DOT:\n%s\ngot:\n%s", expect, b.String())
+	}
+}
+
+func TestGraphCycle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-dependency-graph-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, names := range [][2]string{{"a", "b"}, {"b", "a"}} {
+		c := &chart.Chart{
+			Metadata: &chart.Metadata{
+				Name:       names[0],
+				Version:    "0.1.0",
+				APIVersion: "v2",
+				Dependencies: []*chart.Dependency{
+					{Name: names[1], Version: "0.1.0", Repository: "file://../" + names[1]},
+				},
+			},
+		}
+		if err := chartutil.SaveDir(c, dir); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := &Manager{ChartPath: filepath.Join(dir, "a"), Out: ioutil.Discard, RepositoryConfig: filepath.Join(dir, "repositories.yaml")}
+	if _, err := m.Graph(); err == nil || !strings.Contains(err.Error(), "dependency cycle: a -> b") {
+		t.Errorf("expected a dependency cycle error, got %v", err)
+	}
+}
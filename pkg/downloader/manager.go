@@ -39,7 +39,10 @@ import (
 	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/getter"
 	"helm.sh/helm/v3/pkg/helmpath"
+	"helm.sh/helm/v3/pkg/logging"
 	"helm.sh/helm/v3/pkg/repo"
+	"helm.sh/helm/v3/pkg/transfer"
+	"helm.sh/helm/v3/pkg/versions"
 )
 
 // Manager handles the lifecycle of fetching, resolving, and storing dependencies.
@@ -50,8 +53,11 @@ type Manager struct {
 	ChartPath string
 	// Verification indicates whether the chart should be verified.
 	Verify VerificationStrategy
-	// Debug is the global "--debug" flag
+	// Debug is the global "--debug" flag. Without a Logger, it logs debug
+	// messages to Out.
 	Debug bool
+	// Logger receives the logs of the operation.
+	Logger logging.Logger
 	// Keyring is the key ring file.
 	Keyring string
 	// SkipUpdate indicates that the repository should not be updated first.
@@ -60,6 +66,45 @@ type Manager struct {
 	Getters          []getter.Provider
 	RepositoryConfig string
 	RepositoryCache  string
+	// Pool runs the repository updates and chart downloads, and throttles
+	// their bandwidth. The default pool is used if it is nil.
+	Pool *transfer.Pool
+	// ArchiveCache provides the archives of dependencies downloaded before,
+	// if set.
+	ArchiveCache *ArchiveCache
+	// VersionResolver matches the version constraints of dependencies
+	// against the versions available. versions.Default is used if none is
+	// set.
+	VersionResolver versions.Resolver
+}
+
+// pool returns the Pool, or the default pool if none is set.
+func (m *Manager) pool() *transfer.Pool {
+	if m.Pool == nil {
+		return transfer.Default()
+	}
+	return m.Pool
+}
+
+// versionResolver returns the VersionResolver, or versions.Default if none is
+// set.
+func (m *Manager) versionResolver() versions.Resolver {
+	if m.VersionResolver == nil {
+		return versions.Default
+	}
+	return m.VersionResolver
+}
+
+// logger returns the Logger, defaulting to logging debug messages to Out if
+// Debug is set.
+func (m *Manager) logger() logging.Logger {
+	switch {
+	case m.Logger != nil:
+		return m.Logger
+	case m.Debug:
+		return logging.New(m.Out, logging.LevelDebug)
+	}
+	return logging.Discard
 }
 
 // Build rebuilds a local charts directory from a lockfile.
@@ -183,14 +228,21 @@ func (m *Manager) loadChartDir() (*chart.Chart, error) {
 	} else if !fi.IsDir() {
 		return nil, errors.New("only unpacked charts can be updated")
 	}
-	return loader.LoadDir(m.ChartPath)
+	c, err := loader.LoadDir(m.ChartPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range c.Warnings {
+		fmt.Fprintf(m.Out, "WARNING: %s\n", w)
+	}
+	return c, nil
 }
 
 // resolve takes a list of dependencies and translates them into an exact version to download.
 //
 // This returns a lock file, which has all of the dependencies normalized to a specific version.
 func (m *Manager) resolve(req []*chart.Dependency, repoNames map[string]string) (*chart.Lock, error) {
-	res := resolver.New(m.ChartPath, m.RepositoryCache)
+	res := resolver.New(m.ChartPath, m.RepositoryCache, m.VersionResolver)
 	return res.Resolve(req, repoNames)
 }
 
@@ -224,77 +276,96 @@ func (m *Manager) downloadAll(deps []*chart.Dependency) error {
 		return err
 	}
 
-	fmt.Fprintf(m.Out, "Saving %d charts\n", len(deps))
+	// Charts are downloaded concurrently by the workers of the pool, and
+	// write their warnings to out, which everything else writes to as well.
+	out := &lockedWriter{w: m.Out}
+	fmt.Fprintf(out, "Saving %d charts\n", len(deps))
+	downloads := m.pool().Group()
+	var mu sync.Mutex
 	var saveError error
+	setSaveError := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if saveError == nil {
+			saveError = err
+		}
+	}
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return saveError != nil
+	}
 	for _, dep := range deps {
+		if failed() {
+			break
+		}
 		// No repository means the chart is in charts directory
 		if dep.Repository == "" {
-			fmt.Fprintf(m.Out, "Dependency %s did not declare a repository. Assuming it exists in the charts directory\n", dep.Name)
+			fmt.Fprintf(out, "Dependency %s did not declare a repository. Assuming it exists in the charts directory\n", dep.Name)
 			chartPath := filepath.Join(tmpPath, dep.Name)
 			ch, err := loader.LoadDir(chartPath)
 			if err != nil {
 				return fmt.Errorf("Unable to load chart: %v", err)
 			}
 
-			constraint, err := semver.NewConstraint(dep.Version)
+			resolved, err := m.versionResolver().Resolve(dep.Version, []string{ch.Metadata.Version})
 			if err != nil {
 				return fmt.Errorf("Dependency %s has an invalid version/constraint format: %s", dep.Name, err)
 			}
 
-			v, err := semver.NewVersion(ch.Metadata.Version)
-			if err != nil {
-				return fmt.Errorf("Invalid version %s for dependency %s: %s", dep.Version, dep.Name, err)
-			}
-
-			if !constraint.Check(v) {
-				saveError = fmt.Errorf("Dependency %s at version %s does not satisfy the constraint %s", dep.Name, ch.Metadata.Version, dep.Version)
+			if len(resolved) == 0 {
+				setSaveError(fmt.Errorf("Dependency %s at version %s does not satisfy the constraint %s", dep.Name, ch.Metadata.Version, dep.Version))
 				break
 			}
 			continue
 		}
 		if strings.HasPrefix(dep.Repository, "file://") {
-			if m.Debug {
-				fmt.Fprintf(m.Out, "Archiving %s from repo %s\n", dep.Name, dep.Repository)
-			}
-			ver, err := tarFromLocalDir(m.ChartPath, dep.Name, dep.Repository, dep.Version)
+			m.logger().Debug("archiving dependency", "name", dep.Name, "repository", dep.Repository)
+			ver, err := tarFromLocalDir(m.ChartPath, dep.Name, dep.Repository, dep.Version, m.versionResolver())
 			if err != nil {
-				saveError = err
+				setSaveError(err)
 				break
 			}
 			dep.Version = ver
 			continue
 		}
 
-		fmt.Fprintf(m.Out, "Downloading %s from repo %s\n", dep.Name, dep.Repository)
+		fmt.Fprintf(out, "Downloading %s from repo %s\n", dep.Name, dep.Repository)
 
 		// Any failure to resolve/download a chart should fail:
 		// https://github.com/helm/helm/issues/1439
 		churl, username, password, err := m.findChartURL(dep.Name, dep.Version, dep.Repository, repos)
 		if err != nil {
-			saveError = errors.Wrapf(err, "could not find %s", churl)
+			setSaveError(errors.Wrapf(err, "could not find %s", churl))
 			break
 		}
 
 		dl := ChartDownloader{
-			Out:              m.Out,
+			Out:              out,
+			Logger:           m.logger(),
 			Verify:           m.Verify,
 			Keyring:          m.Keyring,
 			RepositoryConfig: m.RepositoryConfig,
 			RepositoryCache:  m.RepositoryCache,
+			ArchiveCache:     m.ArchiveCache,
+			VersionResolver:  m.VersionResolver,
 			Getters:          m.Getters,
 			Options: []getter.Option{
 				getter.WithBasicAuth(username, password),
+				getter.WithPool(m.pool()),
 			},
 		}
 
-		if _, _, err := dl.DownloadTo(churl, "", destPath); err != nil {
-			saveError = errors.Wrapf(err, "could not download %s", churl)
-			break
-		}
+		downloads.Go(func() {
+			if _, _, err := dl.DownloadTo(churl, "", destPath); err != nil {
+				setSaveError(errors.Wrapf(err, "could not download %s", churl))
+			}
+		})
 	}
+	downloads.Wait()
 
 	if saveError == nil {
-		fmt.Fprintln(m.Out, "Deleting outdated charts")
+		fmt.Fprintln(out, "Deleting outdated charts")
 		for _, dep := range deps {
 			// Chart from local charts directory stays in place
 			if dep.Repository != "" {
@@ -310,8 +381,8 @@ func (m *Manager) downloadAll(deps []*chart.Dependency) error {
 			return errors.Wrapf(err, "failed to remove %v", tmpPath)
 		}
 	} else {
-		fmt.Fprintln(m.Out, "Save error occurred: ", saveError)
-		fmt.Fprintln(m.Out, "Deleting newly downloaded charts, restoring pre-update state")
+		fmt.Fprintln(out, "Save error occurred: ", saveError)
+		fmt.Fprintln(out, "Deleting newly downloaded charts, restoring pre-update state")
 		for _, dep := range deps {
 			if err := m.safeDeleteDep(dep.Name, destPath); err != nil {
 				return err
@@ -423,9 +494,7 @@ func (m *Manager) resolveRepoNames(deps []*chart.Dependency) (map[string]string,
 				return nil, err
 			}
 
-			if m.Debug {
-				fmt.Fprintf(m.Out, "Repository from local path: %s\n", dd.Repository)
-			}
+			m.logger().Debug("dependency repository from local path", "name", dd.Name, "repository", dd.Repository)
 			reposMap[dd.Name] = dd.Repository
 			continue
 		}
@@ -494,25 +563,24 @@ func (m *Manager) UpdateRepositories() error {
 }
 
 func (m *Manager) parallelRepoUpdate(repos []*repo.Entry) error {
-	fmt.Fprintln(m.Out, "Hang tight while we grab the latest from your chart repositories...")
-	var wg sync.WaitGroup
+	out := &lockedWriter{w: m.Out}
+	fmt.Fprintln(out, "Hang tight while we grab the latest from your chart repositories...")
+	updates := m.pool().Group()
 	for _, c := range repos {
 		r, err := repo.NewChartRepository(c, m.Getters)
 		if err != nil {
 			return err
 		}
-		wg.Add(1)
-		go func(r *repo.ChartRepository) {
+		updates.Go(func() {
 			if _, err := r.DownloadIndexFile(); err != nil {
-				fmt.Fprintf(m.Out, "...Unable to get an update from the %q chart repository (%s):\n\t%s\n", r.Config.Name, r.Config.URL, err)
+				fmt.Fprintf(out, "...Unable to get an update from the %q chart repository (%s):\n\t%s\n", r.Config.Name, r.Config.URL, err)
 			} else {
-				fmt.Fprintf(m.Out, "...Successfully got an update from the %q chart repository\n", r.Config.Name)
+				fmt.Fprintf(out, "...Successfully got an update from the %q chart repository\n", r.Config.Name)
 			}
-			wg.Done()
-		}(r)
+		})
 	}
-	wg.Wait()
-	fmt.Fprintln(m.Out, "Update Complete. ⎈Happy Helming!⎈")
+	updates.Wait()
+	fmt.Fprintln(out, "Update Complete. ⎈Happy Helming!⎈")
 	return nil
 }
 
@@ -546,7 +614,7 @@ func (m *Manager) findChartURL(name, version, repoURL string, repos map[string]*
 			return
 		}
 	}
-	url, err = repo.FindChartInRepoURL(repoURL, name, version, "", "", "", m.Getters)
+	url, err = repo.ResolveChartInAuthRepoURL(m.VersionResolver, repoURL, "", "", name, version, "", "", "", m.Getters)
 	if err == nil {
 		return
 	}
@@ -658,8 +726,9 @@ func writeLock(chartpath string, lock *chart.Lock, legacyLockfile bool) error {
 	return ioutil.WriteFile(dest, data, 0644)
 }
 
-// archive a dep chart from local directory and save it into charts/
-func tarFromLocalDir(chartpath, name, repo, version string) (string, error) {
+// archive a dep chart from local directory and save it into charts/ if its
+// version satisfies the constraint version, as resolved by versionResolver
+func tarFromLocalDir(chartpath, name, repo, version string, versionResolver versions.Resolver) (string, error) {
 	destPath := filepath.Join(chartpath, "charts")
 
 	if !strings.HasPrefix(repo, "file://") {
@@ -676,17 +745,12 @@ func tarFromLocalDir(chartpath, name, repo, version string) (string, error) {
 		return "", err
 	}
 
-	constraint, err := semver.NewConstraint(version)
+	resolved, err := versionResolver.Resolve(version, []string{ch.Metadata.Version})
 	if err != nil {
 		return "", errors.Wrapf(err, "dependency %s has an invalid version/constraint format", name)
 	}
 
-	v, err := semver.NewVersion(ch.Metadata.Version)
-	if err != nil {
-		return "", err
-	}
-
-	if constraint.Check(v) {
+	if len(resolved) > 0 {
 		_, err = chartutil.Save(ch, destPath)
 		return ch.Metadata.Version, err
 	}
@@ -707,3 +771,15 @@ func move(tmpPath, destPath string) error {
 	}
 	return nil
 }
+
+// lockedWriter serializes the writes of concurrent downloads to w.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
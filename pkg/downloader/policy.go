@@ -0,0 +1,241 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package downloader
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"helm.sh/helm/v3/pkg/provenance"
+)
+
+// VerificationPolicy is the policy a chart must satisfy before it is used.
+// It is read from YAML files such as
+//
+//	digests:
+//	- sha256:6c3c624b58dbbcd3c0dd82b4c53f04194d1247c6eebdaab7c610cf7d66709b3b
+//	keyring: pubring.gpg
+//	cosign:
+//	  publicKeys:
+//	  - cosign.pub
+//	  identities:
+//	  - issuer: https://token.actions.githubusercontent.com
+//	    subjectRegExp: https://github.com/example/charts/.*
+//	  roots: fulcio.pem
+//	  rekorPublicKeys:
+//	  - rekor.pub
+//
+// A chart satisfies the policy if one of the methods it configures verifies
+// the chart.
+type VerificationPolicy struct {
+	// Digests allows charts by the digest of their archive or, for charts
+	// pulled from an OCI registry, of their manifest.
+	Digests []string `json:"digests,omitempty"`
+	// Keyring allows charts whose provenance file is signed by one of the
+	// keys of the keyring.
+	Keyring string `json:"keyring,omitempty"`
+	// Cosign allows charts pulled from an OCI registry that have a cosign
+	// signature satisfying it.
+	Cosign *CosignPolicy `json:"cosign,omitempty"`
+}
+
+// CosignPolicy constrains the cosign signatures of charts. Signatures made
+// with a key pair are verified with PublicKeys, and keyless signatures with
+// Identities, Roots and RekorPublicKeys.
+type CosignPolicy struct {
+	// PublicKeys are the files of the PEM-encoded public keys of the signers.
+	PublicKeys []string `json:"publicKeys,omitempty"`
+	// Identities are the identities of the signers of keyless signatures.
+	Identities []provenance.CosignIdentity `json:"identities,omitempty"`
+	// Roots is the file of the PEM-encoded root certificates the signing
+	// certificates of keyless signatures chain up to.
+	Roots string `json:"roots,omitempty"`
+	// RekorPublicKeys are the files of the PEM-encoded public keys of the
+	// transparency logs keyless signatures must be recorded in.
+	RekorPublicKeys []string `json:"rekorPublicKeys,omitempty"`
+}
+
+// PolicyViolationError is returned for charts that do not satisfy a
+// VerificationPolicy.
+type PolicyViolationError struct {
+	// Chart is the chart that was refused.
+	Chart string
+	// Reasons tell why each method of the policy refused the chart.
+	Reasons []string
+}
+
+func (e *PolicyViolationError) Error() string {
+	return fmt.Sprintf("chart %s violates the verification policy: %s", e.Chart, strings.Join(e.Reasons, "; "))
+}
+
+// LoadVerificationPolicy reads a verification policy from a YAML file. The
+// files it refers to are relative to the directory of the policy.
+func LoadVerificationPolicy(path string) (*VerificationPolicy, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := &VerificationPolicy{}
+	if err := yaml.UnmarshalStrict(data, p); err != nil {
+		return nil, errors.Wrapf(err, "invalid verification policy %s", path)
+	}
+	if len(p.Digests) == 0 && p.Keyring == "" && p.Cosign == nil {
+		return nil, errors.Errorf("verification policy %s allows no charts", path)
+	}
+
+	dir := filepath.Dir(path)
+	resolve := func(name string) string {
+		if name == "" || filepath.IsAbs(name) {
+			return name
+		}
+		return filepath.Join(dir, name)
+	}
+	p.Keyring = resolve(p.Keyring)
+	if p.Cosign != nil {
+		keyless := len(p.Cosign.Identities) > 0 || p.Cosign.Roots != "" || len(p.Cosign.RekorPublicKeys) > 0
+		if keyless && (len(p.Cosign.Identities) == 0 || p.Cosign.Roots == "" || len(p.Cosign.RekorPublicKeys) == 0) {
+			return nil, errors.Errorf("verification policy %s needs cosign identities, roots and Rekor public keys to accept keyless signatures", path)
+		}
+		if len(p.Cosign.PublicKeys) == 0 && !keyless {
+			return nil, errors.Errorf("verification policy %s needs cosign public keys, or identities, roots and Rekor public keys", path)
+		}
+		for _, id := range p.Cosign.Identities {
+			if err := id.Validate(); err != nil {
+				return nil, errors.Wrapf(err, "invalid verification policy %s", path)
+			}
+		}
+		for i, key := range p.Cosign.PublicKeys {
+			p.Cosign.PublicKeys[i] = resolve(key)
+		}
+		p.Cosign.Roots = resolve(p.Cosign.Roots)
+		for i, key := range p.Cosign.RekorPublicKeys {
+			p.Cosign.RekorPublicKeys[i] = resolve(key)
+		}
+	}
+	return p, nil
+}
+
+// ociChart is a chart pulled from an OCI registry.
+type ociChart struct {
+	// digest is the digest of the manifest of the chart.
+	digest string
+	// signatures fetches the cosign signatures of the chart.
+	signatures func() ([]provenance.CosignSignature, error)
+}
+
+// VerifyFile returns a *PolicyViolationError if the chart archive at path,
+// accompanied by its provenance file if it has one, does not satisfy the
+// policy. Unpacked charts never satisfy it.
+func (p *VerificationPolicy) VerifyFile(path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		return &PolicyViolationError{Chart: path, Reasons: []string{"unpacked charts cannot be verified"}}
+	}
+	return p.verify(path, path, nil)
+}
+
+// verify checks the chart archive at path, named chart in errors, against
+// the policy. oci is nil unless the chart was pulled from an OCI registry.
+func (p *VerificationPolicy) verify(chart, path string, oci *ociChart) error {
+	var reasons []string
+	if len(p.Digests) > 0 {
+		sum, err := provenance.DigestFile(path)
+		if err != nil {
+			return err
+		}
+		digests := []string{"sha256:" + sum}
+		if oci != nil {
+			digests = append(digests, oci.digest)
+		}
+		if containsAny(p.Digests, digests) {
+			return nil
+		}
+		reasons = append(reasons, fmt.Sprintf("digest %s is not allowed", strings.Join(digests, ", ")))
+	}
+
+	if p.Keyring != "" {
+		_, err := VerifyChart(path, p.Keyring)
+		if err == nil {
+			return nil
+		}
+		reasons = append(reasons, "provenance: "+err.Error())
+	}
+
+	if p.Cosign != nil {
+		err := p.verifyCosign(oci)
+		if err == nil {
+			return nil
+		}
+		reasons = append(reasons, "cosign: "+err.Error())
+	}
+	return &PolicyViolationError{Chart: chart, Reasons: reasons}
+}
+
+// verifyCosign verifies the cosign signatures of a chart.
+func (p *VerificationPolicy) verifyCosign(oci *ociChart) error {
+	if oci == nil {
+		return errors.New("only charts pulled from OCI registries have cosign signatures")
+	}
+	v := &provenance.CosignVerifier{Identities: p.Cosign.Identities}
+	for _, file := range p.Cosign.PublicKeys {
+		key, err := provenance.LoadCosignPublicKey(file)
+		if err != nil {
+			return err
+		}
+		v.PublicKeys = append(v.PublicKeys, key)
+	}
+	if p.Cosign.Roots != "" {
+		roots, err := provenance.LoadCertPool(p.Cosign.Roots)
+		if err != nil {
+			return err
+		}
+		v.Roots = roots
+	}
+	for _, file := range p.Cosign.RekorPublicKeys {
+		key, err := provenance.LoadCosignPublicKey(file)
+		if err != nil {
+			return err
+		}
+		v.RekorPublicKeys = append(v.RekorPublicKeys, key)
+	}
+	sigs, err := oci.signatures()
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch signatures")
+	}
+	return v.Verify(oci.digest, sigs)
+}
+
+// containsAny reports whether one of values is in list.
+func containsAny(list, values []string) bool {
+	for _, l := range list {
+		for _, v := range values {
+			if l == v {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package downloader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/internal/test/ensure"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/repo/repotest"
+)
+
+const signtestDigest = "sha256:e5ef611620fb97704d8751c16bab17fedb68883bfb0edc76f78a70e9173f9b55"
+
+func TestLoadVerificationPolicy(t *testing.T) {
+	dir := ensure.TempDir(t)
+	policyFile := filepath.Join(dir, "policy.yaml")
+
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr bool
+	}{
+		{"keyring", "keyring: pubring.gpg\n", false},
+		{"cosign public keys", "cosign:\n  publicKeys: [cosign.pub]\n", false},
+		{"cosign identities", "cosign:\n  identities: [{issuer: https://issuer.example.com, subject: release@example.com}]\n  roots: roots.pem\n  rekorPublicKeys: [rekor.pub]\n", false},
+		{"cosign identities without roots", "cosign:\n  identities: [{issuer: https://issuer.example.com, subject: release@example.com}]\n  rekorPublicKeys: [rekor.pub]\n", true},
+		{"cosign identities without Rekor public keys", "cosign:\n  identities: [{issuer: https://issuer.example.com, subject: release@example.com}]\n  roots: roots.pem\n", true},
+		{"cosign identity without issuer", "cosign:\n  identities: [{subject: release@example.com}]\n  roots: roots.pem\n  rekorPublicKeys: [rekor.pub]\n", true},
+		{"cosign identity without subject", "cosign:\n  identities: [{issuer: https://issuer.example.com}]\n  roots: roots.pem\n  rekorPublicKeys: [rekor.pub]\n", true},
+		{"cosign public keys with roots only", "cosign:\n  publicKeys: [cosign.pub]\n  roots: roots.pem\n", true},
+		{"empty", "digests: []\n", true},
+		{"unknown field", "keyrings: pubring.gpg\n", true},
+	}
+	for _, tt := range tests {
+		if err := ioutil.WriteFile(policyFile, []byte(tt.policy), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := LoadVerificationPolicy(policyFile)
+		if tt.wantErr && err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		} else if !tt.wantErr && err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.name, err)
+		}
+	}
+
+	// Files are relative to the policy.
+	if err := ioutil.WriteFile(policyFile, []byte("keyring: pubring.gpg\ncosign:\n  publicKeys: [/etc/cosign.pub]\n  identities: [{issuer: https://issuer.example.com, subject: release@example.com}]\n  roots: roots.pem\n  rekorPublicKeys: [rekor.pub]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := LoadVerificationPolicy(policyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := filepath.Join(dir, "pubring.gpg"); p.Keyring != expect {
+		t.Errorf("expected keyring %s, got %s", expect, p.Keyring)
+	}
+	if expect := filepath.Join(dir, "roots.pem"); p.Cosign.Roots != expect {
+		t.Errorf("expected roots %s, got %s", expect, p.Cosign.Roots)
+	}
+	if expect := filepath.Join(dir, "rekor.pub"); p.Cosign.RekorPublicKeys[0] != expect {
+		t.Errorf("expected Rekor public key %s, got %s", expect, p.Cosign.RekorPublicKeys[0])
+	}
+	if expect := "/etc/cosign.pub"; p.Cosign.PublicKeys[0] != expect {
+		t.Errorf("expected public key %s, got %s", expect, p.Cosign.PublicKeys[0])
+	}
+}
+
+func TestVerificationPolicyVerifyFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  VerificationPolicy
+		chart   string
+		wantErr bool
+	}{
+		{"allowed digest", VerificationPolicy{Digests: []string{signtestDigest}}, "testdata/signtest-0.1.0.tgz", false},
+		{"other digest", VerificationPolicy{Digests: []string{signtestDigest}}, "testdata/local-subchart-0.1.0.tgz", true},
+		{"signed", VerificationPolicy{Keyring: "testdata/helm-test-key.pub"}, "testdata/signtest-0.1.0.tgz", false},
+		{"unsigned", VerificationPolicy{Keyring: "testdata/helm-test-key.pub"}, "testdata/local-subchart-0.1.0.tgz", true},
+		{"either method", VerificationPolicy{Digests: []string{"sha256:0000"}, Keyring: "testdata/helm-test-key.pub"}, "testdata/signtest-0.1.0.tgz", false},
+		{"cosign", VerificationPolicy{Cosign: &CosignPolicy{PublicKeys: []string{"cosign.pub"}}}, "testdata/signtest-0.1.0.tgz", true},
+		{"unpacked", VerificationPolicy{Keyring: "testdata/helm-test-key.pub"}, "testdata/signtest", true},
+	}
+	for _, tt := range tests {
+		err := tt.policy.VerifyFile(tt.chart)
+		if !tt.wantErr {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", tt.name, err)
+			}
+			continue
+		}
+		if _, ok := err.(*PolicyViolationError); !ok {
+			t.Errorf("%s: expected a policy violation, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestDownloadTo_Policy(t *testing.T) {
+	defer ensure.HelmHome(t)()
+	dest := ensure.TempDir(t)
+
+	srv, err := repotest.NewTempServer("testdata/*.tgz*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+	if err := srv.LinkIndices(); err != nil {
+		t.Fatal(err)
+	}
+
+	c := ChartDownloader{
+		Out:              os.Stderr,
+		Policy:           &VerificationPolicy{Keyring: "testdata/helm-test-key.pub"},
+		RepositoryConfig: repoConfig,
+		RepositoryCache:  repoCache,
+		Getters: getter.All(&cli.EnvSettings{
+			RepositoryConfig: repoConfig,
+			RepositoryCache:  repoCache,
+		}),
+	}
+	if _, _, err := c.DownloadTo(srv.URL()+"/signtest-0.1.0.tgz", "", dest); err != nil {
+		t.Fatal(err)
+	}
+
+	// Refused charts are not kept.
+	_, _, err = c.DownloadTo(srv.URL()+"/local-subchart-0.1.0.tgz", "", dest)
+	if _, ok := errors.Cause(err).(*PolicyViolationError); !ok {
+		t.Fatalf("expected a policy violation, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "local-subchart-0.1.0.tgz")); !os.IsNotExist(err) {
+		t.Errorf("expected the refused chart to be removed, got %v", err)
+	}
+}
@@ -31,6 +31,7 @@ import (
 
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/warning"
 )
 
 // Engine is an implementation of 'cmd/tiller/environment'.Engine that uses Go templates.
@@ -40,6 +41,10 @@ type Engine struct {
 	Strict bool
 	// In LintMode, some 'required' template values may be missing, so don't fail
 	LintMode bool
+	// Warnings, if not nil, collects the problems found rendering that do
+	// not fail it, such as required values missing in LintMode, instead of
+	// them being logged.
+	Warnings *warning.Warnings
 	// the rest config to connect to te kubernetes api
 	config *rest.Config
 }
@@ -83,6 +88,15 @@ func RenderWithClient(chrt *chart.Chart, values chartutil.Values, config *rest.C
 	}.Render(chrt, values)
 }
 
+// missingRequired reports that the required value described by warn is missing.
+func (e Engine) missingRequired(warn string) {
+	if e.Warnings == nil {
+		log.Printf("[INFO] Missing required value: %s", warn)
+		return
+	}
+	e.Warnings.Add(warning.MissingValue, "", "missing required value: %s", warn)
+}
+
 // renderable is an object that can be rendered.
 type renderable struct {
 	// tpl is the current template.
@@ -156,7 +170,7 @@ func (e Engine) initFunMap(t *template.Template, referenceTpls map[string]render
 		if val == nil {
 			if e.LintMode {
 				// Don't fail on missing required values when linting
-				log.Printf("[INFO] Missing required value: %s", warn)
+				e.missingRequired(warn)
 				return "", nil
 			}
 			return val, errors.Errorf(warnWrap(warn))
@@ -164,7 +178,7 @@ func (e Engine) initFunMap(t *template.Template, referenceTpls map[string]render
 			if val == "" {
 				if e.LintMode {
 					// Don't fail on missing required values when linting
-					log.Printf("[INFO] Missing required value: %s", warn)
+					e.missingRequired(warn)
 					return "", nil
 				}
 				return val, errors.Errorf(warnWrap(warn))
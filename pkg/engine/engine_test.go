@@ -24,6 +24,7 @@ import (
 
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/warning"
 )
 
 func TestSortTemplates(t *testing.T) {
@@ -540,11 +541,16 @@ func TestAlterFuncMap_require(t *testing.T) {
 		},
 	}
 	var e Engine
+	var warnings warning.Warnings
 	e.LintMode = true
+	e.Warnings = &warnings
 	out, err = e.Render(c, lintValues)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if len(warnings) != 1 || warnings[0].Kind != warning.MissingValue {
+		t.Errorf("Expected a missing value warning, got %v", warnings)
+	}
 
 	expectStr = "All your base are belong to us"
 	if gotStr := out["conan/templates/quote"]; gotStr != expectStr {
@@ -0,0 +1,151 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package getter
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/plugin"
+)
+
+// Credentials are the credentials of a host.
+type Credentials struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// Token is a bearer token, used instead of the username and password.
+	Token string `json:"token,omitempty"`
+}
+
+// CredentialProvider returns the credentials for a host, or nil if it has
+// none. The host may include a port.
+type CredentialProvider func(host string) (*Credentials, error)
+
+// RegistryCredential returns the credentials for a registry host in the
+// form expected by the registry client: a token is returned as secret with
+// an empty username.
+func (p CredentialProvider) RegistryCredential(host string) (string, string, error) {
+	creds, err := p(host)
+	if err != nil || creds == nil {
+		return "", "", err
+	}
+	if creds.Token != "" {
+		return "", creds.Token, nil
+	}
+	return creds.Username, creds.Password, nil
+}
+
+// credentialPlugin is a credential provider of a plugin.
+type credentialPlugin struct {
+	plugin   *plugin.Plugin
+	provider plugin.CredentialProvider
+}
+
+// PluginCredentialProvider returns the provider getting credentials from the
+// credential providers of plugins, or nil if no plugin provides credentials.
+//
+// The command of the first plugin matching a host is run with the host as
+// last argument and prints the Credentials as JSON. The credentials of each
+// host are looked up once.
+func PluginCredentialProvider(settings *cli.EnvSettings) CredentialProvider {
+	plugins, err := plugin.FindPlugins(settings.PluginsDirectory)
+	if err != nil {
+		return nil
+	}
+	var providers []credentialPlugin
+	for _, p := range plugins {
+		for _, provider := range p.Metadata.CredentialProviders {
+			providers = append(providers, credentialPlugin{plugin: p, provider: provider})
+		}
+	}
+	if len(providers) == 0 {
+		return nil
+	}
+
+	var mu sync.Mutex
+	cache := map[string]*Credentials{}
+	return func(host string) (*Credentials, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if creds, ok := cache[host]; ok {
+			return creds, nil
+		}
+		for _, p := range providers {
+			if !matchHost(p.provider.Hosts, host) {
+				continue
+			}
+			creds, err := p.run(settings, host)
+			if err != nil {
+				return nil, err
+			}
+			cache[host] = creds
+			return creds, nil
+		}
+		cache[host] = nil
+		return nil, nil
+	}
+}
+
+// run runs the command of the provider for host.
+func (p credentialPlugin) run(settings *cli.EnvSettings, host string) (*Credentials, error) {
+	commands := strings.Split(p.provider.Command, " ")
+	argv := append(commands[1:], host)
+	prog := exec.Command(filepath.Join(p.plugin.Dir, commands[0]), argv...)
+	plugin.SetupPluginEnv(settings, p.plugin.Metadata.Name, p.plugin.Dir)
+	prog.Env = os.Environ()
+	buf := bytes.NewBuffer(nil)
+	prog.Stdout = buf
+	prog.Stderr = os.Stderr
+	if err := prog.Run(); err != nil {
+		return nil, errors.Wrapf(err, "plugin %q failed to provide credentials for %s", p.plugin.Metadata.Name, host)
+	}
+	if len(bytes.TrimSpace(buf.Bytes())) == 0 {
+		return nil, nil
+	}
+	creds := &Credentials{}
+	if err := json.Unmarshal(buf.Bytes(), creds); err != nil {
+		return nil, errors.Wrapf(err, "plugin %q returned invalid credentials for %s", p.plugin.Metadata.Name, host)
+	}
+	return creds, nil
+}
+
+// matchHost reports whether host, or its name without the port, matches
+// one of the patterns.
+func matchHost(patterns []string, host string) bool {
+	name := host
+	if i := strings.LastIndex(host, ":"); i >= 0 && !strings.Contains(host[i:], "]") {
+		name = host[:i]
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, host); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
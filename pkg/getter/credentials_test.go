@@ -0,0 +1,163 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package getter
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+func TestMatchHost(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		host     string
+		want     bool
+	}{
+		{[]string{"example.com"}, "example.com", true},
+		{[]string{"example.com"}, "example.com:443", true},
+		{[]string{"example.com:5000"}, "example.com", false},
+		{[]string{"*.example.com"}, "charts.example.com:8443", true},
+		{[]string{"*.example.com"}, "example.com", false},
+		{[]string{"other.com", "example.com"}, "example.com", true},
+	}
+	for _, tt := range tests {
+		if got := matchHost(tt.patterns, tt.host); got != tt.want {
+			t.Errorf("matchHost(%v, %q) = %v, want %v", tt.patterns, tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestRegistryCredential(t *testing.T) {
+	var provider CredentialProvider = func(host string) (*Credentials, error) {
+		switch host {
+		case "token.example.com":
+			return &Credentials{Token: "abc"}, nil
+		case "basic.example.com":
+			return &Credentials{Username: "user", Password: "pass"}, nil
+		}
+		return nil, nil
+	}
+
+	if username, secret, _ := provider.RegistryCredential("token.example.com"); username != "" || secret != "abc" {
+		t.Errorf("unexpected token credential %q/%q", username, secret)
+	}
+	if username, secret, _ := provider.RegistryCredential("basic.example.com"); username != "user" || secret != "pass" {
+		t.Errorf("unexpected basic credential %q/%q", username, secret)
+	}
+	if username, secret, _ := provider.RegistryCredential("none.example.com"); username != "" || secret != "" {
+		t.Errorf("unexpected credential %q/%q", username, secret)
+	}
+}
+
+func TestPluginCredentialProvider(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("TODO: refactor this test to work on windows")
+	}
+
+	pluginsDir, err := ioutil.TempDir("", "helm-credentials-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(pluginsDir)
+
+	dir := filepath.Join(pluginsDir, "creds")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	metadata := `name: "creds"
+version: "0.1.0"
+credentialProviders:
+- command: "creds.sh --json"
+  hosts:
+    - "*.example.com"
+`
+	script := "#!/bin/sh\necho '{\"token\": \"'\"$2\"'\"}'\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, "plugin.yaml"), []byte(metadata), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "creds.sh"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	settings := &cli.EnvSettings{PluginsDirectory: pluginsDir}
+	provider := PluginCredentialProvider(settings)
+	if provider == nil {
+		t.Fatal("expected a credential provider")
+	}
+
+	creds, err := provider("charts.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds == nil || creds.Token != "charts.example.com" {
+		t.Errorf("unexpected credentials %+v", creds)
+	}
+
+	creds, err = provider("other.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds != nil {
+		t.Errorf("expected no credentials for other.com, got %+v", creds)
+	}
+
+	if PluginCredentialProvider(&cli.EnvSettings{PluginsDirectory: pluginDir}) != nil {
+		t.Error("expected no credential provider without credential plugins")
+	}
+}
+
+func TestHTTPGetterCredentialProvider(t *testing.T) {
+	var authHeader string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader = r.Header.Get("Authorization")
+	}))
+	defer srv.Close()
+
+	g, err := NewHTTPGetter(WithCredentialProvider(func(host string) (*Credentials, error) {
+		return &Credentials{Token: "abc"}, nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Get(srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	if authHeader != "Bearer abc" {
+		t.Errorf("expected bearer token, got %q", authHeader)
+	}
+
+	// Basic auth set explicitly takes precedence over the provider.
+	g, err = NewHTTPGetter(WithBasicAuth("user", "pass"), WithCredentialProvider(func(host string) (*Credentials, error) {
+		return &Credentials{Token: "abc"}, nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Get(srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	if authHeader != "Basic dXNlcjpwYXNz" {
+		t.Errorf("expected basic auth, got %q", authHeader)
+	}
+}
@@ -18,23 +18,69 @@ package getter
 
 import (
 	"bytes"
+	"context"
 
 	"github.com/pkg/errors"
 
+	"helm.sh/helm/v3/pkg/audit"
 	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/logging"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/transfer"
+	"helm.sh/helm/v3/pkg/versions"
 )
 
 // options are generic parameters to be provided to the getter during instantiation.
 //
 // Getters may or may not ignore these parameters as they are passed in.
 type options struct {
-	url       string
-	certFile  string
-	keyFile   string
-	caFile    string
-	username  string
-	password  string
-	userAgent string
+	url            string
+	certFile       string
+	keyFile        string
+	caFile         string
+	username       string
+	password       string
+	userAgent      string
+	version        string
+	registryClient *registry.Client
+	credentials    CredentialProvider
+	logger         logging.Logger
+	ctx            context.Context
+	pool           *transfer.Pool
+	auditor        audit.Auditor
+	resolver       versions.Resolver
+}
+
+// log returns the logger set with WithLogger, or logging.Discard.
+func (opts *options) log() logging.Logger {
+	if opts.logger == nil {
+		return logging.Discard
+	}
+	return opts.logger
+}
+
+// context returns the context set with WithContext, or context.Background.
+func (opts *options) context() context.Context {
+	if opts.ctx == nil {
+		return context.Background()
+	}
+	return opts.ctx
+}
+
+// transferPool returns the pool set with WithPool, or the default pool.
+func (opts *options) transferPool() *transfer.Pool {
+	if opts.pool == nil {
+		return transfer.Default()
+	}
+	return opts.pool
+}
+
+// audit returns the auditor set with WithAuditor, or the default auditor.
+func (opts *options) audit() audit.Auditor {
+	if opts.auditor == nil {
+		return audit.Default()
+	}
+	return opts.auditor
 }
 
 // Option allows specifying various settings configurable by the user for overriding the defaults
@@ -57,6 +103,44 @@ func WithBasicAuth(username, password string) Option {
 	}
 }
 
+// WithCredentialProvider sets the provider of the credentials for hosts
+// requested without basic auth credentials.
+func WithCredentialProvider(provider CredentialProvider) Option {
+	return func(opts *options) {
+		opts.credentials = provider
+	}
+}
+
+// WithLogger sets the logger receiving the logs of the getter.
+func WithLogger(logger logging.Logger) Option {
+	return func(opts *options) {
+		opts.logger = logger
+	}
+}
+
+// WithContext sets the context of the requests of the getter, which carries
+// the trace they are part of.
+func WithContext(ctx context.Context) Option {
+	return func(opts *options) {
+		opts.ctx = ctx
+	}
+}
+
+// WithPool sets the pool whose bandwidth the downloads of the getter share.
+func WithPool(pool *transfer.Pool) Option {
+	return func(opts *options) {
+		opts.pool = pool
+	}
+}
+
+// WithAuditor sets the auditor receiving the events of the requests of the
+// getter.
+func WithAuditor(auditor audit.Auditor) Option {
+	return func(opts *options) {
+		opts.auditor = auditor
+	}
+}
+
 // WithUserAgent sets the request's User-Agent header to use the provided agent name.
 func WithUserAgent(userAgent string) Option {
 	return func(opts *options) {
@@ -73,6 +157,31 @@ func WithTLSClientConfig(certFile, keyFile, caFile string) Option {
 	}
 }
 
+// WithVersion sets the version constraint used to resolve references that do
+// not pin a specific version, such as an untagged OCI chart reference.
+func WithVersion(version string) Option {
+	return func(opts *options) {
+		opts.version = version
+	}
+}
+
+// WithVersionResolver sets the resolver matching the version set with
+// WithVersion against the versions available, such as the tags of an OCI
+// repository.
+func WithVersionResolver(resolver versions.Resolver) Option {
+	return func(opts *options) {
+		opts.resolver = resolver
+	}
+}
+
+// WithRegistryClient sets the registry client used by getters that talk to
+// OCI registries.
+func WithRegistryClient(client *registry.Client) Option {
+	return func(opts *options) {
+		opts.registryClient = client
+	}
+}
+
 // Getter is an interface to support GET to the specified URL.
 type Getter interface {
 	// Get file content by url string
@@ -122,11 +231,25 @@ var httpProvider = Provider{
 	New:     NewHTTPGetter,
 }
 
+var ociProvider = Provider{
+	Schemes: []string{registry.OCIScheme},
+	New:     NewOCIGetter,
+}
+
 // All finds all of the registered getters as a list of Provider instances.
 // Currently, the built-in getters and the discovered plugins with downloader
-// notations are collected.
+// notations are collected. The HTTP getter uses the credentials of the
+// plugins providing them.
 func All(settings *cli.EnvSettings) Providers {
-	result := Providers{httpProvider}
+	result := Providers{httpProvider, ociProvider}
+	if credentials := PluginCredentialProvider(settings); credentials != nil {
+		result[0] = Provider{
+			Schemes: httpProvider.Schemes,
+			New: func(options ...Option) (Getter, error) {
+				return NewHTTPGetter(append([]Option{WithCredentialProvider(credentials)}, options...)...)
+			},
+		}
+	}
 	pluginDownloaders, _ := collectPlugins(settings)
 	result = append(result, pluginDownloaders...)
 	return result
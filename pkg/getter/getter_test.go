@@ -56,8 +56,8 @@ func TestAll(t *testing.T) {
 	all := All(&cli.EnvSettings{
 		PluginsDirectory: pluginDir,
 	})
-	if len(all) != 3 {
-		t.Errorf("expected 3 providers (default plus two plugins), got %d", len(all))
+	if len(all) != 4 {
+		t.Errorf("expected 4 providers (defaults plus two plugins), got %d", len(all))
 	}
 
 	if _, err := all.ByScheme("test2"); err != nil {
@@ -75,4 +75,7 @@ func TestByScheme(t *testing.T) {
 	if _, err := g.ByScheme("https"); err != nil {
 		t.Error(err)
 	}
+	if _, err := g.ByScheme("oci"); err != nil {
+		t.Error(err)
+	}
 }
@@ -23,8 +23,10 @@ import (
 	"github.com/pkg/errors"
 
 	"helm.sh/helm/v3/internal/tlsutil"
+	"helm.sh/helm/v3/internal/tracing"
 	"helm.sh/helm/v3/internal/urlutil"
 	"helm.sh/helm/v3/internal/version"
+	"helm.sh/helm/v3/pkg/audit"
 )
 
 // HTTPGetter is the default HTTP(/S) backend handler
@@ -49,6 +51,8 @@ func (g *HTTPGetter) get(href string) (*bytes.Buffer, error) {
 	if err != nil {
 		return buf, err
 	}
+	req = req.WithContext(g.opts.context())
+	tracing.Inject(req.Context(), req.Header)
 
 	req.Header.Set("User-Agent", version.GetUserAgent())
 	if g.opts.userAgent != "" {
@@ -57,6 +61,16 @@ func (g *HTTPGetter) get(href string) (*bytes.Buffer, error) {
 
 	if g.opts.username != "" && g.opts.password != "" {
 		req.SetBasicAuth(g.opts.username, g.opts.password)
+	} else if g.opts.credentials != nil {
+		creds, err := g.opts.credentials(req.URL.Host)
+		if err != nil {
+			return buf, err
+		}
+		if creds != nil && creds.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+creds.Token)
+		} else if creds != nil {
+			req.SetBasicAuth(creds.Username, creds.Password)
+		}
 	}
 
 	client, err := g.httpClient()
@@ -64,16 +78,18 @@ func (g *HTTPGetter) get(href string) (*bytes.Buffer, error) {
 		return nil, err
 	}
 
+	g.opts.log().Debug("fetching", "url", href)
 	resp, err := client.Do(req)
 	if err != nil {
 		return buf, err
 	}
+	defer resp.Body.Close()
+	g.opts.log().Debug("fetched", "url", href, "status", resp.Status)
 	if resp.StatusCode != 200 {
 		return buf, errors.Errorf("failed to fetch %s : %s", href, resp.Status)
 	}
 
 	_, err = io.Copy(buf, resp.Body)
-	resp.Body.Close()
 	return buf, err
 }
 
@@ -103,13 +119,31 @@ func (g *HTTPGetter) httpClient() (*http.Client, error) {
 		tlsConf.ServerName = sni
 
 		client := &http.Client{
-			Transport: &http.Transport{
+			Transport: g.auditTransport(g.opts.transferPool().Transport(&http.Transport{
 				TLSClientConfig: tlsConf,
 				Proxy:           http.ProxyFromEnvironment,
-			},
+			})),
 		}
 
 		return client, nil
 	}
-	return http.DefaultClient, nil
+	return &http.Client{Transport: g.auditTransport(g.opts.transferPool().Transport(http.DefaultTransport))}, nil
+}
+
+// auditTransport returns a transport auditing the requests sent by rt.
+func (g *HTTPGetter) auditTransport(rt http.RoundTripper) http.RoundTripper {
+	return audit.Transport(rt, g.opts.audit(), "getter/http", g.credentialSource)
+}
+
+// credentialSource returns the source of the credentials of req.
+func (g *HTTPGetter) credentialSource(req *http.Request) string {
+	switch {
+	case req.Header.Get("Authorization") != "" && g.opts.username != "" && g.opts.password != "":
+		return audit.CredentialsBasicAuth
+	case req.Header.Get("Authorization") != "":
+		return audit.CredentialsProvider
+	case g.opts.certFile != "" && g.opts.keyFile != "":
+		return audit.CredentialsClientCertificate
+	}
+	return audit.CredentialsNone
 }
@@ -16,6 +16,7 @@ limitations under the License.
 package getter
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -25,9 +26,11 @@ import (
 	"testing"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/api/trace/testtrace"
 
 	"helm.sh/helm/v3/internal/tlsutil"
 	"helm.sh/helm/v3/internal/version"
+	"helm.sh/helm/v3/pkg/audit"
 	"helm.sh/helm/v3/pkg/cli"
 )
 
@@ -143,6 +146,65 @@ func TestDownload(t *testing.T) {
 	}
 }
 
+func TestDownloadTraceContext(t *testing.T) {
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	defer srv.Close()
+
+	ctx, span := testtrace.NewTracer().Start(context.Background(), "download")
+	defer span.End()
+	g, err := NewHTTPGetter(WithContext(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Get(srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	if traceparent == "" {
+		t.Error("expected the trace context in the request headers")
+	}
+}
+
+func TestDownloadAudit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "Call me Ishmael")
+	}))
+	defer srv.Close()
+
+	var events []audit.Event
+	auditor := audit.Func(func(e audit.Event) { events = append(events, e) })
+	g, err := NewHTTPGetter(WithAuditor(auditor), WithBasicAuth("username", "password"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Get(srv.URL + "/index.yaml?token=secret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Get(srv.URL + "/missing"); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	e := events[0]
+	if e.URL != srv.URL+"/index.yaml?token=redacted" || e.Method != "GET" || e.Status != 200 || e.Bytes != 15 {
+		t.Errorf("unexpected event %+v", e)
+	}
+	if e.Component != "getter/http" || e.Credentials != audit.CredentialsBasicAuth {
+		t.Errorf("unexpected event %+v", e)
+	}
+	if events[1].Status != http.StatusNotFound {
+		t.Errorf("expected a 404 status, got %d", events[1].Status)
+	}
+}
+
 func TestDownloadTLS(t *testing.T) {
 	cd := "../../testdata"
 	ca, pub, priv := filepath.Join(cd, "rootca.crt"), filepath.Join(cd, "crt.pem"), filepath.Join(cd, "key.pem")
@@ -0,0 +1,87 @@
+/*
+Copyright The Helm Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package getter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+// OCIGetter is the default OCI registry backend handler
+type OCIGetter struct {
+	opts options
+}
+
+// Get performs a Get from an OCI registry and returns the chart archive.
+func (g *OCIGetter) Get(href string, options ...Option) (*bytes.Buffer, error) {
+	buf, _, err := g.GetWithDetails(href, options...)
+	return buf, err
+}
+
+// GetWithDetails performs a Get from an OCI registry and returns the chart
+// archive along with a summary of the reference that was pulled.
+//
+// If href does not carry a tag, the highest tag satisfying the version set
+// with WithVersion is used, as chosen by the resolver set with
+// WithVersionResolver.
+func (g *OCIGetter) GetWithDetails(href string, options ...Option) (*bytes.Buffer, *registry.CacheRefSummary, error) {
+	for _, opt := range options {
+		opt(&g.opts)
+	}
+
+	client := g.opts.registryClient
+	if client == nil {
+		var err error
+		client, err = registry.NewClient(registry.ClientOptPool(g.opts.transferPool()))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	client = client.WithContext(g.opts.context())
+	if g.opts.resolver != nil {
+		client = client.WithVersionResolver(g.opts.resolver)
+	}
+
+	ref, err := registry.ParseReference(strings.TrimPrefix(href, fmt.Sprintf("%s://", registry.OCIScheme)))
+	if err != nil {
+		return nil, nil, err
+	}
+	ref, err = client.ResolveChartVersion(ref, g.opts.version)
+	if err != nil {
+		return nil, nil, err
+	}
+	g.opts.log().Debug("fetching chart", "ref", ref.FullName())
+
+	r, content, err := client.FetchChart(ref)
+	if err != nil {
+		return nil, r, err
+	}
+	return bytes.NewBuffer(content), r, nil
+}
+
+// NewOCIGetter constructs a valid OCI registry client as a Getter
+func NewOCIGetter(options ...Option) (Getter, error) {
+	var client OCIGetter
+
+	for _, opt := range options {
+		opt(&client.opts)
+	}
+
+	return &client, nil
+}
@@ -21,9 +21,11 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
+	"helm.sh/helm/v3/pkg/audit"
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/plugin"
 )
@@ -75,7 +77,24 @@ func (p *pluginGetter) Get(href string, options ...Option) (*bytes.Buffer, error
 	buf := bytes.NewBuffer(nil)
 	prog.Stdout = buf
 	prog.Stderr = os.Stderr
-	if err := prog.Run(); err != nil {
+	e := audit.Event{
+		Time:        time.Now(),
+		Component:   "getter/plugin/" + p.name,
+		Method:      "GET",
+		URL:         audit.RedactURL(href),
+		Credentials: audit.CredentialsNone,
+	}
+	if p.opts.certFile != "" && p.opts.keyFile != "" {
+		e.Credentials = audit.CredentialsClientCertificate
+	}
+	err := prog.Run()
+	e.Duration = time.Since(e.Time)
+	e.Bytes = int64(buf.Len())
+	if err != nil {
+		e.Error = err.Error()
+	}
+	p.opts.audit().Audit(e)
+	if err != nil {
 		if eerr, ok := err.(*exec.ExitError); ok {
 			os.Stderr.Write(eerr.Stderr)
 			return nil, errors.Errorf("plugin %q exited with error", p.command)
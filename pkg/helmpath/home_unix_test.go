@@ -43,4 +43,18 @@ func TestHelmHome(t *testing.T) {
 	os.Setenv(xdg.CacheHomeEnvVar, "/cache2")
 
 	isEq(t, CachePath(), "/cache2/helm")
+
+	// Helm's own variables set the directories without the helm suffix
+	os.Setenv(CacheHomeEnvVar, "/helm-cache")
+	os.Setenv(ConfigHomeEnvVar, "/helm-config")
+	os.Setenv(DataHomeEnvVar, "/helm-data")
+	defer func() {
+		os.Unsetenv(CacheHomeEnvVar)
+		os.Unsetenv(ConfigHomeEnvVar)
+		os.Unsetenv(DataHomeEnvVar)
+	}()
+
+	isEq(t, CachePath("repository"), "/helm-cache/repository")
+	isEq(t, ConfigPath(), "/helm-config")
+	isEq(t, DataPath(), "/helm-data")
 }
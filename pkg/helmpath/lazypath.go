@@ -20,11 +20,29 @@ import (
 	"helm.sh/helm/v3/pkg/helmpath/xdg"
 )
 
+const (
+	// CacheHomeEnvVar is the environment variable setting the directory
+	// Helm stores cached files in, overriding $XDG_CACHE_HOME/helm.
+	CacheHomeEnvVar = "HELM_CACHE_HOME"
+	// ConfigHomeEnvVar is the environment variable setting the directory
+	// Helm stores configuration in, overriding $XDG_CONFIG_HOME/helm.
+	ConfigHomeEnvVar = "HELM_CONFIG_HOME"
+	// DataHomeEnvVar is the environment variable setting the directory
+	// Helm stores data in, overriding $XDG_DATA_HOME/helm.
+	DataHomeEnvVar = "HELM_DATA_HOME"
+)
+
 // lazypath is an lazy-loaded path buffer for the XDG base directory specification.
 type lazypath string
 
-func (l lazypath) path(envVar string, defaultFn func() string, elem ...string) string {
-	base := os.Getenv(envVar)
+// path returns elem in the directory set by the Helm environment variable
+// helmEnvVar, or else in the lazypath directory of the base directory set by
+// the XDG environment variable xdgEnvVar or returned by defaultFn.
+func (l lazypath) path(helmEnvVar, xdgEnvVar string, defaultFn func() string, elem ...string) string {
+	if base := os.Getenv(helmEnvVar); base != "" {
+		return filepath.Join(base, filepath.Join(elem...))
+	}
+	base := os.Getenv(xdgEnvVar)
 	if base == "" {
 		base = defaultFn()
 	}
@@ -34,16 +52,16 @@ func (l lazypath) path(envVar string, defaultFn func() string, elem ...string) s
 // cachePath defines the base directory relative to which user specific non-essential data files
 // should be stored.
 func (l lazypath) cachePath(elem ...string) string {
-	return l.path(xdg.CacheHomeEnvVar, cacheHome, filepath.Join(elem...))
+	return l.path(CacheHomeEnvVar, xdg.CacheHomeEnvVar, cacheHome, filepath.Join(elem...))
 }
 
 // configPath defines the base directory relative to which user specific configuration files should
 // be stored.
 func (l lazypath) configPath(elem ...string) string {
-	return l.path(xdg.ConfigHomeEnvVar, configHome, filepath.Join(elem...))
+	return l.path(ConfigHomeEnvVar, xdg.ConfigHomeEnvVar, configHome, filepath.Join(elem...))
 }
 
 // dataPath defines the base directory relative to which user specific data files should be stored.
 func (l lazypath) dataPath(elem ...string) string {
-	return l.path(xdg.DataHomeEnvVar, dataHome, filepath.Join(elem...))
+	return l.path(DataHomeEnvVar, xdg.DataHomeEnvVar, dataHome, filepath.Join(elem...))
 }
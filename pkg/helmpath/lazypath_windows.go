@@ -21,4 +21,11 @@ func dataHome() string { return configHome() }
 
 func configHome() string { return os.Getenv("APPDATA") }
 
-func cacheHome() string { return os.Getenv("TEMP") }
+// cacheHome is the local application data directory, as cached files are
+// not to roam with the user profile, or the roaming one if it is not set.
+func cacheHome() string {
+	if dir := os.Getenv("LOCALAPPDATA"); dir != "" {
+		return dir
+	}
+	return configHome()
+}
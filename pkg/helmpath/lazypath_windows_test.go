@@ -32,7 +32,7 @@ const (
 )
 
 func TestDataPath(t *testing.T) {
-	os.Unsetenv(DataHomeEnvVar)
+	os.Unsetenv(xdg.DataHomeEnvVar)
 	os.Setenv("APPDATA", filepath.Join(homedir.HomeDir(), "foo"))
 
 	expected := filepath.Join(homedir.HomeDir(), "foo", appName, testFile)
@@ -41,7 +41,7 @@ func TestDataPath(t *testing.T) {
 		t.Errorf("expected '%s', got '%s'", expected, lazy.dataPath(testFile))
 	}
 
-	os.Setenv(DataHomeEnvVar, filepath.Join(homedir.HomeDir(), "xdg"))
+	os.Setenv(xdg.DataHomeEnvVar, filepath.Join(homedir.HomeDir(), "xdg"))
 
 	expected = filepath.Join(homedir.HomeDir(), "xdg", appName, testFile)
 
@@ -70,7 +70,8 @@ func TestConfigPath(t *testing.T) {
 }
 
 func TestCachePath(t *testing.T) {
-	os.Unsetenv(CacheHomeEnvVar)
+	os.Unsetenv(xdg.CacheHomeEnvVar)
+	os.Unsetenv("LOCALAPPDATA")
 	os.Setenv("APPDATA", filepath.Join(homedir.HomeDir(), "foo"))
 
 	expected := filepath.Join(homedir.HomeDir(), "foo", appName, testFile)
@@ -79,7 +80,15 @@ func TestCachePath(t *testing.T) {
 		t.Errorf("expected '%s', got '%s'", expected, lazy.cachePath(testFile))
 	}
 
-	os.Setenv(CacheHomeEnvVar, filepath.Join(homedir.HomeDir(), "xdg"))
+	os.Setenv("LOCALAPPDATA", filepath.Join(homedir.HomeDir(), "local"))
+
+	expected = filepath.Join(homedir.HomeDir(), "local", appName, testFile)
+
+	if lazy.cachePath(testFile) != expected {
+		t.Errorf("expected '%s', got '%s'", expected, lazy.cachePath(testFile))
+	}
+
+	os.Setenv(xdg.CacheHomeEnvVar, filepath.Join(homedir.HomeDir(), "xdg"))
 
 	expected = filepath.Join(homedir.HomeDir(), "xdg", appName, testFile)
 
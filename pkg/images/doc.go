@@ -0,0 +1,25 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package images extracts and rewrites the container images referenced by
+rendered manifests.
+
+Extract finds the images of the containers of every workload in a manifest.
+A Mapping relocates images to other registries, or pins them to digests, and
+is a post-renderer, so that charts can be installed in air-gapped clusters
+from mirrored registries without changing their values.
+*/
+package images // import "helm.sh/helm/v3/pkg/images"
@@ -0,0 +1,149 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images // import "helm.sh/helm/v3/pkg/images"
+
+import (
+	"regexp"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+
+	"helm.sh/helm/v3/pkg/releaseutil"
+)
+
+// Image is a container image referenced by a manifest.
+type Image struct {
+	// Reference is the image reference as written in the manifest.
+	Reference string
+	// Source is the template the manifest was rendered from, if known.
+	Source string
+	// Kind and Name identify the resource running the image.
+	Kind string
+	Name string
+	// Container is the name of the container running the image.
+	Container string
+}
+
+// containerFields are the fields of pod specs holding containers.
+var containerFields = []string{"initContainers", "containers", "ephemeralContainers"}
+
+var sourceComment = regexp.MustCompile(`(?m)^# Source: (.+)$`)
+
+// Extract returns the images of the containers in manifest, which may hold
+// several YAML documents, in the order they appear. Containers are found in
+// pod specs anywhere in a resource, so that the pod templates of every
+// workload, including custom resources, are covered. Documents that are not
+// valid YAML are skipped.
+func Extract(manifest string) []Image {
+	var images []Image
+	for _, doc := range splitManifests(manifest) {
+		var obj map[string]interface{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil || obj == nil {
+			continue
+		}
+		img := Image{}
+		if m := sourceComment.FindStringSubmatch(doc); m != nil {
+			img.Source = m[1]
+		}
+		img.Kind, _ = obj["kind"].(string)
+		if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+			img.Name, _ = metadata["name"].(string)
+		}
+		images = append(images, containerImages(obj, img)...)
+	}
+	return images
+}
+
+// References returns the distinct references of images, sorted.
+func References(images []Image) []string {
+	seen := map[string]bool{}
+	var refs []string
+	for _, img := range images {
+		if !seen[img.Reference] {
+			seen[img.Reference] = true
+			refs = append(refs, img.Reference)
+		}
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+// splitManifests splits manifest into its documents, in order.
+func splitManifests(manifest string) []string {
+	split := releaseutil.SplitManifests(manifest)
+	keys := make([]string, 0, len(split))
+	for k := range split {
+		keys = append(keys, k)
+	}
+	sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+	docs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		docs = append(docs, split[k])
+	}
+	return docs
+}
+
+// containerImages returns the images of the containers found anywhere in
+// obj, described by the resource img.
+func containerImages(obj interface{}, img Image) []Image {
+	var images []Image
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		for _, field := range containerFields {
+			containers, ok := o[field].([]interface{})
+			if !ok {
+				continue
+			}
+			for _, c := range containers {
+				c, ok := c.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if ref, ok := c["image"].(string); ok && ref != "" {
+					img := img
+					img.Reference = ref
+					img.Container, _ = c["name"].(string)
+					images = append(images, img)
+				}
+			}
+		}
+		keys := make([]string, 0, len(o))
+		for k := range o {
+			if !isContainerField(k) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			images = append(images, containerImages(o[k], img)...)
+		}
+	case []interface{}:
+		for _, v := range o {
+			images = append(images, containerImages(v, img)...)
+		}
+	}
+	return images
+}
+
+func isContainerField(name string) bool {
+	for _, f := range containerFields {
+		if name == f {
+			return true
+		}
+	}
+	return false
+}
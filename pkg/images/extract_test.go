@@ -0,0 +1,84 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images
+
+import (
+	"reflect"
+	"testing"
+)
+
+const manifests = `---
+# Source: app/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: busybox:1.32
+      containers:
+      - name: app
+        image: "quay.io/example/app:2.0" # the app
+      - name: proxy
+        image: 'envoyproxy/envoy:v1.16.0'
+---
+# Source: app/templates/job.yaml
+apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+          - name: backup
+            image: busybox:1.32
+---
+# Source: app/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  image: busybox:1.32
+---
+not: [valid
+`
+
+func TestExtract(t *testing.T) {
+	got := Extract(manifests)
+	expect := []Image{
+		{Reference: "busybox:1.32", Source: "app/templates/deployment.yaml", Kind: "Deployment", Name: "app", Container: "init"},
+		{Reference: "quay.io/example/app:2.0", Source: "app/templates/deployment.yaml", Kind: "Deployment", Name: "app", Container: "app"},
+		{Reference: "envoyproxy/envoy:v1.16.0", Source: "app/templates/deployment.yaml", Kind: "Deployment", Name: "app", Container: "proxy"},
+		{Reference: "busybox:1.32", Source: "app/templates/job.yaml", Kind: "CronJob", Name: "backup", Container: "backup"},
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("expected %+v, got %+v", expect, got)
+	}
+
+	refs := References(got)
+	expectRefs := []string{"busybox:1.32", "envoyproxy/envoy:v1.16.0", "quay.io/example/app:2.0"}
+	if !reflect.DeepEqual(refs, expectRefs) {
+		t.Errorf("expected %v, got %v", expectRefs, refs)
+	}
+}
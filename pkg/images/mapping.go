@@ -0,0 +1,158 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images // import "helm.sh/helm/v3/pkg/images"
+
+import (
+	"bytes"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// Mapping relocates images. It is read from YAML files such as
+//
+//	registries:
+//	  docker.io: registry.internal/dockerhub
+//	  quay.io/prometheus: registry.internal/prometheus
+//	images:
+//	  nginx:1.19: registry.internal/nginx@sha256:4cf620a5c81390ee209398ecc18e5fb9dd0f5155cd82adcbae532fec94006fb9
+//
+// Images are matched by their normalized references, so nginx:1.19 and
+// docker.io/library/nginx:1.19 are the same image.
+type Mapping struct {
+	// Registries maps registries, optionally followed by a repository path
+	// prefix, to the ones replacing them. The longest matching prefix wins.
+	Registries map[string]string `json:"registries,omitempty"`
+	// Images maps images to the references replacing them, such as the same
+	// image pinned to a digest in a mirror. They take precedence over
+	// Registries.
+	Images map[string]string `json:"images,omitempty"`
+
+	// registries and images are Registries without trailing slashes and
+	// Images by normalized references.
+	registries map[string]string
+	images     map[string]string
+}
+
+// LoadMapping reads a mapping from a YAML file.
+func LoadMapping(path string) (*Mapping, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := &Mapping{}
+	if err := yaml.UnmarshalStrict(data, m); err != nil {
+		return nil, errors.Wrapf(err, "invalid image mapping %s", path)
+	}
+	return m, m.normalize()
+}
+
+// normalize validates the mapping and indexes it for rewriting.
+func (m *Mapping) normalize() error {
+	m.registries = make(map[string]string, len(m.Registries))
+	for from, to := range m.Registries {
+		m.registries[strings.TrimSuffix(from, "/")] = strings.TrimSuffix(to, "/")
+	}
+	m.images = make(map[string]string, len(m.Images))
+	for from, to := range m.Images {
+		named, err := reference.ParseNormalizedNamed(from)
+		if err != nil {
+			return errors.Wrapf(err, "invalid image %q in mapping", from)
+		}
+		if _, err := reference.ParseNormalizedNamed(to); err != nil {
+			return errors.Wrapf(err, "invalid image %q in mapping", to)
+		}
+		m.images[named.String()] = to
+	}
+	return nil
+}
+
+// Rewrite returns the reference relocating the image ref, and whether the
+// mapping relocates it. References that cannot be parsed, such as those left
+// empty by templates, are never relocated.
+func (m *Mapping) Rewrite(ref string) (string, bool) {
+	if m.images == nil {
+		if err := m.normalize(); err != nil {
+			return ref, false
+		}
+	}
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return ref, false
+	}
+	full := named.String()
+	if to, ok := m.images[full]; ok {
+		return to, true
+	}
+
+	name := named.Name()
+	prefix := ""
+	for from := range m.registries {
+		if (name == from || strings.HasPrefix(name, from+"/")) && len(from) > len(prefix) {
+			prefix = from
+		}
+	}
+	if prefix == "" {
+		return ref, false
+	}
+	return m.registries[prefix] + strings.TrimPrefix(full, prefix), true
+}
+
+// imageLine matches the image fields of containers in YAML manifests.
+var imageLine = regexp.MustCompile(`^(\s*(?:-\s+)?image:\s*)(["']?)([^"'\s#]+)(["']?)(.*)$`)
+
+// Run relocates the images of the containers in rendered manifests. It
+// implements postrender.PostRenderer, and only changes the image fields of
+// the documents holding containers, leaving the manifests otherwise as they
+// are.
+func (m *Mapping) Run(renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
+	out := &bytes.Buffer{}
+	var doc []string
+	for _, line := range strings.SplitAfter(renderedManifests.String(), "\n") {
+		if strings.HasPrefix(line, "---") {
+			m.rewriteDocument(out, doc)
+			doc = doc[:0]
+		}
+		doc = append(doc, line)
+	}
+	m.rewriteDocument(out, doc)
+	return out, nil
+}
+
+// rewriteDocument writes the lines of a YAML document to out, relocating the
+// images of its containers.
+func (m *Mapping) rewriteDocument(out *bytes.Buffer, lines []string) {
+	relocated := map[string]string{}
+	for _, img := range Extract(strings.Join(lines, "")) {
+		if to, ok := m.Rewrite(img.Reference); ok {
+			relocated[img.Reference] = to
+		}
+	}
+	for _, line := range lines {
+		content := strings.TrimSuffix(line, "\n")
+		if match := imageLine.FindStringSubmatch(content); match != nil {
+			if to, ok := relocated[match[3]]; ok {
+				line = match[1] + match[2] + to + match[4] + match[5] + line[len(content):]
+			}
+		}
+		out.WriteString(line)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/postrender"
+)
+
+const digest = "sha256:115731bab0862031b44766733890091c17924f9b7781b79997f5f163be262178"
+
+func testMapping() *Mapping {
+	return &Mapping{
+		Registries: map[string]string{
+			"docker.io":             "mirror.internal/dockerhub",
+			"docker.io/envoyproxy/": "mirror.internal/envoy/",
+			"quay.io/example":       "mirror.internal/example",
+		},
+		Images: map[string]string{
+			"docker.io/library/busybox:1.32": "mirror.internal/busybox@" + digest,
+		},
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	m := testMapping()
+	tests := []struct {
+		ref     string
+		expect  string
+		rewrite bool
+	}{
+		{"busybox:1.32", "mirror.internal/busybox@" + digest, true},
+		{"busybox:1.31", "mirror.internal/dockerhub/library/busybox:1.31", true},
+		{"nginx", "mirror.internal/dockerhub/library/nginx", true},
+		{"envoyproxy/envoy:v1.16.0", "mirror.internal/envoy/envoy:v1.16.0", true},
+		{"quay.io/example/app@" + digest, "mirror.internal/example/app@" + digest, true},
+		{"quay.io/examples/app:1.0", "quay.io/examples/app:1.0", false},
+		{"gcr.io/project/app:1.0", "gcr.io/project/app:1.0", false},
+		{"Invalid:Reference:", "Invalid:Reference:", false},
+	}
+	for _, tt := range tests {
+		got, ok := m.Rewrite(tt.ref)
+		if got != tt.expect || ok != tt.rewrite {
+			t.Errorf("%s: expected %q, %v, got %q, %v", tt.ref, tt.expect, tt.rewrite, got, ok)
+		}
+	}
+}
+
+func TestMappingRun(t *testing.T) {
+	var pr postrender.PostRenderer = testMapping()
+	out, err := pr.Run(bytes.NewBufferString(manifests))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := strings.NewReplacer(
+		"        image: busybox:1.32", "        image: mirror.internal/busybox@"+digest,
+		`image: "quay.io/example/app:2.0" # the app`, `image: "mirror.internal/example/app:2.0" # the app`,
+		`image: 'envoyproxy/envoy:v1.16.0'`, `image: 'mirror.internal/envoy/envoy:v1.16.0'`,
+	).Replace(manifests)
+	// Only the images of containers are relocated.
+	if !strings.Contains(expect, "  image: busybox:1.32\n---") {
+		t.Fatal("expected the image of the config map to be left as is")
+	}
+	if out.String() != expect {
+		t.Errorf("expected:\n%s\ngot:\n%s", expect, out.String())
+	}
+}
+
+func TestLoadMapping(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-images-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "mapping.yaml")
+	data := "registries:\n  docker.io: mirror.internal\nimages:\n  alpine:3.9: mirror.internal/alpine@" + digest + "\n"
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := LoadMapping(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := m.Rewrite("docker.io/library/alpine:3.9"); got != "mirror.internal/alpine@"+digest {
+		t.Errorf("unexpected rewrite %q", got)
+	}
+
+	for _, data := range []string{"registry: {}\n", "images:\n  alpine: 'Not An Image'\n"} {
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadMapping(path); err == nil {
+			t.Errorf("expected an error loading %q", data)
+		}
+	}
+}
@@ -32,7 +32,7 @@ import (
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -45,11 +45,17 @@ import (
 	cachetools "k8s.io/client-go/tools/cache"
 	watchtools "k8s.io/client-go/tools/watch"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"helm.sh/helm/v3/pkg/release"
 )
 
 // ErrNoObjectsVisited indicates that during a visit operation, no matching objects were found.
 var ErrNoObjectsVisited = errors.New("no objects visited")
 
+// DefaultFieldManager is the field manager used for server-side apply when
+// none is given.
+const DefaultFieldManager = "helm"
+
 // Client represents a client capable of communicating with the Kubernetes API.
 type Client struct {
 	Factory Factory
@@ -95,8 +101,17 @@ func (c *Client) IsReachable() error {
 
 // Create creates Kubernetes resources specified in the resource list.
 func (c *Client) Create(resources ResourceList) (*Result, error) {
+	return c.CreateWithOptions(resources, ApplyOptions{})
+}
+
+// CreateWithOptions creates Kubernetes resources specified in the resource
+// list, using server-side apply if requested.
+func (c *Client) CreateWithOptions(resources ResourceList, opts ApplyOptions) (*Result, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
 	c.Log("creating %d resource(s)", len(resources))
-	if err := perform(resources, createResource); err != nil {
+	if err := perform(resources, c.creator(opts)); err != nil {
 		return nil, err
 	}
 	return &Result{Created: resources}, nil
@@ -104,6 +119,12 @@ func (c *Client) Create(resources ResourceList) (*Result, error) {
 
 // Wait up to the given timeout for the specified resources to be ready
 func (c *Client) Wait(resources ResourceList, timeout time.Duration) error {
+	return c.WaitWithEvents(resources, timeout, nil)
+}
+
+// WaitWithEvents waits like Wait, calling onEvent with each distinct
+// problem that keeps the resources from becoming ready.
+func (c *Client) WaitWithEvents(resources ResourceList, timeout time.Duration, onEvent WaitEventFunc) error {
 	cs, err := c.Factory.KubernetesClientSet()
 	if err != nil {
 		return err
@@ -112,6 +133,7 @@ func (c *Client) Wait(resources ResourceList, timeout time.Duration) error {
 		c:       cs,
 		log:     c.Log,
 		timeout: timeout,
+		onEvent: onEvent,
 	}
 	return w.waitForResources(resources)
 }
@@ -157,6 +179,16 @@ func (c *Client) Build(reader io.Reader, validate bool) (ResourceList, error) {
 // resource updates, creations, and deletions that were attempted. These can be
 // used for cleanup or other logging purposes.
 func (c *Client) Update(original, target ResourceList, force bool) (*Result, error) {
+	return c.UpdateWithOptions(original, target, ApplyOptions{Force: force})
+}
+
+// UpdateWithOptions behaves like Update, but patches resources according to
+// the given options. With server-side apply, the target objects are applied
+// as-is and the API server merges them with fields owned by other managers.
+func (c *Client) UpdateWithOptions(original, target ResourceList, opts ApplyOptions) (*Result, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
 	updateErrors := []string{}
 	res := &Result{}
 
@@ -176,7 +208,7 @@ func (c *Client) Update(original, target ResourceList, force bool) (*Result, err
 			res.Created = append(res.Created, info)
 
 			// Since the resource does not exist, create it.
-			if err := createResource(info); err != nil {
+			if err := c.creator(opts)(info); err != nil {
 				return errors.Wrap(err, "failed to create resource")
 			}
 
@@ -191,7 +223,13 @@ func (c *Client) Update(original, target ResourceList, force bool) (*Result, err
 			return errors.Errorf("no %s with the name %q found", kind, info.Name)
 		}
 
-		if err := updateResource(c, info, originalInfo.Object, force); err != nil {
+		err = opts.Retry.do(c.Log, func() error {
+			if opts.ServerSideApply && !opts.Force {
+				return applyResource(info, opts)
+			}
+			return updateResource(c, info, originalInfo.Object, opts.Force)
+		})
+		if err != nil {
 			c.Log("error updating the resource %q:\n\t %v", info.Name, err)
 			updateErrors = append(updateErrors, err.Error())
 		}
@@ -211,7 +249,7 @@ func (c *Client) Update(original, target ResourceList, force bool) (*Result, err
 	for _, info := range original.Difference(target) {
 		c.Log("Deleting %q in %s...", info.Name, info.Namespace)
 		res.Deleted = append(res.Deleted, info)
-		if err := deleteResource(info); err != nil {
+		if err := deleteResource(info, metav1.DeletePropagationBackground); err != nil {
 			if apierrors.IsNotFound(err) {
 				c.Log("Attempted to delete %q, but the resource was missing", info.Name)
 			} else {
@@ -223,16 +261,40 @@ func (c *Client) Update(original, target ResourceList, force bool) (*Result, err
 	return res, nil
 }
 
+// Fetch gets the live state of the given resources. Resources that are not
+// found get a nil entry in the result.
+func (c *Client) Fetch(resources ResourceList) ([]runtime.Object, error) {
+	objs := make([]runtime.Object, len(resources))
+	for i, info := range resources {
+		obj, err := resource.NewHelper(info.Client, info.Mapping).Get(info.Namespace, info.Name, false)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get information about the resource %s", info.ObjectName())
+		}
+		objs[i] = obj
+	}
+	return objs, nil
+}
+
 // Delete deletes Kubernetes resources specified in the resources list. It will
 // attempt to delete all resources even if one or more fail and collect any
 // errors. All successfully deleted items will be returned in the `Deleted`
 // ResourceList that is part of the result.
 func (c *Client) Delete(resources ResourceList) (*Result, []error) {
+	return c.DeleteWithPropagation(resources, metav1.DeletePropagationBackground)
+}
+
+// DeleteWithPropagation deletes resources like Delete, letting the given
+// policy decide whether their dependents are deleted in the foreground, in
+// the background or orphaned.
+func (c *Client) DeleteWithPropagation(resources ResourceList, policy metav1.DeletionPropagation) (*Result, []error) {
 	var errs []error
 	res := &Result{}
 	err := perform(resources, func(info *resource.Info) error {
 		c.Log("Starting delete for %q %s", info.Name, info.Mapping.GroupVersionKind.Kind)
-		if err := c.skipIfNotFound(deleteResource(info)); err != nil {
+		if err := c.skipIfNotFound(deleteResource(info, policy)); err != nil {
 			// Collect the error and continue on
 			errs = append(errs, err)
 		} else {
@@ -310,6 +372,15 @@ func batchPerform(infos ResourceList, fn func(*resource.Info) error, errs chan<-
 	var wg sync.WaitGroup
 	for _, info := range infos {
 		currentKind := info.Object.GetObjectKind().GroupVersionKind().Kind
+		// Resources with explicit dependencies are handled on their own, so
+		// that the resources they depend on, or that depend on them, are
+		// done first.
+		if hasDependencies(info) {
+			wg.Wait()
+			errs <- fn(info)
+			kind = ""
+			continue
+		}
 		if kind != currentKind {
 			wg.Wait()
 			kind = currentKind
@@ -322,6 +393,27 @@ func batchPerform(infos ResourceList, fn func(*resource.Info) error, errs chan<-
 	}
 }
 
+func hasDependencies(info *resource.Info) bool {
+	accessor, err := meta.Accessor(info.Object)
+	if err != nil {
+		return false
+	}
+	_, ok := accessor.GetAnnotations()[release.DependsOnAnnotation]
+	return ok
+}
+
+// creator returns the function creating a resource according to opts.
+func (c *Client) creator(opts ApplyOptions) func(*resource.Info) error {
+	return func(info *resource.Info) error {
+		return opts.Retry.do(c.Log, func() error {
+			if opts.ServerSideApply {
+				return applyResource(info, opts)
+			}
+			return createResource(info)
+		})
+	}
+}
+
 func createResource(info *resource.Info) error {
 	obj, err := resource.NewHelper(info.Client, info.Mapping).Create(info.Namespace, true, info.Object, nil)
 	if err != nil {
@@ -330,8 +422,41 @@ func createResource(info *resource.Info) error {
 	return info.Refresh(obj, true)
 }
 
-func deleteResource(info *resource.Info) error {
-	policy := metav1.DeletePropagationBackground
+// applyResource sends the object to the API server as a server-side apply
+// patch, creating it if it does not exist. Conflicts with other field
+// managers are resolved according to the conflict policy.
+func applyResource(info *resource.Info, opts ApplyOptions) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(info.Object)
+	if err != nil {
+		return errors.Wrap(err, "serializing target configuration")
+	}
+	fieldManager := opts.FieldManager
+	if fieldManager == "" {
+		fieldManager = DefaultFieldManager
+	}
+	force := opts.ConflictPolicy == ConflictPolicyForce
+	helper := resource.NewHelper(info.Client, info.Mapping)
+	for {
+		data, err := json.Marshal(content)
+		if err != nil {
+			return errors.Wrap(err, "serializing target configuration")
+		}
+		obj, err := helper.Patch(info.Namespace, info.Name, types.ApplyPatchType, data, &metav1.PatchOptions{
+			FieldManager: fieldManager,
+			Force:        &force,
+		})
+		if err == nil {
+			return info.Refresh(obj, true)
+		}
+		// Drop the conflicting fields and try again, so that they stay
+		// with their current managers.
+		if opts.ConflictPolicy != ConflictPolicyIgnore || !apierrors.IsConflict(err) || !dropConflicts(content, err, opts.IgnoreManagers) {
+			return errors.Wrapf(err, "cannot apply %q with kind %s", info.Name, info.Mapping.GroupVersionKind.Kind)
+		}
+	}
+}
+
+func deleteResource(info *resource.Info, policy metav1.DeletionPropagation) error {
 	opts := &metav1.DeleteOptions{PropagationPolicy: &policy}
 	_, err := resource.NewHelper(info.Client, info.Mapping).DeleteWithOptions(info.Namespace, info.Name, opts)
 	return err
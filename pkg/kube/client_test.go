@@ -27,6 +27,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest/fake"
@@ -197,6 +198,73 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdateServerSideApply(t *testing.T) {
+	listA := newPodList("starfish", "squid")
+	listB := newPodList("starfish", "dolphin")
+
+	var actions []string
+
+	c := newTestClient()
+	c.Factory.(*cmdtesting.TestFactory).UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: unstructuredSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			actions = append(actions, p+":"+m)
+			t.Logf("got request %s %s", p, m)
+			switch {
+			case p == "/namespaces/default/pods/starfish" && m == "GET":
+				return newResponse(200, &listA.Items[0])
+			case p == "/namespaces/default/pods/dolphin" && m == "GET":
+				return newResponse(404, notFoundBody())
+			case m == "PATCH":
+				if ct := req.Header.Get("Content-Type"); ct != string(types.ApplyPatchType) {
+					t.Errorf("expected content type %s, got %s", types.ApplyPatchType, ct)
+				}
+				if fm := req.URL.Query().Get("fieldManager"); fm != "test-manager" {
+					t.Errorf("expected field manager test-manager, got %q", fm)
+				}
+				if p == "/namespaces/default/pods/dolphin" {
+					return newResponse(201, &listB.Items[1])
+				}
+				return newResponse(200, &listB.Items[0])
+			case p == "/namespaces/default/pods/squid" && m == "DELETE":
+				return newResponse(200, &listA.Items[1])
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+	first, err := c.Build(objBody(&listA), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := c.Build(objBody(&listB), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := ApplyOptions{ServerSideApply: true, FieldManager: "test-manager"}
+	if _, err := c.UpdateWithOptions(first, second, opts); err != nil {
+		t.Fatal(err)
+	}
+	expectedActions := []string{
+		"/namespaces/default/pods/starfish:GET",
+		"/namespaces/default/pods/starfish:PATCH",
+		"/namespaces/default/pods/dolphin:GET",
+		"/namespaces/default/pods/dolphin:PATCH",
+		"/namespaces/default/pods/squid:DELETE",
+	}
+	if len(expectedActions) != len(actions) {
+		t.Fatalf("unexpected requests, expected %v, got %v", expectedActions, actions)
+	}
+	for k, v := range expectedActions {
+		if actions[k] != v {
+			t.Errorf("expected %s request got %s", v, actions[k])
+		}
+	}
+}
+
 func TestBuild(t *testing.T) {
 	tests := []struct {
 		name      string
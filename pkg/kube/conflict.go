@@ -0,0 +1,286 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "helm.sh/helm/v3/pkg/kube"
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConflictPolicy describes how server-side apply conflicts are resolved.
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyFail fails the apply when another manager owns a field.
+	ConflictPolicyFail ConflictPolicy = "fail"
+	// ConflictPolicyForce takes ownership of conflicting fields.
+	ConflictPolicyForce ConflictPolicy = "force"
+	// ConflictPolicyIgnore leaves conflicting fields to their current
+	// managers and applies the rest of the object.
+	ConflictPolicyIgnore ConflictPolicy = "ignore"
+)
+
+// Validate checks that the options are consistent.
+func (o ApplyOptions) Validate() error {
+	switch o.ConflictPolicy {
+	case "", ConflictPolicyFail, ConflictPolicyForce, ConflictPolicyIgnore:
+	default:
+		return errors.Errorf("invalid conflict policy %q, must be one of %q, %q or %q", o.ConflictPolicy, ConflictPolicyFail, ConflictPolicyForce, ConflictPolicyIgnore)
+	}
+	if len(o.IgnoreManagers) > 0 && o.ConflictPolicy != ConflictPolicyIgnore {
+		return errors.Errorf("ignored managers require the %q conflict policy", ConflictPolicyIgnore)
+	}
+	if o.Retry.Retries < 0 {
+		return errors.New("the number of retries cannot be negative")
+	}
+	return nil
+}
+
+// dropConflicts removes the fields named by the server-side apply conflicts
+// in err from obj. It reports false if a conflict is with a manager that may
+// not be ignored or names a field that cannot be removed.
+func dropConflicts(obj map[string]interface{}, err error, managers []string) bool {
+	status, ok := err.(apierrors.APIStatus)
+	if !ok || status.Status().Details == nil {
+		return false
+	}
+	dropped := false
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		if len(managers) > 0 && !containsManager(managers, conflictManager(cause.Message)) {
+			return false
+		}
+		if !removeFieldPath(obj, cause.Field) {
+			return false
+		}
+		dropped = true
+	}
+	return dropped
+}
+
+// conflictManager extracts the manager name from a conflict message such as
+// `conflict with "kube-controller-manager" using apps/v1`.
+func conflictManager(message string) string {
+	start := strings.Index(message, `"`)
+	if start < 0 {
+		return ""
+	}
+	end := strings.Index(message[start+1:], `"`)
+	if end < 0 {
+		return ""
+	}
+	return message[start+1 : start+1+end]
+}
+
+func containsManager(managers []string, manager string) bool {
+	for _, m := range managers {
+		if m == manager {
+			return true
+		}
+	}
+	return false
+}
+
+// removeFieldPath removes the field at path from obj. Paths use the notation
+// of server-side apply conflicts, e.g. `.spec.containers[name="app"].image`
+// or `.spec.ports[0]`. It reports whether the field was found and removed.
+func removeFieldPath(obj map[string]interface{}, path string) bool {
+	elems, ok := parseFieldPath(path)
+	if !ok || len(elems) == 0 {
+		return false
+	}
+	_, ok = removeElems(obj, elems)
+	return ok
+}
+
+// removeElems removes the value addressed by elems from node and returns the
+// resulting node.
+func removeElems(node interface{}, elems []pathElement) (interface{}, bool) {
+	elem := elems[0]
+	if elem.field != "" {
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return node, false
+		}
+		child, ok := m[elem.field]
+		if !ok {
+			return node, false
+		}
+		if len(elems) == 1 {
+			delete(m, elem.field)
+			return m, true
+		}
+		child, ok = removeElems(child, elems[1:])
+		m[elem.field] = child
+		return m, ok
+	}
+
+	list, ok := node.([]interface{})
+	if !ok {
+		return node, false
+	}
+	idx := findListItem(list, elem)
+	if idx < 0 {
+		return node, false
+	}
+	if len(elems) == 1 {
+		return append(list[:idx:idx], list[idx+1:]...), true
+	}
+	list[idx], ok = removeElems(list[idx], elems[1:])
+	return list, ok
+}
+
+type pathElement struct {
+	field string
+	index *int
+	keys  map[string]interface{}
+}
+
+func findListItem(list []interface{}, elem pathElement) int {
+	if elem.index != nil {
+		if *elem.index < len(list) {
+			return *elem.index
+		}
+		return -1
+	}
+	for i, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		match := true
+		for k, v := range elem.keys {
+			// Compare through JSON so numbers decoded differently still match.
+			want, _ := json.Marshal(v)
+			got, _ := json.Marshal(m[k])
+			if string(want) != string(got) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseFieldPath splits a conflict field path into its elements.
+func parseFieldPath(path string) ([]pathElement, bool) {
+	var elems []pathElement
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			end := strings.IndexAny(path[1:], ".[")
+			if end < 0 {
+				end = len(path) - 1
+			}
+			name := path[1 : end+1]
+			if name == "" {
+				return nil, false
+			}
+			elems = append(elems, pathElement{field: name})
+			path = path[end+1:]
+		case '[':
+			end := closingBracket(path)
+			if end < 0 {
+				return nil, false
+			}
+			inner := path[1:end]
+			path = path[end+1:]
+			if i, err := strconv.Atoi(inner); err == nil {
+				elems = append(elems, pathElement{index: &i})
+				continue
+			}
+			keys, ok := parseKeys(inner)
+			if !ok {
+				return nil, false
+			}
+			elems = append(elems, pathElement{keys: keys})
+		default:
+			return nil, false
+		}
+	}
+	return elems, true
+}
+
+// closingBracket returns the index of the bracket closing the one at the
+// start of s, skipping over quoted strings.
+func closingBracket(s string) int {
+	quoted := false
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quoted && s[i] == '\\':
+			i++
+		case s[i] == '"':
+			quoted = !quoted
+		case !quoted && s[i] == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// parseKeys parses the key=value pairs identifying an associative list item.
+// Values are JSON encoded.
+func parseKeys(s string) (map[string]interface{}, bool) {
+	keys := map[string]interface{}{}
+	for len(s) > 0 {
+		eq := strings.Index(s, "=")
+		if eq <= 0 {
+			return nil, false
+		}
+		name, rest := s[:eq], s[eq+1:]
+		end := len(rest)
+		if strings.HasPrefix(rest, `"`) {
+			end = closingQuote(rest) + 1
+			if end == 0 {
+				return nil, false
+			}
+		} else if i := strings.Index(rest, ","); i >= 0 {
+			end = i
+		}
+		var v interface{}
+		if err := json.Unmarshal([]byte(rest[:end]), &v); err != nil {
+			return nil, false
+		}
+		keys[name] = v
+		s = strings.TrimPrefix(rest[end:], ",")
+	}
+	return keys, len(keys) > 0
+}
+
+// closingQuote returns the index of the quote closing the one at the start
+// of s.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,166 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"encoding/json"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const conflictTestObject = `{
+  "spec": {
+    "replicas": 3,
+    "template": {
+      "spec": {
+        "containers": [
+          {"name": "app", "image": "app:v1", "ports": [{"containerPort": 80, "protocol": "TCP"}]},
+          {"name": "sidecar", "image": "sidecar:v1"}
+        ]
+      }
+    }
+  }
+}`
+
+func TestRemoveFieldPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		removed  bool
+		expected string
+	}{
+		{
+			path:     ".spec.replicas",
+			removed:  true,
+			expected: `{"spec":{"template":{"spec":{"containers":[{"image":"app:v1","name":"app","ports":[{"containerPort":80,"protocol":"TCP"}]},{"image":"sidecar:v1","name":"sidecar"}]}}}}`,
+		},
+		{
+			path:     `.spec.template.spec.containers[name="sidecar"].image`,
+			removed:  true,
+			expected: `{"spec":{"replicas":3,"template":{"spec":{"containers":[{"image":"app:v1","name":"app","ports":[{"containerPort":80,"protocol":"TCP"}]},{"name":"sidecar"}]}}}}`,
+		},
+		{
+			path:     `.spec.template.spec.containers[name="sidecar"]`,
+			removed:  true,
+			expected: `{"spec":{"replicas":3,"template":{"spec":{"containers":[{"image":"app:v1","name":"app","ports":[{"containerPort":80,"protocol":"TCP"}]}]}}}}`,
+		},
+		{
+			path:     `.spec.template.spec.containers[name="app"].ports[containerPort=80,protocol="TCP"]`,
+			removed:  true,
+			expected: `{"spec":{"replicas":3,"template":{"spec":{"containers":[{"image":"app:v1","name":"app","ports":[]},{"image":"sidecar:v1","name":"sidecar"}]}}}}`,
+		},
+		{
+			path:     ".spec.template.spec.containers[1].image",
+			removed:  true,
+			expected: `{"spec":{"replicas":3,"template":{"spec":{"containers":[{"image":"app:v1","name":"app","ports":[{"containerPort":80,"protocol":"TCP"}]},{"name":"sidecar"}]}}}}`,
+		},
+		{path: ".spec.paused"},
+		{path: `.spec.template.spec.containers[name="missing"].image`},
+		{path: ".spec.template.spec.containers[5]"},
+		{path: "spec"},
+		{path: `.spec.template.spec.containers[="app"]`},
+	}
+
+	for _, tt := range tests {
+		var obj map[string]interface{}
+		if err := json.Unmarshal([]byte(conflictTestObject), &obj); err != nil {
+			t.Fatal(err)
+		}
+		if removed := removeFieldPath(obj, tt.path); removed != tt.removed {
+			t.Errorf("%s: expected removed to be %t, got %t", tt.path, tt.removed, removed)
+			continue
+		}
+		if !tt.removed {
+			continue
+		}
+		got, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", tt.path, tt.expected, got)
+		}
+	}
+}
+
+func newConflictError(causes ...metav1.StatusCause) error {
+	err := apierrors.NewConflict(schema.GroupResource{Resource: "deployments"}, "app", nil)
+	err.ErrStatus.Details.Causes = causes
+	return err
+}
+
+func TestDropConflicts(t *testing.T) {
+	replicas := metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldManagerConflict,
+		Message: `conflict with "hpa-controller" using apps/v1`,
+		Field:   ".spec.replicas",
+	}
+	image := metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldManagerConflict,
+		Message: `conflict with "kubectl"`,
+		Field:   `.spec.template.spec.containers[name="app"].image`,
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		managers []string
+		dropped  bool
+	}{
+		{"any manager", newConflictError(replicas, image), nil, true},
+		{"listed manager", newConflictError(replicas), []string{"hpa-controller"}, true},
+		{"unlisted manager", newConflictError(replicas, image), []string{"hpa-controller"}, false},
+		{"no causes", newConflictError(), nil, false},
+	}
+
+	for _, tt := range tests {
+		var obj map[string]interface{}
+		if err := json.Unmarshal([]byte(conflictTestObject), &obj); err != nil {
+			t.Fatal(err)
+		}
+		if dropped := dropConflicts(obj, tt.err, tt.managers); dropped != tt.dropped {
+			t.Errorf("%s: expected dropped to be %t, got %t", tt.name, tt.dropped, dropped)
+		}
+	}
+}
+
+func TestApplyOptionsValidate(t *testing.T) {
+	valid := []ApplyOptions{
+		{},
+		{ConflictPolicy: ConflictPolicyForce},
+		{ConflictPolicy: ConflictPolicyIgnore, IgnoreManagers: []string{"hpa-controller"}},
+		{Retry: RetryPolicy{Retries: 3}},
+	}
+	for _, opts := range valid {
+		if err := opts.Validate(); err != nil {
+			t.Errorf("expected %+v to be valid, got %s", opts, err)
+		}
+	}
+
+	invalid := []ApplyOptions{
+		{ConflictPolicy: "merge"},
+		{ConflictPolicy: ConflictPolicyFail, IgnoreManagers: []string{"hpa-controller"}},
+		{Retry: RetryPolicy{Retries: -1}},
+	}
+	for _, opts := range invalid {
+		if err := opts.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", opts)
+		}
+	}
+}
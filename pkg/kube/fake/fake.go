@@ -22,6 +22,7 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/resource"
 
 	"helm.sh/helm/v3/pkg/kube"
@@ -40,6 +41,8 @@ type FailingKubeClient struct {
 	BuildError                       error
 	BuildUnstructuredError           error
 	WaitAndGetCompletedPodPhaseError error
+	// WaitEvents are reported by WaitWithEvents before returning WaitError
+	WaitEvents []kube.WaitEvent
 }
 
 // Create returns the configured error if set or prints
@@ -58,6 +61,20 @@ func (f *FailingKubeClient) Wait(resources kube.ResourceList, d time.Duration) e
 	return f.PrintingKubeClient.Wait(resources, d)
 }
 
+// WaitWithEvents reports the configured events and returns the configured
+// error if set or prints
+func (f *FailingKubeClient) WaitWithEvents(resources kube.ResourceList, d time.Duration, onEvent kube.WaitEventFunc) error {
+	if onEvent != nil {
+		for _, e := range f.WaitEvents {
+			onEvent(e)
+		}
+	}
+	if f.WaitError != nil {
+		return f.WaitError
+	}
+	return f.PrintingKubeClient.WaitWithEvents(resources, d, onEvent)
+}
+
 // Delete returns the configured error if set or prints
 func (f *FailingKubeClient) Delete(resources kube.ResourceList) (*kube.Result, []error) {
 	if f.DeleteError != nil {
@@ -66,6 +83,14 @@ func (f *FailingKubeClient) Delete(resources kube.ResourceList) (*kube.Result, [
 	return f.PrintingKubeClient.Delete(resources)
 }
 
+// DeleteWithPropagation returns the configured error if set or prints
+func (f *FailingKubeClient) DeleteWithPropagation(resources kube.ResourceList, policy metav1.DeletionPropagation) (*kube.Result, []error) {
+	if f.DeleteError != nil {
+		return nil, []error{f.DeleteError}
+	}
+	return f.PrintingKubeClient.DeleteWithPropagation(resources, policy)
+}
+
 // WatchUntilReady returns the configured error if set or prints
 func (f *FailingKubeClient) WatchUntilReady(resources kube.ResourceList, d time.Duration) error {
 	if f.WatchUntilReadyError != nil {
@@ -82,6 +107,22 @@ func (f *FailingKubeClient) Update(r, modified kube.ResourceList, ignoreMe bool)
 	return f.PrintingKubeClient.Update(r, modified, ignoreMe)
 }
 
+// CreateWithOptions returns the configured error if set or prints
+func (f *FailingKubeClient) CreateWithOptions(resources kube.ResourceList, opts kube.ApplyOptions) (*kube.Result, error) {
+	if f.CreateError != nil {
+		return nil, f.CreateError
+	}
+	return f.PrintingKubeClient.CreateWithOptions(resources, opts)
+}
+
+// UpdateWithOptions returns the configured error if set or prints
+func (f *FailingKubeClient) UpdateWithOptions(r, modified kube.ResourceList, opts kube.ApplyOptions) (*kube.Result, error) {
+	if f.UpdateError != nil {
+		return &kube.Result{}, f.UpdateError
+	}
+	return f.PrintingKubeClient.UpdateWithOptions(r, modified, opts)
+}
+
 // Build returns the configured error if set or prints
 func (f *FailingKubeClient) Build(r io.Reader, _ bool) (kube.ResourceList, error) {
 	if f.BuildError != nil {
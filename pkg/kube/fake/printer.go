@@ -22,6 +22,8 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/resource"
 
 	"helm.sh/helm/v3/pkg/kube"
@@ -63,6 +65,13 @@ func (p *PrintingKubeClient) Delete(resources kube.ResourceList) (*kube.Result,
 	return &kube.Result{Deleted: resources}, nil
 }
 
+// DeleteWithPropagation implements KubeClient DeleteWithPropagation.
+//
+// It only prints out the content to be deleted.
+func (p *PrintingKubeClient) DeleteWithPropagation(resources kube.ResourceList, _ metav1.DeletionPropagation) (*kube.Result, []error) {
+	return p.Delete(resources)
+}
+
 // WatchUntilReady implements KubeClient WatchUntilReady.
 func (p *PrintingKubeClient) WatchUntilReady(resources kube.ResourceList, _ time.Duration) error {
 	_, err := io.Copy(p.Out, bufferize(resources))
@@ -81,6 +90,31 @@ func (p *PrintingKubeClient) Update(_, modified kube.ResourceList, _ bool) (*kub
 	return &kube.Result{Updated: modified}, nil
 }
 
+// CreateWithOptions implements kube.Applier CreateWithOptions.
+func (p *PrintingKubeClient) CreateWithOptions(resources kube.ResourceList, _ kube.ApplyOptions) (*kube.Result, error) {
+	return p.Create(resources)
+}
+
+// UpdateWithOptions implements kube.Applier UpdateWithOptions.
+func (p *PrintingKubeClient) UpdateWithOptions(original, modified kube.ResourceList, opts kube.ApplyOptions) (*kube.Result, error) {
+	return p.Update(original, modified, opts.Force)
+}
+
+// WaitWithEvents implements kube.EventWaiter WaitWithEvents.
+func (p *PrintingKubeClient) WaitWithEvents(resources kube.ResourceList, d time.Duration, _ kube.WaitEventFunc) error {
+	return p.Wait(resources, d)
+}
+
+// Fetch implements kube.Fetcher Fetch. It reports the given objects as the
+// live state of the resources.
+func (p *PrintingKubeClient) Fetch(resources kube.ResourceList) ([]runtime.Object, error) {
+	objs := make([]runtime.Object, len(resources))
+	for i, info := range resources {
+		objs[i] = info.Object
+	}
+	return objs, nil
+}
+
 // Build implements KubeClient Build.
 func (p *PrintingKubeClient) Build(_ io.Reader, _ bool) (kube.ResourceList, error) {
 	return []*resource.Info{}, nil
@@ -21,6 +21,9 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kubectl/pkg/util/openapi"
 )
 
 // Interface represents a client capable of communicating with the Kubernetes API.
@@ -63,4 +66,73 @@ type Interface interface {
 	IsReachable() error
 }
 
+// ApplyOptions controls how resources are created and updated.
+type ApplyOptions struct {
+	// Force replaces resources through a replacement strategy rather than
+	// patching them. It takes precedence over ServerSideApply for updates.
+	Force bool
+	// ServerSideApply creates and updates resources with server-side apply
+	// instead of a client-side three-way merge patch.
+	ServerSideApply bool
+	// FieldManager is the name recorded as the manager of applied fields
+	// when ServerSideApply is set.
+	FieldManager string
+	// ConflictPolicy decides what happens when server-side apply finds
+	// fields owned by another manager. It defaults to ConflictPolicyFail.
+	ConflictPolicy ConflictPolicy
+	// IgnoreManagers lists the managers whose fields are left untouched
+	// under ConflictPolicyIgnore. If empty, conflicts with any manager are
+	// ignored.
+	IgnoreManagers []string
+	// Retry decides how creating or updating a resource is retried after a
+	// transient error. Nothing is retried by default.
+	Retry RetryPolicy
+}
+
+// Applier is implemented by clients that can create and update resources
+// according to ApplyOptions.
+type Applier interface {
+	// CreateWithOptions creates one or more resources.
+	CreateWithOptions(resources ResourceList, opts ApplyOptions) (*Result, error)
+
+	// UpdateWithOptions updates one or more resources or creates the
+	// resource if it doesn't exist.
+	UpdateWithOptions(original, target ResourceList, opts ApplyOptions) (*Result, error)
+}
+
+// Fetcher is implemented by clients that can read the live state of
+// resources from the cluster.
+type Fetcher interface {
+	// Fetch returns the live object for each resource, in the same order.
+	// Resources that do not exist in the cluster have a nil entry.
+	Fetch(resources ResourceList) ([]runtime.Object, error)
+}
+
+// EventWaiter is implemented by clients that can report why resources are
+// not becoming ready while waiting for them.
+type EventWaiter interface {
+	// WaitWithEvents waits like Interface.Wait, calling onEvent with each
+	// distinct problem observed.
+	WaitWithEvents(resources ResourceList, timeout time.Duration, onEvent WaitEventFunc) error
+}
+
+// SchemaGetter is implemented by clients that can provide the OpenAPI schema
+// of the cluster.
+type SchemaGetter interface {
+	OpenAPISchema() (openapi.Resources, error)
+}
+
+// PropagationDeleter is implemented by clients that can choose what happens
+// to the dependents of deleted resources.
+type PropagationDeleter interface {
+	// DeleteWithPropagation destroys one or more resources using the given
+	// deletion propagation policy.
+	DeleteWithPropagation(resources ResourceList, policy metav1.DeletionPropagation) (*Result, []error)
+}
+
 var _ Interface = (*Client)(nil)
+var _ Applier = (*Client)(nil)
+var _ Fetcher = (*Client)(nil)
+var _ EventWaiter = (*Client)(nil)
+var _ SchemaGetter = (*Client)(nil)
+var _ PropagationDeleter = (*Client)(nil)
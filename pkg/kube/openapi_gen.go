@@ -0,0 +1,137 @@
+// Copyright The Helm Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build ignore
+
+// openapi_gen generates openapi_bundled.go from the OpenAPI schema of a
+// Kubernetes release, api/openapi-spec/swagger.json in the Kubernetes
+// repository:
+//
+//	go run openapi_gen.go v1.17.2 path/to/swagger.json
+//
+// Only the definitions are kept, without their descriptions, as manifests are
+// validated against nothing else.
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+)
+
+const header = `/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by openapi_gen.go from the OpenAPI schema of Kubernetes %s. DO NOT EDIT.
+
+package kube // import "helm.sh/helm/v3/pkg/kube"
+
+// bundledOpenAPISchemaVersion is the Kubernetes release bundledOpenAPISchema
+// comes from.
+const bundledOpenAPISchemaVersion = %q
+
+// bundledOpenAPISchema is the gzipped OpenAPI v2 schema of the definitions of
+// Kubernetes %[1]s, without their descriptions.
+const bundledOpenAPISchema = "`
+
+func main() {
+	if len(os.Args) != 3 {
+		log.Fatal("usage: go run openapi_gen.go VERSION path/to/swagger.json")
+	}
+	version := os.Args[1]
+	data, err := ioutil.ReadFile(os.Args[2])
+	if err != nil {
+		log.Fatal(err)
+	}
+	var doc struct {
+		Swagger     string                 `json:"swagger"`
+		Info        map[string]interface{} `json:"info"`
+		Definitions map[string]interface{} `json:"definitions"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		log.Fatal(err)
+	}
+	min, err := json.Marshal(map[string]interface{}{
+		"swagger":     doc.Swagger,
+		"info":        doc.Info,
+		"paths":       map[string]interface{}{},
+		"definitions": stripDescriptions(doc.Definitions),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var gz bytes.Buffer
+	w, err := gzip.NewWriterLevel(&gz, gzip.BestCompression)
+	if err != nil {
+		log.Fatal(err)
+	}
+	w.Write(min)
+	if err := w.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, header, version, version)
+	for i, b := range gz.Bytes() {
+		if i > 0 && i%32 == 0 {
+			out.WriteString("\" +\n\t\"")
+		}
+		fmt.Fprintf(&out, "\\x%02x", b)
+	}
+	out.WriteString("\"\n")
+	if err := ioutil.WriteFile("openapi_bundled.go", []byte(out.String()), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// stripDescriptions removes the descriptions from a JSON value, leaving
+// properties named description alone.
+func stripDescriptions(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			if _, ok := item.(string); ok && k == "description" {
+				continue
+			}
+			out[k] = stripDescriptions(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = stripDescriptions(item)
+		}
+		return out
+	}
+	return v
+}
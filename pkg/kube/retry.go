@@ -0,0 +1,109 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "helm.sh/helm/v3/pkg/kube"
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultRetryDelay is the delay before the first retry when none is
+	// given.
+	DefaultRetryDelay = 500 * time.Millisecond
+	// DefaultRetryMaxDelay caps the delay between retries when no cap is
+	// given.
+	DefaultRetryMaxDelay = 10 * time.Second
+)
+
+// RetryPolicy decides how creating or updating a resource is retried after a
+// transient error: a conflict with a concurrent change of the resource, or an
+// admission webhook that timed out or could not be reached.
+//
+// Delays double from Delay up to MaxDelay, and each one is randomly shortened
+// by up to half so that clients retrying together spread out.
+type RetryPolicy struct {
+	// Retries is the number of times an operation is retried. Zero disables
+	// retries.
+	Retries int
+	// Delay is the delay before the first retry. It defaults to
+	// DefaultRetryDelay.
+	Delay time.Duration
+	// MaxDelay caps the delay between retries. It defaults to
+	// DefaultRetryMaxDelay.
+	MaxDelay time.Duration
+}
+
+// retrySleep waits between retries. Tests replace it.
+var retrySleep = time.Sleep
+
+// do runs fn until it succeeds, fails with an error that is not transient or
+// has been retried as many times as the policy allows.
+func (p RetryPolicy) do(log func(string, ...interface{}), fn func() error) error {
+	delay, maxDelay := p.Delay, p.MaxDelay
+	if delay <= 0 {
+		delay = DefaultRetryDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= p.Retries || !IsTransient(err) {
+			return err
+		}
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+		wait := delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+		log("retrying in %s after a transient error (%d/%d): %s", wait, attempt+1, p.Retries, err)
+		retrySleep(wait)
+		delay *= 2
+	}
+}
+
+// IsTransient reports whether err, which may be wrapped, is a conflict with a
+// concurrent change or a failure to get an answer from an admission webhook
+// in time. Conflicts between field managers of server-side apply are not
+// transient.
+func IsTransient(err error) bool {
+	err = errors.Cause(err)
+	switch {
+	case apierrors.IsConflict(err):
+		status, ok := err.(apierrors.APIStatus)
+		if ok && status.Status().Details != nil {
+			for _, cause := range status.Status().Details.Causes {
+				if cause.Type == metav1.CauseTypeFieldManagerConflict {
+					return false
+				}
+			}
+		}
+		return true
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err):
+		return true
+	case apierrors.IsInternalError(err):
+		// The API server reports webhooks that time out or cannot be
+		// reached as internal errors.
+		return strings.Contains(err.Error(), "failed calling webhook")
+	}
+	return false
+}
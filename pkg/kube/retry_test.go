@@ -0,0 +1,153 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest/fake"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+)
+
+// stubRetrySleep records the delays between retries instead of sleeping and
+// returns a function restoring the sleep.
+func stubRetrySleep(delays *[]time.Duration) func() {
+	sleep := retrySleep
+	retrySleep = func(d time.Duration) { *delays = append(*delays, d) }
+	return func() { retrySleep = sleep }
+}
+
+func TestIsTransient(t *testing.T) {
+	pods := schema.GroupResource{Resource: "pods"}
+	fieldConflict := apierrors.NewApplyConflict([]metav1.StatusCause{{
+		Type:  metav1.CauseTypeFieldManagerConflict,
+		Field: ".spec.replicas",
+	}}, "conflict with \"kubectl\"")
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"conflict", apierrors.NewConflict(pods, "web", errors.New("object has been modified")), true},
+		{"wrapped conflict", errors.Wrap(apierrors.NewConflict(pods, "web", errors.New("object has been modified")), "failed"), true},
+		{"field manager conflict", fieldConflict, false},
+		{"timeout", apierrors.NewTimeoutError("timed out", 1), true},
+		{"server timeout", apierrors.NewServerTimeout(pods, "create", 1), true},
+		{"webhook", apierrors.NewInternalError(errors.New(`failed calling webhook "validate.example.com": context deadline exceeded`)), true},
+		{"internal error", apierrors.NewInternalError(errors.New("etcd is down")), false},
+		{"already exists", apierrors.NewAlreadyExists(pods, "web"), false},
+		{"other", errors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		if got := IsTransient(tt.err); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	var delays []time.Duration
+	defer stubRetrySleep(&delays)()
+
+	conflict := apierrors.NewConflict(schema.GroupResource{Resource: "pods"}, "web", errors.New("object has been modified"))
+	calls := 0
+	p := RetryPolicy{Retries: 4, Delay: time.Second, MaxDelay: 3 * time.Second}
+	err := p.do(nopLogger, func() error {
+		calls++
+		return conflict
+	})
+	if err != conflict {
+		t.Fatalf("expected the last error, got %v", err)
+	}
+	if calls != 5 {
+		t.Errorf("expected 5 calls, got %d", calls)
+	}
+	// Delays double up to the cap, and are shortened by up to half.
+	caps := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}
+	if len(delays) != len(caps) {
+		t.Fatalf("expected %d delays, got %v", len(caps), delays)
+	}
+	for i, d := range delays {
+		if d < caps[i]/2 || d > caps[i] {
+			t.Errorf("delay %d: expected between %s and %s, got %s", i, caps[i]/2, caps[i], d)
+		}
+	}
+
+	// Errors that are not transient are not retried.
+	calls, delays = 0, nil
+	err = p.do(nopLogger, func() error {
+		calls++
+		return errors.New("boom")
+	})
+	if err == nil || calls != 1 || len(delays) != 0 {
+		t.Errorf("expected a single call, got %d calls and error %v", calls, err)
+	}
+
+	// Nothing is retried by default.
+	calls = 0
+	RetryPolicy{}.do(nopLogger, func() error {
+		calls++
+		return conflict
+	})
+	if calls != 1 {
+		t.Errorf("expected a single call, got %d", calls)
+	}
+}
+
+func TestCreateRetry(t *testing.T) {
+	var delays []time.Duration
+	defer stubRetrySleep(&delays)()
+
+	pod := newPod("starfish")
+	attempts := 0
+	c := newTestClient()
+	c.Factory.(*cmdtesting.TestFactory).UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: unstructuredSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/namespaces/default/pods" || req.Method != "POST" {
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+			}
+			attempts++
+			if attempts < 3 {
+				status := apierrors.NewInternalError(errors.New(`failed calling webhook "validate.example.com": context deadline exceeded`)).Status()
+				return newResponse(500, &status)
+			}
+			return newResponse(201, &pod)
+		}),
+	}
+	resources, err := c.Build(objBody(&pod), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Create(resources); err == nil {
+		t.Fatal("expected an error without retries")
+	}
+	attempts = 0
+	if _, err := c.CreateWithOptions(resources, ApplyOptions{Retry: RetryPolicy{Retries: 2}}); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 3 || len(delays) != 2 {
+		t.Errorf("expected 3 attempts and 2 delays, got %d and %v", attempts, delays)
+	}
+}
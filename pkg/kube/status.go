@@ -0,0 +1,68 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "helm.sh/helm/v3/pkg/kube"
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	kstatus "sigs.k8s.io/cli-utils/pkg/kstatus/status"
+)
+
+// ResourceStatus is the health of a live resource, as computed by the kstatus
+// library of sigs.k8s.io/cli-utils.
+type ResourceStatus string
+
+const (
+	// StatusCurrent means the resource is reconciled and ready.
+	StatusCurrent = ResourceStatus(kstatus.CurrentStatus)
+	// StatusInProgress means the resource is being reconciled.
+	StatusInProgress = ResourceStatus(kstatus.InProgressStatus)
+	// StatusFailed means the resource will not become ready without
+	// intervention.
+	StatusFailed = ResourceStatus(kstatus.FailedStatus)
+	// StatusTerminating means the resource is being deleted.
+	StatusTerminating = ResourceStatus(kstatus.TerminatingStatus)
+	// StatusNotFound means the resource does not exist in the cluster.
+	StatusNotFound = ResourceStatus(kstatus.NotFoundStatus)
+	// StatusUnknown means the status could not be computed.
+	StatusUnknown = ResourceStatus(kstatus.UnknownStatus)
+)
+
+// ComputeStatus returns the status of the live object obj and a message
+// explaining it. A nil obj is NotFound.
+//
+// Well-known kinds are checked against their own status fields. Other kinds,
+// such as custom resources, are checked against the Ready, Reconciling and
+// Stalled conditions they follow by convention, and are Current without them.
+func ComputeStatus(obj runtime.Object) (ResourceStatus, string, error) {
+	if obj == nil {
+		return StatusNotFound, "Resource not found", nil
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return StatusUnknown, "", err
+		}
+		u = &unstructured.Unstructured{Object: content}
+	}
+	res, err := kstatus.Compute(u)
+	if err != nil {
+		return StatusUnknown, "", err
+	}
+	return ResourceStatus(res.Status), res.Message, nil
+}
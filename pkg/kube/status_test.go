@@ -0,0 +1,166 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "helm.sh/helm/v3/pkg/kube"
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func TestComputeStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     string
+		status  ResourceStatus
+		message string
+	}{{
+		name: "available deployment",
+		obj: `apiVersion: apps/v1
+kind: Deployment
+metadata: {name: web, generation: 2}
+spec: {replicas: 2}
+status:
+  observedGeneration: 2
+  replicas: 2
+  updatedReplicas: 2
+  readyReplicas: 2
+  availableReplicas: 2
+  conditions:
+  - {type: Available, status: "True"}
+  - {type: Progressing, status: "True", reason: NewReplicaSetAvailable}`,
+		status:  StatusCurrent,
+		message: "Deployment is available. Replicas: 2",
+	}, {
+		name: "rolling deployment",
+		obj: `apiVersion: apps/v1
+kind: Deployment
+metadata: {name: web, generation: 2}
+spec: {replicas: 2}
+status: {observedGeneration: 2, replicas: 3, updatedReplicas: 2, readyReplicas: 2, availableReplicas: 2}`,
+		status:  StatusInProgress,
+		message: "Pending termination: 1",
+	}, {
+		name: "unobserved generation",
+		obj: `apiVersion: apps/v1
+kind: Deployment
+metadata: {name: web, generation: 3}
+status: {observedGeneration: 2}`,
+		status:  StatusInProgress,
+		message: "Deployment generation is 3, but latest observed generation is 2",
+	}, {
+		name: "stuck deployment",
+		obj: `apiVersion: apps/v1
+kind: Deployment
+metadata: {name: web}
+status:
+  conditions:
+  - {type: Progressing, status: "False", reason: ProgressDeadlineExceeded}`,
+		status:  StatusFailed,
+		message: "Progress deadline exceeded",
+	}, {
+		name: "terminating",
+		obj: `apiVersion: v1
+kind: ConfigMap
+metadata: {name: settings, deletionTimestamp: "2020-01-01T00:00:00Z"}`,
+		status:  StatusTerminating,
+		message: "Resource scheduled for deletion",
+	}, {
+		name: "crash looping pod",
+		obj: `apiVersion: v1
+kind: Pod
+metadata: {name: web}
+status:
+  phase: Running
+  containerStatuses:
+  - name: web
+    state: {waiting: {reason: CrashLoopBackOff}}`,
+		status:  StatusFailed,
+		message: "Containers in CrashLoop state: web",
+	}, {
+		name: "failed job",
+		obj: `apiVersion: batch/v1
+kind: Job
+metadata: {name: migrate}
+status:
+  conditions:
+  - {type: Failed, status: "True", reason: BackoffLimitExceeded, message: Job has reached the specified backoff limit}`,
+		status:  StatusFailed,
+		message: "Job Failed. failed: 0/1",
+	}, {
+		name: "pending load balancer",
+		obj: `apiVersion: v1
+kind: Service
+metadata: {name: web}
+spec: {type: LoadBalancer}`,
+		status:  StatusInProgress,
+		message: "ClusterIP not set. Service type: LoadBalancer",
+	}, {
+		name: "custom resource not ready",
+		obj: `apiVersion: example.com/v1
+kind: Database
+metadata: {name: db}
+status:
+  conditions:
+  - {type: Reconciling, status: "True", reason: Provisioning, message: Creating the database}`,
+		status:  StatusInProgress,
+		message: "Creating the database",
+	}, {
+		name: "custom resource without conditions",
+		obj: `apiVersion: example.com/v1
+kind: Database
+metadata: {name: db}`,
+		status:  StatusCurrent,
+		message: "Resource is current",
+	}}
+	for _, tt := range tests {
+		// Decode numbers as int64, as the Kubernetes client does.
+		data, err := yaml.YAMLToJSON([]byte(tt.obj))
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(data); err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		status, message, err := ComputeStatus(obj)
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if status != tt.status || message != tt.message {
+			t.Errorf("%s: expected %s %q, got %s %q", tt.name, tt.status, tt.message, status, message)
+		}
+	}
+}
+
+func TestComputeStatusTyped(t *testing.T) {
+	pvc := &corev1.PersistentVolumeClaim{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"},
+		ObjectMeta: metav1.ObjectMeta{Name: "data"},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+	}
+	if status, _, err := ComputeStatus(pvc); err != nil || status != StatusCurrent {
+		t.Errorf("expected a bound PVC to be Current, got %s (%v)", status, err)
+	}
+	if status, _, err := ComputeStatus(nil); err != nil || status != StatusNotFound {
+		t.Errorf("expected a missing resource to be NotFound, got %s (%v)", status, err)
+	}
+}
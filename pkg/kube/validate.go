@@ -0,0 +1,95 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "helm.sh/helm/v3/pkg/kube"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+
+	openapi_v2 "github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/pkg/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/kubectl/pkg/util/openapi"
+	"k8s.io/kubectl/pkg/util/openapi/validation"
+)
+
+// OpenAPISchema returns the OpenAPI schema served by the cluster, which
+// includes the schemas of installed CustomResourceDefinitions.
+func (c *Client) OpenAPISchema() (openapi.Resources, error) {
+	f, ok := c.Factory.(interface {
+		OpenAPISchema() (openapi.Resources, error)
+	})
+	if !ok {
+		return nil, errors.New("the Kubernetes client factory does not provide an OpenAPI schema")
+	}
+	return f.OpenAPISchema()
+}
+
+// LoadOpenAPISchema reads an OpenAPI v2 document, such as the
+// /openapi/v2 document served by the Kubernetes API server, from a file.
+func LoadOpenAPISchema(path string) (openapi.Resources, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read OpenAPI schema")
+	}
+	return ParseOpenAPISchema(path, data)
+}
+
+// BundledOpenAPISchema returns the OpenAPI schema of the Kubernetes release
+// bundled with Helm, to validate manifests without a cluster. It has no
+// schema for custom resources.
+func BundledOpenAPISchema() (openapi.Resources, error) {
+	r, err := gzip.NewReader(bytes.NewReader([]byte(bundledOpenAPISchema)))
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOpenAPISchema("Kubernetes "+bundledOpenAPISchemaVersion, data)
+}
+
+// ParseOpenAPISchema parses an OpenAPI v2 document in JSON or YAML format.
+// The name is used in error messages.
+func ParseOpenAPISchema(name string, data []byte) (openapi.Resources, error) {
+	info, err := compiler.ReadInfoFromBytes(name, data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse OpenAPI schema %s", name)
+	}
+	doc, err := openapi_v2.NewDocument(info, compiler.NewContext("$root", nil))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid OpenAPI schema %s", name)
+	}
+	return openapi.NewOpenAPIData(doc)
+}
+
+// ValidateManifest validates a single YAML document against schema and
+// returns each problem found, such as unknown fields or values of the wrong
+// type. Kinds missing from the schema are not validated.
+func ValidateManifest(schema openapi.Resources, data []byte) []error {
+	err := validation.NewSchemaValidation(schema).ValidateBytes(data)
+	if err == nil {
+		return nil
+	}
+	if agg, ok := err.(utilerrors.Aggregate); ok {
+		return utilerrors.Flatten(agg).Errors()
+	}
+	return []error{err}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"strings"
+	"testing"
+)
+
+const testOpenAPISchema = `swagger: "2.0"
+info:
+  title: Kubernetes
+  version: v1.17.0
+paths: {}
+definitions:
+  io.k8s.api.core.v1.ConfigMap:
+    type: object
+    properties:
+      apiVersion:
+        type: string
+      kind:
+        type: string
+      data:
+        type: object
+        additionalProperties:
+          type: string
+    x-kubernetes-group-version-kind:
+    - group: ""
+      kind: ConfigMap
+      version: v1
+`
+
+func TestValidateManifest(t *testing.T) {
+	schema, err := ParseOpenAPISchema("test", []byte(testOpenAPISchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := "apiVersion: v1\nkind: ConfigMap\ndata:\n  key: value\n"
+	if errs := ValidateManifest(schema, []byte(valid)); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+
+	// Kinds unknown to the schema are not validated.
+	unknown := "apiVersion: example.com/v1\nkind: Widget\nsize: large\n"
+	if errs := ValidateManifest(schema, []byte(unknown)); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+
+	invalid := "apiVersion: v1\nkind: ConfigMap\ndata: [1, 2]\ndatta:\n  key: value\n"
+	errs := ValidateManifest(schema, []byte(invalid))
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	var messages []string
+	for _, e := range errs {
+		messages = append(messages, e.Error())
+	}
+	all := strings.Join(messages, "\n")
+	for _, want := range []string{`unknown field "datta"`, "ConfigMap.data"} {
+		if !strings.Contains(all, want) {
+			t.Errorf("expected errors to contain %q, got\n%s", want, all)
+		}
+	}
+}
+
+func TestBundledOpenAPISchema(t *testing.T) {
+	schema, err := BundledOpenAPISchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\nspec:\n  selector:\n    matchLabels:\n      app: web\n  template:\n    metadata:\n      labels:\n        app: web\n    spec:\n      containers:\n      - name: web\n        image: nginx\n"
+	if errs := ValidateManifest(schema, []byte(valid)); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+
+	invalid := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\ndatta:\n  key: value\n"
+	errs := ValidateManifest(schema, []byte(invalid))
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `unknown field "datta"`) {
+		t.Errorf("expected an unknown field error, got %v", errs)
+	}
+}
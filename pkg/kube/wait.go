@@ -40,10 +40,93 @@ import (
 	deploymentutil "helm.sh/helm/v3/internal/third_party/k8s.io/kubernetes/deployment/util"
 )
 
+// WaitEvent describes a problem observed while waiting for resources to
+// become ready, such as a pod that cannot be scheduled or pull its image.
+type WaitEvent struct {
+	// Kind, Namespace and Name identify the object the event is about.
+	Kind      string
+	Namespace string
+	Name      string
+	// Reason is a short, machine readable reason like "ImagePullBackOff".
+	Reason string
+	// Message is a human readable description of the problem.
+	Message string
+}
+
+func (e WaitEvent) String() string {
+	s := fmt.Sprintf("%s %s/%s: %s", e.Kind, e.Namespace, e.Name, e.Reason)
+	if e.Message != "" {
+		s += ": " + e.Message
+	}
+	return s
+}
+
+// WaitEventFunc is called with each distinct problem observed during a wait.
+type WaitEventFunc func(WaitEvent)
+
+// podWaitingReasons are container waiting reasons that keep a pod from
+// becoming ready without intervention.
+var podWaitingReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
 type waiter struct {
 	c       kubernetes.Interface
 	timeout time.Duration
 	log     func(string, ...interface{})
+	onEvent WaitEventFunc
+	seen    map[WaitEvent]bool
+}
+
+// event reports a problem once per wait.
+func (w *waiter) event(e WaitEvent) {
+	if w.onEvent == nil || w.seen[e] {
+		return
+	}
+	if w.seen == nil {
+		w.seen = make(map[WaitEvent]bool)
+	}
+	w.seen[e] = true
+	w.onEvent(e)
+}
+
+// inspectPod reports the reasons a pod is not becoming ready.
+func (w *waiter) inspectPod(pod *corev1.Pod) {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse {
+			w.event(WaitEvent{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name, Reason: c.Reason, Message: c.Message})
+		}
+	}
+	var statuses []corev1.ContainerStatus
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, s := range statuses {
+		if s.State.Waiting != nil && podWaitingReasons[s.State.Waiting.Reason] {
+			w.event(WaitEvent{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name, Reason: s.State.Waiting.Reason, Message: s.State.Waiting.Message})
+		}
+	}
+}
+
+// inspectPodsForObject reports the reasons the pods managed by obj are not
+// becoming ready.
+func (w *waiter) inspectPodsForObject(namespace string, obj runtime.Object) {
+	if w.onEvent == nil {
+		return
+	}
+	pods, err := w.podsforObject(namespace, obj)
+	if err != nil {
+		w.log("unable to list pods for %s/%T: %s", namespace, obj, err)
+		return
+	}
+	for i := range pods {
+		w.inspectPod(&pods[i])
+	}
 }
 
 // waitForResources polls to get the current status of all pods, PVCs, and Services
@@ -81,6 +164,7 @@ func (w *waiter) waitForResources(created ResourceList) error {
 					return false, err
 				}
 				if !w.deploymentReady(newReplicaSet, currentDeployment) {
+					w.inspectPodsForObject(v.Namespace, newReplicaSet)
 					return false, nil
 				}
 			case *corev1.PersistentVolumeClaim:
@@ -105,6 +189,7 @@ func (w *waiter) waitForResources(created ResourceList) error {
 					return false, err
 				}
 				if !w.daemonSetReady(ds) {
+					w.inspectPodsForObject(v.Namespace, ds)
 					return false, nil
 				}
 			case *apiextv1beta1.CustomResourceDefinition:
@@ -135,6 +220,7 @@ func (w *waiter) waitForResources(created ResourceList) error {
 					return false, err
 				}
 				if !w.statefulSetReady(sts) {
+					w.inspectPodsForObject(v.Namespace, sts)
 					return false, nil
 				}
 			case *corev1.ReplicationController, *extensionsv1beta1.ReplicaSet, *appsv1beta2.ReplicaSet, *appsv1.ReplicaSet:
@@ -178,6 +264,7 @@ func (w *waiter) isPodReady(pod *corev1.Pod) bool {
 		}
 	}
 	w.log("Pod is not ready: %s/%s", pod.GetNamespace(), pod.GetName())
+	w.inspectPod(pod)
 	return false
 }
 
@@ -0,0 +1,66 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWaiterReportsPodProblems(t *testing.T) {
+	var events []WaitEvent
+	w := &waiter{
+		log:     nopLogger,
+		onEvent: func(e WaitEvent) { events = append(events, e) },
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: "Unschedulable", Message: "0/3 nodes are available"},
+			},
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{Name: "init", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}}},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "web", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"}}},
+			},
+		},
+	}
+
+	// Polling the same pod again must not repeat the events.
+	for i := 0; i < 2; i++ {
+		if w.isPodReady(pod) {
+			t.Fatal("expected pod not to be ready")
+		}
+	}
+
+	expected := []WaitEvent{
+		{Kind: "Pod", Namespace: "default", Name: "web-1", Reason: "Unschedulable", Message: "0/3 nodes are available"},
+		{Kind: "Pod", Namespace: "default", Name: "web-1", Reason: "ImagePullBackOff", Message: "Back-off pulling image"},
+	}
+	if !reflect.DeepEqual(expected, events) {
+		t.Errorf("expected events %v, got %v", expected, events)
+	}
+	if s := events[1].String(); s != "Pod default/web-1: ImagePullBackOff: Back-off pulling image" {
+		t.Errorf("unexpected event string %q", s)
+	}
+}
@@ -19,18 +19,41 @@ package lint // import "helm.sh/helm/v3/pkg/lint"
 import (
 	"path/filepath"
 
+	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/lint/rules"
 	"helm.sh/helm/v3/pkg/lint/support"
 )
 
 // All runs all of the available linters on the given base directory.
 func All(basedir string, values map[string]interface{}, namespace string, strict bool) support.Linter {
+	return AllWithKubeVersion(basedir, values, namespace, nil, strict)
+}
+
+// AllWithKubeVersion runs all of the available linters on the given base
+// directory, rendering the templates for Kubernetes version kubeVersion.
+//
+// If kubeVersion is set, the templates are also checked for APIs deprecated
+// or removed in that version.
+func AllWithKubeVersion(basedir string, values map[string]interface{}, namespace string, kubeVersion *chartutil.KubeVersion, strict bool) support.Linter {
 	// Using abs path to get directory context
 	chartDir, _ := filepath.Abs(basedir)
 
 	linter := support.Linter{ChartDir: chartDir}
 	rules.Chartfile(&linter)
 	rules.Values(&linter)
-	rules.Templates(&linter, values, namespace, strict)
+	rules.TemplatesWithKubeVersion(&linter, values, namespace, kubeVersion, strict)
 	return linter
 }
+
+// Fix corrects the fixable lint findings of the chart in the given base
+// directory, and returns the fixes made.
+func Fix(basedir string) ([]support.Fix, error) {
+	chartDir, _ := filepath.Abs(basedir)
+
+	fixes, err := rules.FixChartfile(chartDir)
+	if err != nil {
+		return fixes, err
+	}
+	templateFixes, err := rules.FixTemplates(chartDir)
+	return append(fixes, templateFixes...), err
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/asaskevich/govalidator"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chartutil"
@@ -46,16 +47,21 @@ func Chartfile(linter *support.Linter) {
 	}
 
 	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartName(chartFile))
+	linter.RunLinterRule(support.InfoSev, chartFileName, validateChartNameDirMatch(linter.ChartDir, chartFile))
 
 	// Chart metadata
 	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartAPIVersion(chartFile))
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartVersion(chartFile))
+	if linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartVersion(chartFile)) {
+		linter.RunLinterRule(support.InfoSev, chartFileName, validateChartVersionFormat(chartFile))
+	}
 	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartMaintainer(chartFile))
 	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartSources(chartFile))
 	linter.RunLinterRule(support.InfoSev, chartFileName, validateChartIconPresence(chartFile))
 	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartIconURL(chartFile))
 	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartType(chartFile))
 	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartDependencies(chartFile))
+	linter.RunLinterRule(support.WarningSev, chartFileName, validateChartReplacedBy(chartFile))
+	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartNamespace(chartFile))
 }
 
 func validateChartYamlNotDirectory(chartPath string) error {
@@ -81,6 +87,13 @@ func validateChartName(cf *chart.Metadata) error {
 	return nil
 }
 
+func validateChartNameDirMatch(chartDir string, cf *chart.Metadata) error {
+	if cf.Name != "" && cf.Name != filepath.Base(chartDir) {
+		return errors.Errorf("chart name '%s' does not match the chart directory '%s'", cf.Name, filepath.Base(chartDir))
+	}
+	return nil
+}
+
 func validateChartAPIVersion(cf *chart.Metadata) error {
 	if cf.APIVersion == "" {
 		return errors.New("apiVersion is required. The value must be either \"v1\" or \"v2\"")
@@ -117,6 +130,25 @@ func validateChartVersion(cf *chart.Metadata) error {
 	return nil
 }
 
+// validateChartVersionFormat checks that a valid version is written in the
+// canonical SemVer 2 form, such as "1.2.0" rather than "v1.2".
+func validateChartVersionFormat(cf *chart.Metadata) error {
+	if version := canonicalVersion(cf.Version); version != cf.Version {
+		return errors.Errorf("version '%s' is not in SemVer 2 form, use '%s'", cf.Version, version)
+	}
+	return nil
+}
+
+// canonicalVersion returns the SemVer 2 form of version, or version itself
+// if it cannot be parsed.
+func canonicalVersion(version string) string {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return version
+	}
+	return v.String()
+}
+
 func validateChartMaintainer(cf *chart.Metadata) error {
 	for _, maintainer := range cf.Maintainers {
 		if maintainer.Name == "" {
@@ -166,3 +198,30 @@ func validateChartType(cf *chart.Metadata) error {
 	}
 	return nil
 }
+
+func validateChartReplacedBy(cf *chart.Metadata) error {
+	if cf.ReplacedBy != "" && !cf.Deprecated {
+		return errors.New("replacedBy is only used for deprecated charts, set deprecated to true")
+	}
+	return nil
+}
+
+func validateChartNamespace(cf *chart.Metadata) error {
+	if cf.Namespace == nil {
+		return nil
+	}
+	for k, v := range cf.Namespace.Labels {
+		if msgs := validation.IsQualifiedName(k); len(msgs) > 0 {
+			return errors.Errorf("namespace label %q is invalid: %s", k, msgs[0])
+		}
+		if msgs := validation.IsValidLabelValue(v); len(msgs) > 0 {
+			return errors.Errorf("namespace label %q has an invalid value: %s", k, msgs[0])
+		}
+	}
+	for k := range cf.Namespace.Annotations {
+		if msgs := validation.IsQualifiedName(k); len(msgs) > 0 {
+			return errors.Errorf("namespace annotation %q is invalid: %s", k, msgs[0])
+		}
+	}
+	return nil
+}
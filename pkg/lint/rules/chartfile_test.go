@@ -70,6 +70,36 @@ func TestValidateChartName(t *testing.T) {
 	}
 }
 
+func TestValidateChartNameDirMatch(t *testing.T) {
+	cf := &chart.Metadata{Name: "albatross"}
+	if err := validateChartNameDirMatch("testdata/albatross", cf); err != nil {
+		t.Errorf("validateChartNameDirMatch to return no error, got a linter error")
+	}
+	if err := validateChartNameDirMatch("testdata/goodone", cf); err == nil {
+		t.Errorf("validateChartNameDirMatch to return a linter error, got no error")
+	}
+}
+
+func TestValidateChartVersionFormat(t *testing.T) {
+	tests := []struct {
+		Version  string
+		ErrorMsg string
+	}{
+		{"1.2.3", ""},
+		{"0.0.1-beta+build", ""},
+		{"v1.2.3", "version 'v1.2.3' is not in SemVer 2 form, use '1.2.3'"},
+		{"1.2", "version '1.2' is not in SemVer 2 form, use '1.2.0'"},
+	}
+	for _, test := range tests {
+		err := validateChartVersionFormat(&chart.Metadata{Version: test.Version})
+		if test.ErrorMsg == "" && err != nil {
+			t.Errorf("validateChartVersionFormat(%s) to return no error, got %s", test.Version, err)
+		} else if test.ErrorMsg != "" && (err == nil || err.Error() != test.ErrorMsg) {
+			t.Errorf("validateChartVersionFormat(%s) to return \"%s\", got %v", test.Version, test.ErrorMsg, err)
+		}
+	}
+}
+
 func TestValidateChartVersion(t *testing.T) {
 	var failTest = []struct {
 		Version  string
@@ -217,3 +247,37 @@ func TestChartfile(t *testing.T) {
 	}
 
 }
+
+func TestValidateChartReplacedBy(t *testing.T) {
+	cf := &chart.Metadata{ReplacedBy: "repo/successor"}
+	if err := validateChartReplacedBy(cf); err == nil {
+		t.Error("expected an error for replacedBy on a chart that is not deprecated")
+	}
+	cf.Deprecated = true
+	if err := validateChartReplacedBy(cf); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestValidateChartNamespace(t *testing.T) {
+	cf := &chart.Metadata{}
+	if err := validateChartNamespace(cf); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	cf.Namespace = &chart.NamespaceMetadata{
+		Labels:      map[string]string{"team": "payments"},
+		Annotations: map[string]string{"example.com/owner": "payments@example.com"},
+	}
+	if err := validateChartNamespace(cf); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	cf.Namespace.Labels["team"] = "payments team"
+	if err := validateChartNamespace(cf); err == nil {
+		t.Error("expected an error for an invalid namespace label value")
+	}
+	cf.Namespace.Labels = nil
+	cf.Namespace.Annotations["bad key"] = "x"
+	if err := validateChartNamespace(cf); err == nil {
+		t.Error("expected an error for an invalid namespace annotation")
+	}
+}
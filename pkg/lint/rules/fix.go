@@ -0,0 +1,133 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules // import "helm.sh/helm/v3/pkg/lint/rules"
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/lint/support"
+)
+
+// iconStub is the placeholder added to a Chart.yaml file without an icon.
+const iconStub = "# icon: https://example.com/icon.png"
+
+var (
+	chartNameLine    = regexp.MustCompile(`(?m)^name:.*$`)
+	chartVersionLine = regexp.MustCompile(`(?m)^version:.*$`)
+	chartIconLine    = regexp.MustCompile(`(?m)^#?\s*icon:`)
+	trailingSpace    = regexp.MustCompile(`(?m)[ \t]+(\r?)$`)
+)
+
+// FixChartfile corrects the fixable findings of the Chart.yaml file in
+// chartDir, editing it in place so that its comments and layout are kept.
+//
+// The chart name is set to the name of the chart directory, the version is
+// normalized to SemVer 2 and a commented icon field is added as a stub to
+// fill in.
+func FixChartfile(chartDir string) ([]support.Fix, error) {
+	chartFileName := "Chart.yaml"
+	chartPath := filepath.Join(chartDir, chartFileName)
+	cf, err := chartutil.LoadChartfile(chartPath)
+	if err != nil {
+		// Unparsable Chart.yaml files are left to the linter to report.
+		return nil, nil
+	}
+	content, err := ioutil.ReadFile(chartPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixes []support.Fix
+	if validateChartNameDirMatch(chartDir, cf) != nil && chartNameLine.Match(content) {
+		dirName := filepath.Base(chartDir)
+		content = chartNameLine.ReplaceAllLiteral(content, []byte("name: "+dirName))
+		fixes = append(fixes, support.Fix{
+			Path:        chartFileName,
+			Description: fmt.Sprintf("renamed chart '%s' to '%s' to match its directory", cf.Name, dirName),
+		})
+	}
+	if validateChartVersionFormat(cf) != nil && chartVersionLine.Match(content) {
+		version := canonicalVersion(cf.Version)
+		content = chartVersionLine.ReplaceAllLiteral(content, []byte("version: "+version))
+		fixes = append(fixes, support.Fix{
+			Path:        chartFileName,
+			Description: fmt.Sprintf("normalized version '%s' to '%s'", cf.Version, version),
+		})
+	}
+	if cf.Icon == "" && !chartIconLine.Match(content) {
+		if len(content) > 0 && content[len(content)-1] != '\n' {
+			content = append(content, '\n')
+		}
+		content = append(content, iconStub+"\n"...)
+		fixes = append(fixes, support.Fix{
+			Path:        chartFileName,
+			Description: "added a commented icon field to fill in",
+		})
+	}
+
+	if len(fixes) == 0 {
+		return nil, nil
+	}
+	return fixes, writeFile(chartPath, content)
+}
+
+// FixTemplates removes trailing whitespace from the templates in chartDir.
+func FixTemplates(chartDir string) ([]support.Fix, error) {
+	var fixes []support.Fix
+	templatesPath := filepath.Join(chartDir, "templates")
+	err := filepath.Walk(templatesPath, func(path string, fi os.FileInfo, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		n := len(trailingSpace.FindAllIndex(content, -1))
+		if n == 0 {
+			return nil
+		}
+		rel, err := filepath.Rel(chartDir, path)
+		if err != nil {
+			return err
+		}
+		fixes = append(fixes, support.Fix{
+			Path:        filepath.ToSlash(rel),
+			Description: fmt.Sprintf("removed trailing whitespace from %d line(s)", n),
+		})
+		return writeFile(path, trailingSpace.ReplaceAll(content, []byte("$1")))
+	})
+	return fixes, err
+}
+
+// writeFile replaces the content of the existing file at path, keeping its
+// permissions.
+func writeFile(path string, content []byte) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, content, fi.Mode())
+}
@@ -0,0 +1,102 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/lint/support"
+)
+
+func TestFix(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-lint-fix-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	chartDir := filepath.Join(tmp, "fixme")
+	if err := os.MkdirAll(filepath.Join(chartDir, "templates"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	chartfile := "# The chart\napiVersion: v2\nname: oldname\nversion: v1.2\n"
+	template := "apiVersion: v1 \nkind: ConfigMap\t\nmetadata:\n  name: fixme\n"
+	if err := ioutil.WriteFile(filepath.Join(chartDir, "Chart.yaml"), []byte(chartfile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(chartDir, "templates", "configmap.yaml"), []byte(template), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	linter := support.Linter{ChartDir: chartDir}
+	Chartfile(&linter)
+	Templates(&linter, nil, namespace, strict)
+	if len(linter.Messages) != 4 {
+		t.Fatalf("Expected 4 messages before fixing, got %d, %v", len(linter.Messages), linter.Messages)
+	}
+
+	fixes, err := FixChartfile(chartDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	templateFixes, err := FixTemplates(chartDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []support.Fix{
+		{Path: "Chart.yaml", Description: "renamed chart 'oldname' to 'fixme' to match its directory"},
+		{Path: "Chart.yaml", Description: "normalized version 'v1.2' to '1.2.0'"},
+		{Path: "Chart.yaml", Description: "added a commented icon field to fill in"},
+		{Path: "templates/configmap.yaml", Description: "removed trailing whitespace from 2 line(s)"},
+	}
+	if got := append(fixes, templateFixes...); !reflect.DeepEqual(got, expect) {
+		t.Errorf("Expected fixes %v, got %v", expect, got)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(chartDir, "Chart.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "# The chart\napiVersion: v2\nname: fixme\nversion: 1.2.0\n" + iconStub + "\n"; string(b) != expect {
+		t.Errorf("Expected Chart.yaml\n%s\ngot\n%s", expect, b)
+	}
+	b, err = ioutil.ReadFile(filepath.Join(chartDir, "templates", "configmap.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: fixme\n"; string(b) != expect {
+		t.Errorf("Expected template\n%s\ngot\n%s", expect, b)
+	}
+
+	// Only the icon recommendation remains, and fixing again changes nothing.
+	linter = support.Linter{ChartDir: chartDir}
+	Chartfile(&linter)
+	Templates(&linter, nil, namespace, strict)
+	if len(linter.Messages) != 1 {
+		t.Errorf("Expected 1 message after fixing, got %d, %v", len(linter.Messages), linter.Messages)
+	}
+	if fixes, _ := FixChartfile(chartDir); len(fixes) != 0 {
+		t.Errorf("Expected no more fixes, got %v", fixes)
+	}
+	if fixes, _ := FixTemplates(chartDir); len(fixes) != 0 {
+		t.Errorf("Expected no more fixes, got %v", fixes)
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/deprecation"
 	"helm.sh/helm/v3/pkg/engine"
 	"helm.sh/helm/v3/pkg/lint/support"
 )
@@ -37,6 +38,16 @@ var (
 
 // Templates lints the templates in the Linter.
 func Templates(linter *support.Linter, values map[string]interface{}, namespace string, strict bool) {
+	TemplatesWithKubeVersion(linter, values, namespace, nil, strict)
+}
+
+// TemplatesWithKubeVersion lints the templates in the Linter, rendered for
+// Kubernetes version kubeVersion.
+//
+// If kubeVersion is set, the rendered resources are also checked for APIs
+// deprecated in that version, which are warnings, or removed from it, which
+// are errors.
+func TemplatesWithKubeVersion(linter *support.Linter, values map[string]interface{}, namespace string, kubeVersion *chartutil.KubeVersion, strict bool) {
 	path := "templates/"
 	templatesPath := filepath.Join(linter.ChartDir, path)
 
@@ -55,6 +66,9 @@ func Templates(linter *support.Linter, values map[string]interface{}, namespace
 	if !chartLoaded {
 		return
 	}
+	for _, w := range chart.Warnings {
+		linter.RunLinterRule(support.WarningSev, w.Source, errors.New(w.Message))
+	}
 
 	options := chartutil.ReleaseOptions{
 		Name:      "testRelease",
@@ -65,7 +79,13 @@ func Templates(linter *support.Linter, values map[string]interface{}, namespace
 	if err != nil {
 		return
 	}
-	valuesToRender, err := chartutil.ToRenderValues(chart, cvals, options, nil)
+	var caps *chartutil.Capabilities
+	if kubeVersion != nil {
+		c := *chartutil.DefaultCapabilities
+		c.KubeVersion = *kubeVersion
+		caps = &c
+	}
+	valuesToRender, err := chartutil.ToRenderValues(chart, cvals, options, caps)
 	if err != nil {
 		linter.RunLinterRule(support.ErrorSev, path, err)
 		return
@@ -87,6 +107,7 @@ func Templates(linter *support.Linter, values map[string]interface{}, namespace
 	- {{}} include | quote
 	- Generated content is a valid Yaml file
 	- Metadata.Namespace is not set
+	- No API is deprecated or removed in the Kubernetes version
 	*/
 	for _, template := range chart.Templates {
 		fileName, data := template.Name, template.Data
@@ -97,6 +118,7 @@ func Templates(linter *support.Linter, values map[string]interface{}, namespace
 		// chart is not compatible with v3
 		linter.RunLinterRule(support.WarningSev, path, validateNoCRDHooks(data))
 		linter.RunLinterRule(support.ErrorSev, path, validateNoReleaseTime(data))
+		linter.RunLinterRule(support.InfoSev, path, validateNoTrailingWhitespace(data))
 
 		// We only apply the following lint rules to yaml files
 		if filepath.Ext(fileName) != ".yaml" || filepath.Ext(fileName) == ".yml" {
@@ -121,10 +143,31 @@ func Templates(linter *support.Linter, values map[string]interface{}, namespace
 		if !validYaml {
 			continue
 		}
+
+		if kubeVersion != nil {
+			findings, err := deprecation.Check(renderedContent, fileName, kubeVersion.Version)
+			if !linter.RunLinterRule(support.ErrorSev, path, err) {
+				continue
+			}
+			for _, f := range findings {
+				sev := support.WarningSev
+				if f.Removed {
+					sev = support.ErrorSev
+				}
+				linter.RunLinterRule(sev, path, f)
+			}
+		}
 	}
 }
 
 // Validation functions
+func validateNoTrailingWhitespace(data []byte) error {
+	if n := len(trailingSpace.FindAllIndex(data, -1)); n > 0 {
+		return errors.Errorf("%d line(s) have trailing whitespace", n)
+	}
+	return nil
+}
+
 func validateTemplatesDir(templatesPath string) error {
 	if fi, err := os.Stat(templatesPath); err != nil {
 		return errors.New("directory not found")
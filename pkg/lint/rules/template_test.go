@@ -22,6 +22,7 @@ import (
 	"strings"
 	"testing"
 
+	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/lint/support"
 )
 
@@ -101,3 +102,50 @@ func TestV3Fail(t *testing.T) {
 		t.Errorf("Unexpected error: %s", res[2].Err)
 	}
 }
+
+func TestTemplatesDeprecatedAPIs(t *testing.T) {
+	linter := support.Linter{ChartDir: "./testdata/deprecated-apis"}
+	Templates(&linter, values, namespace, strict)
+	if len(linter.Messages) != 0 {
+		t.Fatalf("Expected no messages without a Kubernetes version, got %v", linter.Messages)
+	}
+
+	kubeVersion, err := chartutil.ParseKubeVersion("1.22")
+	if err != nil {
+		t.Fatal(err)
+	}
+	linter = support.Linter{ChartDir: "./testdata/deprecated-apis"}
+	TemplatesWithKubeVersion(&linter, values, namespace, kubeVersion, strict)
+	res := linter.Messages
+	if len(res) != 2 {
+		t.Fatalf("Expected 2 messages, got %d, %v", len(res), res)
+	}
+
+	if res[0].Path != "templates/cronjob.yaml" || res[0].Severity != support.WarningSev {
+		t.Errorf("Unexpected message: %s", res[0])
+	}
+	if !strings.Contains(res[0].Err.Error(), "deprecated in Kubernetes v1.21 and removed in v1.25; use batch/v1 instead") {
+		t.Errorf("Unexpected error: %s", res[0].Err)
+	}
+	if res[1].Path != "templates/deployment.yaml" || res[1].Severity != support.ErrorSev {
+		t.Errorf("Unexpected message: %s", res[1])
+	}
+	if !strings.Contains(res[1].Err.Error(), `Deployment "testRelease" uses extensions/v1beta1, which is removed in Kubernetes v1.16; use apps/v1 instead`) {
+		t.Errorf("Unexpected error: %s", res[1].Err)
+	}
+}
+
+func TestTemplatesChartWarnings(t *testing.T) {
+	linter := support.Linter{ChartDir: "./testdata/ignored-subchart"}
+	Templates(&linter, values, namespace, strict)
+	res := linter.Messages
+	if len(res) != 1 {
+		t.Fatalf("Expected 1 message, got %d, %v", len(res), res)
+	}
+	if res[0].Path != "charts/_skipped" || res[0].Severity != support.WarningSev {
+		t.Errorf("Unexpected message: %s", res[0])
+	}
+	if !strings.Contains(res[0].Err.Error(), `skipped as its name starts with "_"`) {
+		t.Errorf("Unexpected error: %s", res[0].Err)
+	}
+}
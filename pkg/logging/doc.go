@@ -0,0 +1,23 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package logging provides the leveled, structured logger used across the Helm SDK.
+
+Embedders route Helm's logs into their own logging by implementing Logger and
+passing it to the action configuration, getters, downloader, and registry
+client.
+*/
+package logging // import "helm.sh/helm/v3/pkg/logging"
@@ -0,0 +1,152 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging // import "helm.sh/helm/v3/pkg/logging"
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Level is the severity of a log message.
+type Level int
+
+const (
+	// LevelDebug is for messages useful when debugging Helm.
+	LevelDebug Level = iota
+	// LevelInfo is for informational messages.
+	LevelInfo
+	// LevelWarn is for problems Helm recovered from.
+	LevelWarn
+	// LevelError is for problems Helm did not recover from.
+	LevelError
+)
+
+// String returns the lowercase name of the level.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	}
+	return "level(" + strconv.Itoa(int(l)) + ")"
+}
+
+// Logger logs messages with alternating key/value fields, such as
+//
+//	logger.Debug("fetching chart", "url", u, "version", v)
+type Logger interface {
+	Debug(msg string, keyvals ...interface{})
+	Info(msg string, keyvals ...interface{})
+	Warn(msg string, keyvals ...interface{})
+	Error(msg string, keyvals ...interface{})
+}
+
+// Discard is a Logger that discards all messages.
+var Discard Logger = discard{}
+
+type discard struct{}
+
+func (discard) Debug(string, ...interface{}) {}
+func (discard) Info(string, ...interface{})  {}
+func (discard) Warn(string, ...interface{})  {}
+func (discard) Error(string, ...interface{}) {}
+
+// New returns a Logger that writes the messages at or above level to w, one
+// per line, as the level and message followed by the fields in key=value form.
+func New(w io.Writer, level Level) Logger {
+	return &writerLogger{w: w, level: level}
+}
+
+type writerLogger struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level Level
+}
+
+func (l *writerLogger) Debug(msg string, keyvals ...interface{}) { l.log(LevelDebug, msg, keyvals) }
+func (l *writerLogger) Info(msg string, keyvals ...interface{})  { l.log(LevelInfo, msg, keyvals) }
+func (l *writerLogger) Warn(msg string, keyvals ...interface{})  { l.log(LevelWarn, msg, keyvals) }
+func (l *writerLogger) Error(msg string, keyvals ...interface{}) { l.log(LevelError, msg, keyvals) }
+
+func (l *writerLogger) log(level Level, msg string, keyvals []interface{}) {
+	if level < l.level {
+		return
+	}
+	line := fmt.Sprintf("[%s] %s%s\n", level, msg, formatFields(keyvals))
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	io.WriteString(l.w, line)
+}
+
+// FromFunc returns a Logger that formats every message with its fields and
+// passes it to the printf-style function f, as Helm's debug functions take.
+// A nil f returns Discard.
+func FromFunc(f func(format string, v ...interface{})) Logger {
+	if f == nil {
+		return Discard
+	}
+	return funcLogger(f)
+}
+
+type funcLogger func(format string, v ...interface{})
+
+func (f funcLogger) Debug(msg string, keyvals ...interface{}) { f.log(LevelDebug, msg, keyvals) }
+func (f funcLogger) Info(msg string, keyvals ...interface{})  { f.log(LevelInfo, msg, keyvals) }
+func (f funcLogger) Warn(msg string, keyvals ...interface{})  { f.log(LevelWarn, msg, keyvals) }
+func (f funcLogger) Error(msg string, keyvals ...interface{}) { f.log(LevelError, msg, keyvals) }
+
+func (f funcLogger) log(level Level, msg string, keyvals []interface{}) {
+	if level == LevelDebug {
+		f("%s%s", msg, formatFields(keyvals))
+		return
+	}
+	f("%s: %s%s", strings.ToUpper(level.String()), msg, formatFields(keyvals))
+}
+
+// DebugFunc returns a printf-style function logging its messages to l at the
+// debug level, for the parts of Helm that take a debug function.
+func DebugFunc(l Logger) func(format string, v ...interface{}) {
+	return func(format string, v ...interface{}) {
+		l.Debug(fmt.Sprintf(format, v...))
+	}
+}
+
+// formatFields formats keyvals as " key=value" pairs, quoting values with
+// spaces. A trailing key without a value is logged with the value "MISSING".
+func formatFields(keyvals []interface{}) string {
+	var b strings.Builder
+	for i := 0; i < len(keyvals); i += 2 {
+		var value interface{} = "MISSING"
+		if i+1 < len(keyvals) {
+			value = keyvals[i+1]
+		}
+		v := fmt.Sprint(value)
+		if v == "" || strings.ContainsAny(v, " \t\n\"=") {
+			v = strconv.Quote(v)
+		}
+		fmt.Fprintf(&b, " %v=%s", keyvals[i], v)
+	}
+	return b.String()
+}
@@ -0,0 +1,66 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, LevelInfo)
+	l.Debug("hidden")
+	l.Info("fetching chart", "url", "https://example.com/a.tgz", "version", "1.0.0")
+	l.Warn("no provenance", "reason", "not found")
+	l.Error("failed", "count", 2, "extra")
+
+	expect := `[info] fetching chart url=https://example.com/a.tgz version=1.0.0
+[warn] no provenance reason="not found"
+[error] failed count=2 extra=MISSING
+`
+	if buf.String() != expect {
+		t.Errorf("expected\n%s\ngot\n%s", expect, buf.String())
+	}
+}
+
+func TestFromFunc(t *testing.T) {
+	var lines []string
+	l := FromFunc(func(format string, v ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, v...))
+	})
+	l.Debug("resolved", "tag", "1.0.0")
+	l.Warn("deprecated", "chart", "a")
+
+	expect := []string{"resolved tag=1.0.0", "WARN: deprecated chart=a"}
+	if fmt.Sprint(lines) != fmt.Sprint(expect) {
+		t.Errorf("expected %q, got %q", expect, lines)
+	}
+
+	if FromFunc(nil) != Discard {
+		t.Error("expected a nil function to discard messages")
+	}
+}
+
+func TestDebugFunc(t *testing.T) {
+	var buf bytes.Buffer
+	DebugFunc(New(&buf, LevelDebug))("%d charts", 3)
+	if buf.String() != "[debug] 3 charts\n" {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
@@ -23,6 +23,15 @@ const (
 	Delete = "delete"
 	// Update is executed after the plugin is updated.
 	Update = "update"
+
+	// PreRender is executed before the chart of a release is rendered.
+	PreRender = "pre-render"
+	// PostRender is executed after the chart of a release is rendered.
+	PostRender = "post-render"
+	// PreApply is executed before the resources of a release are applied.
+	PreApply = "pre-apply"
+	// PostDeploy is executed after a release is deployed.
+	PostDeploy = "post-deploy"
 )
 
 // Hooks is a map of events to commands.
@@ -38,8 +38,9 @@ type HTTPInstaller struct {
 	CacheDir   string
 	PluginName string
 	base
-	extractor Extractor
-	getter    getter.Getter
+	extractor    Extractor
+	getter       getter.Getter
+	verification Verification
 }
 
 // TarGzExtractor extracts gzip compressed tar archives
@@ -117,6 +118,9 @@ func (i *HTTPInstaller) Install() error {
 	if err != nil {
 		return err
 	}
+	if err := i.verify(pluginData.Bytes()); err != nil {
+		return err
+	}
 
 	err = i.extractor.Extract(pluginData, i.CacheDir)
 	if err != nil {
@@ -141,6 +145,25 @@ func (i *HTTPInstaller) Update() error {
 	return errors.Errorf("method Update() not implemented for HttpInstaller")
 }
 
+// verify verifies the plugin archive against the provenance file served
+// next to it.
+func (i *HTTPInstaller) verify(archive []byte) error {
+	if i.verification.Strategy == VerifyNever {
+		return nil
+	}
+	var prov []byte
+	if provData, err := i.getter.Get(i.Source + ".prov"); err == nil {
+		prov = provData.Bytes()
+	} else {
+		debug("failed to get provenance of %s: %s", i.Source, err)
+	}
+	return i.verification.verify(filepath.Base(i.Source), archive, prov)
+}
+
+func (i *HTTPInstaller) setVerification(v Verification) {
+	i.verification = v
+}
+
 // Override link because we want to use HttpInstaller.Path() not base.Path()
 func (i *HTTPInstaller) link(from string) error {
 	debug("symlinking %s to %s", from, i.Path())
@@ -22,6 +22,8 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/pkg/registry"
 )
 
 // ErrMissingMetadata indicates that plugin.yaml is missing.
@@ -64,6 +66,8 @@ func NewForSource(source, version string) (Installer, error) {
 	// Check if source is a local directory
 	if isLocalReference(source) {
 		return NewLocalInstaller(source)
+	} else if registry.IsOCI(source) {
+		return NewOCIInstaller(source, version)
 	} else if isRemoteHTTPArchive(source) {
 		return NewHTTPInstaller(source)
 	}
@@ -72,6 +76,9 @@ func NewForSource(source, version string) (Installer, error) {
 
 // FindSource determines the correct Installer for the given source.
 func FindSource(location string) (Installer, error) {
+	if i, err := existingOCIPlugin(location); i != nil || err != nil {
+		return i, err
+	}
 	installer, err := existingVCSRepo(location)
 	if err != nil && err.Error() == "Cannot detect VCS" {
 		return installer, errors.New("cannot get information about plugin source")
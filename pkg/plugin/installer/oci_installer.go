@@ -0,0 +1,237 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package installer // import "helm.sh/helm/v3/pkg/plugin/installer"
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/pkg/helmpath"
+	"helm.sh/helm/v3/pkg/plugin/cache"
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+// ociClient is the part of the registry client used to pull plugins.
+type ociClient interface {
+	ResolveChartVersion(ref *registry.Reference, version string) (*registry.Reference, error)
+	PullPlugin(ref *registry.Reference) (*registry.PluginArchive, error)
+}
+
+// ociSource records where a plugin was installed from, so it can be updated.
+type ociSource struct {
+	Source  string `json:"source"`
+	Version string `json:"version,omitempty"`
+	Digest  string `json:"digest"`
+}
+
+// OCIInstaller installs plugins from an OCI registry.
+//
+// A plugin reference may be pinned to a manifest digest, as in
+// oci://host/plugins/foo@sha256:<hex>; such plugins are never updated.
+// Without a tag or digest, the highest tag matching the version constraint
+// is installed.
+type OCIInstaller struct {
+	CacheDir   string
+	PluginName string
+	Version    string
+	base
+	ref    *registry.Reference
+	client ociClient
+	// digest is the manifest digest of the installed plugin.
+	digest       string
+	verification Verification
+}
+
+// NewOCIInstaller creates a new OCIInstaller.
+func NewOCIInstaller(source, version string) (*OCIInstaller, error) {
+	ref, err := registry.ParseReference(strings.TrimPrefix(source, registry.OCIScheme+"://"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid plugin reference %s", source)
+	}
+	key, err := cache.Key(source)
+	if err != nil {
+		return nil, err
+	}
+	i := &OCIInstaller{
+		CacheDir:   helmpath.CachePath("plugins", key),
+		PluginName: path.Base(ref.Repo),
+		Version:    version,
+		base:       newBase(source),
+		ref:        ref,
+	}
+	return i, nil
+}
+
+// existingOCIPlugin returns the installer of a plugin installed from a
+// registry into location, or nil if it was not.
+func existingOCIPlugin(location string) (*OCIInstaller, error) {
+	data, err := ioutil.ReadFile(ociSourceFile(location))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var src ociSource
+	if err := json.Unmarshal(data, &src); err != nil {
+		return nil, errors.Wrapf(err, "invalid plugin source in %s", ociSourceFile(location))
+	}
+	i, err := NewOCIInstaller(src.Source, src.Version)
+	if err != nil {
+		return nil, err
+	}
+	i.CacheDir = location
+	i.digest = src.Digest
+	return i, nil
+}
+
+// Install pulls the plugin archive for this platform, extracts it into the
+// cache directory and creates a symlink to the plugin directory.
+//
+// Implements Installer.
+func (i *OCIInstaller) Install() error {
+	archive, err := i.pull()
+	if err != nil {
+		return err
+	}
+	// A plugin uninstalled earlier leaves its archive in the cache.
+	os.RemoveAll(i.CacheDir)
+	if err := i.extract(archive, i.CacheDir); err != nil {
+		return err
+	}
+	i.digest = archive.Digest
+	src, err := filepath.Abs(i.CacheDir)
+	if err != nil {
+		return err
+	}
+	return i.link(src)
+}
+
+// Update pulls the plugin again and replaces it if its digest changed.
+// Plugins pinned to a digest are left unchanged.
+//
+// Implements Installer.
+func (i *OCIInstaller) Update() error {
+	if i.ref.Digest != "" {
+		debug("plugin %s is pinned to %s", i.PluginName, i.ref.Digest)
+		return nil
+	}
+	archive, err := i.pull()
+	if err != nil {
+		return err
+	}
+	if archive.Digest == i.digest {
+		debug("plugin %s is up to date", i.PluginName)
+		return nil
+	}
+
+	// Extract next to the installed plugin so it is only replaced once the
+	// new one is complete.
+	next := i.CacheDir + ".next"
+	os.RemoveAll(next)
+	if err := i.extract(archive, next); err != nil {
+		os.RemoveAll(next)
+		return err
+	}
+	if err := os.RemoveAll(i.CacheDir); err != nil {
+		return err
+	}
+	if err := os.Rename(next, i.CacheDir); err != nil {
+		return err
+	}
+	if err := os.Rename(ociSourceFile(next), ociSourceFile(i.CacheDir)); err != nil {
+		return err
+	}
+	i.digest = archive.Digest
+	return nil
+}
+
+// Path is overridden because we want to join on the plugin name not the source
+func (i OCIInstaller) Path() string {
+	if i.base.Source == "" {
+		return ""
+	}
+	return helmpath.DataPath("plugins", i.PluginName)
+}
+
+// Override link because we want to use OCIInstaller.Path() not base.Path()
+func (i *OCIInstaller) link(from string) error {
+	debug("symlinking %s to %s", from, i.Path())
+	return os.Symlink(from, i.Path())
+}
+
+// pull resolves the version of the plugin and pulls its archive.
+func (i *OCIInstaller) pull() (*registry.PluginArchive, error) {
+	if i.client == nil {
+		client, err := registry.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		i.client = client
+	}
+	ref, err := i.client.ResolveChartVersion(i.ref, i.Version)
+	if err != nil {
+		return nil, err
+	}
+	debug("pulling plugin %s", ref.FullName())
+	archive, err := i.client.PullPlugin(ref)
+	if err != nil {
+		return nil, err
+	}
+	name := archive.Name
+	if name == "" {
+		name = i.PluginName + ".tgz"
+	}
+	if err := i.verification.verify(name, archive.Content, archive.Provenance); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+func (i *OCIInstaller) setVerification(v Verification) {
+	i.verification = v
+}
+
+// extract extracts archive into dir and records its source next to it.
+func (i *OCIInstaller) extract(archive *registry.PluginArchive, dir string) error {
+	if err := new(TarGzExtractor).Extract(bytes.NewBuffer(archive.Content), dir); err != nil {
+		return err
+	}
+	if !isPlugin(dir) {
+		return ErrMissingMetadata
+	}
+	data, err := json.Marshal(&ociSource{
+		Source:  i.Source,
+		Version: i.Version,
+		Digest:  archive.Digest,
+	})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(ociSourceFile(dir), data, 0644)
+}
+
+// ociSourceFile is the file recording the source of the plugin in dir.
+func ociSourceFile(dir string) string {
+	return filepath.Clean(dir) + ".oci.json"
+}
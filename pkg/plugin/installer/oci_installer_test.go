@@ -0,0 +1,156 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package installer // import "helm.sh/helm/v3/pkg/plugin/installer"
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"helm.sh/helm/v3/internal/test/ensure"
+	"helm.sh/helm/v3/pkg/helmpath"
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+var _ Installer = new(OCIInstaller)
+
+// Fake registry client
+type testOCIClient struct {
+	tags    []string
+	archive *registry.PluginArchive
+	pulled  []string
+}
+
+func (c *testOCIClient) ResolveChartVersion(ref *registry.Reference, version string) (*registry.Reference, error) {
+	if ref.Tag != "" || ref.Digest != "" {
+		return ref, nil
+	}
+	return &registry.Reference{Repo: ref.Repo, Tag: c.tags[len(c.tags)-1]}, nil
+}
+
+func (c *testOCIClient) PullPlugin(ref *registry.Reference) (*registry.PluginArchive, error) {
+	c.pulled = append(c.pulled, ref.FullName())
+	return c.archive, nil
+}
+
+func newTestOCIInstaller(t *testing.T, source string, client *testOCIClient) *OCIInstaller {
+	t.Helper()
+	if err := os.MkdirAll(helmpath.DataPath("plugins"), 0755); err != nil {
+		t.Fatalf("Could not create %s: %s", helmpath.DataPath("plugins"), err)
+	}
+	i, err := NewForSource(source, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ociInstaller, ok := i.(*OCIInstaller)
+	if !ok {
+		t.Fatal("expected an OCIInstaller")
+	}
+	ociInstaller.client = client
+	return ociInstaller
+}
+
+func TestOCIInstaller(t *testing.T) {
+	defer ensure.HelmHome(t)()
+	content, err := base64.StdEncoding.DecodeString(fakePluginB64)
+	if err != nil {
+		t.Fatalf("Could not decode fake tgz plugin: %s", err)
+	}
+	client := &testOCIClient{
+		tags:    []string{"0.1.0", "0.2.0"},
+		archive: &registry.PluginArchive{Digest: "sha256:1", Content: content},
+	}
+	i := newTestOCIInstaller(t, "oci://localhost:5000/plugins/fake-plugin", client)
+
+	if err := Install(i); err != nil {
+		t.Fatal(err)
+	}
+	if i.Path() != helmpath.DataPath("plugins", "fake-plugin") {
+		t.Errorf("expected path '$XDG_DATA_HOME/helm/plugins/fake-plugin', got %q", i.Path())
+	}
+	if _, err := os.Stat(filepath.Join(i.Path(), "plugin.yaml")); err != nil {
+		t.Errorf("expected plugin.yaml in the plugin directory: %s", err)
+	}
+	if client.pulled[0] != "localhost:5000/plugins/fake-plugin:0.2.0" {
+		t.Errorf("expected the latest tag to be pulled, got %s", client.pulled[0])
+	}
+
+	// The installer is found again from the plugin directory.
+	location, err := filepath.EvalSymlinks(i.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, err := FindSource(location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updater, ok := found.(*OCIInstaller)
+	if !ok {
+		t.Fatalf("expected an OCIInstaller, got %T", found)
+	}
+	updater.client = client
+
+	// Same digest, nothing changes.
+	if err := Update(updater); err != nil {
+		t.Fatal(err)
+	}
+
+	client.tags = append(client.tags, "0.3.0")
+	client.archive = &registry.PluginArchive{Digest: "sha256:2", Content: content}
+	if err := Update(updater); err != nil {
+		t.Fatal(err)
+	}
+	if updater.digest != "sha256:2" {
+		t.Errorf("expected digest sha256:2 after update, got %s", updater.digest)
+	}
+	if _, err := os.Stat(filepath.Join(i.Path(), "plugin.yaml")); err != nil {
+		t.Errorf("expected plugin.yaml after update: %s", err)
+	}
+	if last := client.pulled[len(client.pulled)-1]; last != "localhost:5000/plugins/fake-plugin:0.3.0" {
+		t.Errorf("expected the new latest tag to be pulled, got %s", last)
+	}
+}
+
+func TestOCIInstallerPinnedDigest(t *testing.T) {
+	defer ensure.HelmHome(t)()
+	content, err := base64.StdEncoding.DecodeString(fakePluginB64)
+	if err != nil {
+		t.Fatalf("Could not decode fake tgz plugin: %s", err)
+	}
+	digest := "sha256:b5b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7"
+	client := &testOCIClient{
+		archive: &registry.PluginArchive{Digest: digest, Content: content},
+	}
+	i := newTestOCIInstaller(t, "oci://localhost:5000/plugins/fake-plugin:1.2.0@"+digest, client)
+
+	if err := Install(i); err != nil {
+		t.Fatal(err)
+	}
+	if err := Update(i); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.pulled) != 1 {
+		t.Errorf("expected a pinned plugin not to be pulled on update, got %v", client.pulled)
+	}
+}
+
+func TestOCIInstallerInvalidReference(t *testing.T) {
+	if _, err := NewForSource("oci://localhost:5000/plugins/fake-plugin@sha256:bad", ""); err == nil {
+		t.Error("expected an error for an invalid digest")
+	}
+}
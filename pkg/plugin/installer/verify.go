@@ -0,0 +1,126 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package installer // import "helm.sh/helm/v3/pkg/plugin/installer"
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/pkg/provenance"
+)
+
+// VerificationStrategy describes a strategy for verifying plugins.
+type VerificationStrategy int
+
+const (
+	// VerifyNever will skip all verification of a plugin.
+	VerifyNever VerificationStrategy = iota
+	// VerifyIfPossible will verify plugins that have a provenance file, and
+	// install unsigned plugins.
+	VerifyIfPossible
+	// VerifyAlways will always verify plugins, and refuse unsigned plugins
+	// and plugins from sources that cannot be verified.
+	VerifyAlways
+)
+
+// ErrUnsigned indicates that a plugin has no provenance file.
+var ErrUnsigned = errors.New("plugin is not signed")
+
+// Verification is how an installer verifies the plugins it installs and
+// updates.
+type Verification struct {
+	// Strategy is the verification strategy.
+	Strategy VerificationStrategy
+	// Keyring is the keyring holding the public keys of trusted signers.
+	Keyring string
+}
+
+// verifier is implemented by installers of plugin archives, which may be
+// accompanied by a provenance file.
+type verifier interface {
+	setVerification(v Verification)
+}
+
+// SetVerification sets how i verifies the plugins it installs and updates.
+//
+// Only plugin archives served over HTTP or pulled from a registry can be
+// verified, so VerifyAlways fails for other installers.
+func SetVerification(i Installer, v Verification) error {
+	if vi, ok := i.(verifier); ok {
+		vi.setVerification(v)
+		return nil
+	}
+	if v.Strategy == VerifyAlways {
+		return errors.New("plugins installed from a local path or a VCS repository cannot be verified")
+	}
+	return nil
+}
+
+// verify verifies the plugin archive named name against its provenance,
+// which is nil if the plugin is unsigned.
+func (v Verification) verify(name string, archive, prov []byte) error {
+	if v.Strategy == VerifyNever {
+		return nil
+	}
+	if prov == nil {
+		if v.Strategy == VerifyAlways {
+			return errors.Wrap(ErrUnsigned, name)
+		}
+		debug("plugin %s is not signed, skipping verification", name)
+		return nil
+	}
+
+	// The name may come from the registry, so it must not leave dir.
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return errors.Errorf("invalid plugin archive name %q", name)
+	}
+
+	// The provenance holds the digest of the archive file by name.
+	dir, err := ioutil.TempDir("", "helm-plugin-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	archivePath := filepath.Join(dir, name)
+	if filepath.Dir(archivePath) != filepath.Clean(dir) {
+		return errors.Errorf("invalid plugin archive name %q", name)
+	}
+	if err := ioutil.WriteFile(archivePath, archive, 0644); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(archivePath+".prov", prov, 0644); err != nil {
+		return err
+	}
+
+	sig, err := provenance.NewFromKeyring(v.Keyring, "")
+	if err != nil {
+		return errors.Wrap(err, "failed to load keyring")
+	}
+	ver, err := sig.Verify(archivePath, archivePath+".prov")
+	if err != nil {
+		return errors.Wrapf(err, "failed to verify plugin %s", name)
+	}
+	for name := range ver.SignedBy.Identities {
+		debug("plugin %s signed by %q", ver.FileName, name)
+	}
+	debug("plugin %s verified with hash %s", ver.FileName, ver.FileHash)
+	return nil
+}
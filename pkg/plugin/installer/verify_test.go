@@ -0,0 +1,195 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package installer // import "helm.sh/helm/v3/pkg/plugin/installer"
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp/clearsign"
+
+	"helm.sh/helm/v3/internal/test/ensure"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/helmpath"
+	"helm.sh/helm/v3/pkg/provenance"
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+const (
+	testKeyring   = "../../provenance/testdata/helm-test-key.pub"
+	testSecretKey = "../../provenance/testdata/helm-test-key.secret"
+)
+
+// Fake http client serving files by URL
+type testFilesGetter map[string][]byte
+
+func (g testFilesGetter) Get(href string, _ ...getter.Option) (*bytes.Buffer, error) {
+	if data, ok := g[href]; ok {
+		return bytes.NewBuffer(data), nil
+	}
+	return nil, errors.Errorf("%s not found", href)
+}
+
+// signArchive returns the provenance of archive, signed with the test key.
+func signArchive(t *testing.T, name string, archive []byte) []byte {
+	t.Helper()
+	signer, err := provenance.NewFromFiles(testSecretKey, testKeyring)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := bytes.NewBuffer(nil)
+	w, err := clearsign.Encode(out, signer.Entity.PrivateKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprintf(w, "name: fake-plugin\n\n...\nfiles:\n  %s: sha256:%x\n", name, sha256.Sum256(archive))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return out.Bytes()
+}
+
+func TestVerification(t *testing.T) {
+	archive, err := base64.StdEncoding.DecodeString(fakePluginB64)
+	if err != nil {
+		t.Fatalf("Could not decode fake tgz plugin: %s", err)
+	}
+	name := "fake-plugin-0.0.1.tar.gz"
+	prov := signArchive(t, name, archive)
+
+	tests := []struct {
+		name     string
+		strategy VerificationStrategy
+		archive  []byte
+		prov     []byte
+		wantErr  bool
+	}{
+		{"never, unsigned", VerifyNever, archive, nil, false},
+		{"never, tampered", VerifyNever, []byte("tampered"), prov, false},
+		{"if possible, unsigned", VerifyIfPossible, archive, nil, false},
+		{"if possible, signed", VerifyIfPossible, archive, prov, false},
+		{"if possible, tampered", VerifyIfPossible, []byte("tampered"), prov, true},
+		{"always, unsigned", VerifyAlways, archive, nil, true},
+		{"always, signed", VerifyAlways, archive, prov, false},
+		{"always, tampered", VerifyAlways, []byte("tampered"), prov, true},
+	}
+	for _, tt := range tests {
+		v := Verification{Strategy: tt.strategy, Keyring: testKeyring}
+		err := v.verify(name, tt.archive, tt.prov)
+		if tt.wantErr && err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		} else if !tt.wantErr && err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.name, err)
+		}
+	}
+
+	// The provenance only signs the archive by its name.
+	v := Verification{Strategy: VerifyAlways, Keyring: testKeyring}
+	if err := v.verify("other-plugin.tgz", archive, prov); err == nil {
+		t.Error("expected an error for an archive not named in the provenance")
+	}
+
+	// Names from registries must not escape the verification directory.
+	for _, name := range []string{"../../bin/x", "..", "dir/plugin.tgz", `dir\plugin.tgz`, ""} {
+		if err := v.verify(name, archive, prov); err == nil {
+			t.Errorf("expected an error for archive name %q", name)
+		}
+	}
+}
+
+func TestSetVerification(t *testing.T) {
+	v := Verification{Strategy: VerifyAlways, Keyring: testKeyring}
+	if err := SetVerification(new(HTTPInstaller), v); err != nil {
+		t.Errorf("unexpected error for an HTTPInstaller: %s", err)
+	}
+	if err := SetVerification(new(OCIInstaller), v); err != nil {
+		t.Errorf("unexpected error for an OCIInstaller: %s", err)
+	}
+	if err := SetVerification(new(LocalInstaller), v); err == nil {
+		t.Error("expected an error for a LocalInstaller")
+	}
+	v.Strategy = VerifyIfPossible
+	if err := SetVerification(new(VCSInstaller), v); err != nil {
+		t.Errorf("unexpected error for a VCSInstaller: %s", err)
+	}
+}
+
+func TestHTTPInstallerVerify(t *testing.T) {
+	defer ensure.HelmHome(t)()
+	source := "https://repo.localdomain/plugins/fake-plugin-0.0.1.tar.gz"
+	if err := os.MkdirAll(helmpath.DataPath("plugins"), 0755); err != nil {
+		t.Fatalf("Could not create %s: %s", helmpath.DataPath("plugins"), err)
+	}
+	archive, err := base64.StdEncoding.DecodeString(fakePluginB64)
+	if err != nil {
+		t.Fatalf("Could not decode fake tgz plugin: %s", err)
+	}
+
+	i, err := NewHTTPInstaller(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SetVerification(i, Verification{Strategy: VerifyAlways, Keyring: testKeyring}); err != nil {
+		t.Fatal(err)
+	}
+
+	// unsigned
+	i.getter = testFilesGetter{source: archive}
+	if err := Install(i); errors.Cause(err) != ErrUnsigned {
+		t.Fatalf("expected unsigned plugin to be refused, got %v", err)
+	}
+
+	// signed
+	i.getter = testFilesGetter{
+		source:           archive,
+		source + ".prov": signArchive(t, "fake-plugin-0.0.1.tar.gz", archive),
+	}
+	if err := Install(i); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOCIInstallerVerify(t *testing.T) {
+	defer ensure.HelmHome(t)()
+	archive, err := base64.StdEncoding.DecodeString(fakePluginB64)
+	if err != nil {
+		t.Fatalf("Could not decode fake tgz plugin: %s", err)
+	}
+	client := &testOCIClient{
+		archive: &registry.PluginArchive{Digest: "sha256:1", Content: archive},
+	}
+	i := newTestOCIInstaller(t, "oci://localhost:5000/plugins/fake-plugin:0.1.0", client)
+	if err := SetVerification(i, Verification{Strategy: VerifyAlways, Keyring: testKeyring}); err != nil {
+		t.Fatal(err)
+	}
+
+	// unsigned
+	if err := Install(i); errors.Cause(err) != ErrUnsigned {
+		t.Fatalf("expected unsigned plugin to be refused, got %v", err)
+	}
+
+	// signed, named after the plugin without a title annotation
+	client.archive.Provenance = signArchive(t, "fake-plugin.tgz", archive)
+	if err := Install(i); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -40,6 +40,38 @@ type Downloaders struct {
 	Command string `json:"command"`
 }
 
+// StorageDriver represents the plugins capability if it can store releases
+// in an external system
+type StorageDriver struct {
+	// Name is the value of HELM_DRIVER selecting this storage driver.
+	Name string `json:"name"`
+	// Command is the executable path with which the plugin performs the
+	// storage operations, as described by driver.External
+	Command string `json:"command"`
+}
+
+// CredentialProvider represents the plugins capability if it can provide
+// credentials for hosts
+type CredentialProvider struct {
+	// Hosts are the hosts, with optional port, to provide credentials for.
+	// They may contain glob patterns, as in "*.example.com".
+	Hosts []string `json:"hosts"`
+	// Command is the executable path with which the plugin gets the
+	// credentials for a host
+	Command string `json:"command"`
+}
+
+// ValueProvider represents the plugins capability if it can resolve
+// references in values
+type ValueProvider struct {
+	// Scheme is the scheme of the references resolved, as "vault" in
+	// "vault:secret/data/db#password".
+	Scheme string `json:"scheme"`
+	// Command is the executable path with which the plugin resolves a
+	// reference
+	Command string `json:"command"`
+}
+
 // PlatformCommand represents a command for a particular operating system and architecture
 type PlatformCommand struct {
 	OperatingSystem string `json:"os"`
@@ -94,6 +126,18 @@ type Metadata struct {
 	// Downloaders field is used if the plugin supply downloader mechanism
 	// for special protocols.
 	Downloaders []Downloaders `json:"downloaders"`
+
+	// StorageDrivers field is used if the plugin supply release storage
+	// drivers.
+	StorageDrivers []StorageDriver `json:"storageDrivers"`
+
+	// CredentialProviders field is used if the plugin supply credentials
+	// for chart repositories and registries.
+	CredentialProviders []CredentialProvider `json:"credentialProviders"`
+
+	// ValueProviders field is used if the plugin resolves references to
+	// secrets or other external values in values files.
+	ValueProviders []ValueProvider `json:"valueProviders"`
 }
 
 // Plugin represents a plugin.
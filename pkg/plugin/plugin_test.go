@@ -227,6 +227,12 @@ func TestDownloader(t *testing.T) {
 				Command:   "echo Download",
 			},
 		},
+		StorageDrivers: []StorageDriver{
+			{
+				Name:    "mystore",
+				Command: "echo Store",
+			},
+		},
 	}
 
 	if !reflect.DeepEqual(expect, plug.Metadata) {
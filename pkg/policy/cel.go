@@ -0,0 +1,100 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
+	"github.com/pkg/errors"
+)
+
+// celPolicy is a compiled CEL policy file.
+type celPolicy struct {
+	name    string
+	program cel.Program
+}
+
+// celEvaluator evaluates CEL policies. Each policy file holds an expression
+// evaluated with each resource as object, which is true or an empty list of
+// messages when the resource complies with the policy, and false or a list
+// of messages telling how it violates it otherwise.
+type celEvaluator struct {
+	name     string
+	policies []celPolicy
+}
+
+func newCELEvaluator(name string, files []string) (Evaluator, error) {
+	env, err := cel.NewEnv(cel.Declarations(
+		decls.NewIdent("object", decls.NewMapType(decls.String, decls.Dyn), nil),
+	))
+	if err != nil {
+		return nil, err
+	}
+	e := &celEvaluator{name: name}
+	for _, f := range files {
+		src, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		ast, issues := env.Compile(string(src))
+		if issues != nil && issues.Err() != nil {
+			return nil, errors.Wrapf(issues.Err(), "invalid policy %s", f)
+		}
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid policy %s", f)
+		}
+		e.policies = append(e.policies, celPolicy{
+			name:    strings.TrimSuffix(filepath.Base(f), filepath.Ext(f)),
+			program: program,
+		})
+	}
+	return e, nil
+}
+
+// Evaluate evaluates each policy with each resource.
+func (e *celEvaluator) Evaluate(resources []Resource) ([]Violation, error) {
+	var violations []Violation
+	for _, r := range resources {
+		for _, p := range e.policies {
+			out, _, err := p.program.Eval(map[string]interface{}{"object": r.Object})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to evaluate policy %s", e.name)
+			}
+			if out.Type() == types.BoolType {
+				if out != types.True {
+					violations = append(violations, r.violation(e.name, "violates "+p.name))
+				}
+				continue
+			}
+			msgs, err := out.ConvertToNative(reflect.TypeOf([]string{}))
+			if err != nil {
+				return nil, errors.Errorf("policy %s of %s must evaluate to a bool or a list of strings, got %s", p.name, e.name, out.Type().TypeName())
+			}
+			for _, msg := range msgs.([]string) {
+				violations = append(violations, r.violation(e.name, msg))
+			}
+		}
+	}
+	return violations, nil
+}
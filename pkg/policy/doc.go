@@ -0,0 +1,29 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package policy evaluates rendered manifests against organizational policies.
+
+Policies come in bundles, files or directories of policy files, which are
+loaded by the engine registered for the extension of their files. Rego
+policies (.rego) are evaluated with the embedded Open Policy Agent, and CEL
+policies (.cel) with the embedded Common Expression Language interpreter.
+Programs embedding Helm can register engines for other policy languages with
+RegisterEngine.
+
+Installs and upgrades evaluate the manifests they render, with their hooks,
+before applying anything, and fail when a policy is violated.
+*/
+package policy // import "helm.sh/helm/v3/pkg/policy"
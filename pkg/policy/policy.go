@@ -0,0 +1,227 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy // import "helm.sh/helm/v3/pkg/policy"
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"helm.sh/helm/v3/pkg/releaseutil"
+)
+
+// Violation is a violation of a policy by a rendered resource.
+type Violation struct {
+	// Policy is the name of the policy bundle.
+	Policy string `json:"policy"`
+	// Message tells how the resource violates the policy.
+	Message string `json:"message"`
+	// Source is the template the resource was rendered from.
+	Source    string `json:"source,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Resource is a rendered resource evaluated against policies.
+type Resource struct {
+	// Source is the template the resource was rendered from.
+	Source string
+	Object map[string]interface{}
+}
+
+// violation returns a violation of policy by the resource.
+func (r Resource) violation(policy, message string) Violation {
+	v := Violation{Policy: policy, Message: message, Source: r.Source}
+	v.Kind, _ = r.Object["kind"].(string)
+	if metadata, ok := r.Object["metadata"].(map[string]interface{}); ok {
+		v.Name, _ = metadata["name"].(string)
+		v.Namespace, _ = metadata["namespace"].(string)
+	}
+	return v
+}
+
+// Evaluator evaluates rendered resources against policies.
+type Evaluator interface {
+	// Evaluate returns the violations of the policies by resources. An
+	// error means the policies could not be evaluated.
+	Evaluate(resources []Resource) ([]Violation, error)
+}
+
+// Evaluators evaluates resources against each of its evaluators.
+type Evaluators []Evaluator
+
+// Evaluate returns the violations found by all evaluators.
+func (e Evaluators) Evaluate(resources []Resource) ([]Violation, error) {
+	var violations []Violation
+	for _, ev := range e {
+		v, err := ev.Evaluate(resources)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, v...)
+	}
+	return violations, nil
+}
+
+// Error is returned when rendered resources violate policies.
+type Error struct {
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "rendered manifests have %d policy violation", len(e.Violations))
+	if len(e.Violations) == 1 {
+		b.WriteString(":")
+	} else {
+		b.WriteString("s:")
+	}
+	for _, v := range e.Violations {
+		fmt.Fprintf(&b, "\n- %s: %s", v.Policy, v.Message)
+		if v.Kind != "" {
+			fmt.Fprintf(&b, " (%s %s in %s)", v.Kind, v.Name, v.Source)
+		}
+	}
+	return b.String()
+}
+
+// Resources returns the resources of a rendered manifest, in order.
+func Resources(manifest string) ([]Resource, error) {
+	split := releaseutil.SplitManifests(manifest)
+	keys := make([]string, 0, len(split))
+	for k := range split {
+		keys = append(keys, k)
+	}
+	sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+
+	var resources []Resource
+	for _, k := range keys {
+		doc := split[k]
+		var obj map[string]interface{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			return nil, errors.Wrap(err, "unable to parse rendered manifest")
+		}
+		if obj == nil {
+			continue
+		}
+		r := Resource{Object: obj}
+		for _, line := range strings.Split(doc, "\n") {
+			if strings.HasPrefix(line, "# Source: ") {
+				r.Source = strings.TrimSpace(strings.TrimPrefix(line, "# Source: "))
+				break
+			}
+		}
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+// Check evaluates a rendered manifest against policies, and returns an *Error
+// listing the violations if there are any.
+func Check(e Evaluator, manifest string) error {
+	resources, err := Resources(manifest)
+	if err != nil {
+		return err
+	}
+	violations, err := e.Evaluate(resources)
+	if err != nil {
+		return errors.Wrap(err, "unable to evaluate policies")
+	}
+	if len(violations) > 0 {
+		return &Error{Violations: violations}
+	}
+	return nil
+}
+
+// Engine returns the evaluator of the policy files of a bundle, named name.
+type Engine func(name string, files []string) (Evaluator, error)
+
+var engines = map[string]Engine{
+	".cel":  newCELEvaluator,
+	".rego": newRegoEvaluator,
+}
+
+// RegisterEngine registers the engine evaluating the policy files with the
+// extension ext, replacing any engine registered for it.
+func RegisterEngine(ext string, engine Engine) {
+	engines[ext] = engine
+}
+
+// Load loads the policy bundle at path, a policy file or a directory of
+// policy files, with the engines registered for the extensions of its files.
+// The bundle is named after the file or directory.
+func Load(path string) (Evaluator, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if fi.IsDir() {
+		infos, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		files = files[:0]
+		for _, info := range infos {
+			if !info.IsDir() {
+				files = append(files, filepath.Join(path, info.Name()))
+			}
+		}
+	}
+
+	byExt := map[string][]string{}
+	var exts []string
+	for _, f := range files {
+		ext := filepath.Ext(f)
+		if _, ok := engines[ext]; !ok {
+			if !fi.IsDir() {
+				return nil, errors.Errorf("no policy engine for %s", path)
+			}
+			// Bundles may hold data and tests along with policies.
+			continue
+		}
+		if byExt[ext] == nil {
+			exts = append(exts, ext)
+		}
+		byExt[ext] = append(byExt[ext], f)
+	}
+	if len(exts) == 0 {
+		return nil, errors.Errorf("no policies in %s", path)
+	}
+	sort.Strings(exts)
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	var evaluators Evaluators
+	for _, ext := range exts {
+		e, err := engines[ext](name, byExt[ext])
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to load policies from %s", path)
+		}
+		evaluators = append(evaluators, e)
+	}
+	if len(evaluators) == 1 {
+		return evaluators[0], nil
+	}
+	return evaluators, nil
+}
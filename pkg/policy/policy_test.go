@@ -0,0 +1,173 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const manifest = `---
+# Source: app/templates/pod.yaml
+apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+  namespace: default
+spec:
+  containers:
+  - name: app
+    image: app:latest
+---
+# Source: app/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+`
+
+func TestResources(t *testing.T) {
+	resources, err := Resources(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) != 2 {
+		t.Fatalf("expected 2 resources, got %d", len(resources))
+	}
+	if resources[0].Source != "app/templates/pod.yaml" || resources[1].Object["kind"] != "Service" {
+		t.Errorf("unexpected resources %+v", resources)
+	}
+	if _, err := Resources("not: [valid"); err == nil {
+		t.Error("expected an error for an invalid manifest")
+	}
+}
+
+func TestRego(t *testing.T) {
+	e, err := Load("testdata/bundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Check(e, manifest)
+	perr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("expected a policy error, got %v", err)
+	}
+	expect := []Violation{
+		{Policy: "bundle", Message: "container app uses a latest image", Source: "app/templates/pod.yaml", Kind: "Pod", Name: "app", Namespace: "default"},
+		{Policy: "bundle", Message: "resources must be labelled with app.kubernetes.io/name", Source: "app/templates/pod.yaml", Kind: "Pod", Name: "app", Namespace: "default"},
+		{Policy: "bundle", Message: "resources must be labelled with app.kubernetes.io/name", Source: "app/templates/service.yaml", Kind: "Service", Name: "app"},
+	}
+	if !reflect.DeepEqual(perr.Violations, expect) {
+		t.Errorf("expected %+v, got %+v", expect, perr.Violations)
+	}
+	if !strings.Contains(err.Error(), "have 3 policy violations") {
+		t.Errorf("unexpected error message %q", err)
+	}
+}
+
+func TestRegoNoViolations(t *testing.T) {
+	e, err := Load("testdata/images.rego")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Check(e, strings.Replace(manifest, "app:latest", "app:1.0", 1)); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestCEL(t *testing.T) {
+	e, err := Load("testdata/cel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Check(e, manifest)
+	perr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("expected a policy error, got %v", err)
+	}
+	expect := []Violation{
+		{Policy: "cel", Message: "container app uses a latest image", Source: "app/templates/pod.yaml", Kind: "Pod", Name: "app", Namespace: "default"},
+		{Policy: "cel", Message: "violates namespaced", Source: "app/templates/service.yaml", Kind: "Service", Name: "app"},
+	}
+	if !reflect.DeepEqual(perr.Violations, expect) {
+		t.Errorf("expected %+v, got %+v", expect, perr.Violations)
+	}
+	if !strings.Contains(err.Error(), "have 2 policy violations") {
+		t.Errorf("unexpected error message %q", err)
+	}
+
+	dir, err := ioutil.TempDir("", "helm-policy-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, src := range map[string]string{
+		"syntax.cel": "object.kind ==",
+		"type.cel":   "object.kind",
+	} {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+		e, err := Load(path)
+		if err == nil {
+			err = Check(e, manifest)
+		}
+		if err == nil {
+			t.Errorf("expected an error for %s", name)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	if _, err := Load("testdata/missing"); err == nil {
+		t.Error("expected an error for a missing bundle")
+	}
+	if _, err := Load("testdata/bundle/README.md"); err == nil {
+		t.Error("expected an error for a file without a policy engine")
+	}
+
+	RegisterEngine(".test", func(name string, files []string) (Evaluator, error) {
+		return Evaluators{}, nil
+	})
+	defer delete(engines, ".test")
+	dir, err := ioutil.TempDir("", "helm-policy-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if _, err := Load(dir); err == nil {
+		t.Error("expected an error for a bundle without policies")
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "a.test"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "b.rego"), []byte("package helm\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	e, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evaluators, ok := e.(Evaluators); !ok || len(evaluators) != 2 {
+		t.Errorf("expected an evaluator per engine, got %#v", e)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy // import "helm.sh/helm/v3/pkg/policy"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/pkg/errors"
+)
+
+// regoQuery collects the messages of the deny rules of the helm package for
+// each resource, evaluating the rules with each resource as input as
+// conftest does, so that the same policies can be used with both.
+const regoQuery = `violations := [[i, msg] | some i; r := input.resources[i]; msg := data.helm.deny[_] with input as r]`
+
+// regoEvaluator evaluates Rego policies with Open Policy Agent.
+type regoEvaluator struct {
+	name  string
+	query rego.PreparedEvalQuery
+}
+
+func newRegoEvaluator(name string, files []string) (Evaluator, error) {
+	query, err := rego.New(rego.Query(regoQuery), rego.Load(files, nil)).PrepareForEval(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &regoEvaluator{name: name, query: query}, nil
+}
+
+// Evaluate evaluates the deny rules of the helm package of the policies,
+// which are sets of messages or of objects with a msg field.
+func (e *regoEvaluator) Evaluate(resources []Resource) ([]Violation, error) {
+	objects := make([]interface{}, len(resources))
+	for i, r := range resources {
+		objects[i] = r.Object
+	}
+	input := map[string]interface{}{"resources": objects}
+	results, err := e.query.Eval(context.Background(), rego.EvalInput(input))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to evaluate policy %s", e.name)
+	}
+
+	type found struct {
+		resource int
+		message  string
+	}
+	var all []found
+	for _, result := range results {
+		bound, _ := result.Bindings["violations"].([]interface{})
+		for _, b := range bound {
+			v, ok := b.([]interface{})
+			if !ok || len(v) != 2 {
+				return nil, errors.Errorf("invalid violation %v of policy %s", b, e.name)
+			}
+			i, ok := regoIndex(v[0])
+			if !ok || i < 0 || i >= len(resources) {
+				return nil, errors.Errorf("invalid violation %v of policy %s", v, e.name)
+			}
+			all = append(all, found{i, regoMessage(v[1])})
+		}
+	}
+	// The messages of the deny rules are sets, in no particular order.
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].resource != all[j].resource {
+			return all[i].resource < all[j].resource
+		}
+		return all[i].message < all[j].message
+	})
+	violations := make([]Violation, len(all))
+	for i, f := range all {
+		violations[i] = resources[f.resource].violation(e.name, f.message)
+	}
+	return violations, nil
+}
+
+// regoIndex returns the index of a resource bound by the query, a JSON number.
+func regoIndex(v interface{}) (int, bool) {
+	switch i := v.(type) {
+	case json.Number:
+		n, err := i.Int64()
+		return int(n), err == nil
+	case float64:
+		return int(i), true
+	}
+	return 0, false
+}
+
+// regoMessage returns the message of a deny rule, a string or an object with
+// a msg field.
+func regoMessage(v interface{}) string {
+	switch m := v.(type) {
+	case string:
+		return m
+	case map[string]interface{}:
+		if msg, ok := m["msg"].(string); ok {
+			return msg
+		}
+	}
+	return fmt.Sprint(v)
+}
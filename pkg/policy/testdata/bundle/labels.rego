@@ -0,0 +1,6 @@
+package helm
+
+deny[{"msg": msg}] {
+	not input.metadata.labels["app.kubernetes.io/name"]
+	msg := "resources must be labelled with app.kubernetes.io/name"
+}
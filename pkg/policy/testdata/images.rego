@@ -0,0 +1,7 @@
+package helm
+
+deny[msg] {
+	c := input.spec.containers[_]
+	endswith(c.image, ":latest")
+	msg := sprintf("container %s uses a latest image", [c.name])
+}
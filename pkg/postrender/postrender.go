@@ -27,3 +27,22 @@ type PostRenderer interface {
 	// error if there was an issue or failure while running the post render step
 	Run(renderedManifests *bytes.Buffer) (modifiedManifests *bytes.Buffer, err error)
 }
+
+type chain []PostRenderer
+
+// Chain returns a PostRenderer running each of renderers in turn, each on
+// the manifests modified by the previous one.
+func Chain(renderers ...PostRenderer) PostRenderer {
+	return chain(renderers)
+}
+
+// Run runs the post renderers of the chain
+func (c chain) Run(renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
+	var err error
+	for _, r := range c {
+		if renderedManifests, err = r.Run(renderedManifests); err != nil {
+			return nil, err
+		}
+	}
+	return renderedManifests, nil
+}
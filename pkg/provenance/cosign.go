@@ -0,0 +1,398 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CosignPayloadType is the type of the payload signed by cosign for an OCI
+// manifest.
+const CosignPayloadType = "cosign container image signature"
+
+var (
+	// oidIssuer and oidIssuerV2 are the extensions of certificates issued by
+	// Fulcio holding the OIDC issuer of the signer's identity. The first holds
+	// the raw issuer, the second a DER-encoded UTF8String.
+	oidIssuer   = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// CosignSignature is a signature of an OCI manifest in the format of cosign,
+// as attached to the manifest in a registry.
+type CosignSignature struct {
+	// Payload is the signed JSON document naming the digest of the manifest.
+	Payload []byte
+	// Signature is the base64-encoded signature of the payload.
+	Signature string
+	// Certificate is the PEM-encoded certificate of the key of a keyless
+	// signature, empty for signatures made with a key pair.
+	Certificate []byte
+	// Chain is the PEM-encoded chain of intermediate certificates from
+	// Certificate to a root.
+	Chain []byte
+	// Bundle is the JSON-encoded Rekor bundle of a keyless signature,
+	// proving that the signature was recorded in the transparency log while
+	// Certificate was valid.
+	Bundle []byte
+}
+
+// CosignIdentity constrains the identity of the signer of a keyless
+// signature, as found in the certificate of its key. An identity needs an
+// issuer, and a subject or a subject regular expression.
+type CosignIdentity struct {
+	// Issuer is the OIDC issuer of the identity, such as
+	// https://token.actions.githubusercontent.com.
+	Issuer string `json:"issuer,omitempty"`
+	// Subject is the email address or URI of the identity.
+	Subject string `json:"subject,omitempty"`
+	// SubjectRegExp is a regular expression the whole email address or URI of
+	// the identity matches.
+	SubjectRegExp string `json:"subjectRegExp,omitempty"`
+}
+
+// CosignVerifier verifies cosign signatures of OCI manifests.
+//
+// A signature made with a key pair is verified with PublicKeys. A keyless
+// signature is verified if its Rekor bundle is signed by one of
+// RekorPublicKeys and records the signature, and if its certificate chained
+// up to Roots at the time the signature was recorded and holds one of the
+// Identities. Keyless signatures are refused without RekorPublicKeys, as
+// their short-lived certificates would otherwise be valid for signatures
+// made at any time.
+type CosignVerifier struct {
+	PublicKeys      []crypto.PublicKey
+	Identities      []CosignIdentity
+	Roots           *x509.CertPool
+	RekorPublicKeys []crypto.PublicKey
+}
+
+// rekorPayload is the entry of a Rekor bundle signed by the transparency
+// log. Its fields are in the order of its canonical JSON encoding.
+type rekorPayload struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+}
+
+// rekorBundle is the Rekor bundle attached to a keyless cosign signature.
+type rekorBundle struct {
+	SignedEntryTimestamp []byte
+	Payload              rekorPayload
+}
+
+// hashedRekord is the body of a Rekor entry recording a signature.
+type hashedRekord struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+		Signature struct {
+			Content   []byte `json:"content"`
+			PublicKey struct {
+				Content []byte `json:"content"`
+			} `json:"publicKey"`
+		} `json:"signature"`
+	} `json:"spec"`
+}
+
+// LoadCosignPublicKey loads a PEM-encoded public key, as written by
+// 'cosign generate-key-pair'.
+func LoadCosignPublicKey(filename string) (crypto.PublicKey, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.Errorf("%s is not a PEM-encoded public key", filename)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	return key, errors.Wrapf(err, "invalid public key in %s", filename)
+}
+
+// LoadCertPool loads the PEM-encoded certificates of a file into a pool.
+func LoadCertPool(filename string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, errors.Errorf("no certificates found in %s", filename)
+	}
+	return pool, nil
+}
+
+// Verify returns nil if one of the signatures is a valid signature of the
+// manifest with the given digest, and an error listing why each failed
+// otherwise.
+func (v *CosignVerifier) Verify(digest string, sigs []CosignSignature) error {
+	if len(sigs) == 0 {
+		return errors.Errorf("no cosign signatures found for %s", digest)
+	}
+	var reasons []string
+	for _, sig := range sigs {
+		err := v.verify(digest, sig)
+		if err == nil {
+			return nil
+		}
+		reasons = append(reasons, err.Error())
+	}
+	return errors.Errorf("no valid cosign signature of %s: %s", digest, strings.Join(reasons, "; "))
+}
+
+func (v *CosignVerifier) verify(digest string, sig CosignSignature) error {
+	var payload struct {
+		Critical struct {
+			Image struct {
+				Digest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+			Type string `json:"type"`
+		} `json:"critical"`
+	}
+	if err := json.Unmarshal(sig.Payload, &payload); err != nil {
+		return errors.Wrap(err, "invalid signature payload")
+	}
+	if payload.Critical.Type != CosignPayloadType {
+		return errors.Errorf("unexpected payload type %q", payload.Critical.Type)
+	}
+	if payload.Critical.Image.Digest != digest {
+		return errors.Errorf("signature is for %s", payload.Critical.Image.Digest)
+	}
+	raw, err := base64.StdEncoding.DecodeString(sig.Signature)
+	if err != nil {
+		return errors.Wrap(err, "invalid signature encoding")
+	}
+
+	for _, key := range v.PublicKeys {
+		if verifySignature(key, sig.Payload, raw) == nil {
+			return nil
+		}
+	}
+	if len(sig.Certificate) == 0 {
+		return errors.New("signature does not match any public key")
+	}
+	if v.Roots == nil || len(v.Identities) == 0 || len(v.RekorPublicKeys) == 0 {
+		return errors.New("keyless signatures are not accepted")
+	}
+	cert, err := parseCertificate(sig.Certificate)
+	if err != nil {
+		return err
+	}
+	signed, err := v.verifyBundle(sig, raw, cert)
+	if err != nil {
+		return err
+	}
+	if err := v.verifyCertificate(cert, sig.Chain, signed); err != nil {
+		return err
+	}
+	return verifySignature(cert.PublicKey, sig.Payload, raw)
+}
+
+// parseCertificate parses a PEM-encoded certificate.
+func parseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, errors.New("invalid signing certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	return cert, errors.Wrap(err, "invalid signing certificate")
+}
+
+// verifyBundle verifies that the Rekor bundle of a keyless signature is
+// signed by the transparency log and records the signature raw of the
+// payload with cert. It returns the time the signature was recorded at.
+func (v *CosignVerifier) verifyBundle(sig CosignSignature, raw []byte, cert *x509.Certificate) (time.Time, error) {
+	if len(sig.Bundle) == 0 {
+		return time.Time{}, errors.New("keyless signature has no Rekor bundle")
+	}
+	var bundle rekorBundle
+	if err := json.Unmarshal(sig.Bundle, &bundle); err != nil {
+		return time.Time{}, errors.Wrap(err, "invalid Rekor bundle")
+	}
+	var canonical bytes.Buffer
+	enc := json.NewEncoder(&canonical)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(bundle.Payload); err != nil {
+		return time.Time{}, err
+	}
+	signedPayload := bytes.TrimSuffix(canonical.Bytes(), []byte("\n"))
+	verified := false
+	for _, key := range v.RekorPublicKeys {
+		if verifySignature(key, signedPayload, bundle.SignedEntryTimestamp) == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return time.Time{}, errors.New("the Rekor bundle is not signed by a trusted transparency log")
+	}
+
+	body, err := base64.StdEncoding.DecodeString(bundle.Payload.Body)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "invalid Rekor entry")
+	}
+	var entry hashedRekord
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return time.Time{}, errors.Wrap(err, "invalid Rekor entry")
+	}
+	sum := sha256.Sum256(sig.Payload)
+	if entry.Kind != "hashedrekord" ||
+		entry.Spec.Data.Hash.Algorithm != "sha256" ||
+		entry.Spec.Data.Hash.Value != hex.EncodeToString(sum[:]) ||
+		!bytes.Equal(entry.Spec.Signature.Content, raw) {
+		return time.Time{}, errors.New("the Rekor entry does not record the signature")
+	}
+	block, _ := pem.Decode(entry.Spec.Signature.PublicKey.Content)
+	if block == nil || !bytes.Equal(block.Bytes, cert.Raw) {
+		return time.Time{}, errors.New("the Rekor entry does not record the signing certificate")
+	}
+	return time.Unix(bundle.Payload.IntegratedTime, 0), nil
+}
+
+// verifyCertificate verifies that the certificate of a keyless signature was
+// valid at the time the signature was recorded, and the identity it holds.
+func (v *CosignVerifier) verifyCertificate(cert *x509.Certificate, chainPEM []byte, signed time.Time) error {
+	intermediates := x509.NewCertPool()
+	intermediates.AppendCertsFromPEM(chainPEM)
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         v.Roots,
+		Intermediates: intermediates,
+		CurrentTime:   signed,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return errors.Wrap(err, "untrusted signing certificate")
+	}
+
+	issuer := certificateIssuer(cert)
+	subjects := append([]string{}, cert.EmailAddresses...)
+	for _, u := range cert.URIs {
+		subjects = append(subjects, u.String())
+	}
+	for _, id := range v.Identities {
+		ok, err := id.matches(issuer, subjects)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+	return errors.Errorf("signer %s from %s is not an accepted identity", strings.Join(subjects, ", "), issuer)
+}
+
+// Validate returns an error unless the identity constrains both the issuer
+// and the subject of signers.
+func (id CosignIdentity) Validate() error {
+	if id.Issuer == "" {
+		return errors.New("cosign identity has no issuer")
+	}
+	if id.Subject == "" && id.SubjectRegExp == "" {
+		return errors.New("cosign identity has no subject or subject regular expression")
+	}
+	return nil
+}
+
+// matches reports whether the issuer and one of the subjects of a
+// certificate match the identity.
+func (id CosignIdentity) matches(issuer string, subjects []string) (bool, error) {
+	if err := id.Validate(); err != nil {
+		return false, err
+	}
+	if id.Issuer != issuer {
+		return false, nil
+	}
+	var re *regexp.Regexp
+	if id.SubjectRegExp != "" {
+		var err error
+		if re, err = regexp.Compile("^(?:" + id.SubjectRegExp + ")$"); err != nil {
+			return false, errors.Wrapf(err, "invalid subject regular expression %q", id.SubjectRegExp)
+		}
+	}
+	for _, s := range subjects {
+		if (id.Subject == "" || id.Subject == s) && (re == nil || re.MatchString(s)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// certificateIssuer returns the OIDC issuer held by a certificate issued by
+// Fulcio.
+func certificateIssuer(cert *x509.Certificate) string {
+	for _, ext := range cert.Extensions {
+		switch {
+		case ext.Id.Equal(oidIssuerV2):
+			var issuer string
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err == nil {
+				return issuer
+			}
+		case ext.Id.Equal(oidIssuer):
+			return string(ext.Value)
+		}
+	}
+	return ""
+}
+
+// verifySignature verifies the signature of the SHA-256 digest of payload.
+func verifySignature(key crypto.PublicKey, payload, sig []byte) error {
+	sum := sha256.Sum256(payload)
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		var rs struct{ R, S *big.Int }
+		if rest, err := asn1.Unmarshal(sig, &rs); err != nil || len(rest) != 0 {
+			return errors.New("invalid ECDSA signature")
+		}
+		if !ecdsa.Verify(k, sum[:], rs.R, rs.S) {
+			return errors.New("invalid ECDSA signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		return errors.Wrap(rsa.VerifyPKCS1v15(k, crypto.SHA256, sum[:], sig), "invalid RSA signature")
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, payload, sig) {
+			return errors.New("invalid Ed25519 signature")
+		}
+		return nil
+	default:
+		return errors.Errorf("unsupported public key type %T", key)
+	}
+}
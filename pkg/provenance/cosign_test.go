@@ -0,0 +1,295 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testManifestDigest = "sha256:6c3c624b58dbbcd3c0dd82b4c53f04194d1247c6eebdaab7c610cf7d66709b3b"
+
+func cosignPayload(digest string) []byte {
+	return []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"localhost:5000/charts/web"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, digest))
+}
+
+func cosignSign(t *testing.T, key *ecdsa.PrivateKey, payload []byte) string {
+	t.Helper()
+	sum := sha256.Sum256(payload)
+	sig, err := key.Sign(rand.Reader, sum[:], crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// newCertificate returns the certificate of key signed by parent, or
+// self-signed if parent is nil, along with its PEM encoding.
+func newCertificate(t *testing.T, tmpl *x509.Certificate, key *ecdsa.PrivateKey, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, []byte) {
+	t.Helper()
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestCosignVerifierPublicKey(t *testing.T) {
+	key := newKey(t)
+	payload := cosignPayload(testManifestDigest)
+	sig := CosignSignature{Payload: payload, Signature: cosignSign(t, key, payload)}
+
+	v := &CosignVerifier{PublicKeys: []crypto.PublicKey{&key.PublicKey}}
+	if err := v.Verify(testManifestDigest, []CosignSignature{sig}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	other := &CosignVerifier{PublicKeys: []crypto.PublicKey{&newKey(t).PublicKey}}
+	if err := other.Verify(testManifestDigest, []CosignSignature{sig}); err == nil {
+		t.Error("expected an error for a signature made with another key")
+	}
+	if err := v.Verify("sha256:0000", []CosignSignature{sig}); err == nil {
+		t.Error("expected an error for a signature of another manifest")
+	}
+	if err := v.Verify(testManifestDigest, nil); err == nil {
+		t.Error("expected an error without signatures")
+	}
+
+	tampered := sig
+	tampered.Payload = []byte(strings.Replace(string(payload), "null", "{}", 1))
+	if err := v.Verify(testManifestDigest, []CosignSignature{tampered}); err == nil {
+		t.Error("expected an error for a tampered payload")
+	}
+
+	// One valid signature is enough.
+	if err := v.Verify(testManifestDigest, []CosignSignature{tampered, sig}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+// newRekorBundle returns a Rekor bundle signed by logKey recording, at the
+// given time, the signature sig of payload made with the key of certPEM.
+func newRekorBundle(t *testing.T, logKey *ecdsa.PrivateKey, payload []byte, sig string, certPEM []byte, at time.Time) []byte {
+	t.Helper()
+	var entry hashedRekord
+	entry.Kind = "hashedrekord"
+	sum := sha256.Sum256(payload)
+	entry.Spec.Data.Hash.Algorithm = "sha256"
+	entry.Spec.Data.Hash.Value = hex.EncodeToString(sum[:])
+	raw, err := base64.StdEncoding.DecodeString(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry.Spec.Signature.Content = raw
+	entry.Spec.Signature.PublicKey.Content = certPEM
+	body, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle := rekorBundle{Payload: rekorPayload{
+		Body:           base64.StdEncoding.EncodeToString(body),
+		IntegratedTime: at.Unix(),
+		LogID:          "test",
+		LogIndex:       1,
+	}}
+	signed, err := json.Marshal(bundle.Payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bundle.SignedEntryTimestamp, err = base64.StdEncoding.DecodeString(cosignSign(t, logKey, signed)); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestCosignVerifierKeyless(t *testing.T) {
+	rootKey := newKey(t)
+	root, rootPEM := newCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, rootKey, nil, nil)
+
+	issuer, err := asn1.Marshal("https://issuer.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := newKey(t)
+	_, certPEM := newCertificate(t, &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		NotBefore:       time.Now().Add(-time.Minute),
+		NotAfter:        time.Now().Add(-time.Second),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		EmailAddresses:  []string{"release@example.com"},
+		ExtraExtensions: []pkix.Extension{{Id: oidIssuerV2, Value: issuer}},
+	}, key, root, rootKey)
+
+	dir, err := ioutil.TempDir("", "helm-cosign-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	rootsFile := filepath.Join(dir, "roots.pem")
+	if err := ioutil.WriteFile(rootsFile, rootPEM, 0644); err != nil {
+		t.Fatal(err)
+	}
+	roots, err := LoadCertPool(rootsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logKey := newKey(t)
+	payload := cosignPayload(testManifestDigest)
+	signature := cosignSign(t, key, payload)
+	signedAt := time.Now().Add(-30 * time.Second)
+	sigs := []CosignSignature{{
+		Payload:     payload,
+		Signature:   signature,
+		Certificate: certPEM,
+		Bundle:      newRekorBundle(t, logKey, payload, signature, certPEM, signedAt),
+	}}
+	rekorKeys := []crypto.PublicKey{&logKey.PublicKey}
+
+	tests := []struct {
+		name     string
+		identity CosignIdentity
+		wantErr  bool
+	}{
+		{"subject", CosignIdentity{Issuer: "https://issuer.example.com", Subject: "release@example.com"}, false},
+		{"subject regexp", CosignIdentity{Issuer: "https://issuer.example.com", SubjectRegExp: ".*@example\\.com"}, false},
+		{"partial subject regexp", CosignIdentity{Issuer: "https://issuer.example.com", SubjectRegExp: "release"}, true},
+		{"other subject", CosignIdentity{Issuer: "https://issuer.example.com", Subject: "someone@example.com"}, true},
+		{"other issuer", CosignIdentity{Issuer: "https://accounts.example.com", Subject: "release@example.com"}, true},
+		{"no issuer", CosignIdentity{Subject: "release@example.com"}, true},
+		{"no subject", CosignIdentity{Issuer: "https://issuer.example.com"}, true},
+		{"any", CosignIdentity{}, true},
+	}
+	for _, tt := range tests {
+		v := &CosignVerifier{Identities: []CosignIdentity{tt.identity}, Roots: roots, RekorPublicKeys: rekorKeys}
+		err := v.Verify(testManifestDigest, sigs)
+		if tt.wantErr && err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		} else if !tt.wantErr && err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.name, err)
+		}
+	}
+
+	identities := []CosignIdentity{{Issuer: "https://issuer.example.com", Subject: "release@example.com"}}
+	bundles := []struct {
+		name   string
+		bundle []byte
+	}{
+		{"no bundle", nil},
+		{"signed after the certificate expired", newRekorBundle(t, logKey, payload, signature, certPEM, time.Now())},
+		{"signed by another log", newRekorBundle(t, newKey(t), payload, signature, certPEM, signedAt)},
+		{"recording another signature", newRekorBundle(t, logKey, payload, cosignSign(t, key, []byte("other")), certPEM, signedAt)},
+		{"recording another payload", newRekorBundle(t, logKey, []byte("other"), signature, certPEM, signedAt)},
+	}
+	for _, tt := range bundles {
+		v := &CosignVerifier{Identities: identities, Roots: roots, RekorPublicKeys: rekorKeys}
+		sig := sigs[0]
+		sig.Bundle = tt.bundle
+		if err := v.Verify(testManifestDigest, []CosignSignature{sig}); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+
+	// Keyless signatures need roots to chain up to, and a transparency log.
+	v := &CosignVerifier{Identities: identities, RekorPublicKeys: rekorKeys}
+	if err := v.Verify(testManifestDigest, sigs); err == nil {
+		t.Error("expected an error without roots")
+	}
+	v.Roots = x509.NewCertPool()
+	if err := v.Verify(testManifestDigest, sigs); err == nil {
+		t.Error("expected an error for a certificate from an untrusted root")
+	}
+	v = &CosignVerifier{Identities: identities, Roots: roots}
+	if err := v.Verify(testManifestDigest, sigs); err == nil {
+		t.Error("expected an error without Rekor public keys")
+	}
+}
+
+func TestLoadCosignPublicKey(t *testing.T) {
+	key := newKey(t)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "helm-cosign-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	keyFile := filepath.Join(dir, "cosign.pub")
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pub, err := LoadCosignPublicKey(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, ok := pub.(*ecdsa.PublicKey); !ok || p.X.Cmp(key.X) != 0 || p.Y.Cmp(key.Y) != 0 {
+		t.Error("loaded another public key")
+	}
+	if _, err := LoadCosignPublicKey(testPubfile); err == nil {
+		t.Error("expected an error for a PGP key")
+	}
+}
@@ -33,5 +33,10 @@ and using `gpg --verify`, `keybase pgp verify`, or similar:
 	$  gpg --verify some.sig
 	gpg: Signature made Mon Jul 25 17:23:44 2016 MDT using RSA key ID 1FC18762
 	gpg: Good signature from "Helm Testing (This key should only be used for testing. DO NOT TRUST.) <helm-testing@helm.sh>" [ultimate]
+
+Charts stored in OCI registries may instead be signed with cosign, which
+attaches signatures to the manifest of a chart. A CosignVerifier verifies them
+against public keys or, for keyless signatures, against the identities of the
+signers held by their certificates.
 */
 package provenance // import "helm.sh/helm/v3/pkg/provenance"
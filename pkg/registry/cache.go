@@ -14,16 +14,15 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package registry // import "helm.sh/helm/v3/internal/experimental/registry"
+package registry // import "helm.sh/helm/v3/pkg/registry"
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"os"
-	"path/filepath"
 	"time"
 
 	"github.com/containerd/containerd/content"
@@ -37,6 +36,7 @@ import (
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/logging"
 )
 
 const (
@@ -45,15 +45,52 @@ const (
 )
 
 type (
-	// Cache handles local/in-memory storage of Helm charts, compliant with OCI Layout
+	// CacheStore stores the charts pulled from and saved for registries as
+	// OCI content, together with an index of their references. It is
+	// implemented by Cache, and by the caches of NewReadOnlyCache
+	CacheStore interface {
+		// FetchReference retrieves a chart ref from the cache
+		FetchReference(ref *Reference) (*CacheRefSummary, error)
+		// StoreReference stores a chart ref in the cache
+		StoreReference(ref *Reference, ch *chart.Chart) (*CacheRefSummary, error)
+		// DeleteReference deletes a chart ref from the cache
+		DeleteReference(ref *Reference) (*CacheRefSummary, error)
+		// ListReferences lists all chart refs in the cache
+		ListReferences() ([]*CacheRefSummary, error)
+		// AddManifest adds a manifest pulled for ref to the index
+		AddManifest(ref *Reference, manifest *ocispec.Descriptor) error
+		// Provider provides the content of the cache
+		Provider() content.Provider
+		// Ingester ingests content into the cache
+		Ingester() content.Ingester
+		// ProvideIngester both provides and ingests content
+		ProvideIngester() orascontent.ProvideIngester
+	}
+
+	// Cache handles local/in-memory storage of Helm charts, compliant with
+	// OCI Layout, on the filesystem or in memory
 	Cache struct {
 		debug       bool
 		out         io.Writer
+		logger      logging.Logger
 		rootDir     string
-		ociStore    *orascontent.OCIStore
+		newStore    func() (contentStore, error)
+		store       contentStore
 		memoryStore *orascontent.Memorystore
 	}
 
+	// contentStore holds the content of a cache and the index of its
+	// references, as an OCI layout does
+	contentStore interface {
+		content.Provider
+		content.Ingester
+		Info(ctx context.Context, dgst digest.Digest) (content.Info, error)
+		ListReferences() map[string]ocispec.Descriptor
+		AddReference(name string, desc ocispec.Descriptor)
+		DeleteReference(name string)
+		SaveIndex() error
+	}
+
 	// CacheRefSummary contains as much info as available describing a chart reference in cache
 	// Note: fields here are sorted by the order in which they are set in FetchReference method
 	CacheRefSummary struct {
@@ -71,19 +108,44 @@ type (
 	}
 )
 
-// NewCache returns a new OCI Layout-compliant cache with config
+// NewCache returns a new OCI Layout-compliant cache on the filesystem with
+// config
 func NewCache(opts ...CacheOption) (*Cache, error) {
+	cache := newCache(opts)
+	// validate
+	if cache.rootDir == "" {
+		return nil, errors.New("must set cache root dir on initialization")
+	}
+	cache.newStore = func() (contentStore, error) {
+		return orascontent.NewOCIStore(cache.rootDir)
+	}
+	return cache, nil
+}
+
+// NewMemoryCache returns a new cache keeping its content in memory with
+// config, for tests and builds that must not write to disk. The root dir
+// setting is ignored.
+func NewMemoryCache(opts ...CacheOption) *Cache {
+	cache := newCache(opts)
+	cache.newStore = func() (contentStore, error) {
+		return newMemoryStore(), nil
+	}
+	return cache
+}
+
+func newCache(opts []CacheOption) *Cache {
 	cache := &Cache{
 		out: ioutil.Discard,
 	}
 	for _, opt := range opts {
 		opt(cache)
 	}
-	// validate
-	if cache.rootDir == "" {
-		return nil, errors.New("must set cache root dir on initialization")
+	if cache.logger == nil && cache.debug {
+		cache.logger = logging.New(cache.out, logging.LevelDebug)
+	} else if cache.logger == nil {
+		cache.logger = logging.Discard
 	}
-	return cache, nil
+	return cache
 }
 
 // FetchReference retrieves a chart ref from cache
@@ -96,7 +158,7 @@ func (cache *Cache) FetchReference(ref *Reference) (*CacheRefSummary, error) {
 		Repo: ref.Repo,
 		Tag:  ref.Tag,
 	}
-	for _, desc := range cache.ociStore.ListReferences() {
+	for _, desc := range cache.store.ListReferences() {
 		if desc.Annotations[ocispec.AnnotationRefName] == r.Name {
 			r.Exists = true
 			manifestBytes, err := cache.fetchBlob(&desc)
@@ -110,16 +172,22 @@ func (cache *Cache) FetchReference(ref *Reference) (*CacheRefSummary, error) {
 			}
 			r.Manifest = &desc
 			r.Config = &manifest.Config
-			numLayers := len(manifest.Layers)
+			// The provenance pushed along with a chart is not a chart layer.
+			numLayers := 0
+			for _, layer := range manifest.Layers {
+				if layer.MediaType != HelmChartProvenanceLayerMediaType {
+					numLayers++
+				}
+			}
 			if numLayers != 1 {
 				return &r, errors.New(
 					fmt.Sprintf("manifest does not contain exactly 1 layer (total: %d)", numLayers))
 			}
 			var contentLayer *ocispec.Descriptor
-			for _, layer := range manifest.Layers {
+			for i, layer := range manifest.Layers {
 				switch layer.MediaType {
 				case HelmChartContentLayerMediaType:
-					contentLayer = &layer
+					contentLayer = &manifest.Layers[i]
 				}
 			}
 			if contentLayer == nil {
@@ -131,7 +199,7 @@ func (cache *Cache) FetchReference(ref *Reference) (*CacheRefSummary, error) {
 					fmt.Sprintf("manifest layer with mediatype %s is of size 0", HelmChartContentLayerMediaType))
 			}
 			r.ContentLayer = contentLayer
-			info, err := cache.ociStore.Info(ctx(cache.out, cache.debug), contentLayer.Digest)
+			info, err := cache.store.Info(ctx(cache.out, cache.debug), contentLayer.Digest)
 			if err != nil {
 				return &r, err
 			}
@@ -175,7 +243,7 @@ func (cache *Cache) StoreReference(ref *Reference, ch *chart.Chart) (*CacheRefSu
 		return &r, err
 	}
 	r.ContentLayer = contentLayer
-	info, err := cache.ociStore.Info(ctx(cache.out, cache.debug), contentLayer.Digest)
+	info, err := cache.store.Info(ctx(cache.out, cache.debug), contentLayer.Digest)
 	if err != nil {
 		return &r, err
 	}
@@ -200,8 +268,8 @@ func (cache *Cache) DeleteReference(ref *Reference) (*CacheRefSummary, error) {
 	if err != nil || !r.Exists {
 		return r, err
 	}
-	cache.ociStore.DeleteReference(r.Name)
-	err = cache.ociStore.SaveIndex()
+	cache.store.DeleteReference(r.Name)
+	err = cache.store.SaveIndex()
 	return r, err
 }
 
@@ -211,12 +279,10 @@ func (cache *Cache) ListReferences() ([]*CacheRefSummary, error) {
 		return nil, err
 	}
 	var rr []*CacheRefSummary
-	for _, desc := range cache.ociStore.ListReferences() {
+	for _, desc := range cache.store.ListReferences() {
 		name := desc.Annotations[ocispec.AnnotationRefName]
 		if name == "" {
-			if cache.debug {
-				fmt.Fprintf(cache.out, "warning: found manifest without name: %s", desc.Digest.Hex())
-			}
+			cache.logger.Warn("found manifest without name", "digest", desc.Digest.Hex())
 			continue
 		}
 		ref, err := ParseReference(name)
@@ -237,34 +303,35 @@ func (cache *Cache) AddManifest(ref *Reference, manifest *ocispec.Descriptor) er
 	if err := cache.init(); err != nil {
 		return err
 	}
-	cache.ociStore.AddReference(ref.FullName(), *manifest)
-	err := cache.ociStore.SaveIndex()
+	cache.store.AddReference(ref.FullName(), *manifest)
+	err := cache.store.SaveIndex()
 	return err
 }
 
 // Provider provides a valid containerd Provider
 func (cache *Cache) Provider() content.Provider {
-	return content.Provider(cache.ociStore)
+	return content.Provider(cache.store)
 }
 
 // Ingester provides a valid containerd Ingester
 func (cache *Cache) Ingester() content.Ingester {
-	return content.Ingester(cache.ociStore)
+	return content.Ingester(cache.store)
 }
 
 // ProvideIngester provides a valid oras ProvideIngester
 func (cache *Cache) ProvideIngester() orascontent.ProvideIngester {
-	return orascontent.ProvideIngester(cache.ociStore)
+	return orascontent.ProvideIngester(cache.store)
 }
 
-// init creates files needed necessary for OCI layout store
+// init creates the store of the cache, and the files needed for an OCI
+// layout store
 func (cache *Cache) init() error {
-	if cache.ociStore == nil {
-		ociStore, err := orascontent.NewOCIStore(cache.rootDir)
+	if cache.store == nil {
+		store, err := cache.newStore()
 		if err != nil {
 			return err
 		}
-		cache.ociStore = ociStore
+		cache.store = store
 		cache.memoryStore = orascontent.NewMemoryStore()
 	}
 	return nil
@@ -286,17 +353,13 @@ func (cache *Cache) saveChartConfig(ch *chart.Chart) (*ocispec.Descriptor, bool,
 
 // saveChartContentLayer stores the chart as tarball blob and returns a descriptor
 func (cache *Cache) saveChartContentLayer(ch *chart.Chart) (*ocispec.Descriptor, bool, error) {
-	destDir := filepath.Join(cache.rootDir, ".build")
-	os.MkdirAll(destDir, 0755)
-	tmpFile, err := chartutil.Save(ch, destDir)
-	defer os.Remove(tmpFile)
-	if err != nil {
+	// The archive is built in memory, so that caches do not need a
+	// directory to build in.
+	var buf bytes.Buffer
+	if err := chartutil.Write(ch, &buf); err != nil {
 		return nil, false, errors.Wrap(err, "failed to save")
 	}
-	contentBytes, err := ioutil.ReadFile(tmpFile)
-	if err != nil {
-		return nil, false, err
-	}
+	contentBytes := buf.Bytes()
 	contentExists, err := cache.storeBlob(contentBytes)
 	if err != nil {
 		return nil, contentExists, err
@@ -328,10 +391,10 @@ func (cache *Cache) saveChartManifest(config *ocispec.Descriptor, contentLayer *
 	return &descriptor, manifestExists, nil
 }
 
-// storeBlob stores a blob on filesystem
+// storeBlob stores a blob in the store
 func (cache *Cache) storeBlob(blobBytes []byte) (bool, error) {
 	var exists bool
-	writer, err := cache.ociStore.Store.Writer(ctx(cache.out, cache.debug),
+	writer, err := cache.store.Writer(ctx(cache.out, cache.debug),
 		content.WithRef(digest.FromBytes(blobBytes).Hex()))
 	if err != nil {
 		return exists, err
@@ -351,9 +414,9 @@ func (cache *Cache) storeBlob(blobBytes []byte) (bool, error) {
 	return exists, err
 }
 
-// fetchBlob retrieves a blob from filesystem
+// fetchBlob retrieves a blob from the store
 func (cache *Cache) fetchBlob(desc *ocispec.Descriptor) ([]byte, error) {
-	reader, err := cache.ociStore.ReaderAt(ctx(cache.out, cache.debug), *desc)
+	reader, err := cache.store.ReaderAt(ctx(cache.out, cache.debug), *desc)
 	if err != nil {
 		return nil, err
 	}
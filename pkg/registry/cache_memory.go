@@ -0,0 +1,209 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry // import "helm.sh/helm/v3/pkg/registry"
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// memoryStore is a contentStore keeping its content and references in memory
+type memoryStore struct {
+	mu         sync.Mutex
+	blobs      map[digest.Digest][]byte
+	infos      map[digest.Digest]content.Info
+	references map[string]ocispec.Descriptor
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		blobs:      make(map[digest.Digest][]byte),
+		infos:      make(map[digest.Digest]content.Info),
+		references: make(map[string]ocispec.Descriptor),
+	}
+}
+
+// ReaderAt provides the content of desc
+func (s *memoryStore) ReaderAt(ctx context.Context, desc ocispec.Descriptor) (content.ReaderAt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	blob, ok := s.blobs[desc.Digest]
+	if !ok {
+		return nil, errors.Wrapf(errdefs.ErrNotFound, "content %v", desc.Digest)
+	}
+	return memoryReaderAt{Reader: bytes.NewReader(blob)}, nil
+}
+
+// Info returns the info of the content with digest dgst
+func (s *memoryStore) Info(ctx context.Context, dgst digest.Digest) (content.Info, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	info, ok := s.infos[dgst]
+	if !ok {
+		return content.Info{}, errors.Wrapf(errdefs.ErrNotFound, "content %v", dgst)
+	}
+	return info, nil
+}
+
+// Writer returns a writer committing content to the store
+func (s *memoryStore) Writer(ctx context.Context, opts ...content.WriterOpt) (content.Writer, error) {
+	var wOpts content.WriterOpts
+	for _, opt := range opts {
+		if err := opt(&wOpts); err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now()
+	return &memoryWriter{
+		store:    s,
+		digester: digest.Canonical.Digester(),
+		status: content.Status{
+			Ref:       wOpts.Ref,
+			Total:     wOpts.Desc.Size,
+			Expected:  wOpts.Desc.Digest,
+			StartedAt: now,
+			UpdatedAt: now,
+		},
+	}, nil
+}
+
+// ListReferences lists the references in the index
+func (s *memoryStore) ListReferences() map[string]ocispec.Descriptor {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	references := make(map[string]ocispec.Descriptor, len(s.references))
+	for name, desc := range s.references {
+		references[name] = desc
+	}
+	return references
+}
+
+// AddReference adds or updates a reference in the index
+func (s *memoryStore) AddReference(name string, desc ocispec.Descriptor) {
+	annotations := map[string]string{}
+	for k, v := range desc.Annotations {
+		annotations[k] = v
+	}
+	annotations[ocispec.AnnotationRefName] = name
+	desc.Annotations = annotations
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.references[name] = desc
+}
+
+// DeleteReference deletes a reference from the index
+func (s *memoryStore) DeleteReference(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.references, name)
+}
+
+// SaveIndex does nothing, as the index is only kept in memory
+func (s *memoryStore) SaveIndex() error {
+	return nil
+}
+
+// commit adds blob to the store, failing if it already exists
+func (s *memoryStore) commit(blob []byte, dgst digest.Digest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.blobs[dgst]; ok {
+		return errors.Wrapf(errdefs.ErrAlreadyExists, "content %v", dgst)
+	}
+	now := time.Now()
+	s.blobs[dgst] = blob
+	s.infos[dgst] = content.Info{
+		Digest:    dgst,
+		Size:      int64(len(blob)),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	return nil
+}
+
+type memoryReaderAt struct {
+	*bytes.Reader
+}
+
+func (memoryReaderAt) Close() error { return nil }
+
+// memoryWriter buffers content until it is committed to its store
+type memoryWriter struct {
+	store    *memoryStore
+	buf      bytes.Buffer
+	digester digest.Digester
+	status   content.Status
+	closed   bool
+}
+
+func (w *memoryWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errors.Wrap(errdefs.ErrFailedPrecondition, "cannot write to closed writer")
+	}
+	n, _ := w.buf.Write(p)
+	w.digester.Hash().Write(p[:n])
+	w.status.Offset += int64(n)
+	w.status.UpdatedAt = time.Now()
+	return n, nil
+}
+
+func (w *memoryWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func (w *memoryWriter) Digest() digest.Digest {
+	return w.digester.Digest()
+}
+
+func (w *memoryWriter) Commit(ctx context.Context, size int64, expected digest.Digest, opts ...content.Opt) error {
+	if w.closed {
+		return errors.Wrap(errdefs.ErrFailedPrecondition, "cannot commit on closed writer")
+	}
+	w.closed = true
+	blob := append([]byte(nil), w.buf.Bytes()...)
+	if size > 0 && size != int64(len(blob)) {
+		return errors.Wrapf(errdefs.ErrFailedPrecondition, "unexpected commit size %d, expected %d", len(blob), size)
+	}
+	dgst := w.digester.Digest()
+	if expected != "" && expected != dgst {
+		return errors.Wrapf(errdefs.ErrFailedPrecondition, "unexpected commit digest %s, expected %s", dgst, expected)
+	}
+	return w.store.commit(blob, dgst)
+}
+
+func (w *memoryWriter) Status() (content.Status, error) {
+	return w.status, nil
+}
+
+func (w *memoryWriter) Truncate(size int64) error {
+	if size != 0 {
+		return errors.Wrap(errdefs.ErrInvalidArgument, "cannot truncate to a size other than 0")
+	}
+	w.buf.Reset()
+	w.digester = digest.Canonical.Digester()
+	w.status.Offset = 0
+	return nil
+}
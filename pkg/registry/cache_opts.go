@@ -14,10 +14,12 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package registry // import "helm.sh/helm/v3/internal/experimental/registry"
+package registry // import "helm.sh/helm/v3/pkg/registry"
 
 import (
 	"io"
+
+	"helm.sh/helm/v3/pkg/logging"
 )
 
 type (
@@ -33,6 +35,14 @@ func CacheOptDebug(debug bool) CacheOption {
 	}
 }
 
+// CacheOptLogger returns a function that sets the logger setting on cache
+// options set
+func CacheOptLogger(logger logging.Logger) CacheOption {
+	return func(cache *Cache) {
+		cache.logger = logger
+	}
+}
+
 // CacheOptWriter returns a function that sets the writer setting on cache options set
 func CacheOptWriter(out io.Writer) CacheOption {
 	return func(cache *Cache) {
@@ -0,0 +1,94 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry // import "helm.sh/helm/v3/pkg/registry"
+
+import (
+	"context"
+
+	"github.com/containerd/containerd/content"
+	orascontent "github.com/deislabs/oras/pkg/content"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+// ErrCacheReadOnly is returned when storing to or deleting from a read-only cache
+var ErrCacheReadOnly = errors.New("registry cache is read-only")
+
+// readOnlyCache serves the refs of another cache, refusing any change to it
+type readOnlyCache struct {
+	cache CacheStore
+}
+
+// NewReadOnlyCache returns a cache serving the refs and content of cache
+// without ever changing it, for pre-seeded caches in hermetic builds.
+// Pulling, saving and removing charts through it fail with
+// ErrCacheReadOnly.
+func NewReadOnlyCache(cache CacheStore) CacheStore {
+	return &readOnlyCache{cache: cache}
+}
+
+// FetchReference retrieves a chart ref from the underlying cache
+func (c *readOnlyCache) FetchReference(ref *Reference) (*CacheRefSummary, error) {
+	return c.cache.FetchReference(ref)
+}
+
+// StoreReference fails with ErrCacheReadOnly
+func (c *readOnlyCache) StoreReference(ref *Reference, ch *chart.Chart) (*CacheRefSummary, error) {
+	return nil, ErrCacheReadOnly
+}
+
+// DeleteReference fails with ErrCacheReadOnly
+func (c *readOnlyCache) DeleteReference(ref *Reference) (*CacheRefSummary, error) {
+	return nil, ErrCacheReadOnly
+}
+
+// ListReferences lists all chart refs in the underlying cache
+func (c *readOnlyCache) ListReferences() ([]*CacheRefSummary, error) {
+	return c.cache.ListReferences()
+}
+
+// AddManifest fails with ErrCacheReadOnly
+func (c *readOnlyCache) AddManifest(ref *Reference, manifest *ocispec.Descriptor) error {
+	return ErrCacheReadOnly
+}
+
+// Provider provides the content of the underlying cache
+func (c *readOnlyCache) Provider() content.Provider {
+	return c.cache.Provider()
+}
+
+// Ingester provides an Ingester whose writers fail with ErrCacheReadOnly
+func (c *readOnlyCache) Ingester() content.Ingester {
+	return readOnlyIngester{}
+}
+
+// ProvideIngester provides the content of the underlying cache, with writers
+// failing with ErrCacheReadOnly
+func (c *readOnlyCache) ProvideIngester() orascontent.ProvideIngester {
+	return struct {
+		content.Provider
+		content.Ingester
+	}{c.cache.Provider(), readOnlyIngester{}}
+}
+
+type readOnlyIngester struct{}
+
+func (readOnlyIngester) Writer(ctx context.Context, opts ...content.WriterOpt) (content.Writer, error) {
+	return nil, ErrCacheReadOnly
+}
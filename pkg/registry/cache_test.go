@@ -0,0 +1,109 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+func testCacheChart() *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{
+			APIVersion: "v1",
+			Name:       "testchart",
+			Version:    "1.2.3",
+		},
+	}
+}
+
+func TestMemoryCache(t *testing.T) {
+	is := assert.New(t)
+
+	cache := NewMemoryCache()
+	ref, err := ParseReference("localhost:5000/testrepo/testchart:1.2.3")
+	is.NoError(err)
+
+	r, err := cache.FetchReference(ref)
+	is.NoError(err)
+	is.False(r.Exists)
+
+	r, err = cache.StoreReference(ref, testCacheChart())
+	is.NoError(err)
+	is.False(r.Exists)
+	is.NoError(cache.AddManifest(ref, r.Manifest))
+
+	r, err = cache.StoreReference(ref, testCacheChart())
+	is.NoError(err)
+	is.True(r.Exists, "storing a ref twice finds its content")
+
+	r, err = cache.FetchReference(ref)
+	is.NoError(err)
+	is.True(r.Exists)
+	is.Equal("testchart", r.Chart.Metadata.Name)
+	is.Equal("1.2.3", r.Chart.Metadata.Version)
+
+	refs, err := cache.ListReferences()
+	is.NoError(err)
+	is.Len(refs, 1)
+	is.Equal(ref.FullName(), refs[0].Name)
+
+	r, err = cache.DeleteReference(ref)
+	is.NoError(err)
+	is.True(r.Exists)
+
+	refs, err = cache.ListReferences()
+	is.NoError(err)
+	is.Empty(refs)
+}
+
+func TestReadOnlyCache(t *testing.T) {
+	is := assert.New(t)
+
+	seed := NewMemoryCache()
+	ref, err := ParseReference("localhost:5000/testrepo/testchart:1.2.3")
+	is.NoError(err)
+	r, err := seed.StoreReference(ref, testCacheChart())
+	is.NoError(err)
+	is.NoError(seed.AddManifest(ref, r.Manifest))
+
+	cache := NewReadOnlyCache(seed)
+	r, err = cache.FetchReference(ref)
+	is.NoError(err)
+	is.True(r.Exists)
+	is.Equal("testchart", r.Chart.Metadata.Name)
+
+	refs, err := cache.ListReferences()
+	is.NoError(err)
+	is.Len(refs, 1)
+
+	_, err = cache.StoreReference(ref, testCacheChart())
+	is.Equal(ErrCacheReadOnly, err)
+	_, err = cache.DeleteReference(ref)
+	is.Equal(ErrCacheReadOnly, err)
+	is.Equal(ErrCacheReadOnly, cache.AddManifest(ref, r.Manifest))
+	_, err = cache.Ingester().Writer(context.Background())
+	is.Equal(ErrCacheReadOnly, err)
+
+	r, err = seed.FetchReference(ref)
+	is.NoError(err)
+	is.True(r.Exists, "the seeded cache is left unchanged")
+}
@@ -0,0 +1,1062 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry // import "helm.sh/helm/v3/pkg/registry"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/remotes/docker"
+	auth "github.com/deislabs/oras/pkg/auth/docker"
+	orascontent "github.com/deislabs/oras/pkg/content"
+	"github.com/deislabs/oras/pkg/oras"
+	"github.com/gosuri/uitable"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/internal/tracing"
+	"helm.sh/helm/v3/pkg/audit"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/helmpath"
+	"helm.sh/helm/v3/pkg/logging"
+	"helm.sh/helm/v3/pkg/provenance"
+	"helm.sh/helm/v3/pkg/transfer"
+	"helm.sh/helm/v3/pkg/versions"
+)
+
+const (
+	// CredentialsFileBasename is the filename of the credentials file
+	// formerly kept in the "registry" directory of the Helm cache, which is
+	// still used while there is none in the Helm configuration directory.
+	CredentialsFileBasename = "config.json"
+
+	// RegistryConfigBasename is the filename of the credentials file in the
+	// Helm configuration directory
+	RegistryConfigBasename = "registry.json"
+
+	// catalogPageSize is the number of repositories asked for per request
+	// to the catalog API
+	catalogPageSize = 100
+)
+
+type (
+	// Client works with OCI-compliant registries and local Helm chart cache
+	Client struct {
+		debug      bool
+		out        io.Writer
+		logger     logging.Logger
+		authorizer *Authorizer
+		resolver   *Resolver
+		cache      CacheStore
+		// cacheRoot is the root directory of the cache created if none is set
+		cacheRoot string
+		// credentials looks up credentials before the authorizer
+		credentials func(string) (string, string, error)
+		// credentialsFile is the file holding the stored credentials
+		credentialsFile string
+		// ctx is the context of requests, set with WithContext
+		ctx context.Context
+		// pool throttles the bandwidth of the requests
+		pool *transfer.Pool
+		// auditor receives the events of the requests
+		auditor audit.Auditor
+		// httpClient sends the requests to registries, with their trace
+		// context
+		httpClient *http.Client
+		// versionResolver resolves chart versions against tags
+		versionResolver versions.Resolver
+	}
+)
+
+// NewClient returns a new registry client with config
+func NewClient(opts ...ClientOption) (*Client, error) {
+	client := &Client{
+		out: ioutil.Discard,
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	// set defaults if fields are missing
+	if client.logger == nil && client.debug {
+		client.logger = logging.New(client.out, logging.LevelDebug)
+	} else if client.logger == nil {
+		client.logger = logging.Discard
+	}
+	if client.pool == nil {
+		client.pool = transfer.Default()
+	}
+	if client.auditor == nil {
+		client.auditor = audit.Default()
+	}
+	if client.versionResolver == nil {
+		client.versionResolver = versions.Default
+	}
+	client.httpClient = &http.Client{
+		Transport: audit.Transport(client.pool.Transport(tracing.Transport(http.DefaultTransport)), client.auditor, "registry", client.credentialSource),
+	}
+	if client.credentialsFile == "" {
+		client.credentialsFile = helmpath.ConfigPath(RegistryConfigBasename)
+	}
+	client.credentialsFile = legacyCredentialsFile(client.credentialsFile)
+	if client.authorizer == nil {
+		authClient, err := auth.NewClient(client.credentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		client.authorizer = &Authorizer{
+			Client: authClient,
+		}
+	}
+	if client.resolver == nil && client.credentials != nil {
+		client.resolver = &Resolver{
+			Resolver: docker.NewResolver(docker.ResolverOptions{
+				Credentials: client.credential,
+				Client:      client.httpClient,
+			}),
+		}
+	} else if client.resolver == nil {
+		resolver, err := client.authorizer.Resolver(context.Background(), client.httpClient, false)
+		if err != nil {
+			return nil, err
+		}
+		client.resolver = &Resolver{
+			Resolver: resolver,
+		}
+	}
+	if client.cache == nil {
+		if client.cacheRoot == "" {
+			client.cacheRoot = helmpath.CachePath("registry", CacheRootDir)
+		}
+		cache, err := NewCache(
+			CacheOptDebug(client.debug),
+			CacheOptWriter(client.out),
+			CacheOptLogger(client.logger),
+			CacheOptRoot(client.cacheRoot),
+		)
+		if err != nil {
+			return nil, err
+		}
+		client.cache = cache
+	}
+	return client, nil
+}
+
+// legacyCredentialsFile returns the credentials file that Helm kept in its
+// cache directory if path is the default credentials file, which does not
+// exist yet, so that logins are not lost when upgrading Helm.
+func legacyCredentialsFile(path string) string {
+	if path != helmpath.ConfigPath(RegistryConfigBasename) {
+		return path
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return path
+	}
+	legacy := helmpath.CachePath("registry", CredentialsFileBasename)
+	if _, err := os.Stat(legacy); err != nil {
+		return path
+	}
+	return legacy
+}
+
+// WithContext returns a shallow copy of the client sending its requests with
+// ctx, which carries the trace they are part of
+func (c *Client) WithContext(ctx context.Context) *Client {
+	client := *c
+	client.ctx = ctx
+	return &client
+}
+
+// WithVersionResolver returns a shallow copy of the client resolving version
+// constraints against tags with resolver
+func (c *Client) WithVersionResolver(resolver versions.Resolver) *Client {
+	client := *c
+	client.versionResolver = resolver
+	return &client
+}
+
+// context returns the context of the requests of the client
+func (c *Client) context() context.Context {
+	parent := c.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	return withLogger(parent, c.out, c.debug)
+}
+
+// Login logs into a registry
+func (c *Client) Login(hostname string, username string, password string, insecure bool) error {
+	err := c.authorizer.Login(c.context(), hostname, username, password, insecure)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "Login succeeded\n")
+	return nil
+}
+
+// Logout logs out of a registry
+func (c *Client) Logout(hostname string) error {
+	err := c.authorizer.Logout(c.context(), hostname)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.out, "Logout succeeded")
+	return nil
+}
+
+// PushChart uploads a chart to a registry
+func (c *Client) PushChart(ref *Reference) error {
+	r, err := c.cache.FetchReference(ref)
+	if err != nil {
+		return err
+	}
+	if !r.Exists {
+		return errors.New(fmt.Sprintf("Chart not found: %s", r.Name))
+	}
+	fmt.Fprintf(c.out, "The push refers to repository [%s]\n", r.Repo)
+	c.printCacheRefSummary(r)
+	layers := []ocispec.Descriptor{*r.ContentLayer}
+	_, err = oras.Push(c.context(), c.resolver, r.Name, c.cache.Provider(), layers,
+		oras.WithConfig(*r.Config), oras.WithNameValidation(nil),
+		oras.WithManifestAnnotations(chartAnnotations(r.Chart.Metadata)))
+	if err != nil {
+		return err
+	}
+	s := ""
+	numLayers := len(layers)
+	if 1 < numLayers {
+		s = "s"
+	}
+	fmt.Fprintf(c.out,
+		"%s: pushed to remote (%d layer%s, %s total)\n", r.Tag, numLayers, s, byteCountBinary(r.Size))
+	return nil
+}
+
+// PushChartArchive uploads a packaged chart, along with its provenance if
+// prov is not nil, to a registry and returns the digest of the pushed
+// manifest. Unlike PushChart, the archive is pushed as is, so the
+// provenance still matches it. Without a tag the chart version is used.
+func (c *Client) PushChartArchive(ref *Reference, archive, prov []byte) (string, error) {
+	ch, err := loader.LoadArchive(bytes.NewReader(archive))
+	if err != nil {
+		return "", err
+	}
+	if ref.Tag == "" {
+		ref.Tag = versionToTag(ch.Metadata.Version)
+	}
+	config, err := json.Marshal(ch.Metadata)
+	if err != nil {
+		return "", err
+	}
+
+	store := orascontent.NewMemoryStore()
+	configLayer := store.Add("", HelmChartConfigMediaType, config)
+	layers := []ocispec.Descriptor{store.Add("", HelmChartContentLayerMediaType, archive)}
+	if prov != nil {
+		layers = append(layers, store.Add("", HelmChartProvenanceLayerMediaType, prov))
+	}
+
+	fmt.Fprintf(c.out, "The push refers to repository [%s]\n", ref.Repo)
+	manifest, err := oras.Push(c.context(), c.resolver, ref.FullName(), store, layers,
+		oras.WithConfig(configLayer), oras.WithNameValidation(nil),
+		oras.WithManifestAnnotations(chartAnnotations(ch.Metadata)))
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(c.out, "%s: pushed to remote (%d layers, %s total)\n",
+		ref.Tag, len(layers), byteCountBinary(int64(len(archive)+len(prov))))
+	return manifest.Digest.String(), nil
+}
+
+// PullChart downloads a chart from a registry
+func (c *Client) PullChart(ref *Reference) error {
+	if ref.Tag == "" {
+		return errors.New("tag explicitly required")
+	}
+	existing, err := c.cache.FetchReference(ref)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "%s: Pulling from %s\n", ref.Tag, ref.Repo)
+	manifest, _, err := oras.Pull(c.context(), c.resolver, ref.FullName(), c.cache.Ingester(),
+		oras.WithPullEmptyNameAllowed(),
+		oras.WithAllowedMediaTypes(KnownMediaTypes()),
+		oras.WithContentProvideIngester(c.cache.ProvideIngester()))
+	if err != nil {
+		return err
+	}
+	err = c.cache.AddManifest(ref, &manifest)
+	if err != nil {
+		return err
+	}
+	r, err := c.cache.FetchReference(ref)
+	if err != nil {
+		return err
+	}
+	if !r.Exists {
+		return errors.New(fmt.Sprintf("Chart not found: %s", r.Name))
+	}
+	c.printCacheRefSummary(r)
+	if !existing.Exists {
+		fmt.Fprintf(c.out, "Status: Downloaded newer chart for %s\n", ref.FullName())
+	} else {
+		fmt.Fprintf(c.out, "Status: Chart is up to date for %s\n", ref.FullName())
+	}
+	return err
+}
+
+// FetchChart pulls a chart from a registry into the local cache and returns
+// its cache summary along with the raw chart archive
+func (c *Client) FetchChart(ref *Reference) (*CacheRefSummary, []byte, error) {
+	if err := c.PullChart(ref); err != nil {
+		return nil, nil, err
+	}
+	r, err := c.cache.FetchReference(ref)
+	if err != nil {
+		return r, nil, err
+	}
+	data, err := content.ReadBlob(c.context(), c.cache.Provider(), *r.ContentLayer)
+	if err != nil {
+		return r, nil, err
+	}
+	return r, data, nil
+}
+
+// FetchChartMetadata pulls only the config of a chart, which holds its
+// Chart.yaml, from a registry without storing it in the local cache
+func (c *Client) FetchChartMetadata(ref *Reference) (*chart.Metadata, error) {
+	config, err := c.fetchBlob(ref, HelmChartConfigMediaType)
+	if err != nil {
+		return nil, err
+	}
+	metadata := &chart.Metadata{}
+	if err := json.Unmarshal(config, metadata); err != nil {
+		return nil, errors.Wrapf(err, "invalid chart config in %s", ref.FullName())
+	}
+	return metadata, nil
+}
+
+// ErrNotChart indicates that a reference in a registry is not a chart
+var ErrNotChart = errors.New("not a chart")
+
+// ChartVersion describes a version of a chart in a registry
+type ChartVersion struct {
+	Metadata *chart.Metadata
+	// Digest is the digest of the manifest of the chart
+	Digest string
+	// Created is when the chart was pushed, zero if the manifest does not
+	// tell
+	Created time.Time
+}
+
+// FetchChartVersion describes the chart at a reference. The chart is
+// described by the annotations of its manifest if it has them, or else by
+// its config, which is pulled without storing it in the local cache
+func (c *Client) FetchChartVersion(ref *Reference) (*ChartVersion, error) {
+	if ref.Tag == "" && ref.Digest == "" {
+		return nil, errors.New("tag or digest explicitly required")
+	}
+	desc, manifest, err := c.fetchManifest(ref.FullName())
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Config.MediaType != HelmChartConfigMediaType {
+		return nil, errors.Wrap(ErrNotChart, ref.FullName())
+	}
+
+	v := &ChartVersion{Digest: desc.Digest.String()}
+	v.Metadata, v.Created = chartFromAnnotations(manifest.Annotations)
+	if v.Metadata == nil {
+		c.logger.Debug("chart manifest has no annotations, fetching config", "ref", ref.FullName())
+		if v.Metadata, err = c.FetchChartMetadata(&Reference{Repo: ref.Repo, Digest: v.Digest}); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// fetchManifest resolves a reference and fetches its manifest
+func (c *Client) fetchManifest(ref string) (ocispec.Descriptor, *ocispec.Manifest, error) {
+	desc, content, err := c.fetchResolved(ref)
+	if err != nil {
+		return desc, nil, err
+	}
+	manifest := &ocispec.Manifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return desc, nil, errors.Wrapf(err, "invalid manifest of %s", ref)
+	}
+	return desc, manifest, nil
+}
+
+// fetchResolved resolves a reference and fetches the content it points to
+func (c *Client) fetchResolved(ref string) (ocispec.Descriptor, []byte, error) {
+	name, desc, err := c.resolver.Resolve(c.context(), ref)
+	if err != nil {
+		return desc, nil, err
+	}
+	content, err := c.fetchDescriptor(name, desc)
+	return desc, content, err
+}
+
+// fetchDescriptor fetches the content of a descriptor from the repository
+// of a reference
+func (c *Client) fetchDescriptor(ref string, desc ocispec.Descriptor) ([]byte, error) {
+	ctx := c.context()
+	fetcher, err := c.resolver.Fetcher(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return ioutil.ReadAll(rc)
+}
+
+// FetchChartArchive pulls the chart archive from a registry without storing
+// it in the local cache
+func (c *Client) FetchChartArchive(ref *Reference) ([]byte, error) {
+	return c.fetchBlob(ref, HelmChartContentLayerMediaType)
+}
+
+// FetchChartProvenance pulls the provenance pushed along with a chart
+// archive from a registry without storing it in the local cache
+func (c *Client) FetchChartProvenance(ref *Reference) ([]byte, error) {
+	return c.fetchBlob(ref, HelmChartProvenanceLayerMediaType)
+}
+
+// fetchBlob pulls the blob of a reference with the given media type into
+// memory
+func (c *Client) fetchBlob(ref *Reference, mediaType string) ([]byte, error) {
+	if ref.Tag == "" && ref.Digest == "" {
+		return nil, errors.New("tag or digest explicitly required")
+	}
+	c.logger.Debug("fetching blob", "ref", ref.FullName(), "mediaType", mediaType)
+	store := orascontent.NewMemoryStore()
+	manifest, descs, err := oras.Pull(c.context(), c.resolver, ref.FullName(), store,
+		oras.WithPullEmptyNameAllowed(),
+		oras.WithAllowedMediaTypes([]string{mediaType}))
+	if err != nil {
+		return nil, err
+	}
+	if ref.Digest != "" && manifest.Digest.String() != ref.Digest {
+		return nil, errors.Errorf("digest mismatch for %s: got %s", ref.FullName(), manifest.Digest)
+	}
+	for _, desc := range descs {
+		if desc.MediaType != mediaType {
+			continue
+		}
+		if _, content, ok := store.Get(desc); ok {
+			return content, nil
+		}
+	}
+	return nil, errors.Errorf("manifest of %s does not contain a blob with mediatype %s", ref.FullName(), mediaType)
+}
+
+// referrerManifest is an image manifest referring to the manifest of its
+// subject, as defined by version 1.1 of the OCI image spec
+type referrerManifest struct {
+	ocispec.Manifest
+	MediaType    string              `json:"mediaType"`
+	ArtifactType string              `json:"artifactType,omitempty"`
+	Subject      *ocispec.Descriptor `json:"subject,omitempty"`
+}
+
+// sbomTag returns the tag of the SBOM of the manifest with the given
+// digest. Registries without the referrers API find referrers by tag.
+func sbomTag(d digest.Digest) string {
+	return fmt.Sprintf("%s-%s.sbom", d.Algorithm(), d.Encoded())
+}
+
+// ReleaseBundle is a rendered release, pushed to a registry so that it can be
+// promoted between environments by digest rather than rendered again in each
+type ReleaseBundle struct {
+	// Metadata describes the release. It is the config of the bundle.
+	Metadata ReleaseBundleMetadata
+	// Manifest is the rendered manifest of the release
+	Manifest []byte
+	// Hooks are the rendered hooks of the release, nil if it has none
+	Hooks []byte
+	// Values are the values the release was rendered with
+	Values []byte
+}
+
+// ReleaseBundleMetadata describes the release of a ReleaseBundle
+type ReleaseBundleMetadata struct {
+	Name         string    `json:"name"`
+	Namespace    string    `json:"namespace"`
+	Revision     int       `json:"revision"`
+	Chart        string    `json:"chart"`
+	ChartVersion string    `json:"chartVersion"`
+	AppVersion   string    `json:"appVersion,omitempty"`
+	Created      time.Time `json:"created"`
+}
+
+// PushReleaseBundle pushes a rendered release to ref and returns the digest
+// of the pushed manifest
+func (c *Client) PushReleaseBundle(ref *Reference, bundle *ReleaseBundle) (string, error) {
+	if ref.Tag == "" {
+		return "", errors.New("tag explicitly required")
+	}
+	config, err := json.Marshal(bundle.Metadata)
+	if err != nil {
+		return "", err
+	}
+
+	store := orascontent.NewMemoryStore()
+	configLayer := store.Add("", HelmReleaseConfigMediaType, config)
+	layers := []ocispec.Descriptor{store.Add("", HelmReleaseManifestLayerMediaType, bundle.Manifest)}
+	size := len(bundle.Manifest) + len(bundle.Values)
+	if bundle.Hooks != nil {
+		layers = append(layers, store.Add("", HelmReleaseHooksLayerMediaType, bundle.Hooks))
+		size += len(bundle.Hooks)
+	}
+	layers = append(layers, store.Add("", HelmReleaseValuesLayerMediaType, bundle.Values))
+
+	fmt.Fprintf(c.out, "The push refers to repository [%s]\n", ref.Repo)
+	manifest, err := oras.Push(c.context(), c.resolver, ref.FullName(), store, layers,
+		oras.WithConfig(configLayer), oras.WithNameValidation(nil),
+		oras.WithManifestAnnotations(map[string]string{
+			ocispec.AnnotationTitle:   bundle.Metadata.Name,
+			ocispec.AnnotationCreated: bundle.Metadata.Created.UTC().Format(time.RFC3339),
+		}))
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(c.out, "%s: pushed to remote (%d layers, %s total)\n",
+		ref.Tag, len(layers), byteCountBinary(int64(size)))
+	return manifest.Digest.String(), nil
+}
+
+// PushSBOM attaches an SBOM of the given media type to the chart at the
+// subject reference, as a referrer of its manifest, and returns the digest
+// of the manifest of the SBOM
+func (c *Client) PushSBOM(subject *Reference, mediaType string, sbom []byte) (string, error) {
+	if subject.Tag == "" && subject.Digest == "" {
+		return "", errors.New("tag or digest explicitly required")
+	}
+	_, desc, err := c.resolver.Resolve(c.context(), subject.FullName())
+	if err != nil {
+		return "", err
+	}
+
+	// The config names the subject so that it differs between SBOMs, as
+	// blobs pushed before are skipped even when pushed to other repositories.
+	config, err := json.Marshal(map[string]string{"subject": desc.Digest.String(), "mediaType": mediaType})
+	if err != nil {
+		return "", err
+	}
+	store := orascontent.NewMemoryStore()
+	configLayer := store.Add("", HelmChartSBOMConfigMediaType, config)
+	layer := store.Add("", mediaType, sbom)
+	manifest := referrerManifest{
+		Manifest: ocispec.Manifest{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			Config:    configLayer,
+			Layers:    []ocispec.Descriptor{layer},
+			Annotations: map[string]string{
+				ocispec.AnnotationCreated: time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: mediaType,
+		Subject: &ocispec.Descriptor{
+			MediaType: desc.MediaType,
+			Digest:    desc.Digest,
+			Size:      desc.Size,
+		},
+	}
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+	manifestDesc := store.Add("", ocispec.MediaTypeImageManifest, manifestBytes)
+
+	ref := fmt.Sprintf("%s:%s", subject.Repo, sbomTag(desc.Digest))
+	c.logger.Debug("pushing SBOM", "ref", ref, "subject", desc.Digest.String())
+	if _, err := oras.Push(c.context(), c.resolver, ref, store, []ocispec.Descriptor{layer},
+		oras.WithManifest(manifestDesc), oras.WithNameValidation(nil)); err != nil {
+		return "", err
+	}
+	return manifestDesc.Digest.String(), nil
+}
+
+// FetchSBOM fetches the SBOM attached to the chart at the subject reference
+// and returns it along with its media type
+func (c *Client) FetchSBOM(subject *Reference) ([]byte, string, error) {
+	if subject.Tag == "" && subject.Digest == "" {
+		return nil, "", errors.New("tag or digest explicitly required")
+	}
+	_, desc, err := c.resolver.Resolve(c.context(), subject.FullName())
+	if err != nil {
+		return nil, "", err
+	}
+
+	ref := fmt.Sprintf("%s:%s", subject.Repo, sbomTag(desc.Digest))
+	_, content, err := c.fetchResolved(ref)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to find an SBOM for %s", subject.FullName())
+	}
+	var manifest referrerManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, "", errors.Wrapf(err, "invalid manifest of %s", ref)
+	}
+	if manifest.Config.MediaType != HelmChartSBOMConfigMediaType || len(manifest.Layers) != 1 {
+		return nil, "", errors.Errorf("%s is not an SBOM", ref)
+	}
+	if manifest.Subject == nil || manifest.Subject.Digest != desc.Digest {
+		return nil, "", errors.Errorf("SBOM %s does not refer to %s", ref, subject.FullName())
+	}
+	layer := manifest.Layers[0]
+	sbom, err := c.fetchDescriptor(ref, layer)
+	if err != nil {
+		return nil, "", err
+	}
+	return sbom, layer.MediaType, nil
+}
+
+// cosignTag returns the tag cosign attaches the signatures of the manifest
+// with the given digest to.
+func cosignTag(d digest.Digest) string {
+	return fmt.Sprintf("%s-%s.sig", d.Algorithm(), d.Encoded())
+}
+
+// FetchCosignSignatures fetches the cosign signatures attached to the chart
+// at the subject reference and returns them along with the digest of the
+// manifest of the chart. Charts without signatures have none.
+func (c *Client) FetchCosignSignatures(subject *Reference) (string, []provenance.CosignSignature, error) {
+	if subject.Tag == "" && subject.Digest == "" {
+		return "", nil, errors.New("tag or digest explicitly required")
+	}
+	_, desc, err := c.resolver.Resolve(c.context(), subject.FullName())
+	if err != nil {
+		return "", nil, err
+	}
+
+	ref := fmt.Sprintf("%s:%s", subject.Repo, cosignTag(desc.Digest))
+	c.logger.Debug("fetching cosign signatures", "ref", ref)
+	_, manifest, err := c.fetchManifest(ref)
+	if errdefs.IsNotFound(err) {
+		return desc.Digest.String(), nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	var sigs []provenance.CosignSignature
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != CosignSignatureLayerMediaType {
+			continue
+		}
+		payload, err := c.fetchDescriptor(ref, layer)
+		if err != nil {
+			return "", nil, err
+		}
+		sigs = append(sigs, provenance.CosignSignature{
+			Payload:     payload,
+			Signature:   layer.Annotations[CosignSignatureAnnotation],
+			Certificate: []byte(layer.Annotations[CosignCertificateAnnotation]),
+			Chain:       []byte(layer.Annotations[CosignChainAnnotation]),
+			Bundle:      []byte(layer.Annotations[CosignBundleAnnotation]),
+		})
+	}
+	return desc.Digest.String(), sigs, nil
+}
+
+// PluginArchive is a plugin archive pulled from a registry
+type PluginArchive struct {
+	// Digest is the digest of the manifest the archive was pulled from
+	Digest string
+	// Platform is the platform of the archive, empty if it runs anywhere
+	Platform string
+	// Content is the gzip compressed tar archive of the plugin
+	Content []byte
+	// Name is the file name of the archive, empty if the registry has none
+	Name string
+	// Provenance is the provenance of the archive, nil if it is unsigned
+	Provenance []byte
+}
+
+// PullPlugin downloads the plugin archive for the current platform from a
+// registry
+func (c *Client) PullPlugin(ref *Reference) (*PluginArchive, error) {
+	if ref.Tag == "" && ref.Digest == "" {
+		return nil, errors.New("tag or digest explicitly required")
+	}
+	store := orascontent.NewMemoryStore()
+	fmt.Fprintf(c.out, "%s: Pulling from %s\n", ref.FullName(), ref.Repo)
+	manifest, layers, err := oras.Pull(c.context(), c.resolver, ref.FullName(), store,
+		oras.WithPullEmptyNameAllowed(),
+		oras.WithAllowedMediaTypes([]string{HelmPluginConfigMediaType, HelmPluginContentLayerMediaType, HelmPluginProvenanceLayerMediaType}))
+	if err != nil {
+		return nil, err
+	}
+	if ref.Digest != "" && manifest.Digest.String() != ref.Digest {
+		return nil, errors.Errorf("digest mismatch for %s: got %s", ref.FullName(), manifest.Digest)
+	}
+	layer, err := selectPluginLayer(layers, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return nil, errors.Wrap(err, ref.FullName())
+	}
+	_, content, ok := store.Get(*layer)
+	if !ok {
+		return nil, errors.Errorf("plugin archive %s not found in %s", layer.Digest, ref.FullName())
+	}
+	archive := &PluginArchive{
+		Digest:   manifest.Digest.String(),
+		Platform: layer.Annotations[PluginPlatformAnnotation],
+		Content:  content,
+		Name:     layer.Annotations[ocispec.AnnotationTitle],
+	}
+	if prov := selectProvenanceLayer(layers, layer); prov != nil {
+		_, provenance, ok := store.Get(*prov)
+		if !ok {
+			return nil, errors.Errorf("plugin provenance %s not found in %s", prov.Digest, ref.FullName())
+		}
+		archive.Provenance = provenance
+	}
+	fmt.Fprintf(c.out, "Digest: %s\n", manifest.Digest)
+	return archive, nil
+}
+
+// Tags lists the tags available in the remote repository of a reference
+func (c *Client) Tags(ref *Reference) ([]string, error) {
+	scheme := "https"
+	if plainHTTP, _ := docker.MatchLocalhost(ref.Host()); plainHTTP {
+		scheme = "http"
+	}
+	u := fmt.Sprintf("%s://%s/v2/%s/tags/list", scheme, ref.Host(), ref.Path())
+
+	resp, err := c.getAuthorized(u, fmt.Sprintf("repository:%s:pull", ref.Path()))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to list tags for %s: %s", ref.Repo, resp.Status)
+	}
+
+	var list struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode tags for %s", ref.Repo)
+	}
+	return list.Tags, nil
+}
+
+// Repositories lists the repositories of a registry host whose path is under
+// namespace, or all repositories for an empty namespace, using the catalog
+// API of the registry
+func (c *Client) Repositories(host, namespace string) ([]string, error) {
+	scheme := "https"
+	if plainHTTP, _ := docker.MatchLocalhost(host); plainHTTP {
+		scheme = "http"
+	}
+	namespace = strings.Trim(namespace, "/")
+
+	var repositories []string
+	next := fmt.Sprintf("/v2/_catalog?n=%d", catalogPageSize)
+	for next != "" {
+		resp, err := c.getAuthorized(fmt.Sprintf("%s://%s%s", scheme, host, next), "registry:catalog:*")
+		if err != nil {
+			return nil, err
+		}
+		var list struct {
+			Repositories []string `json:"repositories"`
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, errors.Errorf("failed to list repositories of %s: %s", host, resp.Status)
+		}
+		err = json.NewDecoder(resp.Body).Decode(&list)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode repositories of %s", host)
+		}
+		for _, repository := range list.Repositories {
+			if namespace == "" || strings.HasPrefix(repository, namespace+"/") {
+				repositories = append(repositories, repository)
+			}
+		}
+		next = nextLink(resp.Header.Get("Link"))
+	}
+	return repositories, nil
+}
+
+// ResolveChartVersion returns a copy of ref pointing at the highest tag in the
+// remote repository satisfying the given semver constraint, as chosen by the
+// version resolver of the client.
+//
+// If the reference already has a tag or digest, it is returned unchanged. An
+// empty version selects the latest version the resolver picks, the latest
+// stable release with versions.Default.
+func (c *Client) ResolveChartVersion(ref *Reference, version string) (*Reference, error) {
+	if ref.Tag != "" || ref.Digest != "" {
+		return ref, nil
+	}
+	tags, err := c.Tags(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := c.versionResolver
+	if resolver == nil {
+		resolver = versions.Default
+	}
+	byVersion := make(map[string]string, len(tags))
+	candidates := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		v := tagToVersion(tag)
+		if _, ok := byVersion[v]; ok {
+			continue
+		}
+		byVersion[v] = tag
+		candidates = append(candidates, v)
+	}
+	resolved, err := resolver.Resolve(version, candidates)
+	if err != nil {
+		return nil, err
+	}
+	if len(resolved) == 0 {
+		return nil, errors.Errorf("no chart version found for %s matching %q", ref.Repo, version)
+	}
+	bestTag := byVersion[resolved[0]]
+	c.logger.Debug("resolved chart version", "repo", ref.Repo, "constraint", version, "tag", bestTag)
+	return &Reference{Repo: ref.Repo, Tag: bestTag}, nil
+}
+
+// SaveChart stores a copy of chart in local cache
+func (c *Client) SaveChart(ch *chart.Chart, ref *Reference) error {
+	r, err := c.cache.StoreReference(ref, ch)
+	if err != nil {
+		return err
+	}
+	c.printCacheRefSummary(r)
+	err = c.cache.AddManifest(ref, r.Manifest)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "%s: saved\n", r.Tag)
+	return nil
+}
+
+// LoadChart retrieves a chart object by reference
+func (c *Client) LoadChart(ref *Reference) (*chart.Chart, error) {
+	r, err := c.cache.FetchReference(ref)
+	if err != nil {
+		return nil, err
+	}
+	if !r.Exists {
+		return nil, errors.New(fmt.Sprintf("Chart not found: %s", ref.FullName()))
+	}
+	c.printCacheRefSummary(r)
+	return r.Chart, nil
+}
+
+// RemoveChart deletes a locally saved chart
+func (c *Client) RemoveChart(ref *Reference) error {
+	r, err := c.cache.DeleteReference(ref)
+	if err != nil {
+		return err
+	}
+	if !r.Exists {
+		return errors.New(fmt.Sprintf("Chart not found: %s", ref.FullName()))
+	}
+	fmt.Fprintf(c.out, "%s: removed\n", r.Tag)
+	return nil
+}
+
+// PrintChartTable prints a list of locally stored charts
+func (c *Client) PrintChartTable() error {
+	table := uitable.New()
+	table.MaxColWidth = 60
+	table.AddRow("REF", "NAME", "VERSION", "DIGEST", "SIZE", "CREATED")
+	rows, err := c.getChartTableRows()
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		table.AddRow(row...)
+	}
+	fmt.Fprintln(c.out, table.String())
+	return nil
+}
+
+// getAuthorized performs a GET request against a registry API endpoint,
+// answering any auth challenge with the stored credentials for the host
+func (c *Client) getAuthorized(u string, scope string) (*http.Response, error) {
+	authorizer := docker.NewDockerAuthorizer(docker.WithAuthCreds(c.credential))
+	ctx := docker.WithScope(c.context(), scope)
+
+	get := func() (*http.Response, error) {
+		req, err := http.NewRequest("GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+		req = req.WithContext(ctx)
+		if err := authorizer.Authorize(ctx, req); err != nil {
+			return nil, err
+		}
+		return c.httpClient.Do(req)
+	}
+
+	c.logger.Debug("requesting registry API", "url", u, "scope", scope)
+	resp, err := get()
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	c.logger.Debug("answering auth challenge", "url", u)
+	resp.Body.Close()
+	if err := authorizer.AddResponses(ctx, []*http.Response{resp}); err != nil {
+		return nil, errors.Wrapf(err, "unable to authorize request to %s", u)
+	}
+	return get()
+}
+
+// credential looks up the credentials for a registry host, asking the
+// credentials function before the stored credentials
+func (c *Client) credential(hostname string) (string, string, error) {
+	if c.credentials != nil {
+		username, secret, err := c.credentials(hostname)
+		if err != nil || secret != "" {
+			return username, secret, err
+		}
+	}
+	if cred, ok := c.authorizer.Client.(interface {
+		Credential(string) (string, string, error)
+	}); ok {
+		return cred.Credential(hostname)
+	}
+	return "", "", nil
+}
+
+// credentialSource returns the source of the credentials of req.
+func (c *Client) credentialSource(req *http.Request) string {
+	if req.Header.Get("Authorization") == "" {
+		return audit.CredentialsNone
+	}
+	if c.credentials != nil {
+		if _, secret, err := c.credentials(req.URL.Host); err == nil && secret != "" {
+			return audit.CredentialsProvider
+		}
+	}
+	return audit.CredentialsRegistryConfig
+}
+
+// selectPluginLayer returns the plugin archive for the platform goos/goarch,
+// preferring an archive for both the os and the arch, then one for the os
+// only and finally one without a platform.
+func selectPluginLayer(layers []ocispec.Descriptor, goos, goarch string) (*ocispec.Descriptor, error) {
+	var osMatch, generic *ocispec.Descriptor
+	for i := range layers {
+		layer := &layers[i]
+		if layer.MediaType != HelmPluginContentLayerMediaType {
+			continue
+		}
+		platform := layer.Annotations[PluginPlatformAnnotation]
+		switch {
+		case platform == "":
+			if generic == nil {
+				generic = layer
+			}
+		case strings.EqualFold(platform, goos+"/"+goarch):
+			return layer, nil
+		case strings.EqualFold(platform, goos):
+			if osMatch == nil {
+				osMatch = layer
+			}
+		}
+	}
+	if osMatch != nil {
+		return osMatch, nil
+	}
+	if generic != nil {
+		return generic, nil
+	}
+	return nil, errors.Errorf("no plugin archive for %s/%s", goos, goarch)
+}
+
+// selectProvenanceLayer returns the provenance of the plugin archive, or nil
+// if it is unsigned.
+func selectProvenanceLayer(layers []ocispec.Descriptor, archive *ocispec.Descriptor) *ocispec.Descriptor {
+	for i := range layers {
+		layer := &layers[i]
+		if layer.MediaType == HelmPluginProvenanceLayerMediaType &&
+			layer.Annotations[PluginArchiveAnnotation] == archive.Digest.String() {
+			return layer
+		}
+	}
+	return nil
+}
+
+// printCacheRefSummary prints out chart ref summary
+func (c *Client) printCacheRefSummary(r *CacheRefSummary) {
+	fmt.Fprintf(c.out, "ref:     %s\n", r.Name)
+	fmt.Fprintf(c.out, "digest:  %s\n", r.Digest.Hex())
+	fmt.Fprintf(c.out, "size:    %s\n", byteCountBinary(r.Size))
+	fmt.Fprintf(c.out, "name:    %s\n", r.Chart.Metadata.Name)
+	fmt.Fprintf(c.out, "version: %s\n", r.Chart.Metadata.Version)
+}
+
+// getChartTableRows returns rows in uitable-friendly format
+func (c *Client) getChartTableRows() ([][]interface{}, error) {
+	rr, err := c.cache.ListReferences()
+	if err != nil {
+		return nil, err
+	}
+	refsMap := map[string]map[string]string{}
+	for _, r := range rr {
+		refsMap[r.Name] = map[string]string{
+			"name":    r.Chart.Metadata.Name,
+			"version": r.Chart.Metadata.Version,
+			"digest":  shortDigest(r.Digest.Hex()),
+			"size":    byteCountBinary(r.Size),
+			"created": timeAgo(r.CreatedAt),
+		}
+	}
+	// Sort and convert to format expected by uitable
+	rows := make([][]interface{}, len(refsMap))
+	keys := make([]string, 0, len(refsMap))
+	for key := range refsMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for i, key := range keys {
+		rows[i] = make([]interface{}, 6)
+		rows[i][0] = key
+		ref := refsMap[key]
+		for j, k := range []string{"name", "version", "digest", "size", "created"} {
+			rows[i][j+1] = ref[k]
+		}
+	}
+	return rows, nil
+}
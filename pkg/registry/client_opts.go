@@ -0,0 +1,136 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry // import "helm.sh/helm/v3/pkg/registry"
+
+import (
+	"io"
+
+	"helm.sh/helm/v3/pkg/audit"
+	"helm.sh/helm/v3/pkg/logging"
+	"helm.sh/helm/v3/pkg/transfer"
+	"helm.sh/helm/v3/pkg/versions"
+)
+
+type (
+	// ClientOption allows specifying various settings configurable by the user for overriding the defaults
+	// used when creating a new default client
+	ClientOption func(*Client)
+)
+
+// ClientOptDebug returns a function that sets the debug setting on client options set
+func ClientOptDebug(debug bool) ClientOption {
+	return func(client *Client) {
+		client.debug = debug
+	}
+}
+
+// ClientOptLogger returns a function that sets the logger setting on client
+// options set. Without a logger, debug messages are written to the writer if
+// debug is set.
+func ClientOptLogger(logger logging.Logger) ClientOption {
+	return func(client *Client) {
+		client.logger = logger
+	}
+}
+
+// ClientOptWriter returns a function that sets the writer setting on client options set
+func ClientOptWriter(out io.Writer) ClientOption {
+	return func(client *Client) {
+		client.out = out
+	}
+}
+
+// ClientOptPool returns a function that sets the pool whose bandwidth the
+// pulls of the client share. Without a pool, the default pool is used.
+func ClientOptPool(pool *transfer.Pool) ClientOption {
+	return func(client *Client) {
+		client.pool = pool
+	}
+}
+
+// ClientOptAuditor returns a function that sets the auditor receiving the
+// events of the requests of the client. Without an auditor, the default
+// auditor is used.
+func ClientOptAuditor(auditor audit.Auditor) ClientOption {
+	return func(client *Client) {
+		client.auditor = auditor
+	}
+}
+
+// ClientOptResolver returns a function that sets the resolver setting on client options set
+func ClientOptResolver(resolver *Resolver) ClientOption {
+	return func(client *Client) {
+		client.resolver = resolver
+	}
+}
+
+// ClientOptAuthorizer returns a function that sets the authorizer setting on client options set
+func ClientOptAuthorizer(authorizer *Authorizer) ClientOption {
+	return func(client *Client) {
+		client.authorizer = authorizer
+	}
+}
+
+// ClientOptCredentials returns a function that sets the function looking up
+// the credentials of a registry host, which are preferred to the stored
+// credentials. An empty username with a secret is a long lived token.
+func ClientOptCredentials(credentials func(host string) (string, string, error)) ClientOption {
+	return func(client *Client) {
+		client.credentials = credentials
+	}
+}
+
+// ClientOptCredentialsFile returns a function that sets the file holding the
+// stored credentials on a client options set
+func ClientOptCredentialsFile(credentialsFile string) ClientOption {
+	return func(client *Client) {
+		client.credentialsFile = credentialsFile
+	}
+}
+
+// ClientOptCacheRoot returns a function that sets the root directory of the
+// cache created for the client on a client options set. It is ignored if the
+// cache is set with ClientOptCache or ClientOptCacheStore.
+func ClientOptCacheRoot(rootDir string) ClientOption {
+	return func(client *Client) {
+		client.cacheRoot = rootDir
+	}
+}
+
+// ClientOptCache returns a function that sets the cache setting on a client options set
+func ClientOptCache(cache *Cache) ClientOption {
+	return func(client *Client) {
+		client.cache = cache
+	}
+}
+
+// ClientOptCacheStore returns a function that sets the cache setting on a
+// client options set to any CacheStore, such as a read-only cache
+func ClientOptCacheStore(store CacheStore) ClientOption {
+	return func(client *Client) {
+		client.cache = store
+	}
+}
+
+// ClientOptVersionResolver returns a function that sets the resolver matching
+// version constraints against the tags of a repository. Without a resolver,
+// versions.Default is used.
+func ClientOptVersionResolver(resolver versions.Resolver) ClientOption {
+	return func(client *Client) {
+		client.versionResolver = resolver
+	}
+}
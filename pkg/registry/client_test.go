@@ -0,0 +1,629 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	auth "github.com/deislabs/oras/pkg/auth/docker"
+	orascontent "github.com/deislabs/oras/pkg/content"
+	"github.com/deislabs/oras/pkg/oras"
+	"github.com/docker/distribution/configuration"
+	"github.com/docker/distribution/registry"
+	_ "github.com/docker/distribution/registry/auth/htpasswd"
+	_ "github.com/docker/distribution/registry/storage/driver/inmemory"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/versions"
+)
+
+var (
+	testCacheRootDir         = "helm-registry-test"
+	testHtpasswdFileBasename = "authtest.htpasswd"
+	testUsername             = "myuser"
+	testPassword             = "mypass"
+)
+
+type RegistryClientTestSuite struct {
+	suite.Suite
+	Out                io.Writer
+	DockerRegistryHost string
+	CacheRootDir       string
+	RegistryClient     *Client
+}
+
+func (suite *RegistryClientTestSuite) SetupSuite() {
+	suite.CacheRootDir = testCacheRootDir
+	os.RemoveAll(suite.CacheRootDir)
+	os.Mkdir(suite.CacheRootDir, 0700)
+
+	var out bytes.Buffer
+	suite.Out = &out
+	credentialsFile := filepath.Join(suite.CacheRootDir, CredentialsFileBasename)
+
+	client, err := auth.NewClient(credentialsFile)
+	suite.Nil(err, "no error creating auth client")
+
+	resolver, err := client.Resolver(context.Background(), http.DefaultClient, false)
+	suite.Nil(err, "no error creating resolver")
+
+	// create cache
+	cache, err := NewCache(
+		CacheOptDebug(true),
+		CacheOptWriter(suite.Out),
+		CacheOptRoot(filepath.Join(suite.CacheRootDir, CacheRootDir)),
+	)
+	suite.Nil(err, "no error creating cache")
+
+	// init test client
+	suite.RegistryClient, err = NewClient(
+		ClientOptDebug(true),
+		ClientOptWriter(suite.Out),
+		ClientOptAuthorizer(&Authorizer{
+			Client: client,
+		}),
+		ClientOptResolver(&Resolver{
+			Resolver: resolver,
+		}),
+		ClientOptCache(cache),
+		ClientOptCredentialsFile(credentialsFile),
+	)
+	suite.Nil(err, "no error creating registry client")
+
+	// create htpasswd file (w BCrypt, which is required)
+	pwBytes, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.DefaultCost)
+	suite.Nil(err, "no error generating bcrypt password for test htpasswd file")
+	htpasswdPath := filepath.Join(suite.CacheRootDir, testHtpasswdFileBasename)
+	err = ioutil.WriteFile(htpasswdPath, []byte(fmt.Sprintf("%s:%s\n", testUsername, string(pwBytes))), 0644)
+	suite.Nil(err, "no error creating test htpasswd file")
+
+	// Registry config
+	config := &configuration.Configuration{}
+	port, err := getFreePort()
+	suite.Nil(err, "no error finding free port for test registry")
+	suite.DockerRegistryHost = fmt.Sprintf("localhost:%d", port)
+	config.HTTP.Addr = fmt.Sprintf(":%d", port)
+	config.HTTP.DrainTimeout = time.Duration(10) * time.Second
+	config.Storage = map[string]configuration.Parameters{"inmemory": map[string]interface{}{}}
+	config.Auth = configuration.Auth{
+		"htpasswd": configuration.Parameters{
+			"realm": "localhost",
+			"path":  htpasswdPath,
+		},
+	}
+	dockerRegistry, err := registry.NewRegistry(context.Background(), config)
+	suite.Nil(err, "no error creating test registry")
+
+	// Start Docker registry
+	go dockerRegistry.ListenAndServe()
+}
+
+func (suite *RegistryClientTestSuite) TearDownSuite() {
+	os.RemoveAll(suite.CacheRootDir)
+}
+
+func (suite *RegistryClientTestSuite) Test_0_Login() {
+	err := suite.RegistryClient.Login(suite.DockerRegistryHost, "badverybad", "ohsobad", false)
+	suite.NotNil(err, "error logging into registry with bad credentials")
+
+	err = suite.RegistryClient.Login(suite.DockerRegistryHost, "badverybad", "ohsobad", true)
+	suite.NotNil(err, "error logging into registry with bad credentials, insecure mode")
+
+	err = suite.RegistryClient.Login(suite.DockerRegistryHost, testUsername, testPassword, false)
+	suite.Nil(err, "no error logging into registry with good credentials")
+
+	err = suite.RegistryClient.Login(suite.DockerRegistryHost, testUsername, testPassword, true)
+	suite.Nil(err, "no error logging into registry with good credentials, insecure mode")
+}
+
+func (suite *RegistryClientTestSuite) Test_1_SaveChart() {
+	ref, err := ParseReference(fmt.Sprintf("%s/testrepo/testchart:1.2.3", suite.DockerRegistryHost))
+	suite.Nil(err)
+
+	// empty chart
+	err = suite.RegistryClient.SaveChart(&chart.Chart{}, ref)
+	suite.NotNil(err)
+
+	// valid chart
+	ch := &chart.Chart{}
+	ch.Metadata = &chart.Metadata{
+		APIVersion: "v1",
+		Name:       "testchart",
+		Version:    "1.2.3",
+	}
+	err = suite.RegistryClient.SaveChart(ch, ref)
+	suite.Nil(err)
+}
+
+func (suite *RegistryClientTestSuite) Test_2_LoadChart() {
+
+	// non-existent ref
+	ref, err := ParseReference(fmt.Sprintf("%s/testrepo/whodis:9.9.9", suite.DockerRegistryHost))
+	suite.Nil(err)
+	ch, err := suite.RegistryClient.LoadChart(ref)
+	suite.NotNil(err)
+
+	// existing ref
+	ref, err = ParseReference(fmt.Sprintf("%s/testrepo/testchart:1.2.3", suite.DockerRegistryHost))
+	suite.Nil(err)
+	ch, err = suite.RegistryClient.LoadChart(ref)
+	suite.Nil(err)
+	suite.Equal("testchart", ch.Metadata.Name)
+	suite.Equal("1.2.3", ch.Metadata.Version)
+}
+
+func (suite *RegistryClientTestSuite) Test_3_PushChart() {
+
+	// non-existent ref
+	ref, err := ParseReference(fmt.Sprintf("%s/testrepo/whodis:9.9.9", suite.DockerRegistryHost))
+	suite.Nil(err)
+	err = suite.RegistryClient.PushChart(ref)
+	suite.NotNil(err)
+
+	// existing ref
+	ref, err = ParseReference(fmt.Sprintf("%s/testrepo/testchart:1.2.3", suite.DockerRegistryHost))
+	suite.Nil(err)
+	err = suite.RegistryClient.PushChart(ref)
+	suite.Nil(err)
+}
+
+func (suite *RegistryClientTestSuite) Test_3_ResolveChartVersion() {
+
+	// tag already set
+	ref, err := ParseReference(fmt.Sprintf("%s/testrepo/testchart:9.9.9", suite.DockerRegistryHost))
+	suite.Nil(err)
+	resolved, err := suite.RegistryClient.ResolveChartVersion(ref, "^1.0.0")
+	suite.Nil(err)
+	suite.Equal("9.9.9", resolved.Tag)
+
+	// matching constraint
+	ref, err = ParseReference(fmt.Sprintf("%s/testrepo/testchart", suite.DockerRegistryHost))
+	suite.Nil(err)
+	resolved, err = suite.RegistryClient.ResolveChartVersion(ref, "~1.2.0")
+	suite.Nil(err)
+	suite.Equal("1.2.3", resolved.Tag)
+
+	// latest
+	resolved, err = suite.RegistryClient.ResolveChartVersion(ref, "")
+	suite.Nil(err)
+	suite.Equal("1.2.3", resolved.Tag)
+
+	// no matching version
+	_, err = suite.RegistryClient.ResolveChartVersion(ref, ">=2.0.0")
+	suite.NotNil(err)
+
+	// latest as defined by the version resolver
+	client := suite.RegistryClient.WithVersionResolver(versions.Strategy{Latest: "<1.0.0"})
+	_, err = client.ResolveChartVersion(ref, "")
+	suite.NotNil(err)
+	resolved, err = client.ResolveChartVersion(ref, "~1.2.0")
+	suite.Nil(err)
+	suite.Equal("1.2.3", resolved.Tag)
+}
+
+func (suite *RegistryClientTestSuite) Test_3_Tags() {
+
+	// non-existent repo
+	ref, err := ParseReference(fmt.Sprintf("%s/testrepo/whodis", suite.DockerRegistryHost))
+	suite.Nil(err)
+	_, err = suite.RegistryClient.Tags(ref)
+	suite.NotNil(err)
+
+	// existing repo
+	ref, err = ParseReference(fmt.Sprintf("%s/testrepo/testchart", suite.DockerRegistryHost))
+	suite.Nil(err)
+	tags, err := suite.RegistryClient.Tags(ref)
+	suite.Nil(err)
+	suite.Equal([]string{"1.2.3"}, tags)
+}
+
+func (suite *RegistryClientTestSuite) Test_4_FetchChart() {
+
+	// non-existent ref
+	ref, err := ParseReference(fmt.Sprintf("%s/testrepo/whodis:9.9.9", suite.DockerRegistryHost))
+	suite.Nil(err)
+	_, _, err = suite.RegistryClient.FetchChart(ref)
+	suite.NotNil(err)
+
+	// existing ref
+	ref, err = ParseReference(fmt.Sprintf("%s/testrepo/testchart:1.2.3", suite.DockerRegistryHost))
+	suite.Nil(err)
+	r, content, err := suite.RegistryClient.FetchChart(ref)
+	suite.Nil(err)
+	suite.Equal("testchart", r.Chart.Metadata.Name)
+	suite.Equal(r.Size, int64(len(content)))
+}
+
+func (suite *RegistryClientTestSuite) Test_4_PullChart() {
+
+	// non-existent ref
+	ref, err := ParseReference(fmt.Sprintf("%s/testrepo/whodis:9.9.9", suite.DockerRegistryHost))
+	suite.Nil(err)
+	err = suite.RegistryClient.PullChart(ref)
+	suite.NotNil(err)
+
+	// existing ref
+	ref, err = ParseReference(fmt.Sprintf("%s/testrepo/testchart:1.2.3", suite.DockerRegistryHost))
+	suite.Nil(err)
+	err = suite.RegistryClient.PullChart(ref)
+	suite.Nil(err)
+}
+
+func (suite *RegistryClientTestSuite) Test_4_PullPlugin() {
+	store := orascontent.NewMemoryStore()
+	config := store.Add("", HelmPluginConfigMediaType, []byte("{}"))
+	generic := store.Add("", HelmPluginContentLayerMediaType, []byte("generic"))
+	native := store.Add("", HelmPluginContentLayerMediaType, []byte("native"))
+	native.Annotations = map[string]string{PluginPlatformAnnotation: runtime.GOOS + "/" + runtime.GOARCH}
+	prov := store.Add("", HelmPluginProvenanceLayerMediaType, []byte("provenance"))
+	prov.Annotations = map[string]string{PluginArchiveAnnotation: native.Digest.String()}
+	ref, err := ParseReference(fmt.Sprintf("%s/testrepo/testplugin:0.1.0", suite.DockerRegistryHost))
+	suite.Nil(err)
+	manifest, err := oras.Push(context.Background(), suite.RegistryClient.resolver, ref.FullName(), store,
+		[]ocispec.Descriptor{generic, native, prov}, oras.WithConfig(config), oras.WithNameValidation(nil))
+	suite.Nil(err, "no error pushing plugin")
+
+	// tag
+	archive, err := suite.RegistryClient.PullPlugin(ref)
+	suite.Nil(err)
+	suite.Equal("native", string(archive.Content))
+	suite.Equal("provenance", string(archive.Provenance))
+	suite.Equal(manifest.Digest.String(), archive.Digest)
+
+	// digest
+	ref.Tag = ""
+	ref.Digest = manifest.Digest.String()
+	archive, err = suite.RegistryClient.PullPlugin(ref)
+	suite.Nil(err)
+	suite.Equal("native", string(archive.Content))
+
+	// no tag or digest
+	ref.Digest = ""
+	_, err = suite.RegistryClient.PullPlugin(ref)
+	suite.NotNil(err)
+}
+
+func (suite *RegistryClientTestSuite) Test_4_PushChartArchive() {
+	ch := &chart.Chart{
+		Metadata: &chart.Metadata{
+			APIVersion: "v1",
+			Name:       "signedchart",
+			Version:    "2.0.0+build",
+		},
+	}
+	path, err := chartutil.Save(ch, suite.CacheRootDir)
+	suite.Nil(err)
+	archive, err := ioutil.ReadFile(path)
+	suite.Nil(err)
+
+	// the tag defaults to the chart version
+	ref, err := ParseReference(fmt.Sprintf("%s/testrepo/signedchart", suite.DockerRegistryHost))
+	suite.Nil(err)
+	digest, err := suite.RegistryClient.PushChartArchive(ref, archive, []byte("chart provenance"))
+	suite.Nil(err)
+	suite.Equal("2.0.0_build", ref.Tag)
+	suite.NotEmpty(digest)
+
+	// the archive is pulled unchanged, ignoring the provenance layer
+	_, content, err := suite.RegistryClient.FetchChart(ref)
+	suite.Nil(err)
+	suite.Equal(archive, content)
+
+	// only the config or the archive is pulled, without caching
+	metadata, err := suite.RegistryClient.FetchChartMetadata(ref)
+	suite.Nil(err)
+	suite.Equal("signedchart", metadata.Name)
+	suite.Equal("2.0.0+build", metadata.Version)
+	content, err = suite.RegistryClient.FetchChartArchive(ref)
+	suite.Nil(err)
+	suite.Equal(archive, content)
+	content, err = suite.RegistryClient.FetchChartProvenance(ref)
+	suite.Nil(err)
+	suite.Equal([]byte("chart provenance"), content)
+
+	// the chart is described by the annotations of its manifest
+	cv, err := suite.RegistryClient.FetchChartVersion(ref)
+	suite.Nil(err)
+	suite.Equal(digest, cv.Digest)
+	suite.Equal("signedchart", cv.Metadata.Name)
+	suite.Equal("2.0.0+build", cv.Metadata.Version)
+	suite.Equal("v1", cv.Metadata.APIVersion)
+	suite.False(cv.Created.IsZero())
+
+	// not a chart
+	_, err = suite.RegistryClient.PushChartArchive(ref, []byte("not a chart"), nil)
+	suite.NotNil(err)
+}
+
+func (suite *RegistryClientTestSuite) Test_5_PushSBOM() {
+	ref, err := ParseReference(fmt.Sprintf("%s/testrepo/signedchart:2.0.0_build", suite.DockerRegistryHost))
+	suite.Nil(err)
+
+	// no SBOM attached yet
+	_, _, err = suite.RegistryClient.FetchSBOM(ref)
+	suite.NotNil(err)
+
+	sbom := []byte(`{"spdxVersion": "SPDX-2.2"}`)
+	digest, err := suite.RegistryClient.PushSBOM(ref, "application/spdx+json", sbom)
+	suite.Nil(err)
+	suite.NotEmpty(digest)
+
+	content, mediaType, err := suite.RegistryClient.FetchSBOM(ref)
+	suite.Nil(err)
+	suite.Equal(sbom, content)
+	suite.Equal("application/spdx+json", mediaType)
+
+	// the SBOM is tagged after the chart manifest, but is not a version
+	tags, err := suite.RegistryClient.Tags(&Reference{Repo: ref.Repo})
+	suite.Nil(err)
+	suite.Len(tags, 2)
+	latest, err := suite.RegistryClient.ResolveChartVersion(&Reference{Repo: ref.Repo}, "")
+	suite.Nil(err)
+	suite.Equal("2.0.0_build", latest.Tag)
+
+	// no tag or digest
+	ref.Tag = ""
+	_, err = suite.RegistryClient.PushSBOM(ref, "application/spdx+json", sbom)
+	suite.NotNil(err)
+}
+
+func (suite *RegistryClientTestSuite) Test_5_PushReleaseBundle() {
+	ref, err := ParseReference(fmt.Sprintf("%s/testrepo/bundles/myrelease:3", suite.DockerRegistryHost))
+	suite.Nil(err)
+
+	bundle := &ReleaseBundle{
+		Metadata: ReleaseBundleMetadata{
+			Name:         "myrelease",
+			Namespace:    "default",
+			Revision:     3,
+			Chart:        "testchart",
+			ChartVersion: "1.2.3",
+			Created:      time.Now(),
+		},
+		Manifest: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n"),
+		Values:   []byte("replicas: 2\n"),
+	}
+	digest, err := suite.RegistryClient.PushReleaseBundle(ref, bundle)
+	suite.Nil(err)
+	suite.NotEmpty(digest)
+
+	manifest, err := suite.RegistryClient.fetchBlob(ref, HelmReleaseManifestLayerMediaType)
+	suite.Nil(err)
+	suite.Equal(bundle.Manifest, manifest)
+	values, err := suite.RegistryClient.fetchBlob(&Reference{Repo: ref.Repo, Digest: digest}, HelmReleaseValuesLayerMediaType)
+	suite.Nil(err)
+	suite.Equal(bundle.Values, values)
+	_, err = suite.RegistryClient.fetchBlob(ref, HelmReleaseHooksLayerMediaType)
+	suite.NotNil(err, "no hooks layer without hooks")
+
+	// no tag
+	ref.Tag = ""
+	_, err = suite.RegistryClient.PushReleaseBundle(ref, bundle)
+	suite.NotNil(err)
+}
+
+func (suite *RegistryClientTestSuite) Test_6_FetchCosignSignatures() {
+	ref, err := ParseReference(fmt.Sprintf("%s/testrepo/signedchart:2.0.0_build", suite.DockerRegistryHost))
+	suite.Nil(err)
+
+	// not signed yet
+	digest, sigs, err := suite.RegistryClient.FetchCosignSignatures(ref)
+	suite.Nil(err)
+	suite.NotEmpty(digest)
+	suite.Empty(sigs)
+
+	// attach a signature the way cosign does
+	store := orascontent.NewMemoryStore()
+	config := store.Add("", ocispec.MediaTypeImageConfig, []byte("{}"))
+	layer := store.Add("", CosignSignatureLayerMediaType, []byte(`{"critical":{}}`))
+	layer.Annotations = map[string]string{
+		CosignSignatureAnnotation:   "c2lnbmF0dXJl",
+		CosignCertificateAnnotation: "certificate",
+	}
+	manifest, err := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Config:    config,
+		Layers:    []ocispec.Descriptor{layer},
+	})
+	suite.Nil(err)
+	manifestDesc := store.Add("", ocispec.MediaTypeImageManifest, manifest)
+	sigRef := fmt.Sprintf("%s:%s", ref.Repo, strings.Replace(digest, ":", "-", 1)+".sig")
+	_, err = oras.Push(context.Background(), suite.RegistryClient.resolver, sigRef, store, []ocispec.Descriptor{layer},
+		oras.WithManifest(manifestDesc), oras.WithNameValidation(nil))
+	suite.Nil(err)
+
+	signedDigest, sigs, err := suite.RegistryClient.FetchCosignSignatures(ref)
+	suite.Nil(err)
+	suite.Equal(digest, signedDigest)
+	suite.Len(sigs, 1)
+	suite.Equal([]byte(`{"critical":{}}`), sigs[0].Payload)
+	suite.Equal("c2lnbmF0dXJl", sigs[0].Signature)
+	suite.Equal([]byte("certificate"), sigs[0].Certificate)
+	suite.Empty(sigs[0].Chain)
+}
+
+func (suite *RegistryClientTestSuite) Test_4_Repositories() {
+	repositories, err := suite.RegistryClient.Repositories(suite.DockerRegistryHost, "testrepo")
+	suite.Nil(err)
+	suite.Contains(repositories, "testrepo/testchart")
+	suite.Contains(repositories, "testrepo/signedchart")
+
+	repositories, err = suite.RegistryClient.Repositories(suite.DockerRegistryHost, "/testrepo/testchart/")
+	suite.Nil(err)
+	suite.Empty(repositories)
+
+	repositories, err = suite.RegistryClient.Repositories(suite.DockerRegistryHost, "")
+	suite.Nil(err)
+	suite.Contains(repositories, "testrepo/testplugin")
+}
+
+func (suite *RegistryClientTestSuite) Test_5_PrintChartTable() {
+	err := suite.RegistryClient.PrintChartTable()
+	suite.Nil(err)
+}
+
+func (suite *RegistryClientTestSuite) Test_6_RemoveChart() {
+
+	// non-existent ref
+	ref, err := ParseReference(fmt.Sprintf("%s/testrepo/whodis:9.9.9", suite.DockerRegistryHost))
+	suite.Nil(err)
+	err = suite.RegistryClient.RemoveChart(ref)
+	suite.NotNil(err)
+
+	// existing ref
+	ref, err = ParseReference(fmt.Sprintf("%s/testrepo/testchart:1.2.3", suite.DockerRegistryHost))
+	suite.Nil(err)
+	err = suite.RegistryClient.RemoveChart(ref)
+	suite.Nil(err)
+}
+
+func (suite *RegistryClientTestSuite) Test_7_Logins() {
+	logins, err := suite.RegistryClient.Logins()
+	suite.Nil(err, "no error listing logins")
+	suite.Equal(1, len(logins))
+	suite.Equal(suite.DockerRegistryHost, logins[0].Host)
+	suite.Equal(testUsername, logins[0].Username)
+	suite.Equal(LoginSourceFile, logins[0].Source)
+	suite.Nil(logins[0].Expiry)
+}
+
+func (suite *RegistryClientTestSuite) Test_8_Logout() {
+	err := suite.RegistryClient.Logout("this-host-aint-real:5000")
+	suite.NotNil(err, "error logging out of registry that has no entry")
+
+	err = suite.RegistryClient.Logout(suite.DockerRegistryHost)
+	suite.Nil(err, "no error logging out of registry")
+
+	logins, err := suite.RegistryClient.Logins()
+	suite.Nil(err, "no error listing logins")
+	suite.Empty(logins)
+}
+
+func TestRegistryClientTestSuite(t *testing.T) {
+	suite.Run(t, new(RegistryClientTestSuite))
+}
+
+func TestChartAnnotations(t *testing.T) {
+	md := &chart.Metadata{
+		APIVersion:  "v2",
+		Name:        "mychart",
+		Version:     "0.1.0",
+		Description: "My chart",
+		AppVersion:  "1.0",
+		Keywords:    []string{"web", "proxy"},
+	}
+	got, created := chartFromAnnotations(chartAnnotations(md))
+	if !reflect.DeepEqual(got, md) {
+		t.Errorf("expected %+v, got %+v", md, got)
+	}
+	if created.IsZero() {
+		t.Error("expected a creation time")
+	}
+
+	if got, _ := chartFromAnnotations(map[string]string{ocispec.AnnotationTitle: "mychart"}); got != nil {
+		t.Errorf("expected no chart without a version, got %+v", got)
+	}
+}
+
+func TestNextLink(t *testing.T) {
+	tests := map[string]string{
+		"":                                      "",
+		`</v2/_catalog?last=b&n=2>; rel="next"`: "/v2/_catalog?last=b&n=2",
+		`</v2/_catalog?last=a>; rel="prev", </v2/_catalog?last=c>; rel="next"`: "/v2/_catalog?last=c",
+		`</v2/_catalog?last=a>; rel="prev"`:                                    "",
+	}
+	for header, expect := range tests {
+		if got := nextLink(header); got != expect {
+			t.Errorf("nextLink(%q) = %q, expected %q", header, got, expect)
+		}
+	}
+}
+
+// borrowed from https://github.com/phayes/freeport
+func TestSelectPluginLayer(t *testing.T) {
+	layer := func(name, platform string) ocispec.Descriptor {
+		desc := ocispec.Descriptor{
+			MediaType:   HelmPluginContentLayerMediaType,
+			Annotations: map[string]string{ocispec.AnnotationTitle: name},
+		}
+		if platform != "" {
+			desc.Annotations[PluginPlatformAnnotation] = platform
+		}
+		return desc
+	}
+	layers := []ocispec.Descriptor{
+		{MediaType: HelmPluginConfigMediaType},
+		layer("any", ""),
+		layer("linux", "linux"),
+		layer("linux-arm64", "linux/arm64"),
+		layer("darwin-amd64", "darwin/amd64"),
+	}
+	tests := []struct {
+		goos, goarch, expect string
+	}{
+		{"linux", "arm64", "linux-arm64"},
+		{"linux", "amd64", "linux"},
+		{"darwin", "amd64", "darwin-amd64"},
+		{"windows", "amd64", "any"},
+	}
+	for _, tt := range tests {
+		got, err := selectPluginLayer(layers, tt.goos, tt.goarch)
+		if err != nil {
+			t.Fatalf("%s/%s: %s", tt.goos, tt.goarch, err)
+		}
+		if name := got.Annotations[ocispec.AnnotationTitle]; name != tt.expect {
+			t.Errorf("%s/%s: expected layer %s, got %s", tt.goos, tt.goarch, tt.expect, name)
+		}
+	}
+
+	if _, err := selectPluginLayer(layers[2:4], "windows", "amd64"); err == nil {
+		t.Error("expected an error without a layer for the platform")
+	}
+}
+
+func getFreePort() (int, error) {
+	addr, err := net.ResolveTCPAddr("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+
+	l, err := net.ListenTCP("tcp", addr)
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
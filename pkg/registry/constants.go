@@ -0,0 +1,112 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry // import "helm.sh/helm/v3/pkg/registry"
+
+const (
+	// OCIScheme is the URL scheme for OCI-based requests
+	OCIScheme = "oci"
+
+	// HelmChartConfigMediaType is the reserved media type for the Helm chart manifest config
+	HelmChartConfigMediaType = "application/vnd.cncf.helm.config.v1+json"
+
+	// HelmChartContentLayerMediaType is the reserved media type for Helm chart package content
+	HelmChartContentLayerMediaType = "application/tar+gzip"
+
+	// HelmChartProvenanceLayerMediaType is the reserved media type for the
+	// provenance of Helm chart packages
+	HelmChartProvenanceLayerMediaType = "application/vnd.cncf.helm.chart.provenance.v1.prov"
+
+	// HelmPluginConfigMediaType is the reserved media type for the Helm plugin manifest config
+	HelmPluginConfigMediaType = "application/vnd.cncf.helm.plugin.config.v1+json"
+
+	// HelmPluginContentLayerMediaType is the reserved media type for Helm plugin archives
+	HelmPluginContentLayerMediaType = "application/vnd.cncf.helm.plugin.content.v1.tar+gzip"
+
+	// HelmPluginProvenanceLayerMediaType is the reserved media type for the
+	// provenance of Helm plugin archives
+	HelmPluginProvenanceLayerMediaType = "application/vnd.cncf.helm.plugin.provenance.v1.prov"
+
+	// HelmChartSBOMConfigMediaType is the reserved media type for the config
+	// of SBOMs attached to Helm charts. The SBOM itself is the only layer,
+	// with the media type of its format.
+	HelmChartSBOMConfigMediaType = "application/vnd.cncf.helm.chart.sbom.config.v1+json"
+
+	// HelmReleaseConfigMediaType is the reserved media type for the config of
+	// rendered release bundles, describing the release
+	HelmReleaseConfigMediaType = "application/vnd.cncf.helm.release.config.v1+json"
+
+	// HelmReleaseManifestLayerMediaType is the reserved media type for the
+	// rendered manifest of a release bundle
+	HelmReleaseManifestLayerMediaType = "application/vnd.cncf.helm.release.manifest.v1+yaml"
+
+	// HelmReleaseHooksLayerMediaType is the reserved media type for the
+	// rendered hooks of a release bundle
+	HelmReleaseHooksLayerMediaType = "application/vnd.cncf.helm.release.hooks.v1+yaml"
+
+	// HelmReleaseValuesLayerMediaType is the reserved media type for the
+	// values a release bundle was rendered with
+	HelmReleaseValuesLayerMediaType = "application/vnd.cncf.helm.release.values.v1+yaml"
+
+	// CosignSignatureLayerMediaType is the media type of the layers of cosign
+	// signatures, each holding a signed payload.
+	CosignSignatureLayerMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+
+	// CosignSignatureAnnotation is the layer annotation holding the base64
+	// encoded cosign signature of the payload of the layer.
+	CosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	// CosignCertificateAnnotation and CosignChainAnnotation are the layer
+	// annotations holding the signing certificate of a keyless cosign
+	// signature and its chain.
+	CosignCertificateAnnotation = "dev.sigstore.cosign/certificate"
+	CosignChainAnnotation       = "dev.sigstore.cosign/chain"
+
+	// CosignBundleAnnotation is the layer annotation holding the Rekor
+	// bundle of a keyless cosign signature.
+	CosignBundleAnnotation = "dev.sigstore.cosign/bundle"
+
+	// ChartAPIVersionAnnotation is the manifest annotation holding the API
+	// version of a chart. Its name, version, description and creation time
+	// are held by the annotations predefined by the OCI image spec.
+	ChartAPIVersionAnnotation = "sh.helm.chart.apiVersion"
+
+	// ChartAppVersionAnnotation is the manifest annotation holding the app
+	// version of a chart.
+	ChartAppVersionAnnotation = "sh.helm.chart.appVersion"
+
+	// ChartKeywordsAnnotation is the manifest annotation holding the
+	// comma-separated keywords of a chart.
+	ChartKeywordsAnnotation = "sh.helm.chart.keywords"
+
+	// PluginPlatformAnnotation is the layer annotation holding the platform,
+	// as os or os/arch, a plugin archive is built for. Layers without it
+	// are used on any platform.
+	PluginPlatformAnnotation = "sh.helm.plugin.platform"
+
+	// PluginArchiveAnnotation is the provenance layer annotation holding the
+	// digest of the plugin archive it signs.
+	PluginArchiveAnnotation = "sh.helm.plugin.archive"
+)
+
+// KnownMediaTypes returns a list of layer mediaTypes that the Helm client knows about
+func KnownMediaTypes() []string {
+	return []string{
+		HelmChartConfigMediaType,
+		HelmChartContentLayerMediaType,
+		HelmChartProvenanceLayerMediaType,
+	}
+}
@@ -0,0 +1,31 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package registry provides a client for storing Helm charts and plugins in
+OCI-compliant registries.
+
+A Client is created with NewClient and configured with the ClientOpt
+functions, such as ClientOptLogger and ClientOptCredentials. It logs in to
+and out of registries, pushes and pulls charts and plugins, and lists the tags
+and repositories of a registry. Charts and plugins are addressed by a
+Reference, parsed from a string with ParseReference.
+
+Charts pulled with PullChart and saved with SaveChart are kept in a Cache, an
+OCI layout on disk, from which they are loaded and pushed. The Fetch methods
+pull from a registry without storing in the cache. Charts are also fetched
+from oci:// URLs by the OCI getter of the getter package.
+*/
+package registry // import "helm.sh/helm/v3/pkg/registry"
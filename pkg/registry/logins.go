@@ -0,0 +1,133 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry // import "helm.sh/helm/v3/pkg/registry"
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/docker/cli/cli/config/credentials"
+	"github.com/pkg/errors"
+)
+
+const (
+	// LoginSourceFile is the source of credentials stored in the
+	// credentials file
+	LoginSourceFile = "file"
+	// LoginSourceStore is the prefix of the source of credentials kept by
+	// the default credentials store, as "store:<name>"
+	LoginSourceStore = "store:"
+	// LoginSourceHelper is the prefix of the source of credentials kept by
+	// the credential helper of a host, as "helper:<name>"
+	LoginSourceHelper = "helper:"
+)
+
+// Login describes the stored credentials of a registry host
+type Login struct {
+	// Host is the registry host the credentials are for
+	Host string `json:"host"`
+	// Username is the user logged in, empty for a token
+	Username string `json:"username,omitempty"`
+	// Source is where the credentials are kept: LoginSourceFile, or a
+	// credentials store or credential helper
+	Source string `json:"source"`
+	// Expiry is when the token in the credentials expires, nil if unknown
+	Expiry *time.Time `json:"expiry,omitempty"`
+}
+
+// Logins lists the registry hosts with stored credentials, sorted by host
+func (c *Client) Logins() ([]*Login, error) {
+	cfg, err := loadCredentialsFile(c.credentialsFile)
+	if err != nil {
+		return nil, err
+	}
+	auths, err := cfg.GetAllCredentials()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read stored credentials")
+	}
+
+	var logins []*Login
+	for host, auth := range auths {
+		login := &Login{
+			Host:     host,
+			Username: auth.Username,
+			Source:   LoginSourceFile,
+		}
+		if helper, ok := cfg.CredentialHelpers[host]; ok {
+			login.Source = LoginSourceHelper + helper
+		} else if cfg.CredentialsStore != "" {
+			login.Source = LoginSourceStore + cfg.CredentialsStore
+		}
+		for _, token := range []string{auth.RegistryToken, auth.IdentityToken, auth.Password} {
+			if expiry := tokenExpiry(token); expiry != nil {
+				login.Expiry = expiry
+				break
+			}
+		}
+		logins = append(logins, login)
+	}
+	sort.Slice(logins, func(i, j int) bool {
+		return logins[i].Host < logins[j].Host
+	})
+	return logins, nil
+}
+
+// loadCredentialsFile loads the credentials file the way the authorizer
+// does, using the default credentials store of the platform if the file
+// holds no credentials.
+func loadCredentialsFile(path string) (*configfile.ConfigFile, error) {
+	cfg := configfile.New(path)
+	f, err := os.Open(path)
+	if err == nil {
+		defer f.Close()
+		if err := cfg.LoadFromReader(f); err != nil {
+			return nil, errors.Wrapf(err, "failed to load credentials file %s", path)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	if !cfg.ContainsAuth() {
+		cfg.CredentialsStore = credentials.DetectDefaultStore(cfg.CredentialsStore)
+	}
+	return cfg, nil
+}
+
+// tokenExpiry returns the expiry of a JSON web token, or nil if token is not
+// one or does not expire. The token is not verified.
+func tokenExpiry(token string) *time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil
+	}
+	var claims struct {
+		Expiry int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Expiry == 0 {
+		return nil
+	}
+	expiry := time.Unix(claims.Expiry, 0).UTC()
+	return &expiry
+}
@@ -0,0 +1,133 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"helm.sh/helm/v3/pkg/helmpath"
+)
+
+func testToken(payload string) string {
+	return "e30." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".c2ln"
+}
+
+func TestTokenExpiry(t *testing.T) {
+	expiry := time.Unix(1700000000, 0).UTC()
+	tests := []struct {
+		token  string
+		expect *time.Time
+	}{
+		{"", nil},
+		{"password", nil},
+		{"a.b.c", nil},
+		{testToken(`{"sub":"user"}`), nil},
+		{testToken(`{"exp":1700000000}`), &expiry},
+	}
+	for _, tt := range tests {
+		got := tokenExpiry(tt.token)
+		if (got == nil) != (tt.expect == nil) || got != nil && !got.Equal(*tt.expect) {
+			t.Errorf("tokenExpiry(%q) = %v, expected %v", tt.token, got, tt.expect)
+		}
+	}
+}
+
+func TestLogins(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-registry-logins-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	credentialsFile := filepath.Join(dir, CredentialsFileBasename)
+	config := `{"auths": {
+	"b.example.com": {"auth": "` + base64.StdEncoding.EncodeToString([]byte("user:pass")) + `"},
+	"a.example.com": {"identitytoken": "` + testToken(`{"exp":1700000000}`) + `"}
+}}`
+	if err := ioutil.WriteFile(credentialsFile, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewClient(ClientOptCredentialsFile(credentialsFile), ClientOptWriter(ioutil.Discard))
+	if err != nil {
+		t.Fatal(err)
+	}
+	logins, err := client.Logins()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logins) != 2 {
+		t.Fatalf("expected 2 logins, got %d", len(logins))
+	}
+	if logins[0].Host != "a.example.com" || logins[0].Expiry == nil || logins[0].Expiry.Unix() != 1700000000 {
+		t.Errorf("unexpected login %+v", logins[0])
+	}
+	if logins[1].Host != "b.example.com" || logins[1].Username != "user" || logins[1].Expiry != nil {
+		t.Errorf("unexpected login %+v", logins[1])
+	}
+	for _, l := range logins {
+		if l.Source != LoginSourceFile {
+			t.Errorf("expected %s to be stored in the file, got %q", l.Host, l.Source)
+		}
+	}
+}
+
+func TestLegacyCredentialsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-registry-credentials-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer os.Unsetenv(helmpath.CacheHomeEnvVar)
+	defer os.Unsetenv(helmpath.ConfigHomeEnvVar)
+	os.Setenv(helmpath.CacheHomeEnvVar, filepath.Join(dir, "cache"))
+	os.Setenv(helmpath.ConfigHomeEnvVar, filepath.Join(dir, "config"))
+
+	current := filepath.Join(dir, "config", RegistryConfigBasename)
+	legacy := filepath.Join(dir, "cache", "registry", CredentialsFileBasename)
+	other := filepath.Join(dir, "other.json")
+
+	if got := legacyCredentialsFile(current); got != current {
+		t.Errorf("expected %s without legacy credentials, got %s", current, got)
+	}
+	if err := os.MkdirAll(filepath.Dir(legacy), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(legacy, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := legacyCredentialsFile(current); got != legacy {
+		t.Errorf("expected legacy credentials %s, got %s", legacy, got)
+	}
+	if got := legacyCredentialsFile(other); got != other {
+		t.Errorf("expected %s to be kept, got %s", other, got)
+	}
+	if err := os.MkdirAll(filepath.Dir(current), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(current, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := legacyCredentialsFile(current); got != current {
+		t.Errorf("expected %s once it exists, got %s", current, got)
+	}
+}
@@ -14,7 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package registry // import "helm.sh/helm/v3/internal/experimental/registry"
+package registry // import "helm.sh/helm/v3/pkg/registry"
 
 import (
 	"errors"
@@ -23,17 +23,18 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/opencontainers/go-digest"
 )
 
 var (
 	validPortRegEx = regexp.MustCompile(`^([1-9]\d{0,3}|0|[1-5][0-9]{4}|6[0-4][0-9]{3}|65[0-4][0-9]{2}|655[0-2][0-9]|6553[0-5])$`) // adapted from https://stackoverflow.com/a/12968117
-	// TODO: Currently we don't support digests, so we are only splitting on the
-	// colon. However, when we add support for digests, we'll need to use the
-	// regexp anyway to split on both colons and @, so leaving it like this for
-	// now
+	// The digest is split off at the @ before splitting on the colon.
 	referenceDelimiter = regexp.MustCompile(`[:]`)
 	errEmptyRepo       = errors.New("parsed repo was empty")
 	errTooManyColons   = errors.New("ref may only contain a single colon character (:) unless specifying a port number")
+	errBackslash       = errors.New("ref may not contain backslashes, its path is separated by forward slashes (/)")
+	errPathSegment     = errors.New("ref path may not contain empty, '.' or '..' segments")
 )
 
 type (
@@ -41,6 +42,9 @@ type (
 	Reference struct {
 		Tag  string
 		Repo string
+		// Digest pins the reference to a manifest digest, e.g.
+		// sha256:<hex>. It may be set with or without a tag.
+		Digest string
 	}
 )
 
@@ -49,7 +53,16 @@ func ParseReference(s string) (*Reference, error) {
 	if s == "" {
 		return nil, errEmptyRepo
 	}
-	// Split the components of the string on the colon or @, if it is more than 3,
+	var dgst string
+	if i := strings.Index(s, "@"); i >= 0 {
+		d, err := digest.Parse(s[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid digest %q: %s", s[i+1:], err)
+		}
+		s, dgst = s[:i], d.String()
+	}
+
+	// Split the components of the string on the colon, if it is more than 3,
 	// immediately return an error. Other validation will be performed later in
 	// the function
 	splitComponents := fixSplitComponents(referenceDelimiter.Split(s, -1))
@@ -67,6 +80,8 @@ func ParseReference(s string) (*Reference, error) {
 		ref = &Reference{Repo: strings.Join(splitComponents[:2], ":"), Tag: splitComponents[2]}
 	}
 
+	ref.Digest = dgst
+
 	// ensure the reference is valid
 	err := ref.validate()
 	if err != nil {
@@ -76,12 +91,30 @@ func ParseReference(s string) (*Reference, error) {
 	return ref, nil
 }
 
-// FullName the full name of a reference (repo:tag)
+// FullName the full name of a reference (repo:tag@digest)
 func (ref *Reference) FullName() string {
-	if ref.Tag == "" {
-		return ref.Repo
+	name := ref.Repo
+	if ref.Tag != "" {
+		name = fmt.Sprintf("%s:%s", name, ref.Tag)
+	}
+	if ref.Digest != "" {
+		name = fmt.Sprintf("%s@%s", name, ref.Digest)
+	}
+	return name
+}
+
+// Host returns the registry hostname (including any port) of a reference
+func (ref *Reference) Host() string {
+	return strings.SplitN(ref.Repo, "/", 2)[0]
+}
+
+// Path returns the repository path of a reference, relative to its host
+func (ref *Reference) Path() string {
+	parts := strings.SplitN(ref.Repo, "/", 2)
+	if len(parts) < 2 {
+		return ""
 	}
-	return fmt.Sprintf("%s:%s", ref.Repo, ref.Tag)
+	return parts[1]
 }
 
 // validate makes sure the ref meets our criteria
@@ -98,6 +131,18 @@ func (ref *Reference) validateRepo() error {
 	if ref.Repo == "" {
 		return errEmptyRepo
 	}
+	// Repos name files in caches and export directories, so path separators
+	// other than '/' and relative segments must not slip through.
+	if strings.Contains(ref.Repo, `\`) {
+		return errBackslash
+	}
+	if p := ref.Path(); p != "" || strings.HasSuffix(ref.Repo, "/") {
+		for _, segment := range strings.Split(p, "/") {
+			if segment == "" || segment == "." || segment == ".." {
+				return errPathSegment
+			}
+		}
+	}
 	// Makes sure the repo results in a parsable URL (similar to what is done
 	// with containerd reference parsing)
 	_, err := url.Parse(ref.Repo)
@@ -38,6 +38,15 @@ func TestParseReference(t *testing.T) {
 	_, err = ParseReference(s)
 	is.Error(err, "ref contains too many colons (3)")
 
+	s = `localhost:5000\myrepo\mychart:1.5.0`
+	_, err = ParseReference(s)
+	is.Equal(errBackslash, err)
+
+	for _, s = range []string{"localhost:5000/myrepo/../mychart", "localhost:5000/./mychart", "localhost:5000//mychart", "localhost:5000/"} {
+		_, err = ParseReference(s)
+		is.Equal(errPathSegment, err, s)
+	}
+
 	// good refs
 	s = "mychart"
 	ref, err := ParseReference(s)
@@ -108,6 +117,8 @@ func TestParseReference(t *testing.T) {
 	is.Equal("localhost:5000/x/y/z", ref.Repo)
 	is.Equal("123", ref.Tag)
 	is.Equal("localhost:5000/x/y/z:123", ref.FullName())
+	is.Equal("localhost:5000", ref.Host())
+	is.Equal("x/y/z", ref.Path())
 
 	s = "localhost:5000/x/y/z:123:x"
 	_, err = ParseReference(s)
@@ -116,4 +127,25 @@ func TestParseReference(t *testing.T) {
 	s = "localhost:5000/x/y/z:123:x:y"
 	_, err = ParseReference(s)
 	is.Error(err, "ref contains too many colons (4)")
+
+	dgst := "sha256:b5b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7"
+	s = "localhost:5000/x/y/z@" + dgst
+	ref, err = ParseReference(s)
+	is.NoError(err)
+	is.Equal("localhost:5000/x/y/z", ref.Repo)
+	is.Equal("", ref.Tag)
+	is.Equal(dgst, ref.Digest)
+	is.Equal(s, ref.FullName())
+
+	s = "localhost:5000/x/y/z:1.2.0@" + dgst
+	ref, err = ParseReference(s)
+	is.NoError(err)
+	is.Equal("localhost:5000/x/y/z", ref.Repo)
+	is.Equal("1.2.0", ref.Tag)
+	is.Equal(dgst, ref.Digest)
+	is.Equal(s, ref.FullName())
+
+	s = "localhost:5000/x/y/z@sha256:bad"
+	_, err = ParseReference(s)
+	is.Error(err, "invalid digest")
 }
@@ -0,0 +1,148 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry // import "helm.sh/helm/v3/pkg/registry"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	orascontext "github.com/deislabs/oras/pkg/context"
+	units "github.com/docker/go-units"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+// IsOCI determines whether or not a URL is to be treated as an OCI URL
+func IsOCI(url string) bool {
+	return strings.HasPrefix(url, fmt.Sprintf("%s://", OCIScheme))
+}
+
+// tagToVersion converts an OCI tag to a semver string. Tags cannot contain
+// the '+' character, so build metadata is conventionally separated by '_'.
+func tagToVersion(tag string) string {
+	return strings.Replace(tag, "_", "+", 1)
+}
+
+// versionToTag converts a semver string to an OCI tag, the reverse of
+// tagToVersion.
+func versionToTag(version string) string {
+	return strings.Replace(version, "+", "_", 1)
+}
+
+// chartAnnotations returns the manifest annotations describing a chart, so
+// that registries can be listed without pulling the config of every chart.
+func chartAnnotations(md *chart.Metadata) map[string]string {
+	annotations := map[string]string{
+		ocispec.AnnotationTitle:   md.Name,
+		ocispec.AnnotationVersion: md.Version,
+		ocispec.AnnotationCreated: time.Now().UTC().Format(time.RFC3339),
+		ChartAPIVersionAnnotation: md.APIVersion,
+	}
+	if md.Description != "" {
+		annotations[ocispec.AnnotationDescription] = md.Description
+	}
+	if md.AppVersion != "" {
+		annotations[ChartAppVersionAnnotation] = md.AppVersion
+	}
+	if len(md.Keywords) > 0 {
+		annotations[ChartKeywordsAnnotation] = strings.Join(md.Keywords, ",")
+	}
+	return annotations
+}
+
+// chartFromAnnotations returns the metadata of a chart and when it was
+// pushed from the annotations of its manifest, or nil if they do not name
+// the chart.
+func chartFromAnnotations(annotations map[string]string) (*chart.Metadata, time.Time) {
+	md := &chart.Metadata{
+		APIVersion:  annotations[ChartAPIVersionAnnotation],
+		Name:        annotations[ocispec.AnnotationTitle],
+		Version:     annotations[ocispec.AnnotationVersion],
+		Description: annotations[ocispec.AnnotationDescription],
+		AppVersion:  annotations[ChartAppVersionAnnotation],
+	}
+	if md.Name == "" || md.Version == "" || md.APIVersion == "" {
+		return nil, time.Time{}
+	}
+	if keywords := annotations[ChartKeywordsAnnotation]; keywords != "" {
+		md.Keywords = strings.Split(keywords, ",")
+	}
+	created, _ := time.Parse(time.RFC3339, annotations[ocispec.AnnotationCreated])
+	return md, created
+}
+
+// nextLink returns the path of the next page from a Link header of a
+// paginated registry API response, or an empty string on the last page.
+func nextLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 || !strings.Contains(parts[1], `rel="next"`) {
+			continue
+		}
+		return strings.Trim(strings.TrimSpace(parts[0]), "<>")
+	}
+	return ""
+}
+
+// byteCountBinary produces a human-readable file size
+func byteCountBinary(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
+// shortDigest returns first 7 characters of a sha256 digest
+func shortDigest(digest string) string {
+	if len(digest) == 64 {
+		return digest[:7]
+	}
+	return digest
+}
+
+// timeAgo returns a human-readable timestamp representing time that has passed
+func timeAgo(t time.Time) string {
+	return units.HumanDuration(time.Now().UTC().Sub(t))
+}
+
+// ctx retrieves a fresh context.
+// disable verbose logging coming from ORAS (unless debug is enabled)
+func ctx(out io.Writer, debug bool) context.Context {
+	return withLogger(context.Background(), out, debug)
+}
+
+// withLogger derives a context from parent with the logger of ORAS, which
+// discards verbose logging unless debug is enabled
+func withLogger(parent context.Context, out io.Writer, debug bool) context.Context {
+	if !debug {
+		return orascontext.WithLoggerDiscarded(parent)
+	}
+	ctx := orascontext.WithLoggerFromWriter(parent, out)
+	orascontext.GetLogger(ctx).Logger.SetLevel(logrus.DebugLevel)
+	return ctx
+}
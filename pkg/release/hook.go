@@ -50,6 +50,22 @@ const (
 
 func (x HookDeletePolicy) String() string { return string(x) }
 
+// HookFailurePolicy specifies what happens when a hook fails
+type HookFailurePolicy string
+
+// Hook failure policy types
+const (
+	// HookFailRelease fails the operation running the hook. It is the default.
+	HookFailRelease HookFailurePolicy = "fail-release"
+	// HookWarnAndContinue logs the failure and carries on with the operation.
+	HookWarnAndContinue HookFailurePolicy = "warn-and-continue"
+	// HookRetry runs the hook again up to the number of times in the hook
+	// retries annotation, failing the operation if it never succeeds.
+	HookRetry HookFailurePolicy = "retry"
+)
+
+func (x HookFailurePolicy) String() string { return string(x) }
+
 // HookAnnotation is the label name for a hook
 const HookAnnotation = "helm.sh/hook"
 
@@ -59,6 +75,17 @@ const HookWeightAnnotation = "helm.sh/hook-weight"
 // HookDeleteAnnotation is the label name for the delete policy for a hook
 const HookDeleteAnnotation = "helm.sh/hook-delete-policy"
 
+// HookFailureAnnotation is the label name for the failure policy for a hook
+const HookFailureAnnotation = "helm.sh/hook-failure-policy"
+
+// HookRetriesAnnotation is the label name for the number of times a hook with
+// the retry failure policy is run again. It defaults to 1.
+const HookRetriesAnnotation = "helm.sh/hook-retries"
+
+// DependsOnAnnotation is the annotation listing the resources, as Kind/name
+// or name separated by commas, that must be applied before the annotated one
+const DependsOnAnnotation = "helm.sh/depends-on"
+
 // Hook defines a hook object.
 type Hook struct {
 	Name string `json:"name,omitempty"`
@@ -76,6 +103,12 @@ type Hook struct {
 	Weight int `json:"weight,omitempty"`
 	// DeletePolicies are the policies that indicate when to delete the hook
 	DeletePolicies []HookDeletePolicy `json:"delete_policies,omitempty"`
+	// FailurePolicy is the policy that indicates what to do when the hook
+	// fails. Empty means HookFailRelease.
+	FailurePolicy HookFailurePolicy `json:"failure_policy,omitempty"`
+	// Retries is the number of times the hook is run again after failing
+	// with the HookRetry failure policy
+	Retries int `json:"retries,omitempty"`
 }
 
 // A HookExecution records the result for the last execution of a hook for a given release.
@@ -15,7 +15,10 @@ limitations under the License.
 
 package release
 
-import "helm.sh/helm/v3/pkg/chart"
+import (
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/warning"
+)
 
 // Release describes a deployment of a chart, together with the chart
 // and the variables used to deploy that chart.
@@ -29,6 +32,10 @@ type Release struct {
 	// Config is the set of extra Values added to the chart.
 	// These values override the default values inside of the chart.
 	Config map[string]interface{} `json:"config,omitempty"`
+	// ValueSources are the sources of the values in Config, such as the
+	// values file or the flag that set them, keyed by their path such as
+	// "image.tag".
+	ValueSources map[string]string `json:"valueSources,omitempty"`
 	// Manifest is the string representation of the rendered template.
 	Manifest string `json:"manifest,omitempty"`
 	// Hooks are all of the hooks declared for this release.
@@ -37,6 +44,20 @@ type Release struct {
 	Version int `json:"version,omitempty"`
 	// Namespace is the kubernetes namespace of the release.
 	Namespace string `json:"namespace,omitempty"`
+	// Namespaces are all namespaces the resources and hooks of the release
+	// are in, including Namespace.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Labels are user metadata attached to the release, such as the team
+	// owning it, that releases can be selected by.
+	Labels map[string]string `json:"labels,omitempty"`
+	// ManagesNamespace records that the release created its namespace,
+	// which uninstalling it may then delete. Adopted namespaces are not
+	// managed.
+	ManagesNamespace bool `json:"managesNamespace,omitempty"`
+	// Warnings are the problems found by the operation that returned the
+	// release, such as loading or rendering its chart, that did not stop it.
+	// They are not stored with the release.
+	Warnings warning.Warnings `json:"-"`
 }
 
 // SetStatus is a helper for setting the status on a release.
@@ -0,0 +1,125 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseutil
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// SortByDependencies reorders manifests so that every manifest comes after
+// the ones named in its helm.sh/depends-on annotation. When uninstall is
+// true, the order is reversed so that dependents come first. Manifests
+// without dependencies between them keep their relative order, and
+// dependencies on resources outside of manifests are ignored.
+func SortByDependencies(manifests []Manifest, uninstall bool) ([]Manifest, error) {
+	byKey := make(map[string]int, len(manifests))
+	byName := make(map[string][]int, len(manifests))
+	for i, m := range manifests {
+		if m.Head == nil || m.Head.Metadata == nil {
+			continue
+		}
+		byKey[m.Head.Kind+"/"+m.Head.Metadata.Name] = i
+		byName[m.Head.Metadata.Name] = append(byName[m.Head.Metadata.Name], i)
+	}
+
+	// before[i] holds the manifests that must come before manifest i.
+	before := make([]map[int]bool, len(manifests))
+	for i := range before {
+		before[i] = map[int]bool{}
+	}
+	for i, m := range manifests {
+		for _, dep := range dependencies(m) {
+			var targets []int
+			if strings.Contains(dep, "/") {
+				if j, ok := byKey[dep]; ok {
+					targets = []int{j}
+				}
+			} else {
+				targets = byName[dep]
+			}
+			for _, j := range targets {
+				if j == i {
+					continue
+				}
+				if uninstall {
+					before[j][i] = true
+				} else {
+					before[i][j] = true
+				}
+			}
+		}
+	}
+
+	sorted := make([]Manifest, 0, len(manifests))
+	done := make([]bool, len(manifests))
+	for len(sorted) < len(manifests) {
+		progress := false
+		for i := range manifests {
+			if done[i] || !allDone(before[i], done) {
+				continue
+			}
+			done[i] = true
+			sorted = append(sorted, manifests[i])
+			progress = true
+			// Start over so that earlier manifests unblocked by this one
+			// keep their place.
+			break
+		}
+		if !progress {
+			var cycle []string
+			for i, m := range manifests {
+				if done[i] {
+					continue
+				}
+				if m.Head != nil && m.Head.Metadata != nil {
+					cycle = append(cycle, m.Head.Kind+"/"+m.Head.Metadata.Name)
+				} else {
+					cycle = append(cycle, m.Name)
+				}
+			}
+			return nil, errors.Errorf("circular %s dependency between %s", release.DependsOnAnnotation, strings.Join(cycle, ", "))
+		}
+	}
+	return sorted, nil
+}
+
+// dependencies returns the references in the depends-on annotation of m.
+func dependencies(m Manifest) []string {
+	if m.Head == nil || m.Head.Metadata == nil {
+		return nil
+	}
+	var deps []string
+	for _, d := range strings.Split(m.Head.Metadata.Annotations[release.DependsOnAnnotation], ",") {
+		if d = strings.TrimSpace(d); d != "" {
+			deps = append(deps, d)
+		}
+	}
+	return deps
+}
+
+func allDone(set map[int]bool, done []bool) bool {
+	for i := range set {
+		if !done[i] {
+			return false
+		}
+	}
+	return true
+}
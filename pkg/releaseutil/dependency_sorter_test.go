@@ -0,0 +1,83 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseutil
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func dependencyManifest(t *testing.T, kind, name, dependsOn string) Manifest {
+	t.Helper()
+	content := "kind: " + kind + "\nmetadata:\n  name: " + name + "\n"
+	if dependsOn != "" {
+		content += "  annotations:\n    helm.sh/depends-on: " + dependsOn + "\n"
+	}
+	var head SimpleHead
+	if err := yaml.Unmarshal([]byte(content), &head); err != nil {
+		t.Fatal(err)
+	}
+	return Manifest{Name: kind + "/" + name, Content: content, Head: &head}
+}
+
+func manifestNames(manifests []Manifest) string {
+	names := make([]string, len(manifests))
+	for i, m := range manifests {
+		names[i] = m.Name
+	}
+	return strings.Join(names, " ")
+}
+
+func TestSortByDependencies(t *testing.T) {
+	manifests := []Manifest{
+		dependencyManifest(t, "Deployment", "web", "Service/db, cache"),
+		dependencyManifest(t, "ConfigMap", "settings", ""),
+		dependencyManifest(t, "Service", "db", "missing"),
+		dependencyManifest(t, "Deployment", "cache", ""),
+	}
+
+	sorted, err := SortByDependencies(manifests, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "ConfigMap/settings Service/db Deployment/cache Deployment/web"
+	if got := manifestNames(sorted); got != expected {
+		t.Errorf("Expected install order %q, got %q", expected, got)
+	}
+
+	sorted, err = SortByDependencies(manifests, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "Deployment/web ConfigMap/settings Service/db Deployment/cache"
+	if got := manifestNames(sorted); got != expected {
+		t.Errorf("Expected uninstall order %q, got %q", expected, got)
+	}
+}
+
+func TestSortByDependenciesCycle(t *testing.T) {
+	manifests := []Manifest{
+		dependencyManifest(t, "ConfigMap", "a", "b"),
+		dependencyManifest(t, "ConfigMap", "b", "ConfigMap/a"),
+	}
+	_, err := SortByDependencies(manifests, false)
+	if err == nil || !strings.Contains(err.Error(), "ConfigMap/a, ConfigMap/b") {
+		t.Errorf("Expected a circular dependency error, got %v", err)
+	}
+}
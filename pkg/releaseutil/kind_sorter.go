@@ -16,7 +16,10 @@ limitations under the License.
 
 package releaseutil
 
-import "sort"
+import (
+	"sort"
+	"strings"
+)
 
 // KindSortOrder is an ordering of Kinds.
 type KindSortOrder []string
@@ -99,6 +102,52 @@ var UninstallOrder KindSortOrder = []string{
 	"Namespace",
 }
 
+// KindOrderAnnotation is the Chart.yaml annotation listing, separated by
+// commas, the kinds a chart needs installed before all others.
+const KindOrderAnnotation = "helm.sh/kind-order"
+
+// InstallOrderWith returns InstallOrder changed so that kinds are installed
+// first, in the given order.
+func InstallOrderWith(kinds []string) KindSortOrder {
+	order := append(KindSortOrder{}, kinds...)
+	return append(order, withoutKinds(InstallOrder, kinds)...)
+}
+
+// UninstallOrderWith returns UninstallOrder changed so that kinds are
+// uninstalled last, in the reverse of the given order.
+func UninstallOrderWith(kinds []string) KindSortOrder {
+	reversed := make([]string, len(kinds))
+	for i, k := range kinds {
+		reversed[len(kinds)-1-i] = k
+	}
+	return append(withoutKinds(UninstallOrder, kinds), reversed...)
+}
+
+// ParseKindOrder splits a comma separated list of kinds.
+func ParseKindOrder(s string) []string {
+	var kinds []string
+	for _, k := range strings.Split(s, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds
+}
+
+func withoutKinds(order KindSortOrder, kinds []string) KindSortOrder {
+	skip := make(map[string]bool, len(kinds))
+	for _, k := range kinds {
+		skip[k] = true
+	}
+	out := make(KindSortOrder, 0, len(order))
+	for _, k := range order {
+		if !skip[k] {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
 // sortByKind does an in-place sort of manifests by Kind.
 //
 // Results are sorted by 'ordering', keeping order of items with equal kind/priority
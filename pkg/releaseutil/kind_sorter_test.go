@@ -266,3 +266,26 @@ func TestKindSorterNamespaceAgainstUnknown(t *testing.T) {
 		}
 	}
 }
+
+func TestKindOrderWith(t *testing.T) {
+	kinds := ParseKindOrder(" Secret, ConfigMap ,")
+	if len(kinds) != 2 || kinds[0] != "Secret" || kinds[1] != "ConfigMap" {
+		t.Fatalf("Unexpected kinds %v", kinds)
+	}
+
+	install := InstallOrderWith(kinds)
+	if len(install) != len(InstallOrder) {
+		t.Errorf("Expected %d kinds, got %d", len(InstallOrder), len(install))
+	}
+	if install[0] != "Secret" || install[1] != "ConfigMap" || install[2] != "Namespace" {
+		t.Errorf("Unexpected install order %v", install[:3])
+	}
+
+	uninstall := UninstallOrderWith(kinds)
+	if len(uninstall) != len(UninstallOrder) {
+		t.Errorf("Expected %d kinds, got %d", len(UninstallOrder), len(uninstall))
+	}
+	if n := len(uninstall); uninstall[n-2] != "ConfigMap" || uninstall[n-1] != "Secret" {
+		t.Errorf("Unexpected uninstall order %v", uninstall[n-2:])
+	}
+}
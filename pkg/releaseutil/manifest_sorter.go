@@ -130,6 +130,15 @@ func SortManifests(files map[string]string, apis chartutil.VersionSet, ordering
 //  metadata:
 // 		annotations:
 // 			helm.sh/hook-delete-policy: hook-succeeded
+//
+// To determine what to do when the hook fails, it looks for a YAML structure like this:
+//
+//  kind: SomeKind
+//  apiVersion: v1
+//  metadata:
+// 		annotations:
+// 			helm.sh/hook-failure-policy: retry
+// 			helm.sh/hook-retries: "3"
 func (file *manifestFile) sort(result *result) error {
 	// Go through manifests in order found in file (function `SplitManifests` creates integer-sortable keys)
 	var sortedEntryKeys []string
@@ -193,6 +202,10 @@ func (file *manifestFile) sort(result *result) error {
 			continue
 		}
 
+		if err := setHookFailurePolicy(h, entry); err != nil {
+			return errors.Wrapf(err, "invalid hook %s in %s", h.Name, file.path)
+		}
+
 		result.hooks = append(result.hooks, h)
 
 		operateAnnotationValues(entry, release.HookDeleteAnnotation, func(value string) {
@@ -222,6 +235,29 @@ func calculateHookWeight(entry SimpleHead) int {
 	return hw
 }
 
+// setHookFailurePolicy sets the failure policy of h, and the number of times
+// to retry it, from the hook failure policy and hook retries annotations.
+func setHookFailurePolicy(h *release.Hook, entry SimpleHead) error {
+	policy := strings.ToLower(strings.TrimSpace(entry.Metadata.Annotations[release.HookFailureAnnotation]))
+	switch release.HookFailurePolicy(policy) {
+	case "", release.HookFailRelease, release.HookWarnAndContinue:
+		h.FailurePolicy = release.HookFailurePolicy(policy)
+	case release.HookRetry:
+		h.FailurePolicy = release.HookRetry
+		h.Retries = 1
+		if rs, ok := entry.Metadata.Annotations[release.HookRetriesAnnotation]; ok {
+			retries, err := strconv.Atoi(strings.TrimSpace(rs))
+			if err != nil || retries < 1 {
+				return errors.Errorf("%s must be a positive number, got %q", release.HookRetriesAnnotation, rs)
+			}
+			h.Retries = retries
+		}
+	default:
+		return errors.Errorf("unknown %s %q", release.HookFailureAnnotation, policy)
+	}
+	return nil
+}
+
 // operateAnnotationValues finds the given annotation and runs the operate function with the value of that annotation
 func operateAnnotationValues(entry SimpleHead, annotation string, operate func(p string)) {
 	if dps, ok := entry.Metadata.Annotations[annotation]; ok {
@@ -226,3 +226,47 @@ metadata:
 		}
 	}
 }
+
+func TestSortManifestsHookFailurePolicy(t *testing.T) {
+	hook := func(annotations string) string {
+		return `apiVersion: v1
+kind: Job
+metadata:
+  name: migrate
+  annotations:
+    "helm.sh/hook": pre-upgrade
+` + annotations
+	}
+
+	tests := []struct {
+		name        string
+		annotations string
+		policy      release.HookFailurePolicy
+		retries     int
+		wantErr     bool
+	}{
+		{"default", "", "", 0, false},
+		{"fail release", "    helm.sh/hook-failure-policy: fail-release\n", release.HookFailRelease, 0, false},
+		{"warn and continue", "    helm.sh/hook-failure-policy: Warn-And-Continue\n", release.HookWarnAndContinue, 0, false},
+		{"retry once", "    helm.sh/hook-failure-policy: retry\n", release.HookRetry, 1, false},
+		{"retry", "    helm.sh/hook-failure-policy: retry\n    helm.sh/hook-retries: \"3\"\n", release.HookRetry, 3, false},
+		{"bad retries", "    helm.sh/hook-failure-policy: retry\n    helm.sh/hook-retries: none\n", "", 0, true},
+		{"unknown policy", "    helm.sh/hook-failure-policy: ignore\n", "", 0, true},
+	}
+	for _, tt := range tests {
+		hs, _, err := SortManifests(map[string]string{"job": hook(tt.annotations)}, chartutil.VersionSet{"v1"}, InstallOrder)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.name, err)
+			continue
+		}
+		if hs[0].FailurePolicy != tt.policy || hs[0].Retries != tt.retries {
+			t.Errorf("%s: expected policy %q with %d retries, got %q with %d", tt.name, tt.policy, tt.retries, hs[0].FailurePolicy, hs[0].Retries)
+		}
+	}
+}
@@ -34,6 +34,8 @@ import (
 	"helm.sh/helm/v3/pkg/getter"
 	"helm.sh/helm/v3/pkg/helmpath"
 	"helm.sh/helm/v3/pkg/provenance"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/versions"
 )
 
 // Entry represents a collection of parameters for chart repository
@@ -54,6 +56,9 @@ type ChartRepository struct {
 	IndexFile  *IndexFile
 	Client     getter.Getter
 	CachePath  string
+	// RegistryClient lists the charts of a repository that is a namespace
+	// of an OCI registry. A default client is used if it is nil.
+	RegistryClient RegistryClient
 }
 
 // NewChartRepository constructs ChartRepository
@@ -109,26 +114,18 @@ func (r *ChartRepository) Load() error {
 }
 
 // DownloadIndexFile fetches the index from a repository.
+//
+// The index of a repository whose URL is an oci:// reference to a namespace
+// of a registry is synthesized from the charts under the namespace, as
+// RegistryIndex does.
 func (r *ChartRepository) DownloadIndexFile() (string, error) {
-	parsedURL, err := url.Parse(r.Config.URL)
-	if err != nil {
-		return "", err
-	}
-	parsedURL.RawPath = path.Join(parsedURL.RawPath, "index.yaml")
-	parsedURL.Path = path.Join(parsedURL.Path, "index.yaml")
-
-	indexURL := parsedURL.String()
-	// TODO add user-agent
-	resp, err := r.Client.Get(indexURL,
-		getter.WithURL(r.Config.URL),
-		getter.WithTLSClientConfig(r.Config.CertFile, r.Config.KeyFile, r.Config.CAFile),
-		getter.WithBasicAuth(r.Config.Username, r.Config.Password),
-	)
-	if err != nil {
-		return "", err
+	var index []byte
+	var err error
+	if registry.IsOCI(r.Config.URL) {
+		index, err = r.registryIndex()
+	} else {
+		index, err = r.fetchIndex()
 	}
-
-	index, err := ioutil.ReadAll(resp)
 	if err != nil {
 		return "", err
 	}
@@ -153,6 +150,46 @@ func (r *ChartRepository) DownloadIndexFile() (string, error) {
 	return fname, ioutil.WriteFile(fname, index, 0644)
 }
 
+// fetchIndex fetches the index.yaml file of the repository.
+func (r *ChartRepository) fetchIndex() ([]byte, error) {
+	parsedURL, err := url.Parse(r.Config.URL)
+	if err != nil {
+		return nil, err
+	}
+	parsedURL.RawPath = path.Join(parsedURL.RawPath, "index.yaml")
+	parsedURL.Path = path.Join(parsedURL.Path, "index.yaml")
+
+	indexURL := parsedURL.String()
+	// TODO add user-agent
+	resp, err := r.Client.Get(indexURL,
+		getter.WithURL(r.Config.URL),
+		getter.WithTLSClientConfig(r.Config.CertFile, r.Config.KeyFile, r.Config.CAFile),
+		getter.WithBasicAuth(r.Config.Username, r.Config.Password),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(resp)
+}
+
+// registryIndex lists the charts of the registry namespace of the repository
+// as an index.yaml file.
+func (r *ChartRepository) registryIndex() ([]byte, error) {
+	client := r.RegistryClient
+	if client == nil {
+		c, err := registry.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		client = c
+	}
+	i, err := RegistryIndex(client, r.Config.URL, "", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(i)
+}
+
 // Index generates an index for the chart repository and writes an index.yaml file.
 func (r *ChartRepository) Index() error {
 	err := r.generateIndex()
@@ -201,6 +238,14 @@ func FindChartInRepoURL(repoURL, chartName, chartVersion, certFile, keyFile, caF
 // without adding repo to repositories, like FindChartInRepoURL,
 // but it also receives credentials for the chart repository.
 func FindChartInAuthRepoURL(repoURL, username, password, chartName, chartVersion, certFile, keyFile, caFile string, getters getter.Providers) (string, error) {
+	return ResolveChartInAuthRepoURL(nil, repoURL, username, password, chartName, chartVersion, certFile, keyFile, caFile, getters)
+}
+
+// ResolveChartInAuthRepoURL finds chart in chart repository pointed by
+// repoURL like FindChartInAuthRepoURL, but resolves chartVersion against the
+// versions of the chart with resolver. It resolves like
+// FindChartInAuthRepoURL if resolver is nil.
+func ResolveChartInAuthRepoURL(resolver versions.Resolver, repoURL, username, password, chartName, chartVersion, certFile, keyFile, caFile string, getters getter.Providers) (string, error) {
 
 	// Download and write the index file to a temporary location
 	buf := make([]byte, 20)
@@ -235,7 +280,7 @@ func FindChartInAuthRepoURL(repoURL, username, password, chartName, chartVersion
 	if chartVersion != "" {
 		errMsg = fmt.Sprintf("%s version %q", errMsg, chartVersion)
 	}
-	cv, err := repoIndex.Get(chartName, chartVersion)
+	cv, err := repoIndex.Resolve(resolver, chartName, chartVersion)
 	if err != nil {
 		return "", errors.Errorf("%s not found in %s repository", errMsg, repoURL)
 	}
@@ -33,6 +33,7 @@ import (
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/provenance"
+	"helm.sh/helm/v3/pkg/versions"
 )
 
 var indexPath = "index.yaml"
@@ -148,6 +149,13 @@ func (i IndexFile) SortEntries() {
 // If version is empty, this will return the chart with the latest stable version,
 // prerelease versions will be skipped.
 func (i IndexFile) Get(name, version string) (*ChartVersion, error) {
+	return i.Resolve(nil, name, version)
+}
+
+// Resolve returns the ChartVersion for the given name that resolver picks as
+// the best for version, a version or a constraint. It resolves like Get if
+// resolver is nil.
+func (i IndexFile) Resolve(resolver versions.Resolver, name, version string) (*ChartVersion, error) {
 	vs, ok := i.Entries[name]
 	if !ok {
 		return nil, ErrNoChartName
@@ -155,37 +163,25 @@ func (i IndexFile) Get(name, version string) (*ChartVersion, error) {
 	if len(vs) == 0 {
 		return nil, ErrNoChartVersion
 	}
-
-	var constraint *semver.Constraints
-	if version == "" {
-		constraint, _ = semver.NewConstraint("*")
-	} else {
-		var err error
-		constraint, err = semver.NewConstraint(version)
-		if err != nil {
-			return nil, err
-		}
+	if resolver == nil {
+		resolver = versions.Default
 	}
 
-	// when customer input exact version, check whether have exact match one first
-	if len(version) != 0 {
+	available := make([]string, len(vs))
+	for n, ver := range vs {
+		available[n] = ver.Version
+	}
+	resolved, err := resolver.Resolve(version, available)
+	if err != nil {
+		return nil, err
+	}
+	if len(resolved) > 0 {
 		for _, ver := range vs {
-			if version == ver.Version {
+			if ver.Version == resolved[0] {
 				return ver, nil
 			}
 		}
 	}
-
-	for _, ver := range vs {
-		test, err := semver.NewVersion(ver.Version)
-		if err != nil {
-			continue
-		}
-
-		if constraint.Check(test) {
-			return ver, nil
-		}
-	}
 	return nil, errors.Errorf("no chart version found for %s-%s", name, version)
 }
 
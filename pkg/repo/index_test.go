@@ -30,6 +30,7 @@ import (
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/getter"
 	"helm.sh/helm/v3/pkg/helmpath"
+	"helm.sh/helm/v3/pkg/versions"
 
 	"helm.sh/helm/v3/pkg/chart"
 )
@@ -83,6 +84,33 @@ func TestIndexFile(t *testing.T) {
 	}
 }
 
+func TestIndexFileResolve(t *testing.T) {
+	i := NewIndexFile()
+	i.Add(&chart.Metadata{Name: "cutter", Version: "1.0.0"}, "cutter-1.0.0.tgz", "http://example.com/charts", "sha256:1234567890abc")
+	i.Add(&chart.Metadata{Name: "cutter", Version: "1.1.0-beta.1"}, "cutter-1.1.0-beta.1.tgz", "http://example.com/charts", "sha256:1234567890abc")
+	i.Add(&chart.Metadata{Name: "cutter", Version: "1.1.0-rc.1"}, "cutter-1.1.0-rc.1.tgz", "http://example.com/charts", "sha256:1234567890abc")
+	i.SortEntries()
+
+	cv, err := i.Resolve(nil, "cutter", "")
+	if err != nil || cv.Version != "1.0.0" {
+		t.Errorf("Expected version 1.0.0, got %v (%v)", cv, err)
+	}
+
+	resolver := versions.Strategy{
+		Prereleases:        versions.PrereleaseInclude,
+		ExcludePrereleases: []string{"rc"},
+	}
+	cv, err = i.Resolve(resolver, "cutter", "")
+	if err != nil || cv.Version != "1.1.0-beta.1" {
+		t.Errorf("Expected version 1.1.0-beta.1, got %v (%v)", cv, err)
+	}
+
+	resolver = versions.Strategy{ExcludePrereleases: []string{"rc"}}
+	if _, err := i.Resolve(resolver, "cutter", ">1.1.0-beta.1"); err == nil {
+		t.Error("Expected release candidates to be excluded")
+	}
+}
+
 func TestLoadIndex(t *testing.T) {
 	b, err := ioutil.ReadFile(testfile)
 	if err != nil {
@@ -0,0 +1,140 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo // import "helm.sh/helm/v3/pkg/repo"
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/versions"
+)
+
+// RegistryClient lists the charts of an OCI registry. It is implemented by
+// *registry.Client.
+type RegistryClient interface {
+	Repositories(host, namespace string) ([]string, error)
+	Tags(ref *registry.Reference) ([]string, error)
+	FetchChartVersion(ref *registry.Reference) (*registry.ChartVersion, error)
+}
+
+// RegistryIndex returns the index of the virtual chart repository made of the
+// charts under a namespace of an OCI registry, given as oci://host[/namespace].
+//
+// Each repository under the namespace holding charts is a chart, named by its
+// path below the namespace, and each of its tags that is a version satisfying
+// constraint is a version of the chart. An empty constraint allows every
+// version, and with latest only the newest version of each chart is indexed.
+// Constraints are resolved with resolver, or versions.Default if it is nil.
+// The chart versions are described by the annotations of their manifests, and
+// their URLs are OCI references.
+func RegistryIndex(client RegistryClient, url, constraint string, latest bool, resolver versions.Resolver) (*IndexFile, error) {
+	if !registry.IsOCI(url) {
+		return nil, errors.Errorf("registry %q must be an oci:// reference", url)
+	}
+	parts := strings.SplitN(strings.Trim(strings.TrimPrefix(url, registry.OCIScheme+"://"), "/"), "/", 2)
+	host, namespace := parts[0], ""
+	if len(parts) == 2 {
+		namespace = parts[1]
+	}
+	if resolver == nil {
+		resolver = versions.Default
+	}
+	if _, err := resolver.Resolve(constraint, nil); err != nil {
+		return nil, errors.Wrap(err, "an invalid version/constraint format")
+	}
+
+	repositories, err := client.Repositories(host, namespace)
+	if err != nil {
+		return nil, err
+	}
+	i := NewIndexFile()
+	for _, repository := range repositories {
+		ref := &registry.Reference{Repo: path.Join(host, repository)}
+		tags, err := client.Tags(ref)
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(repository, namespace), "/")
+		tags, err = registryVersions(tags, resolver, constraint)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			ref.Tag = tag
+			cv, err := client.FetchChartVersion(ref)
+			if errors.Cause(err) == registry.ErrNotChart {
+				// Not every repository of a registry holds charts.
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			i.Entries[name] = append(i.Entries[name], &ChartVersion{
+				Metadata: cv.Metadata,
+				URLs:     []string{registry.OCIScheme + "://" + ref.FullName()},
+				Created:  cv.Created,
+				Digest:   cv.Digest,
+			})
+			if latest {
+				break
+			}
+		}
+	}
+	i.SortEntries()
+	return i, nil
+}
+
+// registryVersions returns the tags that are versions satisfying constraint,
+// best first as resolved by resolver, or all versions newest first if
+// constraint is empty. Build metadata is separated by '_' in tags.
+func registryVersions(tags []string, resolver versions.Resolver, constraint string) ([]string, error) {
+	byVersion := make(map[string]string, len(tags))
+	parsed := make(map[string]*semver.Version, len(tags))
+	var available []string
+	for _, tag := range tags {
+		v := strings.Replace(tag, "_", "+", 1)
+		sv, err := semver.NewVersion(v)
+		if err != nil {
+			continue
+		}
+		if _, ok := byVersion[v]; !ok {
+			byVersion[v] = tag
+			parsed[v] = sv
+			available = append(available, v)
+		}
+	}
+	if constraint == "" {
+		sort.SliceStable(available, func(i, j int) bool {
+			return parsed[available[i]].GreaterThan(parsed[available[j]])
+		})
+	} else {
+		var err error
+		if available, err = resolver.Resolve(constraint, available); err != nil {
+			return nil, err
+		}
+	}
+	resolved := make([]string, len(available))
+	for i, v := range available {
+		resolved[i] = byVersion[v]
+	}
+	return resolved, nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/helmpath"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/versions"
+)
+
+// testRegistryClient is a registry of repositories, by path, holding tags.
+// Tags of repositories under "images/" are not charts.
+type testRegistryClient map[string][]string
+
+func (c testRegistryClient) Repositories(host, namespace string) ([]string, error) {
+	var repositories []string
+	for repository := range c {
+		if namespace == "" || strings.HasPrefix(repository, namespace+"/") {
+			repositories = append(repositories, repository)
+		}
+	}
+	return repositories, nil
+}
+
+func (c testRegistryClient) Tags(ref *registry.Reference) ([]string, error) {
+	return c[ref.Path()], nil
+}
+
+func (c testRegistryClient) FetchChartVersion(ref *registry.Reference) (*registry.ChartVersion, error) {
+	if strings.HasPrefix(ref.Path(), "images/") {
+		return nil, errors.Wrap(registry.ErrNotChart, ref.FullName())
+	}
+	return &registry.ChartVersion{
+		Metadata: &chart.Metadata{
+			APIVersion: chart.APIVersionV2,
+			Name:       filepath.Base(ref.Path()),
+			Version:    strings.Replace(ref.Tag, "_", "+", 1),
+		},
+		Digest: "sha256:" + ref.Tag,
+	}, nil
+}
+
+var testRegistry = testRegistryClient{
+	"charts/alpine":      {"0.1.0", "latest", "0.2.0-rc.1", "0.2.0"},
+	"charts/team/nginx":  {"1.0.0_build.1"},
+	"images/alpine":      {"3.11"},
+	"other/charts/mysql": {"1.0.0"},
+}
+
+func TestRegistryIndex(t *testing.T) {
+	i, err := RegistryIndex(testRegistry, "oci://example.com/charts", "", false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(i.Entries) != 2 {
+		t.Fatalf("expected 2 charts, got %d", len(i.Entries))
+	}
+	alpine := i.Entries["alpine"]
+	if len(alpine) != 3 {
+		t.Fatalf("expected 3 versions of alpine, got %d", len(alpine))
+	}
+	for n, expect := range []string{"0.2.0", "0.2.0-rc.1", "0.1.0"} {
+		if alpine[n].Version != expect {
+			t.Errorf("expected version %s at %d, got %s", expect, n, alpine[n].Version)
+		}
+	}
+	if url := alpine[0].URLs[0]; url != "oci://example.com/charts/alpine:0.2.0" {
+		t.Errorf("unexpected URL %s", url)
+	}
+	if alpine[0].Digest != "sha256:0.2.0" {
+		t.Errorf("unexpected digest %s", alpine[0].Digest)
+	}
+	nginx, err := i.Get("team/nginx", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nginx.Version != "1.0.0+build.1" || nginx.URLs[0] != "oci://example.com/charts/team/nginx:1.0.0_build.1" {
+		t.Errorf("unexpected chart version %s at %s", nginx.Version, nginx.URLs[0])
+	}
+
+	// only the latest version satisfying the constraint
+	i, err = RegistryIndex(testRegistry, "oci://example.com/charts/", "<0.2.0", true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(i.Entries["alpine"]) != 1 || i.Entries["alpine"][0].Version != "0.1.0" {
+		t.Errorf("expected only alpine 0.1.0, got %v", i.Entries["alpine"])
+	}
+
+	// the whole registry
+	i, err = RegistryIndex(testRegistry, "oci://example.com", "", true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !i.Has("other/charts/mysql", "1.0.0") || i.Has("images/alpine", "") {
+		t.Errorf("unexpected charts %v", i.Entries)
+	}
+
+	if _, err := RegistryIndex(testRegistry, "https://example.com", "", false, nil); err == nil {
+		t.Error("expected an error for a URL that is not an OCI reference")
+	}
+	if _, err := RegistryIndex(testRegistry, "oci://example.com", "not a constraint", false, nil); err == nil {
+		t.Error("expected an error for an invalid constraint")
+	}
+}
+
+func TestRegistryVersions(t *testing.T) {
+	tags := []string{"0.1.0", "latest", "0.3.0-rc.1", "0.2.0_build.1", "0.10.0"}
+	resolved, err := registryVersions(tags, versions.Default, ">0.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []string{"0.10.0", "0.2.0_build.1", "0.1.0"}
+	if !reflect.DeepEqual(resolved, expect) {
+		t.Errorf("expected %v, got %v", expect, resolved)
+	}
+
+	resolved, err = registryVersions(tags, versions.Strategy{Prereleases: versions.PrereleaseInclude}, ">0.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect = []string{"0.10.0", "0.3.0-rc.1", "0.2.0_build.1", "0.1.0"}
+	if !reflect.DeepEqual(resolved, expect) {
+		t.Errorf("expected %v with pre-releases, got %v", expect, resolved)
+	}
+
+	if resolved, _ := registryVersions([]string{"0.1.0", "0.3.0-rc.1"}, versions.Default, ""); len(resolved) != 2 {
+		t.Errorf("expected all versions without a constraint, got %d", len(resolved))
+	}
+}
+
+func TestDownloadRegistryIndexFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-registry-index-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	r, err := NewChartRepository(&Entry{
+		Name: "oci-repo",
+		URL:  "oci://example.com/charts",
+	}, getter.All(&cli.EnvSettings{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.CachePath = dir
+	r.RegistryClient = testRegistry
+
+	idx, err := r.DownloadIndexFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	i, err := LoadIndexFile(idx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cv, err := i.Get("alpine", "^0.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cv.URLs[0] != "oci://example.com/charts/alpine:0.1.0" {
+		t.Errorf("unexpected URL %s", cv.URLs[0])
+	}
+
+	charts, err := ioutil.ReadFile(filepath.Join(dir, helmpath.CacheChartsFile("oci-repo")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := strings.Fields(string(charts)); len(names) != 2 {
+		t.Errorf("expected 2 charts in the charts file, got %v", names)
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package resolver resolves references to secrets and other external values
+in the values of a release.
+
+A value written as a map with the single key "valueFrom", such as
+
+	password:
+	  valueFrom: vault:secret/data/db#password
+
+is replaced by the value the provider registered for the scheme of the
+reference, here "vault", returns for the rest of the reference. This keeps
+secrets out of values files. Helm provides the "env" and "file" schemes, and
+plugins can provide others.
+*/
+package resolver // import "helm.sh/helm/v3/pkg/resolver"
@@ -0,0 +1,70 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver // import "helm.sh/helm/v3/pkg/resolver"
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/plugin"
+)
+
+// WithPlugins returns the default providers and the value providers of the
+// installed plugins. A plugin providing a built-in scheme overrides it.
+//
+// The command of a plugin is run with the reference, without the scheme, as
+// last argument, and prints the value.
+func WithPlugins(settings *cli.EnvSettings) (Providers, error) {
+	providers := Default()
+	plugins, err := plugin.FindPlugins(settings.PluginsDirectory)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range plugins {
+		for _, vp := range p.Metadata.ValueProviders {
+			providers[vp.Scheme] = pluginProvider{settings: settings, plugin: p, command: vp.Command}
+		}
+	}
+	return providers, nil
+}
+
+type pluginProvider struct {
+	settings *cli.EnvSettings
+	plugin   *plugin.Plugin
+	command  string
+}
+
+func (p pluginProvider) Resolve(ref string) (interface{}, error) {
+	commands := strings.Split(p.command, " ")
+	argv := append(commands[1:], ref)
+	prog := exec.Command(filepath.Join(p.plugin.Dir, commands[0]), argv...)
+	plugin.SetupPluginEnv(p.settings, p.plugin.Metadata.Name, p.plugin.Dir)
+	prog.Env = os.Environ()
+	buf := bytes.NewBuffer(nil)
+	prog.Stdout = buf
+	prog.Stderr = os.Stderr
+	if err := prog.Run(); err != nil {
+		return nil, errors.Wrapf(err, "plugin %q failed to resolve %s", p.plugin.Metadata.Name, ref)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
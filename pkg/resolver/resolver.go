@@ -0,0 +1,152 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver // import "helm.sh/helm/v3/pkg/resolver"
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ValueFromKey is the key of the maps replaced by resolved values.
+const ValueFromKey = "valueFrom"
+
+// Resolver resolves the references in values.
+type Resolver interface {
+	// ResolveValues returns a copy of vals with the references replaced by
+	// the values they refer to. vals is left unchanged.
+	ResolveValues(vals map[string]interface{}) (map[string]interface{}, error)
+}
+
+// Provider resolves the references of one scheme.
+type Provider interface {
+	// Resolve returns the value ref, the reference without the scheme,
+	// refers to.
+	Resolve(ref string) (interface{}, error)
+}
+
+// ProviderFunc adapts a function to a Provider.
+type ProviderFunc func(ref string) (interface{}, error)
+
+// Resolve calls f(ref).
+func (f ProviderFunc) Resolve(ref string) (interface{}, error) {
+	return f(ref)
+}
+
+// Env resolves references to environment variables, as in "env:DB_PASSWORD".
+// Unset variables are an error.
+var Env Provider = ProviderFunc(func(ref string) (interface{}, error) {
+	v, ok := os.LookupEnv(ref)
+	if !ok {
+		return nil, errors.Errorf("environment variable %s is not set", ref)
+	}
+	return v, nil
+})
+
+// File resolves references to the content of files, as in
+// "file:/run/secrets/db-password". A trailing newline is removed.
+var File Provider = ProviderFunc(func(ref string) (interface{}, error) {
+	b, err := ioutil.ReadFile(ref)
+	if err != nil {
+		return nil, err
+	}
+	return strings.TrimSuffix(string(b), "\n"), nil
+})
+
+// Providers is a Resolver resolving references with the provider of their
+// scheme. References of other schemes are an error.
+type Providers map[string]Provider
+
+// Default returns the providers of the schemes built into Helm.
+func Default() Providers {
+	return Providers{"env": Env, "file": File}
+}
+
+// ResolveValues implements Resolver.
+func (p Providers) ResolveValues(vals map[string]interface{}) (map[string]interface{}, error) {
+	out, err := p.resolveMap("", vals)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (p Providers) resolve(path string, v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := reference(v); ok {
+			return p.resolveRef(path, ref)
+		}
+		return p.resolveMap(path, v)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			var err error
+			if out[i], err = p.resolve(path+"["+strconv.Itoa(i)+"]", item); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	return v, nil
+}
+
+func (p Providers) resolveMap(path string, m map[string]interface{}) (map[string]interface{}, error) {
+	if m == nil {
+		return nil, nil
+	}
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		key := k
+		if path != "" {
+			key = path + "." + k
+		}
+		var err error
+		if out[k], err = p.resolve(key, v); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (p Providers) resolveRef(path, ref string) (interface{}, error) {
+	i := strings.Index(ref, ":")
+	if i <= 0 {
+		return nil, errors.Errorf("%s: invalid reference %q, expected SCHEME:REF", path, ref)
+	}
+	provider, ok := p[ref[:i]]
+	if !ok {
+		return nil, errors.Errorf("%s: no provider for references of scheme %q", path, ref[:i])
+	}
+	v, err := provider.Resolve(ref[i+1:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s: failed to resolve %s", path, ref)
+	}
+	return v, nil
+}
+
+// reference returns the reference of a map holding only a valueFrom string.
+func reference(m map[string]interface{}) (string, bool) {
+	if len(m) != 1 {
+		return "", false
+	}
+	ref, ok := m[ValueFromKey].(string)
+	return ref, ok
+}
@@ -0,0 +1,152 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/cli"
+)
+
+func TestResolveValues(t *testing.T) {
+	os.Setenv("HELM_TEST_DB_PASSWORD", "s3cr3t")
+	defer os.Unsetenv("HELM_TEST_DB_PASSWORD")
+
+	dir, err := ioutil.TempDir("", "helm-resolver-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	token := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(token, []byte("abc\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	vals := map[string]interface{}{
+		"name": "db",
+		"db": map[string]interface{}{
+			"password": map[string]interface{}{"valueFrom": "env:HELM_TEST_DB_PASSWORD"},
+		},
+		"tokens": []interface{}{
+			map[string]interface{}{"valueFrom": "file:" + token},
+			"plain",
+		},
+		// A map with other keys is not a reference.
+		"env": map[string]interface{}{"name": "X", "valueFrom": "env:HELM_TEST_DB_PASSWORD"},
+	}
+	got, err := Default().ResolveValues(vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"name":   "db",
+		"db":     map[string]interface{}{"password": "s3cr3t"},
+		"tokens": []interface{}{"abc", "plain"},
+		"env":    map[string]interface{}{"name": "X", "valueFrom": "env:HELM_TEST_DB_PASSWORD"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	// The values are left unchanged.
+	if ref := vals["db"].(map[string]interface{})["password"]; !reflect.DeepEqual(ref, map[string]interface{}{"valueFrom": "env:HELM_TEST_DB_PASSWORD"}) {
+		t.Errorf("expected the reference to be left unchanged, got %v", ref)
+	}
+}
+
+func TestResolveValuesErrors(t *testing.T) {
+	os.Unsetenv("HELM_TEST_UNSET")
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"vault:secret/data/db#password", `db.password: no provider for references of scheme "vault"`},
+		{"HELM_TEST_UNSET", `db.password: invalid reference "HELM_TEST_UNSET"`},
+		{"env:HELM_TEST_UNSET", "db.password: failed to resolve env:HELM_TEST_UNSET: environment variable HELM_TEST_UNSET is not set"},
+	}
+	for _, tt := range tests {
+		vals := map[string]interface{}{
+			"db": map[string]interface{}{
+				"password": map[string]interface{}{"valueFrom": tt.ref},
+			},
+		}
+		_, err := Default().ResolveValues(vals)
+		if err == nil {
+			t.Errorf("%s: expected an error", tt.ref)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("%s: expected error %q, got %q", tt.ref, tt.want, err)
+		}
+	}
+}
+
+func TestWithPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("TODO: refactor this test to work on windows")
+	}
+
+	pluginsDir, err := ioutil.TempDir("", "helm-resolver-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(pluginsDir)
+
+	dir := filepath.Join(pluginsDir, "vault")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	metadata := `name: "vault"
+version: "0.1.0"
+valueProviders:
+- scheme: "vault"
+  command: "vault.sh --field"
+`
+	script := "#!/bin/sh\necho \"$1 $2\"\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, "plugin.yaml"), []byte(metadata), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "vault.sh"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	providers, err := WithPlugins(&cli.EnvSettings{PluginsDirectory: pluginsDir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, scheme := range []string{"env", "file", "vault"} {
+		if _, ok := providers[scheme]; !ok {
+			t.Errorf("expected a provider for %s", scheme)
+		}
+	}
+
+	got, err := providers.ResolveValues(map[string]interface{}{
+		"password": map[string]interface{}{"valueFrom": "vault:secret/data/db#password"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["password"] != "--field secret/data/db#password" {
+		t.Errorf("unexpected value %q", got["password"])
+	}
+}
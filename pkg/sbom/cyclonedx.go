@@ -0,0 +1,150 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sbom // import "helm.sh/helm/v3/pkg/sbom"
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"helm.sh/helm/v3/internal/version"
+)
+
+type cdxDocument struct {
+	BOMFormat    string          `json:"bomFormat"`
+	SpecVersion  string          `json:"specVersion"`
+	SerialNumber string          `json:"serialNumber"`
+	Version      int             `json:"version"`
+	Metadata     cdxMetadata     `json:"metadata"`
+	Components   []cdxComponent  `json:"components,omitempty"`
+	Dependencies []cdxDependency `json:"dependencies,omitempty"`
+}
+
+type cdxMetadata struct {
+	Timestamp string       `json:"timestamp"`
+	Tools     []cdxTool    `json:"tools"`
+	Component cdxComponent `json:"component"`
+}
+
+type cdxTool struct {
+	Vendor  string `json:"vendor"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type cdxComponent struct {
+	Type               string           `json:"type"`
+	BOMRef             string           `json:"bom-ref"`
+	Name               string           `json:"name"`
+	Version            string           `json:"version,omitempty"`
+	Description        string           `json:"description,omitempty"`
+	PURL               string           `json:"purl,omitempty"`
+	Hashes             []cdxHash        `json:"hashes,omitempty"`
+	ExternalReferences []cdxExternalRef `json:"externalReferences,omitempty"`
+	Components         []cdxComponent   `json:"components,omitempty"`
+}
+
+type cdxHash struct {
+	Algorithm string `json:"alg"`
+	Content   string `json:"content"`
+}
+
+type cdxExternalRef struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+func (d *Document) encodeCycloneDX() ([]byte, error) {
+	digest := d.digest()
+	doc := cdxDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.4",
+		// The serial number is a UUID derived from the content, so that
+		// the same chart always has the same serial number.
+		SerialNumber: fmt.Sprintf("urn:uuid:%s-%s-%s-%s-%s", digest[0:8], digest[8:12], digest[12:16], digest[16:20], digest[20:32]),
+		Version:      1,
+	}
+
+	n := 0
+	newRef := func(kind, name string) string {
+		n++
+		return fmt.Sprintf("%s:%s:%d", kind, name, n)
+	}
+
+	var component func(p *Package) cdxComponent
+	component = func(p *Package) cdxComponent {
+		c := cdxComponent{
+			Type:        "application",
+			BOMRef:      newRef("chart", p.Name),
+			Name:        p.Name,
+			Version:     p.Version,
+			Description: p.Description,
+			PURL:        purl(p),
+		}
+		if p.Digest != "" {
+			c.Hashes = []cdxHash{{Algorithm: "SHA-256", Content: trimDigest(p.Digest)}}
+		}
+		if p.Home != "" {
+			c.ExternalReferences = append(c.ExternalReferences, cdxExternalRef{Type: "website", URL: p.Home})
+		}
+		if p.Repository != "" {
+			c.ExternalReferences = append(c.ExternalReferences, cdxExternalRef{Type: "distribution", URL: p.Repository})
+		}
+		for _, f := range p.Files {
+			c.Components = append(c.Components, cdxComponent{
+				Type:   "file",
+				BOMRef: newRef("file", f.Path),
+				Name:   f.Path,
+				Hashes: []cdxHash{{Algorithm: "SHA-256", Content: f.Digest}},
+			})
+		}
+		dep := cdxDependency{Ref: c.BOMRef}
+		for _, child := range p.Dependencies {
+			sub := component(child)
+			doc.Components = append(doc.Components, sub)
+			dep.DependsOn = append(dep.DependsOn, sub.BOMRef)
+		}
+		doc.Dependencies = append(doc.Dependencies, dep)
+		return c
+	}
+	chart := component(d.Chart)
+
+	for _, image := range d.Images {
+		c := cdxComponent{
+			Type:   "container",
+			BOMRef: newRef("image", image),
+			Name:   image,
+		}
+		doc.Components = append(doc.Components, c)
+		for i := range doc.Dependencies {
+			if doc.Dependencies[i].Ref == chart.BOMRef {
+				doc.Dependencies[i].DependsOn = append(doc.Dependencies[i].DependsOn, c.BOMRef)
+			}
+		}
+	}
+
+	doc.Metadata = cdxMetadata{
+		Timestamp: d.Created.UTC().Format("2006-01-02T15:04:05Z"),
+		Tools:     []cdxTool{{Vendor: "Helm", Name: "helm", Version: version.GetVersion()}},
+		Component: chart,
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
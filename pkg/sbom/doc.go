@@ -0,0 +1,24 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package sbom generates software bills of materials for charts.
+
+An SBOM describes a chart, the files it is made of, its dependencies and the
+container images its templates reference, in the SPDX or CycloneDX JSON
+format. It is written next to packaged charts, and attached to charts pushed
+to registries so that it can be retrieved for supply-chain audits.
+*/
+package sbom // import "helm.sh/helm/v3/pkg/sbom"
@@ -0,0 +1,235 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sbom // import "helm.sh/helm/v3/pkg/sbom"
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/images"
+)
+
+// Format is a format of SBOMs.
+type Format string
+
+const (
+	// SPDX is the SPDX 2.2 JSON format.
+	SPDX Format = "spdx"
+	// CycloneDX is the CycloneDX 1.4 JSON format.
+	CycloneDX Format = "cyclonedx"
+)
+
+// ParseFormat returns the format named s.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case SPDX, CycloneDX:
+		return f, nil
+	}
+	return "", errors.Errorf("unknown SBOM format %q, expected spdx or cyclonedx", s)
+}
+
+// MediaType returns the media type of SBOMs in the format.
+func (f Format) MediaType() string {
+	if f == CycloneDX {
+		return "application/vnd.cyclonedx+json"
+	}
+	return "application/spdx+json"
+}
+
+// Extension returns the file extension of SBOMs in the format.
+func (f Format) Extension() string {
+	if f == CycloneDX {
+		return ".cdx.json"
+	}
+	return ".spdx.json"
+}
+
+// Document describes a chart for an SBOM.
+type Document struct {
+	// Chart is the chart the SBOM is about.
+	Chart *Package
+	// Images are the container images referenced by the templates of the
+	// chart and its dependencies, sorted.
+	Images []string
+	// Created is when the SBOM was generated.
+	Created time.Time
+}
+
+// Package is a chart, or a dependency of a chart.
+type Package struct {
+	Name        string
+	Version     string
+	AppVersion  string
+	Description string
+	Home        string
+	// Repository is the repository of a dependency.
+	Repository string
+	// Digest is the SHA-256 digest of the chart archive, as sha256:HEX, if
+	// it is known.
+	Digest string
+	// Files are the files of the chart, sorted by path, without those of
+	// its dependencies. A dependency that is not in the charts/ directory
+	// has none.
+	Files        []File
+	Dependencies []*Package
+}
+
+// File is a file of a chart.
+type File struct {
+	// Path is the path of the file in the chart.
+	Path string
+	// Digest is the SHA-256 digest of the file, in hex.
+	Digest string
+}
+
+// Describe describes a chart for an SBOM.
+//
+// The images are found in the templates of the chart rendered with its
+// default values, which processes its dependencies as an install would, so
+// the dependencies disabled by default are removed from ch. If the templates
+// cannot be rendered without other values, no images are found.
+func Describe(ch *chart.Chart) (*Document, error) {
+	if ch.Metadata == nil {
+		return nil, errors.New("chart has no metadata")
+	}
+	p := describePackage(ch)
+	images, err := renderImages(ch)
+	if err != nil {
+		return nil, err
+	}
+	return &Document{
+		Chart:   p,
+		Images:  images,
+		Created: time.Now().UTC(),
+	}, nil
+}
+
+// Encode encodes the document in the format f.
+func (d *Document) Encode(f Format) ([]byte, error) {
+	switch f {
+	case SPDX:
+		return d.encodeSPDX()
+	case CycloneDX:
+		return d.encodeCycloneDX()
+	}
+	return nil, errors.Errorf("unknown SBOM format %q", f)
+}
+
+func describePackage(ch *chart.Chart) *Package {
+	p := &Package{
+		Name:        ch.Metadata.Name,
+		Version:     ch.Metadata.Version,
+		AppVersion:  ch.Metadata.AppVersion,
+		Description: ch.Metadata.Description,
+		Home:        ch.Metadata.Home,
+	}
+	for _, f := range ch.Raw {
+		if strings.HasPrefix(f.Name, "charts/") {
+			continue
+		}
+		p.Files = append(p.Files, File{Path: f.Name, Digest: fmt.Sprintf("%x", sha256.Sum256(f.Data))})
+	}
+	sort.Slice(p.Files, func(i, j int) bool { return p.Files[i].Path < p.Files[j].Path })
+
+	// Dependencies in the charts/ directory are described with their
+	// files, and the others by their requirement.
+	vendored := map[string]*chart.Chart{}
+	for _, sub := range ch.Dependencies() {
+		vendored[sub.Name()] = sub
+	}
+	locked := map[string]string{}
+	if ch.Lock != nil {
+		for _, d := range ch.Lock.Dependencies {
+			locked[d.Name] = d.Version
+		}
+	}
+	for _, d := range ch.Metadata.Dependencies {
+		var dep *Package
+		if sub, ok := vendored[d.Name]; ok {
+			dep = describePackage(sub)
+			delete(vendored, d.Name)
+		} else {
+			dep = &Package{Name: d.Name, Version: d.Version}
+			if v, ok := locked[d.Name]; ok {
+				dep.Version = v
+			}
+		}
+		dep.Repository = d.Repository
+		p.Dependencies = append(p.Dependencies, dep)
+	}
+	for _, sub := range ch.Dependencies() {
+		if _, ok := vendored[sub.Name()]; ok {
+			p.Dependencies = append(p.Dependencies, describePackage(sub))
+		}
+	}
+	return p
+}
+
+// renderImages renders the templates of a chart with its default values,
+// and returns the images of the containers in them.
+func renderImages(ch *chart.Chart) ([]string, error) {
+	vals, err := chartutil.CoalesceValues(ch, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := chartutil.ProcessDependencies(ch, vals); err != nil {
+		return nil, err
+	}
+	options := chartutil.ReleaseOptions{Name: "release-name", Namespace: "default", IsInstall: true}
+	renderVals, err := chartutil.ToRenderValues(ch, vals, options, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Lint mode renders templates calling required or fail, with empty
+	// values where they would have failed.
+	e := engine.Engine{LintMode: true}
+	rendered, err := e.Render(ch, renderVals)
+	if err != nil {
+		return nil, nil
+	}
+
+	var found []images.Image
+	for name, content := range rendered {
+		if strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml") {
+			found = append(found, images.Extract(content)...)
+		}
+	}
+	return images.References(found), nil
+}
+
+// purl returns the package URL of a chart.
+func purl(p *Package) string {
+	s := fmt.Sprintf("pkg:helm/%s@%s", url.PathEscape(p.Name), url.PathEscape(p.Version))
+	if p.Repository != "" {
+		s += "?repository_url=" + url.QueryEscape(p.Repository)
+	}
+	return s
+}
+
+// trimDigest returns the hex of a sha256:HEX digest.
+func trimDigest(digest string) string {
+	return strings.TrimPrefix(digest, "sha256:")
+}
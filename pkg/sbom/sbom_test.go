@@ -0,0 +1,254 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sbom
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+const deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Release.Name }}
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: busybox:1.32
+      containers:
+      - name: app
+        image: "{{ .Values.image }}"
+`
+
+const pod = `apiVersion: v1
+kind: Pod
+metadata:
+  name: {{ .Release.Name }}-sidecar
+spec:
+  containers:
+  - name: sidecar
+    image: envoy:1.16
+`
+
+func testChart() *chart.Chart {
+	sub := &chart.Chart{
+		Metadata: &chart.Metadata{APIVersion: chart.APIVersionV2, Name: "sidecar", Version: "0.2.0"},
+		Templates: []*chart.File{
+			{Name: "templates/pod.yaml", Data: []byte(pod)},
+		},
+		Raw: []*chart.File{
+			{Name: "Chart.yaml", Data: []byte("name: sidecar\n")},
+			{Name: "templates/pod.yaml", Data: []byte(pod)},
+		},
+	}
+	ch := &chart.Chart{
+		Metadata: &chart.Metadata{
+			APIVersion:  chart.APIVersionV2,
+			Name:        "app",
+			Version:     "1.0.0",
+			AppVersion:  "2.0",
+			Description: "An app",
+			Home:        "https://example.com",
+			Dependencies: []*chart.Dependency{
+				{Name: "sidecar", Version: "~0.2", Repository: "https://charts.example.com"},
+				{Name: "database", Version: "^3", Repository: "oci://registry.example.com/charts"},
+			},
+		},
+		Lock: &chart.Lock{
+			Dependencies: []*chart.Dependency{
+				{Name: "sidecar", Version: "0.2.0"},
+				{Name: "database", Version: "3.1.0"},
+			},
+		},
+		Values: map[string]interface{}{"image": "example/app:2.0"},
+		Templates: []*chart.File{
+			{Name: "templates/deployment.yaml", Data: []byte(deployment)},
+			{Name: "templates/NOTES.txt", Data: []byte("image: {{ .Values.image }}")},
+		},
+		Raw: []*chart.File{
+			{Name: "Chart.yaml", Data: []byte("name: app\n")},
+			{Name: "values.yaml", Data: []byte("image: example/app:2.0\n")},
+			{Name: "templates/deployment.yaml", Data: []byte(deployment)},
+			{Name: "charts/sidecar/Chart.yaml", Data: []byte("name: sidecar\n")},
+		},
+	}
+	ch.AddDependency(sub)
+	return ch
+}
+
+func TestDescribe(t *testing.T) {
+	doc, err := Describe(testChart())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectImages := []string{"busybox:1.32", "envoy:1.16", "example/app:2.0"}
+	if !reflect.DeepEqual(doc.Images, expectImages) {
+		t.Errorf("expected images %v, got %v", expectImages, doc.Images)
+	}
+
+	p := doc.Chart
+	if p.Name != "app" || p.Version != "1.0.0" || p.AppVersion != "2.0" {
+		t.Errorf("unexpected chart %+v", p)
+	}
+	var files []string
+	for _, f := range p.Files {
+		files = append(files, f.Path)
+		if len(f.Digest) != 64 {
+			t.Errorf("unexpected digest %q of %s", f.Digest, f.Path)
+		}
+	}
+	expectFiles := []string{"Chart.yaml", "templates/deployment.yaml", "values.yaml"}
+	if !reflect.DeepEqual(files, expectFiles) {
+		t.Errorf("expected files %v, got %v", expectFiles, files)
+	}
+
+	if len(p.Dependencies) != 2 {
+		t.Fatalf("expected 2 dependencies, got %d", len(p.Dependencies))
+	}
+	sidecar, database := p.Dependencies[0], p.Dependencies[1]
+	if sidecar.Name != "sidecar" || sidecar.Version != "0.2.0" || sidecar.Repository != "https://charts.example.com" || len(sidecar.Files) != 2 {
+		t.Errorf("unexpected vendored dependency %+v", sidecar)
+	}
+	if database.Name != "database" || database.Version != "3.1.0" || database.Repository != "oci://registry.example.com/charts" || database.Files != nil {
+		t.Errorf("unexpected dependency %+v", database)
+	}
+}
+
+func TestDescribeUnrenderable(t *testing.T) {
+	ch := testChart()
+	ch.Templates = append(ch.Templates, &chart.File{Name: "templates/bad.yaml", Data: []byte("{{ .Values.nope.nope }}")})
+	doc, err := Describe(ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Images != nil {
+		t.Errorf("expected no images, got %v", doc.Images)
+	}
+}
+
+func TestEncodeSPDX(t *testing.T) {
+	doc, err := Describe(testChart())
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc.Chart.Digest = "sha256:0123"
+	data, err := doc.Encode(SPDX)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out spdxDocument
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.SPDXVersion != "SPDX-2.2" || out.Name != "app-1.0.0" {
+		t.Errorf("unexpected document %s %s", out.SPDXVersion, out.Name)
+	}
+	if !strings.HasPrefix(out.DocumentNamespace, "https://helm.sh/spdxdocs/app-1.0.0-") {
+		t.Errorf("unexpected namespace %s", out.DocumentNamespace)
+	}
+	// app, sidecar, database and 3 images
+	if len(out.Packages) != 6 {
+		t.Errorf("expected 6 packages, got %d", len(out.Packages))
+	}
+	app := out.Packages[0]
+	if app.Checksums[0].ChecksumValue != "0123" || app.ExternalRefs[0].ReferenceLocator != "pkg:helm/app@1.0.0" {
+		t.Errorf("unexpected package %+v", app)
+	}
+	if len(out.Files) != 5 {
+		t.Errorf("expected 5 files, got %d", len(out.Files))
+	}
+	counts := map[string]int{}
+	for _, r := range out.Relationships {
+		counts[r.RelationshipType]++
+	}
+	expect := map[string]int{"DESCRIBES": 1, "CONTAINS": 5, "DEPENDS_ON": 5}
+	if !reflect.DeepEqual(counts, expect) {
+		t.Errorf("expected relationships %v, got %v", expect, counts)
+	}
+	if out.Relationships[0].RelatedSPDXElement != app.SPDXID {
+		t.Errorf("expected the document to describe %s, got %+v", app.SPDXID, out.Relationships[0])
+	}
+
+	// the namespace only depends on the content
+	doc.Created = doc.Created.Add(time.Hour)
+	again, err := doc.Encode(SPDX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var outAgain spdxDocument
+	if err := json.Unmarshal(again, &outAgain); err != nil {
+		t.Fatal(err)
+	}
+	if outAgain.DocumentNamespace != out.DocumentNamespace {
+		t.Errorf("expected namespace %s, got %s", out.DocumentNamespace, outAgain.DocumentNamespace)
+	}
+}
+
+func TestEncodeCycloneDX(t *testing.T) {
+	doc, err := Describe(testChart())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := doc.Encode(CycloneDX)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out cdxDocument
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.BOMFormat != "CycloneDX" || out.SpecVersion != "1.4" || !strings.HasPrefix(out.SerialNumber, "urn:uuid:") {
+		t.Errorf("unexpected document %s %s %s", out.BOMFormat, out.SpecVersion, out.SerialNumber)
+	}
+	app := out.Metadata.Component
+	if app.Name != "app" || len(app.Components) != 3 {
+		t.Errorf("unexpected component %+v", app)
+	}
+	// sidecar, database and 3 images
+	if len(out.Components) != 5 {
+		t.Errorf("expected 5 components, got %d", len(out.Components))
+	}
+	for _, d := range out.Dependencies {
+		if d.Ref == app.BOMRef && len(d.DependsOn) != 5 {
+			t.Errorf("expected the chart to depend on 5 components, got %v", d.DependsOn)
+		}
+	}
+	if out.Components[1].PURL != "pkg:helm/database@3.1.0?repository_url=oci%3A%2F%2Fregistry.example.com%2Fcharts" {
+		t.Errorf("unexpected purl %s", out.Components[1].PURL)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, s := range []string{"spdx", "SPDX", "cyclonedx"} {
+		if _, err := ParseFormat(s); err != nil {
+			t.Errorf("unexpected error for %q: %s", s, err)
+		}
+	}
+	if _, err := ParseFormat("swid"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
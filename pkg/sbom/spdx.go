@@ -0,0 +1,194 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sbom // import "helm.sh/helm/v3/pkg/sbom"
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"helm.sh/helm/v3/internal/version"
+)
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Files             []spdxFile         `json:"files,omitempty"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name             string            `json:"name"`
+	SPDXID           string            `json:"SPDXID"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	Homepage         string            `json:"homepage,omitempty"`
+	Description      string            `json:"description,omitempty"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	CopyrightText    string            `json:"copyrightText"`
+	Checksums        []spdxChecksum    `json:"checksums,omitempty"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxFile struct {
+	FileName         string         `json:"fileName"`
+	SPDXID           string         `json:"SPDXID"`
+	Checksums        []spdxChecksum `json:"checksums"`
+	LicenseConcluded string         `json:"licenseConcluded"`
+	CopyrightText    string         `json:"copyrightText"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+const spdxNoAssertion = "NOASSERTION"
+
+// spdxInvalid matches the characters not allowed in SPDX identifiers.
+var spdxInvalid = regexp.MustCompile(`[^a-zA-Z0-9.-]+`)
+
+func (d *Document) encodeSPDX() ([]byte, error) {
+	doc := spdxDocument{
+		SPDXVersion:       "SPDX-2.2",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              d.Chart.Name + "-" + d.Chart.Version,
+		DocumentNamespace: fmt.Sprintf("https://helm.sh/spdxdocs/%s-%s-%s", d.Chart.Name, d.Chart.Version, d.digest()),
+		CreationInfo: spdxCreationInfo{
+			Created:  d.Created.UTC().Format("2006-01-02T15:04:05Z"),
+			Creators: []string{"Tool: helm-" + version.GetVersion()},
+		},
+	}
+
+	// Identifiers are numbered in the order packages are visited, so that
+	// they are unique and stable for a chart.
+	n := 0
+	newID := func(kind, name string) string {
+		n++
+		return fmt.Sprintf("SPDXRef-%s-%s-%d", kind, spdxInvalid.ReplaceAllString(name, "-"), n)
+	}
+
+	var addPackage func(p *Package) string
+	addPackage = func(p *Package) string {
+		id := newID("Chart", p.Name)
+		pkg := spdxPackage{
+			Name:             p.Name,
+			SPDXID:           id,
+			VersionInfo:      p.Version,
+			DownloadLocation: spdxNoAssertion,
+			Homepage:         p.Home,
+			Description:      p.Description,
+			LicenseConcluded: spdxNoAssertion,
+			LicenseDeclared:  spdxNoAssertion,
+			CopyrightText:    spdxNoAssertion,
+			FilesAnalyzed:    len(p.Files) > 0,
+			ExternalRefs: []spdxExternalRef{{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  purl(p),
+			}},
+		}
+		if p.Repository != "" {
+			pkg.DownloadLocation = p.Repository
+		}
+		if p.Digest != "" {
+			pkg.Checksums = []spdxChecksum{{Algorithm: "SHA256", ChecksumValue: trimDigest(p.Digest)}}
+		}
+		doc.Packages = append(doc.Packages, pkg)
+
+		for _, f := range p.Files {
+			fileID := newID("File", f.Path)
+			doc.Files = append(doc.Files, spdxFile{
+				FileName:         "./" + f.Path,
+				SPDXID:           fileID,
+				Checksums:        []spdxChecksum{{Algorithm: "SHA256", ChecksumValue: f.Digest}},
+				LicenseConcluded: spdxNoAssertion,
+				CopyrightText:    spdxNoAssertion,
+			})
+			doc.Relationships = append(doc.Relationships, spdxRelationship{id, "CONTAINS", fileID})
+		}
+		for _, dep := range p.Dependencies {
+			depID := addPackage(dep)
+			doc.Relationships = append(doc.Relationships, spdxRelationship{id, "DEPENDS_ON", depID})
+		}
+		return id
+	}
+	chartID := addPackage(d.Chart)
+	doc.Relationships = append([]spdxRelationship{{doc.SPDXID, "DESCRIBES", chartID}}, doc.Relationships...)
+
+	for _, image := range d.Images {
+		id := newID("Image", image)
+		doc.Packages = append(doc.Packages, spdxPackage{
+			Name:             image,
+			SPDXID:           id,
+			DownloadLocation: spdxNoAssertion,
+			LicenseConcluded: spdxNoAssertion,
+			LicenseDeclared:  spdxNoAssertion,
+			CopyrightText:    spdxNoAssertion,
+		})
+		doc.Relationships = append(doc.Relationships, spdxRelationship{chartID, "DEPENDS_ON", id})
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// digest returns a digest of the content of the document, in hex, which
+// identifies it regardless of when it was created.
+func (d *Document) digest() string {
+	h := sha256.New()
+	var write func(p *Package)
+	write = func(p *Package) {
+		fmt.Fprintf(h, "chart %s %s %s %s\n", p.Name, p.Version, p.Repository, p.Digest)
+		for _, f := range p.Files {
+			fmt.Fprintf(h, "file %s %s\n", f.Path, f.Digest)
+		}
+		for _, dep := range p.Dependencies {
+			write(dep)
+		}
+	}
+	write(d.Chart)
+	for _, image := range d.Images {
+		fmt.Fprintf(h, "image %s\n", image)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
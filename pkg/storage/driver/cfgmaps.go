@@ -33,6 +33,8 @@ import (
 )
 
 var _ Driver = (*ConfigMaps)(nil)
+var _ Lister = (*ConfigMaps)(nil)
+var _ Locker = (*ConfigMaps)(nil)
 
 // ConfigMapsDriverName is the string name of the driver.
 const ConfigMapsDriverName = "ConfigMap"
@@ -42,6 +44,8 @@ const ConfigMapsDriverName = "ConfigMap"
 type ConfigMaps struct {
 	impl corev1.ConfigMapInterface
 	Log  func(string, ...interface{})
+	// Encryption encrypts releases at rest if set.
+	Encryption *Encryption
 }
 
 // NewConfigMaps initializes a new ConfigMaps wrapping an implementation of
@@ -72,7 +76,7 @@ func (cfgmaps *ConfigMaps) Get(key string) (*rspb.Release, error) {
 		return nil, err
 	}
 	// found the configmap, decode the base64 data string
-	r, err := decodeRelease(obj.Data["release"])
+	r, err := cfgmaps.decode(obj.Data["release"])
 	if err != nil {
 		cfgmaps.Log("get: failed to decode data %q: %s", key, err)
 		return nil, err
@@ -99,7 +103,7 @@ func (cfgmaps *ConfigMaps) List(filter func(*rspb.Release) bool) ([]*rspb.Releas
 	// iterate over the configmaps object list
 	// and decode each release
 	for _, item := range list.Items {
-		rls, err := decodeRelease(item.Data["release"])
+		rls, err := cfgmaps.decode(item.Data["release"])
 		if err != nil {
 			cfgmaps.Log("list: failed to decode release: %v: %s", item, err)
 			continue
@@ -111,6 +115,35 @@ func (cfgmaps *ConfigMaps) List(filter func(*rspb.Release) bool) ([]*rspb.Releas
 	return results, nil
 }
 
+// ListPage fetches a page of the releases selected by opts, filtering and
+// paging them in the Kubernetes API server.
+func (cfgmaps *ConfigMaps) ListPage(opts ListOptions) ([]*rspb.Release, string, error) {
+	sel, err := opts.selector()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "list: invalid label selector")
+	}
+	list, err := cfgmaps.impl.List(metav1.ListOptions{
+		LabelSelector: sel,
+		Limit:         int64(opts.Limit),
+		Continue:      opts.Continue,
+	})
+	if err != nil {
+		cfgmaps.Log("list: failed to list: %s", err)
+		return nil, "", err
+	}
+
+	var results []*rspb.Release
+	for _, item := range list.Items {
+		rls, err := cfgmaps.decode(item.Data["release"])
+		if err != nil {
+			cfgmaps.Log("list: failed to decode release: %v: %s", item, err)
+			continue
+		}
+		results = append(results, rls)
+	}
+	return results, list.Continue, nil
+}
+
 // Query fetches all releases that match the provided map of labels.
 // An error is returned if the configmap fails to retrieve the releases.
 func (cfgmaps *ConfigMaps) Query(labels map[string]string) ([]*rspb.Release, error) {
@@ -136,7 +169,7 @@ func (cfgmaps *ConfigMaps) Query(labels map[string]string) ([]*rspb.Release, err
 
 	var results []*rspb.Release
 	for _, item := range list.Items {
-		rls, err := decodeRelease(item.Data["release"])
+		rls, err := cfgmaps.decode(item.Data["release"])
 		if err != nil {
 			cfgmaps.Log("query: failed to decode release: %s", err)
 			continue
@@ -157,6 +190,9 @@ func (cfgmaps *ConfigMaps) Create(key string, rls *rspb.Release) error {
 
 	// create a new configmap to hold the release
 	obj, err := newConfigMapsObject(key, rls, lbs)
+	if err == nil {
+		obj.Data["release"], err = cfgmaps.Encryption.seal(obj.Data["release"])
+	}
 	if err != nil {
 		cfgmaps.Log("create: failed to encode release %q: %s", rls.Name, err)
 		return err
@@ -184,6 +220,9 @@ func (cfgmaps *ConfigMaps) Update(key string, rls *rspb.Release) error {
 
 	// create a new configmap object to hold the release
 	obj, err := newConfigMapsObject(key, rls, lbs)
+	if err == nil {
+		obj.Data["release"], err = cfgmaps.Encryption.seal(obj.Data["release"])
+	}
 	if err != nil {
 		cfgmaps.Log("update: failed to encode release %q: %s", rls.Name, err)
 		return err
@@ -215,6 +254,30 @@ func (cfgmaps *ConfigMaps) Delete(key string) (rls *rspb.Release, err error) {
 	return rls, nil
 }
 
+// decode decrypts and decodes the data of a release.
+// LockRelease acquires lock on the release name, held in a ConfigMap.
+func (cfgmaps *ConfigMaps) LockRelease(name string, lock Lock) error {
+	err := acquireKubeLock(configMapLock{cfgmaps.impl, name}, name, lock)
+	if _, ok := err.(*LockedError); ok || err == nil {
+		return err
+	}
+	return errors.Wrapf(err, "lock: failed to lock release %q", name)
+}
+
+// UnlockRelease releases the lock of holder on the release name.
+func (cfgmaps *ConfigMaps) UnlockRelease(name, holder string) error {
+	err := releaseKubeLock(configMapLock{cfgmaps.impl, name}, holder)
+	return errors.Wrapf(err, "unlock: failed to unlock release %q", name)
+}
+
+func (cfgmaps *ConfigMaps) decode(data string) (*rspb.Release, error) {
+	s, err := cfgmaps.Encryption.open(data)
+	if err != nil {
+		return nil, err
+	}
+	return decodeRelease(s)
+}
+
 // newConfigMapsObject constructs a kubernetes ConfigMap object
 // to store a release. Each configmap data entry is the base64
 // encoded gzipped string of a release.
@@ -256,3 +319,39 @@ func newConfigMapsObject(key string, rls *rspb.Release, lbs labels) (*v1.ConfigM
 		Data: map[string]string{"release": s},
 	}, nil
 }
+
+// configMapLock is the ConfigMap holding the lock on a release.
+type configMapLock struct {
+	impl corev1.ConfigMapInterface
+	name string
+}
+
+func (l configMapLock) create(data []byte) error {
+	_, err := l.impl.Create(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: lockKey(l.name), Labels: lockLabels(l.name)},
+		Data:       map[string]string{"lock": string(data)},
+	})
+	return err
+}
+
+func (l configMapLock) get() ([]byte, string, error) {
+	obj, err := l.impl.Get(lockKey(l.name), metav1.GetOptions{})
+	if err != nil {
+		return nil, "", err
+	}
+	return []byte(obj.Data["lock"]), obj.ResourceVersion, nil
+}
+
+func (l configMapLock) update(data []byte, resourceVersion string) error {
+	_, err := l.impl.Update(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: lockKey(l.name), Labels: lockLabels(l.name), ResourceVersion: resourceVersion},
+		Data:       map[string]string{"lock": string(data)},
+	})
+	return err
+}
+
+func (l configMapLock) delete(resourceVersion string) error {
+	return l.impl.Delete(lockKey(l.name), &metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{ResourceVersion: &resourceVersion},
+	})
+}
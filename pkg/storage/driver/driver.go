@@ -17,7 +17,11 @@ limitations under the License.
 package driver // import "helm.sh/helm/v3/pkg/storage/driver"
 
 import (
+	"strconv"
+
 	"github.com/pkg/errors"
+	kblabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 
 	rspb "helm.sh/helm/v3/pkg/release"
 )
@@ -80,3 +84,74 @@ type Driver interface {
 	Queryor
 	Name() string
 }
+
+// ListOptions selects a page of the releases returned by a Lister.
+type ListOptions struct {
+	// Labels are the labels releases must have, out of "name", "owner",
+	// "status" and "version".
+	Labels map[string]string
+	// Statuses restricts the releases to those in one of these statuses.
+	// All statuses are included if it is empty.
+	Statuses []string
+	// Limit is the largest number of releases in a page. Zero means no
+	// limit.
+	Limit int
+	// Continue is the token returned with the previous page.
+	Continue string
+}
+
+// Lister is implemented by drivers that can filter and page releases in
+// their backend, rather than loading all releases at once.
+//
+// ListPage returns the releases matching opts and the token to pass as
+// opts.Continue to get the next page, which is empty after the last page.
+type Lister interface {
+	ListPage(opts ListOptions) ([]*rspb.Release, string, error)
+}
+
+// Matches reports whether rls is selected by the labels and statuses of o.
+func (o ListOptions) Matches(rls *rspb.Release) bool {
+	lbs := map[string]string{
+		"name":    rls.Name,
+		"owner":   "helm",
+		"version": strconv.Itoa(rls.Version),
+	}
+	if rls.Info != nil {
+		lbs["status"] = rls.Info.Status.String()
+	}
+	for k, v := range o.Labels {
+		if lbs[k] != v {
+			return false
+		}
+	}
+	if len(o.Statuses) == 0 {
+		return true
+	}
+	for _, status := range o.Statuses {
+		if lbs["status"] == status {
+			return true
+		}
+	}
+	return false
+}
+
+// selector returns the Kubernetes label selector for o, restricted to
+// objects owned by Helm.
+func (o ListOptions) selector() (string, error) {
+	sel := kblabels.SelectorFromSet(kblabels.Set{"owner": "helm"})
+	for k, v := range o.Labels {
+		req, err := kblabels.NewRequirement(k, selection.Equals, []string{v})
+		if err != nil {
+			return "", err
+		}
+		sel = sel.Add(*req)
+	}
+	if len(o.Statuses) > 0 {
+		req, err := kblabels.NewRequirement("status", selection.In, o.Statuses)
+		if err != nil {
+			return "", err
+		}
+		sel = sel.Add(*req)
+	}
+	return sel.String(), nil
+}
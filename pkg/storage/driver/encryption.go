@@ -0,0 +1,299 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "helm.sh/helm/v3/pkg/storage/driver"
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// magicEncrypted starts the data of encrypted releases.
+var magicEncrypted = []byte("HELMENC1")
+
+// errNoEncryption is returned when reading an encrypted release without keys.
+var errNoEncryption = errors.New("release is encrypted but no encryption keys are configured")
+
+// KeyProvider wraps the data keys releases are encrypted with, typically
+// by encrypting them with a key held by a key management service.
+type KeyProvider interface {
+	// KeyID identifies the key data keys are wrapped with. It is stored
+	// with each release to find the provider able to unwrap its data key.
+	KeyID() string
+	// WrapKey encrypts a data key.
+	WrapKey(dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts a data key wrapped by WrapKey.
+	UnwrapKey(wrapped []byte) ([]byte, error)
+}
+
+// Encryption encrypts release data at rest with envelope encryption. Each
+// release is encrypted with its own data key, which is stored with the
+// release wrapped by a KeyProvider.
+//
+// Keys are rotated by adding a new Provider and moving the old one to
+// Previous. Releases are encrypted with the new key when they are next
+// written, or right away with Storage.Reencrypt, which 'helm release
+// reencrypt' runs.
+type Encryption struct {
+	// Provider wraps the data keys of releases being written.
+	Provider KeyProvider
+	// Previous are providers of rotated keys, only used to read releases.
+	Previous []KeyProvider
+}
+
+// seal encrypts data, the base64 encoded representation of a release.
+func (e *Encryption) seal(data string) (string, error) {
+	if e == nil || e.Provider == nil {
+		return data, nil
+	}
+	plain, err := b64.DecodeString(data)
+	if err != nil {
+		return "", err
+	}
+
+	dataKey := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", errors.Wrap(err, "unable to generate data key")
+	}
+	wrapped, err := e.Provider.WrapKey(dataKey)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to wrap data key with key %q", e.Provider.KeyID())
+	}
+	sealed, err := aesSeal(dataKey, plain)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(magicEncrypted)
+	writeField(&buf, []byte(e.Provider.KeyID()))
+	writeField(&buf, wrapped)
+	buf.Write(sealed)
+	return b64.EncodeToString(buf.Bytes()), nil
+}
+
+// open decrypts data sealed by seal. Data that is not encrypted is returned
+// unchanged, so that releases stored before encryption was enabled can
+// still be read.
+func (e *Encryption) open(data string) (string, error) {
+	b, err := b64.DecodeString(data)
+	if err != nil || !bytes.HasPrefix(b, magicEncrypted) {
+		return data, nil
+	}
+	if e == nil {
+		return "", errNoEncryption
+	}
+
+	r := bytes.NewReader(b[len(magicEncrypted):])
+	keyID, err := readField(r)
+	if err != nil {
+		return "", err
+	}
+	wrapped, err := readField(r)
+	if err != nil {
+		return "", err
+	}
+	provider := e.provider(string(keyID))
+	if provider == nil {
+		return "", errors.Errorf("release is encrypted with unknown key %q", keyID)
+	}
+	dataKey, err := provider.UnwrapKey(wrapped)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to unwrap data key with key %q", keyID)
+	}
+	sealed, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	plain, err := aesOpen(dataKey, sealed)
+	if err != nil {
+		return "", err
+	}
+	return b64.EncodeToString(plain), nil
+}
+
+func (e *Encryption) provider(keyID string) KeyProvider {
+	for _, p := range append([]KeyProvider{e.Provider}, e.Previous...) {
+		if p != nil && p.KeyID() == keyID {
+			return p
+		}
+	}
+	return nil
+}
+
+// AESKeyProvider wraps data keys with a local AES key.
+type AESKeyProvider struct {
+	id  string
+	key []byte
+}
+
+// NewAESKeyProvider returns a KeyProvider wrapping data keys with key, which
+// must be 16, 24 or 32 bytes long.
+func NewAESKeyProvider(id string, key []byte) (*AESKeyProvider, error) {
+	if _, err := aes.NewCipher(key); err != nil {
+		return nil, errors.Wrapf(err, "invalid key %q", id)
+	}
+	return &AESKeyProvider{id: id, key: key}, nil
+}
+
+// KeyID returns the identifier of the key.
+func (p *AESKeyProvider) KeyID() string { return p.id }
+
+// WrapKey encrypts a data key.
+func (p *AESKeyProvider) WrapKey(dataKey []byte) ([]byte, error) { return aesSeal(p.key, dataKey) }
+
+// UnwrapKey decrypts a data key.
+func (p *AESKeyProvider) UnwrapKey(wrapped []byte) ([]byte, error) { return aesOpen(p.key, wrapped) }
+
+// ExecKeyProvider wraps data keys by running a command, which allows keys
+// held by a key management service or tools such as age to be used.
+//
+// The command is run with "wrap" or "unwrap" appended to its arguments. It
+// reads the key from its standard input and writes the result to its
+// standard output.
+type ExecKeyProvider struct {
+	ID      string
+	Command string
+	Args    []string
+}
+
+// KeyID returns the identifier of the key.
+func (p *ExecKeyProvider) KeyID() string { return p.ID }
+
+// WrapKey encrypts a data key.
+func (p *ExecKeyProvider) WrapKey(dataKey []byte) ([]byte, error) { return p.run("wrap", dataKey) }
+
+// UnwrapKey decrypts a data key.
+func (p *ExecKeyProvider) UnwrapKey(wrapped []byte) ([]byte, error) { return p.run("unwrap", wrapped) }
+
+func (p *ExecKeyProvider) run(op string, in []byte) ([]byte, error) {
+	cmd := exec.Command(p.Command, append(append([]string{}, p.Args...), op)...)
+	cmd.Stdin = bytes.NewReader(in)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s %s: %s", p.Command, op, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// ParseEncryption configures encryption from a comma separated list of keys.
+// The first key encrypts releases and the others are only used to read
+// releases encrypted before a rotation. Each key is one of
+//
+//	aes:ID:FILE       an AES key read from FILE
+//	exec:ID:COMMAND   a command run as described by ExecKeyProvider
+//
+// An empty spec disables encryption.
+func ParseEncryption(spec string) (*Encryption, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+	enc := &Encryption{}
+	for _, s := range strings.Split(spec, ",") {
+		parts := strings.SplitN(strings.TrimSpace(s), ":", 3)
+		if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+			return nil, errors.Errorf("invalid encryption key %q, expected TYPE:ID:VALUE", s)
+		}
+		var p KeyProvider
+		switch parts[0] {
+		case "aes":
+			key, err := ioutil.ReadFile(parts[2])
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to read encryption key %q", parts[1])
+			}
+			if p, err = NewAESKeyProvider(parts[1], bytes.TrimSpace(key)); err != nil {
+				return nil, err
+			}
+		case "exec":
+			fields := strings.Fields(parts[2])
+			if len(fields) == 0 {
+				return nil, errors.Errorf("invalid encryption key %q, expected a command", s)
+			}
+			p = &ExecKeyProvider{ID: parts[1], Command: fields[0], Args: fields[1:]}
+		default:
+			return nil, errors.Errorf("unknown encryption key type %q", parts[0])
+		}
+		if enc.Provider == nil {
+			enc.Provider = p
+		} else {
+			enc.Previous = append(enc.Previous, p)
+		}
+	}
+	return enc, nil
+}
+
+func aesSeal(key, plain []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plain, nil), nil
+}
+
+func aesOpen(key, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("encrypted data is too short")
+	}
+	nonce, data := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, data, nil)
+	return plain, errors.Wrap(err, "unable to decrypt release")
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func writeField(buf *bytes.Buffer, b []byte) {
+	var n [2]byte
+	binary.BigEndian.PutUint16(n[:], uint16(len(b)))
+	buf.Write(n[:])
+	buf.Write(b)
+}
+
+func readField(r io.Reader) ([]byte, error) {
+	var n [2]byte
+	if _, err := io.ReadFull(r, n[:]); err != nil {
+		return nil, errors.Wrap(err, "invalid encrypted release")
+	}
+	b := make([]byte, binary.BigEndian.Uint16(n[:]))
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, errors.Wrap(err, "invalid encrypted release")
+	}
+	return b, nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"helm.sh/helm/v3/internal/test/ensure"
+	rspb "helm.sh/helm/v3/pkg/release"
+)
+
+func testKeyProvider(t *testing.T, id string) KeyProvider {
+	t.Helper()
+	p, err := NewAESKeyProvider(id, []byte(strings.Repeat(id[:1], 32)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestEncryptionSealOpen(t *testing.T) {
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.StatusDeployed)
+	rel.Manifest = "kind: Secret\ndata:\n  password: hunter2\n"
+	data, err := encodeRelease(rel)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := &Encryption{Provider: testKeyProvider(t, "old")}
+	sealed, err := old.seal(data)
+	if err != nil {
+		t.Fatalf("Failed to seal release: %s", err)
+	}
+	if sealed == data {
+		t.Fatal("Expected sealed data to differ from the release data")
+	}
+
+	// After a rotation, releases encrypted with the old key can still be read
+	rotated := &Encryption{Provider: testKeyProvider(t, "new"), Previous: []KeyProvider{old.Provider}}
+	opened, err := rotated.open(sealed)
+	if err != nil {
+		t.Fatalf("Failed to open release: %s", err)
+	}
+	if opened != data {
+		t.Error("Expected opened data to match the release data")
+	}
+
+	other := &Encryption{Provider: testKeyProvider(t, "new")}
+	if _, err := other.open(sealed); err == nil || !strings.Contains(err.Error(), `unknown key "old"`) {
+		t.Errorf("Expected an unknown key error, got %v", err)
+	}
+	var none *Encryption
+	if _, err := none.open(sealed); err != errNoEncryption {
+		t.Errorf("Expected errNoEncryption, got %v", err)
+	}
+
+	// Releases stored before encryption was enabled are read unchanged
+	if opened, err := rotated.open(data); err != nil || opened != data {
+		t.Errorf("Expected unencrypted data to be returned unchanged, got %v", err)
+	}
+}
+
+func TestEncryptedSecrets(t *testing.T) {
+	key := testKey("smug-pigeon", 1)
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.StatusDeployed)
+
+	var mock MockSecretsInterface
+	mock.objects = map[string]*v1.Secret{}
+	secrets := NewSecrets(&mock)
+	secrets.Encryption = &Encryption{Provider: &ExecKeyProvider{ID: "cat", Command: "sh", Args: []string{"-c", "cat"}}}
+
+	if err := secrets.Create(key, rel); err != nil {
+		t.Fatalf("Failed to create release: %s", err)
+	}
+	if _, err := decodeRelease(string(mock.objects[key].Data["release"])); err == nil {
+		t.Error("Expected the stored release to be encrypted")
+	}
+	got, err := secrets.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get release: %s", err)
+	}
+	if !reflect.DeepEqual(rel, got) {
+		t.Errorf("Expected {%v}, got {%v}", rel, got)
+	}
+}
+
+func TestParseEncryption(t *testing.T) {
+	dir := ensure.TempDir(t)
+	defer os.RemoveAll(dir)
+	keyFile := filepath.Join(dir, "key")
+	if err := ioutil.WriteFile(keyFile, []byte(strings.Repeat("k", 32)+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	enc, err := ParseEncryption("aes:current:" + keyFile + ", exec:previous:kms-helper --region eu")
+	if err != nil {
+		t.Fatalf("Failed to parse encryption keys: %s", err)
+	}
+	if enc.Provider.KeyID() != "current" {
+		t.Errorf("Expected current key, got %q", enc.Provider.KeyID())
+	}
+	if len(enc.Previous) != 1 || !reflect.DeepEqual(enc.Previous[0], &ExecKeyProvider{ID: "previous", Command: "kms-helper", Args: []string{"--region", "eu"}}) {
+		t.Errorf("Unexpected previous keys %v", enc.Previous)
+	}
+
+	if enc, err := ParseEncryption(""); enc != nil || err != nil {
+		t.Errorf("Expected no encryption, got %v, %v", enc, err)
+	}
+	for _, spec := range []string{"aes:short", "rot13:id:value", "aes:id:" + filepath.Join(dir, "missing")} {
+		if _, err := ParseEncryption(spec); err == nil {
+			t.Errorf("Expected an error for %q", spec)
+		}
+	}
+}
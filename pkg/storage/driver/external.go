@@ -0,0 +1,306 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "helm.sh/helm/v3/pkg/storage/driver"
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	rspb "helm.sh/helm/v3/pkg/release"
+)
+
+var _ Driver = (*External)(nil)
+
+// ExternalDriverName is the string name of this driver.
+const ExternalDriverName = "External"
+
+// The errors an external storage command reports in ExternalResponse.Error
+// for ErrReleaseNotFound and ErrReleaseExists.
+const (
+	ExternalErrNotFound = "not_found"
+	ExternalErrExists   = "already_exists"
+)
+
+// ExternalRecord is a release as exchanged with an external storage command.
+//
+// Name, Version, Status and Owner are the labels releases are queried by;
+// Data is the encoded, and possibly encrypted, release and is opaque to the
+// command.
+type ExternalRecord struct {
+	Key       string `json:"key"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Version   int    `json:"version"`
+	Status    string `json:"status"`
+	Owner     string `json:"owner"`
+	Data      string `json:"data"`
+}
+
+// ExternalRequest is written as JSON to the standard input of an external
+// storage command.
+type ExternalRequest struct {
+	// Namespace restricts the operation to a namespace. It is empty when
+	// listing or querying releases of all namespaces.
+	Namespace string `json:"namespace"`
+	// Key names the release to get or delete.
+	Key string `json:"key,omitempty"`
+	// Labels are the labels releases to query must have.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Record is the release to create or update.
+	Record *ExternalRecord `json:"record,omitempty"`
+}
+
+// ExternalResponse is read as JSON from the standard output of an external
+// storage command.
+type ExternalResponse struct {
+	// Records are the releases found by get, list, query and delete.
+	Records []*ExternalRecord `json:"records,omitempty"`
+	// Error is ExternalErrNotFound, ExternalErrExists or any other message
+	// if the operation failed.
+	Error string `json:"error,omitempty"`
+}
+
+// External is the storage driver delegating to an external command, so that
+// releases can be kept in any system without changing Helm.
+//
+// The command is run once per operation with the operation, one of "get",
+// "list", "query", "create", "update" and "delete", appended to its
+// arguments. It reads an ExternalRequest from its standard input and writes
+// an ExternalResponse to its standard output. A command exiting with a
+// non-zero status fails the operation.
+type External struct {
+	// Command is the path of the executable.
+	Command string
+	// Args are passed to the command before the operation.
+	Args      []string
+	namespace string
+	Log       func(string, ...interface{})
+	// Encryption encrypts releases before passing them to the command if set.
+	Encryption *Encryption
+
+	// run runs an operation, it is replaced in tests.
+	run func(op string, in []byte) ([]byte, error)
+}
+
+// NewExternal returns a driver running command with args to access the
+// releases of namespace. An empty namespace lists and queries releases of
+// all namespaces.
+func NewExternal(command string, args []string, namespace string) *External {
+	e := &External{
+		Command:   command,
+		Args:      args,
+		namespace: namespace,
+		Log:       func(_ string, _ ...interface{}) {},
+	}
+	e.run = e.exec
+	return e
+}
+
+// Name returns the name of the driver.
+func (e *External) Name() string {
+	return ExternalDriverName
+}
+
+// Get returns the release named by key or returns ErrReleaseNotFound.
+func (e *External) Get(key string) (*rspb.Release, error) {
+	records, err := e.call("get", &ExternalRequest{Key: key})
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, ErrReleaseNotFound
+	}
+	rls, err := e.decode(records[0].Data)
+	if err != nil {
+		e.Log("get: failed to decode data %q: %s", key, err)
+		return nil, err
+	}
+	return rls, nil
+}
+
+// List returns the list of all releases such that filter(release) == true
+func (e *External) List(filter func(*rspb.Release) bool) ([]*rspb.Release, error) {
+	records, err := e.call("list", &ExternalRequest{})
+	if err != nil {
+		e.Log("list: failed to list: %s", err)
+		return nil, err
+	}
+	var results []*rspb.Release
+	for _, rls := range e.decodeAll(records) {
+		if filter(rls) {
+			results = append(results, rls)
+		}
+	}
+	return results, nil
+}
+
+// Query returns the set of releases that match the provided set of labels.
+func (e *External) Query(labels map[string]string) ([]*rspb.Release, error) {
+	records, err := e.call("query", &ExternalRequest{Labels: labels})
+	if err != nil {
+		e.Log("query: failed to query with labels: %s", err)
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, ErrReleaseNotFound
+	}
+	return e.decodeAll(records), nil
+}
+
+// Create creates a new release or returns ErrReleaseExists.
+func (e *External) Create(key string, rls *rspb.Release) error {
+	record, err := e.record(key, rls)
+	if err != nil {
+		e.Log("create: failed to encode release %q: %s", rls.Name, err)
+		return err
+	}
+	if _, err := e.call("create", &ExternalRequest{Record: record}); err != nil {
+		if err != ErrReleaseExists {
+			e.Log("create: failed to create: %s", err)
+		}
+		return err
+	}
+	return nil
+}
+
+// Update updates a release or returns ErrReleaseNotFound.
+func (e *External) Update(key string, rls *rspb.Release) error {
+	record, err := e.record(key, rls)
+	if err != nil {
+		e.Log("update: failed to encode release %q: %s", rls.Name, err)
+		return err
+	}
+	if _, err := e.call("update", &ExternalRequest{Record: record}); err != nil {
+		if err != ErrReleaseNotFound {
+			e.Log("update: failed to update: %s", err)
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete deletes a release or returns ErrReleaseNotFound.
+func (e *External) Delete(key string) (*rspb.Release, error) {
+	records, err := e.call("delete", &ExternalRequest{Key: key})
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, ErrReleaseNotFound
+	}
+	return e.decode(records[0].Data)
+}
+
+// record returns the external record holding rls.
+func (e *External) record(key string, rls *rspb.Release) (*ExternalRecord, error) {
+	data, err := encodeRelease(rls)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = e.Encryption.seal(data); err != nil {
+		return nil, err
+	}
+	record := &ExternalRecord{
+		Key:       key,
+		Namespace: rls.Namespace,
+		Name:      rls.Name,
+		Version:   rls.Version,
+		Owner:     "helm",
+		Data:      data,
+	}
+	if rls.Info != nil {
+		record.Status = rls.Info.Status.String()
+	}
+	return record, nil
+}
+
+func (e *External) decode(data string) (*rspb.Release, error) {
+	s, err := e.Encryption.open(data)
+	if err != nil {
+		return nil, err
+	}
+	return decodeRelease(s)
+}
+
+// decodeAll decodes records, skipping those that fail to decode.
+func (e *External) decodeAll(records []*ExternalRecord) []*rspb.Release {
+	results := make([]*rspb.Release, 0, len(records))
+	for _, record := range records {
+		rls, err := e.decode(record.Data)
+		if err != nil {
+			e.Log("failed to decode release %q: %s", record.Key, err)
+			continue
+		}
+		results = append(results, rls)
+	}
+	return results
+}
+
+// call runs the operation op with req and returns the records of the
+// response.
+func (e *External) call(op string, req *ExternalRequest) ([]*ExternalRecord, error) {
+	if req.Namespace == "" {
+		req.Namespace = e.namespace
+	}
+	if req.Record != nil && req.Record.Namespace == "" {
+		req.Record.Namespace = req.Namespace
+	}
+	in, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	out, err := e.run(op, in)
+	if err != nil {
+		return nil, err
+	}
+	var resp ExternalResponse
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return nil, errors.Wrapf(err, "invalid response of storage command %s to %s", e.Command, op)
+	}
+	switch resp.Error {
+	case "":
+		return resp.Records, nil
+	case ExternalErrNotFound:
+		return nil, ErrReleaseNotFound
+	case ExternalErrExists:
+		return nil, ErrReleaseExists
+	default:
+		return nil, errors.Errorf("storage command %s failed to %s: %s", e.Command, op, resp.Error)
+	}
+}
+
+// exec runs the command for the operation op, passing it in.
+func (e *External) exec(op string, in []byte) ([]byte, error) {
+	cmd := exec.Command(e.Command, append(e.Args[:len(e.Args):len(e.Args)], op)...)
+	cmd.Env = os.Environ()
+	cmd.Stdin = bytes.NewReader(in)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.Wrapf(err, "storage command %s failed to %s: %s", e.Command, op, msg)
+		}
+		return nil, errors.Wrapf(err, "storage command %s failed to %s", e.Command, op)
+	}
+	return stdout.Bytes(), nil
+}
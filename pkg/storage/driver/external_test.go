@@ -0,0 +1,234 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"encoding/json"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	rspb "helm.sh/helm/v3/pkg/release"
+)
+
+// mockExternalCommand implements the external storage protocol in memory.
+type mockExternalCommand struct {
+	records map[string]*ExternalRecord
+	ops     []string
+}
+
+func (m *mockExternalCommand) run(op string, in []byte) ([]byte, error) {
+	m.ops = append(m.ops, op)
+	var req ExternalRequest
+	if err := json.Unmarshal(in, &req); err != nil {
+		return nil, err
+	}
+	var resp ExternalResponse
+	id := req.Namespace + "/" + req.Key
+	switch op {
+	case "get", "delete":
+		record, ok := m.records[id]
+		if !ok {
+			resp.Error = ExternalErrNotFound
+			break
+		}
+		if op == "delete" {
+			delete(m.records, id)
+		}
+		resp.Records = []*ExternalRecord{record}
+	case "list", "query":
+		for _, record := range m.records {
+			if req.Namespace != "" && record.Namespace != req.Namespace {
+				continue
+			}
+			lbs := map[string]string{
+				"name":    record.Name,
+				"owner":   record.Owner,
+				"status":  record.Status,
+				"version": strconv.Itoa(record.Version),
+			}
+			match := true
+			for k, v := range req.Labels {
+				match = match && lbs[k] == v
+			}
+			if match {
+				resp.Records = append(resp.Records, record)
+			}
+		}
+	case "create", "update":
+		id = req.Record.Namespace + "/" + req.Record.Key
+		if _, ok := m.records[id]; ok && op == "create" {
+			resp.Error = ExternalErrExists
+		} else if !ok && op == "update" {
+			resp.Error = ExternalErrNotFound
+		} else {
+			m.records[id] = req.Record
+		}
+	default:
+		resp.Error = "unknown operation " + op
+	}
+	return json.Marshal(resp)
+}
+
+func newTestFixtureExternal(t *testing.T, releases ...*rspb.Release) (*External, *mockExternalCommand) {
+	t.Helper()
+	mock := &mockExternalCommand{records: map[string]*ExternalRecord{}}
+	e := NewExternal("mock", nil, "default")
+	e.run = mock.run
+	for _, rls := range releases {
+		if err := e.Create(testKey(rls.Name, rls.Version), rls); err != nil {
+			t.Fatalf("Failed to create release: %s", err)
+		}
+	}
+	return e, mock
+}
+
+func TestExternalName(t *testing.T) {
+	e, _ := newTestFixtureExternal(t)
+	if e.Name() != ExternalDriverName {
+		t.Errorf("Expected name to be %q, got %q", ExternalDriverName, e.Name())
+	}
+}
+
+func TestExternalCreateGetUpdateDelete(t *testing.T) {
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.StatusDeployed)
+	key := testKey(rel.Name, rel.Version)
+	e, mock := newTestFixtureExternal(t, rel)
+
+	record := mock.records["default/"+key]
+	if record == nil {
+		t.Fatal("Expected the release to be passed to the command")
+	}
+	if record.Name != "smug-pigeon" || record.Version != 1 || record.Status != "deployed" || record.Owner != "helm" {
+		t.Errorf("Unexpected record labels %+v", record)
+	}
+
+	if err := e.Create(key, rel); err != ErrReleaseExists {
+		t.Errorf("Expected ErrReleaseExists, got %v", err)
+	}
+	got, err := e.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get release: %s", err)
+	}
+	if got.Name != rel.Name || got.Version != rel.Version {
+		t.Errorf("Expected release %s.v%d, got %s.v%d", rel.Name, rel.Version, got.Name, got.Version)
+	}
+
+	rel.Info.Status = rspb.StatusSuperseded
+	if err := e.Update(key, rel); err != nil {
+		t.Fatalf("Failed to update release: %s", err)
+	}
+	if err := e.Update("smug-pigeon.v2", releaseStub("smug-pigeon", 2, "default", rspb.StatusDeployed)); err != ErrReleaseNotFound {
+		t.Errorf("Expected ErrReleaseNotFound, got %v", err)
+	}
+
+	deleted, err := e.Delete(key)
+	if err != nil {
+		t.Fatalf("Failed to delete release: %s", err)
+	}
+	if deleted.Info.Status != rspb.StatusSuperseded {
+		t.Errorf("Expected status %s, got %s", rspb.StatusSuperseded, deleted.Info.Status)
+	}
+	if _, err := e.Get(key); err != ErrReleaseNotFound {
+		t.Errorf("Expected ErrReleaseNotFound after delete, got %v", err)
+	}
+
+	expected := "create,create,get,update,update,delete,get"
+	if ops := strings.Join(mock.ops, ","); ops != expected {
+		t.Errorf("Expected operations %s, got %s", expected, ops)
+	}
+}
+
+func TestExternalListQuery(t *testing.T) {
+	e, _ := newTestFixtureExternal(t,
+		releaseStub("rls-a", 1, "default", rspb.StatusSuperseded),
+		releaseStub("rls-a", 2, "default", rspb.StatusDeployed),
+		releaseStub("rls-b", 1, "default", rspb.StatusDeployed),
+	)
+
+	deployed, err := e.List(func(rls *rspb.Release) bool {
+		return rls.Info.Status == rspb.StatusDeployed
+	})
+	if err != nil {
+		t.Fatalf("Failed to list releases: %s", err)
+	}
+	if len(deployed) != 2 {
+		t.Errorf("Expected 2 deployed releases, got %d", len(deployed))
+	}
+
+	history, err := e.Query(map[string]string{"name": "rls-a", "owner": "helm"})
+	if err != nil {
+		t.Fatalf("Failed to query releases: %s", err)
+	}
+	if len(history) != 2 {
+		t.Errorf("Expected 2 revisions of rls-a, got %d", len(history))
+	}
+
+	if _, err := e.Query(map[string]string{"name": "rls-c"}); err != ErrReleaseNotFound {
+		t.Errorf("Expected ErrReleaseNotFound, got %v", err)
+	}
+}
+
+func TestExternalEncryption(t *testing.T) {
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.StatusDeployed)
+	key := testKey(rel.Name, rel.Version)
+	e, mock := newTestFixtureExternal(t)
+	provider, err := NewAESKeyProvider("k1", make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Encryption = &Encryption{Provider: provider}
+
+	if err := e.Create(key, rel); err != nil {
+		t.Fatalf("Failed to create release: %s", err)
+	}
+	if _, err := e.Get(key); err != nil {
+		t.Fatalf("Failed to get encrypted release: %s", err)
+	}
+
+	plain := NewExternal("mock", nil, "default")
+	plain.run = mock.run
+	if _, err := plain.Get(key); err == nil {
+		t.Error("Expected the command to receive an encrypted release")
+	}
+}
+
+func TestExternalCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test command is a shell script")
+	}
+	script := `
+cat > /dev/null
+case "$1" in
+get) echo '{"error": "not_found"}' ;;
+list) echo '{"records": []}' ;;
+*) echo "no $1 here" >&2; exit 3 ;;
+esac`
+	e := NewExternal("sh", []string{"-c", script, "sh"}, "default")
+
+	if _, err := e.Get("smug-pigeon.v1"); err != ErrReleaseNotFound {
+		t.Errorf("Expected ErrReleaseNotFound, got %v", err)
+	}
+	if ls, err := e.List(func(*rspb.Release) bool { return true }); err != nil || len(ls) != 0 {
+		t.Errorf("Expected no releases, got %v, %v", ls, err)
+	}
+	err := e.Create("smug-pigeon.v1", releaseStub("smug-pigeon", 1, "default", rspb.StatusDeployed))
+	if err == nil || !strings.Contains(err.Error(), "no create here") {
+		t.Errorf("Expected the error output of the command, got %v", err)
+	}
+}
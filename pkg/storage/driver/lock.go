@@ -0,0 +1,147 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "helm.sh/helm/v3/pkg/storage/driver"
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Lock is a lease on a release, held by a client while it changes the
+// release so that other clients do not change it at the same time.
+type Lock struct {
+	// Holder identifies the client holding the lock.
+	Holder string `json:"holder"`
+	// Acquired is when the lock was acquired.
+	Acquired time.Time `json:"acquired"`
+	// TTL is how long the lock is held for. Once it has passed, the lock
+	// can be taken over, in case its holder did not release it. Zero means
+	// the lock does not expire.
+	TTL time.Duration `json:"ttl"`
+}
+
+// Expired reports whether the lease of l has passed at now.
+func (l Lock) Expired(now time.Time) bool {
+	return l.TTL > 0 && now.After(l.Acquired.Add(l.TTL))
+}
+
+// LockedError is returned when locking a release locked by another client.
+type LockedError struct {
+	Release string
+	Lock    Lock
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("release %q is locked by %s since %s", e.Release, e.Lock.Holder, e.Lock.Acquired.Format(time.RFC3339))
+}
+
+// Locker is implemented by drivers that can lock releases.
+//
+// LockRelease acquires lock on the release name. It returns a *LockedError
+// if another holder has a lock on the release that has not expired. The
+// holder of a lock can lock the release again to renew its lease.
+//
+// UnlockRelease releases the lock of holder on the release name, if it
+// still has it.
+type Locker interface {
+	LockRelease(name string, lock Lock) error
+	UnlockRelease(name, holder string) error
+}
+
+// lockKey returns the name of the storage object holding the lock on the
+// release name. It cannot clash with the keys of the releases, which end
+// with their version.
+func lockKey(name string) string {
+	return fmt.Sprintf("sh.helm.release.v1.%s.lock", name)
+}
+
+// lockLabels are the labels of the storage objects holding locks. They lack
+// the owner label of releases so that they are never listed as releases.
+func lockLabels(name string) map[string]string {
+	return map[string]string{"name": name, "lock": "true"}
+}
+
+// lockObject accesses the Kubernetes object holding the lock on a release.
+// The lock is stored as JSON, and the object is updated and deleted only if
+// its resource version is unchanged.
+type lockObject interface {
+	create(data []byte) error
+	get() (data []byte, resourceVersion string, err error)
+	update(data []byte, resourceVersion string) error
+	delete(resourceVersion string) error
+}
+
+// acquireKubeLock acquires lock on the release name held in obj.
+func acquireKubeLock(obj lockObject, name string, lock Lock) error {
+	data, err := json.Marshal(lock)
+	if err != nil {
+		return err
+	}
+	if err := obj.create(data); !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	held, version, err := getKubeLock(obj)
+	if apierrors.IsNotFound(err) {
+		// The lock was released in the meantime.
+		return obj.create(data)
+	}
+	if err != nil {
+		return err
+	}
+	if held.Holder != lock.Holder && !held.Expired(lock.Acquired) {
+		return &LockedError{Release: name, Lock: held}
+	}
+	err = obj.update(data, version)
+	if apierrors.IsConflict(err) {
+		// Another client acquired the lock in the meantime.
+		if held, _, err = getKubeLock(obj); err != nil {
+			return err
+		}
+		return &LockedError{Release: name, Lock: held}
+	}
+	return err
+}
+
+// releaseKubeLock releases the lock of holder held in obj.
+func releaseKubeLock(obj lockObject, holder string) error {
+	held, version, err := getKubeLock(obj)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil || held.Holder != holder {
+		return err
+	}
+	if err := obj.delete(version); err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+		return err
+	}
+	return nil
+}
+
+func getKubeLock(obj lockObject) (Lock, string, error) {
+	var lock Lock
+	data, version, err := obj.get()
+	if err != nil {
+		return lock, "", err
+	}
+	// A lock that cannot be decoded has no holder and is taken over.
+	_ = json.Unmarshal(data, &lock)
+	return lock, version, nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"helm.sh/helm/v3/internal/test/ensure"
+)
+
+func TestLockRelease(t *testing.T) {
+	dir := ensure.TempDir(t)
+	defer os.RemoveAll(dir)
+	sqlite := newTestFixtureSQLite(t, dir, "default")
+	defer sqlite.Close()
+
+	drivers := map[string]Locker{
+		"memory":     NewMemory(),
+		"secrets":    newTestFixtureSecrets(t),
+		"configmaps": newTestFixtureCfgMaps(t),
+		"sqlite":     sqlite,
+	}
+	now := time.Now()
+	for name, d := range drivers {
+		first := Lock{Holder: "alice@laptop", Acquired: now, TTL: time.Minute}
+		second := Lock{Holder: "bob@desktop", Acquired: now.Add(time.Second), TTL: time.Minute}
+
+		if err := d.LockRelease("angry-bird", first); err != nil {
+			t.Fatalf("%s: failed to lock: %s", name, err)
+		}
+		// The holder renews its lease.
+		if err := d.LockRelease("angry-bird", first); err != nil {
+			t.Errorf("%s: failed to lock again: %s", name, err)
+		}
+		// Other releases are not locked.
+		if err := d.LockRelease("smug-pigeon", second); err != nil {
+			t.Errorf("%s: failed to lock another release: %s", name, err)
+		}
+
+		err := d.LockRelease("angry-bird", second)
+		locked, ok := err.(*LockedError)
+		if !ok {
+			t.Fatalf("%s: expected a LockedError, got %v", name, err)
+		}
+		if locked.Lock.Holder != first.Holder || !locked.Lock.Acquired.Equal(first.Acquired) {
+			t.Errorf("%s: expected the lock of %s, got %+v", name, first.Holder, locked.Lock)
+		}
+		expected := `release "angry-bird" is locked by alice@laptop since ` + now.Format(time.RFC3339)
+		if err.Error() != expected {
+			t.Errorf("%s: expected error %q, got %q", name, expected, err)
+		}
+
+		// Only the holder releases the lock.
+		if err := d.UnlockRelease("angry-bird", second.Holder); err != nil {
+			t.Errorf("%s: failed to unlock: %s", name, err)
+		}
+		if err := d.LockRelease("angry-bird", second); err == nil {
+			t.Errorf("%s: expected the lock to be held", name)
+		}
+		if err := d.UnlockRelease("angry-bird", first.Holder); err != nil {
+			t.Errorf("%s: failed to unlock: %s", name, err)
+		}
+		if err := d.LockRelease("angry-bird", second); err != nil {
+			t.Errorf("%s: failed to lock an unlocked release: %s", name, err)
+		}
+
+		// Expired locks are taken over.
+		third := Lock{Holder: "carol@server", Acquired: now.Add(2 * time.Minute), TTL: time.Minute}
+		if err := d.LockRelease("angry-bird", third); err != nil {
+			t.Errorf("%s: failed to take over an expired lock: %s", name, err)
+		}
+	}
+}
@@ -25,6 +25,7 @@ import (
 )
 
 var _ Driver = (*Memory)(nil)
+var _ Locker = (*Memory)(nil)
 
 const (
 	// MemoryDriverName is the string name of this driver.
@@ -42,6 +43,8 @@ type Memory struct {
 	namespace string
 	// A map of namespaces to releases
 	cache map[string]memReleases
+	// A map of namespaces to the locks on their releases
+	locks map[string]map[string]Lock
 }
 
 // NewMemory initializes a new memory driver.
@@ -217,6 +220,33 @@ func (mem *Memory) Delete(key string) (*rspb.Release, error) {
 	return nil, ErrReleaseNotFound
 }
 
+// LockRelease acquires lock on the release name.
+func (mem *Memory) LockRelease(name string, lock Lock) error {
+	defer unlock(mem.wlock())
+
+	if held, ok := mem.locks[mem.namespace][name]; ok && held.Holder != lock.Holder && !held.Expired(lock.Acquired) {
+		return &LockedError{Release: name, Lock: held}
+	}
+	if mem.locks == nil {
+		mem.locks = map[string]map[string]Lock{}
+	}
+	if _, ok := mem.locks[mem.namespace]; !ok {
+		mem.locks[mem.namespace] = map[string]Lock{}
+	}
+	mem.locks[mem.namespace][name] = lock
+	return nil
+}
+
+// UnlockRelease releases the lock of holder on the release name.
+func (mem *Memory) UnlockRelease(name, holder string) error {
+	defer unlock(mem.wlock())
+
+	if held, ok := mem.locks[mem.namespace][name]; ok && held.Holder == holder {
+		delete(mem.locks[mem.namespace], name)
+	}
+	return nil
+}
+
 // wlock locks mem for writing
 func (mem *Memory) wlock() func() {
 	mem.Lock()
@@ -17,6 +17,10 @@ limitations under the License.
 package driver // import "helm.sh/helm/v3/pkg/storage/driver"
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"time"
@@ -33,23 +37,50 @@ import (
 )
 
 var _ Driver = (*Secrets)(nil)
+var _ Lister = (*Secrets)(nil)
+var _ Locker = (*Secrets)(nil)
 
 // SecretsDriverName is the string name of the driver.
 const SecretsDriverName = "Secret"
 
+const (
+	// secretType is the type of the Secrets holding releases.
+	secretType = "helm.sh/release.v1"
+	// secretChunkType is the type of the Secrets holding the remaining
+	// chunks of releases too large for a single Secret.
+	secretChunkType = "helm.sh/release.v1.chunk"
+	// secretLockType is the type of the Secrets holding the locks on
+	// releases.
+	secretLockType = "helm.sh/release.v1.lock"
+	// DefaultSecretChunkSize is the largest encoded release stored in a
+	// single Secret. It leaves room for the metadata within the 1MB limit
+	// of Kubernetes Secrets.
+	DefaultSecretChunkSize = 1000 * 1024
+)
+
 // Secrets is a wrapper around an implementation of a kubernetes
 // SecretsInterface.
 type Secrets struct {
 	impl corev1.SecretInterface
 	Log  func(string, ...interface{})
+	// ChunkSize is the largest encoded release stored in a single Secret.
+	// Larger releases are split across several Secrets.
+	ChunkSize int
+	// Encryption encrypts releases at rest if set.
+	Encryption *Encryption
+	// Zstd compresses releases with zstd instead of gzip. Releases
+	// compressed with zstd are smaller, but older versions of Helm cannot
+	// read them.
+	Zstd bool
 }
 
 // NewSecrets initializes a new Secrets wrapping an implementation of
 // the kubernetes SecretsInterface.
 func NewSecrets(impl corev1.SecretInterface) *Secrets {
 	return &Secrets{
-		impl: impl,
-		Log:  func(_ string, _ ...interface{}) {},
+		impl:      impl,
+		Log:       func(_ string, _ ...interface{}) {},
+		ChunkSize: DefaultSecretChunkSize,
 	}
 }
 
@@ -70,7 +101,7 @@ func (secrets *Secrets) Get(key string) (*rspb.Release, error) {
 		return nil, errors.Wrapf(err, "get: failed to get %q", key)
 	}
 	// found the secret, decode the base64 data string
-	r, err := decodeRelease(string(obj.Data["release"]))
+	r, err := secrets.decodeSecret(obj)
 	return r, errors.Wrapf(err, "get: failed to decode data %q", key)
 }
 
@@ -90,8 +121,12 @@ func (secrets *Secrets) List(filter func(*rspb.Release) bool) ([]*rspb.Release,
 
 	// iterate over the secrets object list
 	// and decode each release
-	for _, item := range list.Items {
-		rls, err := decodeRelease(string(item.Data["release"]))
+	for i := range list.Items {
+		item := &list.Items[i]
+		if item.Type == secretChunkType {
+			continue
+		}
+		rls, err := secrets.decodeSecret(item)
 		if err != nil {
 			secrets.Log("list: failed to decode release: %v: %s", item, err)
 			continue
@@ -103,6 +138,38 @@ func (secrets *Secrets) List(filter func(*rspb.Release) bool) ([]*rspb.Release,
 	return results, nil
 }
 
+// ListPage fetches a page of the releases selected by opts, filtering and
+// paging them in the Kubernetes API server.
+func (secrets *Secrets) ListPage(opts ListOptions) ([]*rspb.Release, string, error) {
+	sel, err := opts.selector()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "list: invalid label selector")
+	}
+	list, err := secrets.impl.List(metav1.ListOptions{
+		LabelSelector: sel,
+		Limit:         int64(opts.Limit),
+		Continue:      opts.Continue,
+	})
+	if err != nil {
+		return nil, "", errors.Wrap(err, "list: failed to list")
+	}
+
+	var results []*rspb.Release
+	for i := range list.Items {
+		item := &list.Items[i]
+		if item.Type == secretChunkType {
+			continue
+		}
+		rls, err := secrets.decodeSecret(item)
+		if err != nil {
+			secrets.Log("list: failed to decode release: %v: %s", item, err)
+			continue
+		}
+		results = append(results, rls)
+	}
+	return results, list.Continue, nil
+}
+
 // Query fetches all releases that match the provided map of labels.
 // An error is returned if the secret fails to retrieve the releases.
 func (secrets *Secrets) Query(labels map[string]string) ([]*rspb.Release, error) {
@@ -126,8 +193,12 @@ func (secrets *Secrets) Query(labels map[string]string) ([]*rspb.Release, error)
 	}
 
 	var results []*rspb.Release
-	for _, item := range list.Items {
-		rls, err := decodeRelease(string(item.Data["release"]))
+	for i := range list.Items {
+		item := &list.Items[i]
+		if item.Type == secretChunkType {
+			continue
+		}
+		rls, err := secrets.decodeSecret(item)
 		if err != nil {
 			secrets.Log("query: failed to decode release: %s", err)
 			continue
@@ -147,12 +218,28 @@ func (secrets *Secrets) Create(key string, rls *rspb.Release) error {
 	lbs.set("createdAt", strconv.Itoa(int(time.Now().Unix())))
 
 	// create a new secret to hold the release
-	obj, err := newSecretsObject(key, rls, lbs)
+	obj, err := newSecretsObject(key, rls, lbs, secrets.Zstd)
+	if err == nil {
+		err = secrets.seal(obj)
+	}
 	if err != nil {
 		return errors.Wrapf(err, "create: failed to encode release %q", rls.Name)
 	}
+	chunks, err := splitSecret(obj, secrets.chunkSize())
+	if err != nil {
+		return errors.Wrapf(err, "create: failed to split release %q", rls.Name)
+	}
+	// store the chunks first so that the release is never visible
+	// without them
+	if err := secrets.createChunks(chunks); err != nil {
+		if apierrors.IsAlreadyExists(errors.Cause(err)) {
+			return ErrReleaseExists
+		}
+		return errors.Wrap(err, "create: failed to create")
+	}
 	// push the secret object out into the kubiverse
 	if _, err := secrets.impl.Create(obj); err != nil {
+		secrets.deleteChunks(obj)
 		if apierrors.IsAlreadyExists(err) {
 			return ErrReleaseExists
 		}
@@ -172,13 +259,37 @@ func (secrets *Secrets) Update(key string, rls *rspb.Release) error {
 	lbs.set("modifiedAt", strconv.Itoa(int(time.Now().Unix())))
 
 	// create a new secret object to hold the release
-	obj, err := newSecretsObject(key, rls, lbs)
+	obj, err := newSecretsObject(key, rls, lbs, secrets.Zstd)
+	if err == nil {
+		err = secrets.seal(obj)
+	}
 	if err != nil {
 		return errors.Wrapf(err, "update: failed to encode release %q", rls.Name)
 	}
+	old, err := secrets.impl.Get(key, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ErrReleaseNotFound
+		}
+		return errors.Wrap(err, "update: failed to update")
+	}
+	chunks, err := splitSecret(obj, secrets.chunkSize())
+	if err != nil {
+		return errors.Wrapf(err, "update: failed to split release %q", rls.Name)
+	}
+	// the new chunks are stored alongside the old ones, which the stored
+	// release keeps reading until it is updated
+	if err := secrets.createChunks(chunks); err != nil {
+		return errors.Wrap(err, "update: failed to update")
+	}
 	// push the secret object out into the kubiverse
-	_, err = secrets.impl.Update(obj)
-	return errors.Wrap(err, "update: failed to update")
+	if _, err = secrets.impl.Update(obj); err != nil {
+		secrets.deleteChunks(obj)
+		return errors.Wrap(err, "update: failed to update")
+	}
+	// remove the chunks the release no longer reads
+	secrets.deleteChunks(old)
+	return nil
 }
 
 // Delete deletes the Secret holding the release named by key.
@@ -191,14 +302,169 @@ func (secrets *Secrets) Delete(key string) (rls *rspb.Release, err error) {
 
 		return nil, errors.Wrapf(err, "delete: failed to get release %q", key)
 	}
+	obj, err := secrets.impl.Get(key, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "delete: failed to get release %q", key)
+	}
 	// delete the release
-	err = secrets.impl.Delete(key, &metav1.DeleteOptions{})
-	return rls, err
+	if err = secrets.impl.Delete(key, &metav1.DeleteOptions{}); err != nil {
+		return rls, err
+	}
+	secrets.deleteChunks(obj)
+	return rls, nil
+}
+
+// LockRelease acquires lock on the release name, held in a Secret.
+func (secrets *Secrets) LockRelease(name string, lock Lock) error {
+	err := acquireKubeLock(secretLock{secrets.impl, name}, name, lock)
+	if _, ok := err.(*LockedError); ok || err == nil {
+		return err
+	}
+	return errors.Wrapf(err, "lock: failed to lock release %q", name)
+}
+
+// UnlockRelease releases the lock of holder on the release name.
+func (secrets *Secrets) UnlockRelease(name, holder string) error {
+	err := releaseKubeLock(secretLock{secrets.impl, name}, holder)
+	return errors.Wrapf(err, "unlock: failed to unlock release %q", name)
+}
+
+func (secrets *Secrets) chunkSize() int {
+	if secrets.ChunkSize > 0 {
+		return secrets.ChunkSize
+	}
+	return DefaultSecretChunkSize
+}
+
+// decodeSecret decodes the release held by obj, reading the chunks of the
+// release stored in other Secrets.
+func (secrets *Secrets) decodeSecret(obj *v1.Secret) (*rspb.Release, error) {
+	data := obj.Data["release"]
+	if n := secretChunks(obj); n > 1 {
+		data = append([]byte{}, data...)
+		for i := 2; i <= n; i++ {
+			chunk, err := secrets.impl.Get(chunkName(obj, i), metav1.GetOptions{})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get chunk %d of %d", i, n)
+			}
+			data = append(data, chunk.Data["release"]...)
+		}
+	}
+	s, err := secrets.Encryption.open(string(data))
+	if err != nil {
+		return nil, err
+	}
+	return decodeRelease(s)
+}
+
+// seal encrypts the release held by obj.
+func (secrets *Secrets) seal(obj *v1.Secret) error {
+	s, err := secrets.Encryption.seal(string(obj.Data["release"]))
+	if err != nil {
+		return err
+	}
+	obj.Data["release"] = []byte(s)
+	return nil
+}
+
+// createChunks creates the given chunks. Existing chunks are never
+// replaced: if one of them fails, the chunks created so far are deleted
+// and the error is returned.
+func (secrets *Secrets) createChunks(chunks []*v1.Secret) error {
+	for i, chunk := range chunks {
+		if _, err := secrets.impl.Create(chunk); err != nil {
+			for _, created := range chunks[:i] {
+				secrets.deleteChunk(created.Name)
+			}
+			return errors.Wrapf(err, "failed to store chunk %s", chunk.Name)
+		}
+	}
+	return nil
+}
+
+// deleteChunks deletes the chunks of the release held by obj.
+func (secrets *Secrets) deleteChunks(obj *v1.Secret) {
+	for i := 2; i <= secretChunks(obj); i++ {
+		secrets.deleteChunk(chunkName(obj, i))
+	}
+}
+
+func (secrets *Secrets) deleteChunk(name string) {
+	if err := secrets.impl.Delete(name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		secrets.Log("failed to delete chunk %q: %s", name, err)
+	}
+}
+
+// secretChunks returns the number of Secrets the release held by obj is
+// split across.
+func secretChunks(obj *v1.Secret) int {
+	n, err := strconv.Atoi(obj.Labels["chunks"])
+	if err != nil || n < 1 {
+		return 1
+	}
+	return n
+}
+
+// chunkName returns the name of the Secret holding chunk i of the release
+// held by obj. The first chunk is held by obj itself. The names include the
+// chunk generation of obj, so that every write of a release stores its
+// chunks apart from those of the release it replaces.
+func chunkName(obj *v1.Secret, i int) string {
+	return fmt.Sprintf("%s.%s.chunk%d", obj.Name, obj.Labels["chunkGeneration"], i)
+}
+
+// newChunkGeneration returns a random chunk generation.
+func newChunkGeneration() (string, error) {
+	b := make([]byte, 8)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// splitSecret keeps the first size bytes of the release held by obj and
+// returns Secrets holding the rest, in chunks of at most size bytes. The
+// number of chunks and their generation are recorded in the "chunks" and
+// "chunkGeneration" labels of obj.
+func splitSecret(obj *v1.Secret, size int) ([]*v1.Secret, error) {
+	data := obj.Data["release"]
+	if len(data) <= size {
+		return nil, nil
+	}
+	generation, err := newChunkGeneration()
+	if err != nil {
+		return nil, err
+	}
+	obj.Data["release"] = data[:size]
+	obj.Labels["chunkGeneration"] = generation
+	var chunks []*v1.Secret
+	for i, off := 2, size; off < len(data); i, off = i+1, off+size {
+		end := off + size
+		if end > len(data) {
+			end = len(data)
+		}
+		chunks = append(chunks, &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: chunkName(obj, i),
+				Labels: map[string]string{
+					"name":            obj.Labels["name"],
+					"version":         obj.Labels["version"],
+					"chunk":           strconv.Itoa(i),
+					"chunkGeneration": generation,
+				},
+			},
+			Type: secretChunkType,
+			Data: map[string][]byte{"release": data[off:end]},
+		})
+	}
+	obj.Labels["chunks"] = strconv.Itoa(len(chunks) + 1)
+	return chunks, nil
 }
 
 // newSecretsObject constructs a kubernetes Secret object
 // to store a release. Each secret data entry is the base64
-// encoded gzipped string of a release.
+// encoded gzipped string of a release, or its zstd compressed
+// string if useZstd is set.
 //
 // The following labels are used within each secret:
 //
@@ -208,12 +474,18 @@ func (secrets *Secrets) Delete(key string) (rls *rspb.Release, err error) {
 //    "status"         - status of the release (see pkg/release/status.go for variants)
 //    "owner"          - owner of the secret, currently "helm".
 //    "name"           - name of the release.
+//    "chunks"         - number of Secrets the release is split across, if more than one. (set by splitSecret)
+//    "chunkGeneration" - generation of the chunks of the release, if more than one. (set by splitSecret)
 //
-func newSecretsObject(key string, rls *rspb.Release, lbs labels) (*v1.Secret, error) {
+func newSecretsObject(key string, rls *rspb.Release, lbs labels, useZstd bool) (*v1.Secret, error) {
 	const owner = "helm"
 
 	// encode the release
-	s, err := encodeRelease(rls)
+	encode := encodeRelease
+	if useZstd {
+		encode = encodeReleaseZstd
+	}
+	s, err := encode(rls)
 	if err != nil {
 		return nil, err
 	}
@@ -244,7 +516,45 @@ func newSecretsObject(key string, rls *rspb.Release, lbs labels) (*v1.Secret, er
 			Name:   key,
 			Labels: lbs.toMap(),
 		},
-		Type: "helm.sh/release.v1",
+		Type: secretType,
 		Data: map[string][]byte{"release": []byte(s)},
 	}, nil
 }
+
+// secretLock is the Secret holding the lock on a release.
+type secretLock struct {
+	impl corev1.SecretInterface
+	name string
+}
+
+func (l secretLock) create(data []byte) error {
+	_, err := l.impl.Create(&v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: lockKey(l.name), Labels: lockLabels(l.name)},
+		Type:       secretLockType,
+		Data:       map[string][]byte{"lock": data},
+	})
+	return err
+}
+
+func (l secretLock) get() ([]byte, string, error) {
+	obj, err := l.impl.Get(lockKey(l.name), metav1.GetOptions{})
+	if err != nil {
+		return nil, "", err
+	}
+	return obj.Data["lock"], obj.ResourceVersion, nil
+}
+
+func (l secretLock) update(data []byte, resourceVersion string) error {
+	_, err := l.impl.Update(&v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: lockKey(l.name), Labels: lockLabels(l.name), ResourceVersion: resourceVersion},
+		Type:       secretLockType,
+		Data:       map[string][]byte{"lock": data},
+	})
+	return err
+}
+
+func (l secretLock) delete(resourceVersion string) error {
+	return l.impl.Delete(lockKey(l.name), &metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{ResourceVersion: &resourceVersion},
+	})
+}
@@ -14,12 +14,17 @@ limitations under the License.
 package driver
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	rspb "helm.sh/helm/v3/pkg/release"
 )
@@ -59,7 +64,7 @@ func TestUNcompressedSecretGet(t *testing.T) {
 	rel := releaseStub(name, vers, namespace, rspb.StatusDeployed)
 
 	// Create a test fixture which contains an uncompressed release
-	secret, err := newSecretsObject(key, rel, nil)
+	secret, err := newSecretsObject(key, rel, nil, false)
 	if err != nil {
 		t.Fatalf("Failed to create secret: %s", err)
 	}
@@ -183,4 +188,203 @@ func TestSecretUpdate(t *testing.T) {
 	if rel.Info.Status != got.Info.Status {
 		t.Errorf("Expected status %s, got status %s", rel.Info.Status.String(), got.Info.Status.String())
 	}
+
+	// updating a missing release fails with ErrReleaseNotFound
+	missing := releaseStub(name, vers+1, namespace, rspb.StatusDeployed)
+	if err := secrets.Update(testKey(name, vers+1), missing); err != ErrReleaseNotFound {
+		t.Errorf("Expected ErrReleaseNotFound, got %v", err)
+	}
+}
+
+func TestZstdSecretGet(t *testing.T) {
+	key := testKey("smug-pigeon", 1)
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.StatusDeployed)
+
+	var mock MockSecretsInterface
+	mock.objects = map[string]*v1.Secret{}
+	secrets := NewSecrets(&mock)
+	secrets.Zstd = true
+
+	if err := secrets.Create(key, rel); err != nil {
+		t.Fatalf("Failed to create release: %s", err)
+	}
+	data, err := b64.DecodeString(string(mock.objects[key].Data["release"]))
+	if err != nil {
+		t.Fatalf("Failed to decode release: %s", err)
+	}
+	if !bytes.HasPrefix(data, magicZstd) {
+		t.Errorf("Expected the release to be compressed with zstd")
+	}
+
+	got, err := secrets.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get release: %s", err)
+	}
+	if !reflect.DeepEqual(rel, got) {
+		t.Errorf("Expected {%v}, got {%v}", rel, got)
+	}
+}
+
+func TestSecretChunks(t *testing.T) {
+	key := testKey("smug-pigeon", 1)
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.StatusDeployed)
+	for i := 0; i < 200; i++ {
+		rel.Manifest += fmt.Sprintf("---\nkind: ConfigMap\nmetadata:\n  name: config-%d\n", i)
+	}
+
+	var mock MockSecretsInterface
+	mock.objects = map[string]*v1.Secret{}
+	secrets := NewSecrets(&mock)
+	secrets.ChunkSize = 64
+
+	if err := secrets.Create(key, rel); err != nil {
+		t.Fatalf("Failed to create release: %s", err)
+	}
+	chunks := len(mock.objects)
+	if chunks < 2 {
+		t.Fatalf("Expected the release to be split across several secrets, got %d", chunks)
+	}
+	if err := secrets.Create(key, rel); err != ErrReleaseExists {
+		t.Errorf("Expected ErrReleaseExists, got %v", err)
+	}
+
+	got, err := secrets.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get release: %s", err)
+	}
+	if !reflect.DeepEqual(rel, got) {
+		t.Errorf("Expected {%v}, got {%v}", rel, got)
+	}
+	list, err := secrets.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		t.Fatalf("Failed to list releases: %s", err)
+	}
+	if len(list) != 1 {
+		t.Errorf("Expected 1 release, got %d", len(list))
+	}
+
+	// A smaller release needs fewer chunks
+	rel.Manifest = ""
+	if err := secrets.Update(key, rel); err != nil {
+		t.Fatalf("Failed to update release: %s", err)
+	}
+	if len(mock.objects) >= chunks {
+		t.Errorf("Expected unused chunks to be deleted, got %d secrets", len(mock.objects))
+	}
+	if got, err = secrets.Get(key); err != nil || got.Manifest != "" {
+		t.Errorf("Expected the updated release, got %v, %v", got, err)
+	}
+
+	if _, err := secrets.Delete(key); err != nil {
+		t.Fatalf("Failed to delete release: %s", err)
+	}
+	if len(mock.objects) != 0 {
+		t.Errorf("Expected all chunks to be deleted, got %d secrets", len(mock.objects))
+	}
+}
+
+// failingUpdateSecrets fails the updates of the Secret named key.
+type failingUpdateSecrets struct {
+	*MockSecretsInterface
+	key string
+}
+
+func (f failingUpdateSecrets) Update(secret *v1.Secret) (*v1.Secret, error) {
+	if secret.Name == f.key {
+		return nil, apierrors.NewConflict(v1.Resource("tests"), f.key, errors.New("conflict"))
+	}
+	return f.MockSecretsInterface.Update(secret)
+}
+
+func TestSecretChunksFailedUpdate(t *testing.T) {
+	key := testKey("smug-pigeon", 1)
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.StatusDeployed)
+	for i := 0; i < 200; i++ {
+		rel.Manifest += fmt.Sprintf("---\nkind: ConfigMap\nmetadata:\n  name: config-%d\n", i)
+	}
+
+	mock := &MockSecretsInterface{objects: map[string]*v1.Secret{}}
+	secrets := NewSecrets(failingUpdateSecrets{mock, key})
+	secrets.ChunkSize = 64
+
+	if err := secrets.Create(key, rel); err != nil {
+		t.Fatalf("Failed to create release: %s", err)
+	}
+	stored := len(mock.objects)
+
+	updated := releaseStub("smug-pigeon", 1, "default", rspb.StatusFailed)
+	updated.Manifest = strings.Repeat("---\nkind: Secret\n", 200)
+	if err := secrets.Update(key, updated); err == nil {
+		t.Fatal("Expected the update to fail")
+	}
+	if len(mock.objects) != stored {
+		t.Errorf("Expected the chunks of the failed update to be deleted, got %d secrets", len(mock.objects))
+	}
+	got, err := secrets.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get release: %s", err)
+	}
+	if !reflect.DeepEqual(rel, got) {
+		t.Errorf("Expected {%v}, got {%v}", rel, got)
+	}
+}
+
+func TestSecretChunksCreateRace(t *testing.T) {
+	key := testKey("smug-pigeon", 1)
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.StatusDeployed)
+	for i := 0; i < 200; i++ {
+		rel.Manifest += fmt.Sprintf("---\nkind: ConfigMap\nmetadata:\n  name: config-%d\n", i)
+	}
+
+	var mock MockSecretsInterface
+	mock.objects = map[string]*v1.Secret{}
+	secrets := NewSecrets(&mock)
+	secrets.ChunkSize = 64
+
+	if err := secrets.Create(key, rel); err != nil {
+		t.Fatalf("Failed to create release: %s", err)
+	}
+	stored := len(mock.objects)
+
+	// A concurrent creator of the same release loses without touching the
+	// chunks of the release that won
+	other := releaseStub("smug-pigeon", 1, "default", rspb.StatusFailed)
+	other.Manifest = strings.Repeat("---\nkind: Secret\n", 200)
+	if err := secrets.Create(key, other); err != ErrReleaseExists {
+		t.Errorf("Expected ErrReleaseExists, got %v", err)
+	}
+	if len(mock.objects) != stored {
+		t.Errorf("Expected the chunks of the losing release to be deleted, got %d secrets", len(mock.objects))
+	}
+	got, err := secrets.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get release: %s", err)
+	}
+	if !reflect.DeepEqual(rel, got) {
+		t.Errorf("Expected {%v}, got {%v}", rel, got)
+	}
+}
+
+func TestListOptionsSelector(t *testing.T) {
+	opts := ListOptions{
+		Labels:   map[string]string{"name": "smug-pigeon"},
+		Statuses: []string{"deployed", "failed"},
+	}
+	sel, err := opts.selector()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "name=smug-pigeon,owner=helm,status in (deployed,failed)"; sel != expected {
+		t.Errorf("Expected selector %q, got %q", expected, sel)
+	}
+
+	if !opts.Matches(releaseStub("smug-pigeon", 1, "default", rspb.StatusFailed)) {
+		t.Error("Expected failed release to match")
+	}
+	if opts.Matches(releaseStub("smug-pigeon", 1, "default", rspb.StatusSuperseded)) {
+		t.Error("Expected superseded release not to match")
+	}
+	if opts.Matches(releaseStub("other", 1, "default", rspb.StatusDeployed)) {
+		t.Error("Expected other release not to match")
+	}
 }
@@ -0,0 +1,404 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "helm.sh/helm/v3/pkg/storage/driver"
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	rspb "helm.sh/helm/v3/pkg/release"
+)
+
+var _ Driver = (*SQLite)(nil)
+var _ Lister = (*SQLite)(nil)
+var _ Locker = (*SQLite)(nil)
+
+// SQLiteDriverName is the string name of this driver.
+const SQLiteDriverName = "SQLite"
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS releases (
+	key         TEXT    NOT NULL,
+	namespace   TEXT    NOT NULL,
+	name        TEXT    NOT NULL,
+	version     INTEGER NOT NULL,
+	status      TEXT    NOT NULL,
+	owner       TEXT    NOT NULL,
+	body        TEXT    NOT NULL,
+	created_at  INTEGER NOT NULL,
+	modified_at INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (key, namespace)
+);
+CREATE INDEX IF NOT EXISTS releases_name ON releases (namespace, name);
+CREATE TABLE IF NOT EXISTS release_locks (
+	namespace TEXT    NOT NULL,
+	name      TEXT    NOT NULL,
+	holder    TEXT    NOT NULL,
+	acquired  INTEGER NOT NULL,
+	ttl       INTEGER NOT NULL,
+	PRIMARY KEY (namespace, name)
+);
+`
+
+// sqliteOrder orders releases so that pages are stable.
+const sqliteOrder = " ORDER BY namespace, name, version, key"
+
+// sqliteLabelColumns maps the labels releases are queried by to the columns
+// holding them.
+var sqliteLabelColumns = map[string]string{
+	"name":    "name",
+	"owner":   "owner",
+	"status":  "status",
+	"version": "version",
+}
+
+// SQLite is the storage driver keeping releases in a SQLite database file.
+//
+// It needs neither a cluster nor a database server, which makes it suitable
+// for local or edge clusters and for tests.
+type SQLite struct {
+	db        *sql.DB
+	namespace string
+	Log       func(string, ...interface{})
+	// Encryption encrypts releases at rest if set.
+	Encryption *Encryption
+}
+
+// NewSQLite opens, creating it if needed, the SQLite database at path and
+// returns a driver accessing the releases of namespace. An empty namespace
+// lists and queries releases of all namespaces. The driver is only available
+// in builds of Helm with cgo.
+func NewSQLite(path, namespace string) (*SQLite, error) {
+	if !sqliteSupported {
+		return nil, errors.New("the sqlite storage driver is not supported by this build of Helm, which was built without cgo")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, errors.Wrap(err, "unable to create the release database directory")
+	}
+	// SQLite allows a single writer, so concurrent writes wait for each
+	// other instead of failing.
+	db, err := sql.Open("sqlite3", path+"?_busy_timeout=5000")
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open release database %s", path)
+	}
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, errors.Wrapf(err, "unable to initialize release database %s", path)
+	}
+	return &SQLite{
+		db:        db,
+		namespace: namespace,
+		Log:       func(_ string, _ ...interface{}) {},
+	}, nil
+}
+
+// Name returns the name of the driver.
+func (s *SQLite) Name() string {
+	return SQLiteDriverName
+}
+
+// Close closes the database.
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
+
+// Get returns the release named by key or returns ErrReleaseNotFound.
+func (s *SQLite) Get(key string) (*rspb.Release, error) {
+	query, args := s.where("SELECT body FROM releases WHERE key = ?", key)
+	var body string
+	if err := s.db.QueryRow(query, args...).Scan(&body); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrReleaseNotFound
+		}
+		s.Log("get: failed to get %q: %s", key, err)
+		return nil, err
+	}
+	rls, err := s.decode(body)
+	if err != nil {
+		s.Log("get: failed to decode data %q: %s", key, err)
+		return nil, err
+	}
+	return rls, nil
+}
+
+// List returns the list of all releases such that filter(release) == true
+func (s *SQLite) List(filter func(*rspb.Release) bool) ([]*rspb.Release, error) {
+	query, args := s.where("SELECT body FROM releases WHERE owner = ?", "helm")
+	results, err := s.queryReleases(query+sqliteOrder, args...)
+	if err != nil {
+		s.Log("list: failed to list: %s", err)
+		return nil, err
+	}
+	var filtered []*rspb.Release
+	for _, rls := range results {
+		if filter(rls) {
+			filtered = append(filtered, rls)
+		}
+	}
+	return filtered, nil
+}
+
+// Query returns the set of releases that match the provided set of labels.
+func (s *SQLite) Query(labels map[string]string) ([]*rspb.Release, error) {
+	conds, args, err := labelConditions(labels)
+	if err != nil {
+		return nil, err
+	}
+	query, args := s.where("SELECT body FROM releases WHERE "+strings.Join(conds, " AND "), args...)
+
+	results, err := s.queryReleases(query+sqliteOrder, args...)
+	if err != nil {
+		s.Log("query: failed to query with labels: %s", err)
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, ErrReleaseNotFound
+	}
+	return results, nil
+}
+
+// ListPage returns a page of the releases selected by opts. The continue
+// token is the offset of the next page.
+func (s *SQLite) ListPage(opts ListOptions) ([]*rspb.Release, string, error) {
+	conds, args, err := labelConditions(opts.Labels)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(opts.Statuses) > 0 {
+		conds = append(conds, "status IN (?"+strings.Repeat(", ?", len(opts.Statuses)-1)+")")
+		for _, status := range opts.Statuses {
+			args = append(args, status)
+		}
+	}
+	query, args := s.where("SELECT body FROM releases WHERE "+strings.Join(conds, " AND "), args...)
+	query += sqliteOrder
+
+	offset := 0
+	if opts.Continue != "" {
+		if offset, err = strconv.Atoi(opts.Continue); err != nil || offset < 0 {
+			return nil, "", errors.Errorf("invalid continue token %q", opts.Continue)
+		}
+	}
+	if opts.Limit > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, opts.Limit, offset)
+	} else if offset > 0 {
+		query += " LIMIT -1 OFFSET ?"
+		args = append(args, offset)
+	}
+
+	results, err := s.queryReleases(query, args...)
+	if err != nil {
+		s.Log("list: failed to list: %s", err)
+		return nil, "", err
+	}
+	next := ""
+	if opts.Limit > 0 && len(results) == opts.Limit {
+		next = strconv.Itoa(offset + opts.Limit)
+	}
+	return results, next, nil
+}
+
+// Create creates a new release or returns ErrReleaseExists.
+func (s *SQLite) Create(key string, rls *rspb.Release) error {
+	body, err := s.encode(rls)
+	if err != nil {
+		s.Log("create: failed to encode release %q: %s", rls.Name, err)
+		return err
+	}
+	_, err = s.db.Exec(
+		"INSERT INTO releases (key, namespace, name, version, status, owner, body, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		key, s.releaseNamespace(rls), rls.Name, rls.Version, rls.Info.Status.String(), "helm", body, time.Now().Unix(),
+	)
+	if err != nil {
+		if isSQLiteConstraint(err) {
+			return ErrReleaseExists
+		}
+		s.Log("create: failed to create: %s", err)
+		return err
+	}
+	return nil
+}
+
+// Update updates a release or returns ErrReleaseNotFound.
+func (s *SQLite) Update(key string, rls *rspb.Release) error {
+	body, err := s.encode(rls)
+	if err != nil {
+		s.Log("update: failed to encode release %q: %s", rls.Name, err)
+		return err
+	}
+	res, err := s.db.Exec(
+		"UPDATE releases SET name = ?, version = ?, status = ?, body = ?, modified_at = ? WHERE key = ? AND namespace = ?",
+		rls.Name, rls.Version, rls.Info.Status.String(), body, time.Now().Unix(), key, s.releaseNamespace(rls),
+	)
+	if err != nil {
+		s.Log("update: failed to update: %s", err)
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrReleaseNotFound
+	}
+	return nil
+}
+
+// Delete deletes a release or returns ErrReleaseNotFound.
+func (s *SQLite) Delete(key string) (*rspb.Release, error) {
+	rls, err := s.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.db.Exec("DELETE FROM releases WHERE key = ? AND namespace = ?", key, s.releaseNamespace(rls)); err != nil {
+		s.Log("delete: failed to delete %q: %s", key, err)
+		return nil, err
+	}
+	return rls, nil
+}
+
+// LockRelease acquires lock on the release name. The lock is taken over
+// only if it is held by the same holder or has expired, in a single
+// statement so that concurrent clients cannot both acquire it.
+func (s *SQLite) LockRelease(name string, lock Lock) error {
+	res, err := s.db.Exec(
+		`INSERT INTO release_locks (namespace, name, holder, acquired, ttl) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (namespace, name) DO UPDATE SET holder = excluded.holder, acquired = excluded.acquired, ttl = excluded.ttl
+		WHERE release_locks.holder = excluded.holder OR (release_locks.ttl > 0 AND release_locks.acquired + release_locks.ttl < excluded.acquired)`,
+		s.lockNamespace(), name, lock.Holder, lock.Acquired.UnixNano(), int64(lock.TTL),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "lock: failed to lock release %q", name)
+	}
+	if n, err := res.RowsAffected(); err != nil || n > 0 {
+		return err
+	}
+
+	var acquired, ttl int64
+	held := Lock{}
+	err = s.db.QueryRow(
+		"SELECT holder, acquired, ttl FROM release_locks WHERE namespace = ? AND name = ?", s.lockNamespace(), name,
+	).Scan(&held.Holder, &acquired, &ttl)
+	if err != nil {
+		return errors.Wrapf(err, "lock: failed to get the lock on release %q", name)
+	}
+	held.Acquired, held.TTL = time.Unix(0, acquired), time.Duration(ttl)
+	return &LockedError{Release: name, Lock: held}
+}
+
+// UnlockRelease releases the lock of holder on the release name.
+func (s *SQLite) UnlockRelease(name, holder string) error {
+	_, err := s.db.Exec("DELETE FROM release_locks WHERE namespace = ? AND name = ? AND holder = ?", s.lockNamespace(), name, holder)
+	return errors.Wrapf(err, "unlock: failed to unlock release %q", name)
+}
+
+// lockNamespace returns the namespace the locks of the driver are in.
+func (s *SQLite) lockNamespace() string {
+	if s.namespace != "" {
+		return s.namespace
+	}
+	return defaultNamespace
+}
+
+// encode encodes and encrypts rls.
+func (s *SQLite) encode(rls *rspb.Release) (string, error) {
+	body, err := encodeRelease(rls)
+	if err != nil {
+		return "", err
+	}
+	return s.Encryption.seal(body)
+}
+
+// decode decrypts and decodes the body of a release.
+func (s *SQLite) decode(body string) (*rspb.Release, error) {
+	data, err := s.Encryption.open(body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeRelease(data)
+}
+
+// labelConditions returns the conditions selecting releases with labels.
+func labelConditions(labels map[string]string) ([]string, []interface{}, error) {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var conds []string
+	var args []interface{}
+	for _, k := range keys {
+		column, ok := sqliteLabelColumns[k]
+		if !ok {
+			return nil, nil, errors.Errorf("unsupported label %q in release query", k)
+		}
+		conds = append(conds, column+" = ?")
+		args = append(args, labels[k])
+	}
+	if len(conds) == 0 {
+		// Every release is owned by Helm.
+		conds, args = []string{"owner = ?"}, []interface{}{"helm"}
+	}
+	return conds, args, nil
+}
+
+// where restricts query to the namespace of the driver, if any.
+func (s *SQLite) where(query string, args ...interface{}) (string, []interface{}) {
+	if s.namespace == "" {
+		return query, args
+	}
+	return query + " AND namespace = ?", append(args, s.namespace)
+}
+
+// releaseNamespace returns the namespace rls is stored in.
+func (s *SQLite) releaseNamespace(rls *rspb.Release) string {
+	if s.namespace != "" {
+		return s.namespace
+	}
+	if rls.Namespace != "" {
+		return rls.Namespace
+	}
+	return defaultNamespace
+}
+
+func (s *SQLite) queryReleases(query string, args ...interface{}) ([]*rspb.Release, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*rspb.Release
+	for rows.Next() {
+		var body string
+		if err := rows.Scan(&body); err != nil {
+			return nil, err
+		}
+		rls, err := s.decode(body)
+		if err != nil {
+			s.Log("failed to decode release: %s", err)
+			continue
+		}
+		results = append(results, rls)
+	}
+	return results, rows.Err()
+}
@@ -0,0 +1,30 @@
+// Copyright The Helm Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build cgo
+
+package driver // import "helm.sh/helm/v3/pkg/storage/driver"
+
+import (
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteSupported reports whether the SQLite driver is available, which
+// needs cgo.
+const sqliteSupported = true
+
+// isSQLiteConstraint reports whether err is a constraint violation.
+func isSQLiteConstraint(err error) bool {
+	sqliteErr, ok := err.(sqlite3.Error)
+	return ok && sqliteErr.Code == sqlite3.ErrConstraint
+}
@@ -0,0 +1,206 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"helm.sh/helm/v3/internal/test/ensure"
+	rspb "helm.sh/helm/v3/pkg/release"
+)
+
+// newTestFixtureSQLite opens the database in dir and creates the releases.
+func newTestFixtureSQLite(t *testing.T, dir, namespace string, releases ...*rspb.Release) *SQLite {
+	t.Helper()
+	if !sqliteSupported {
+		t.Skip("sqlite driver requires cgo")
+	}
+	s, err := NewSQLite(filepath.Join(dir, "data", "releases.db"), namespace)
+	if err != nil {
+		t.Fatalf("Failed to open database: %s", err)
+	}
+	for _, rls := range releases {
+		if err := s.Create(testKey(rls.Name, rls.Version), rls); err != nil {
+			t.Fatalf("Failed to create release: %s", err)
+		}
+	}
+	return s
+}
+
+func TestSQLiteName(t *testing.T) {
+	dir := ensure.TempDir(t)
+	defer os.RemoveAll(dir)
+	s := newTestFixtureSQLite(t, dir, "default")
+	defer s.Close()
+	if s.Name() != SQLiteDriverName {
+		t.Errorf("Expected name to be %q, got %q", SQLiteDriverName, s.Name())
+	}
+}
+
+func TestSQLiteCreateGet(t *testing.T) {
+	dir := ensure.TempDir(t)
+	defer os.RemoveAll(dir)
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.StatusDeployed)
+	s := newTestFixtureSQLite(t, dir, "default", rel)
+	defer s.Close()
+
+	if err := s.Create(testKey(rel.Name, rel.Version), rel); err != ErrReleaseExists {
+		t.Errorf("Expected ErrReleaseExists, got %v", err)
+	}
+
+	got, err := s.Get(testKey(rel.Name, rel.Version))
+	if err != nil {
+		t.Fatalf("Failed to get release: %s", err)
+	}
+	if got.Name != rel.Name || got.Version != rel.Version {
+		t.Errorf("Expected release %s.v%d, got %s.v%d", rel.Name, rel.Version, got.Name, got.Version)
+	}
+
+	if _, err := s.Get("smug-pigeon.v2"); err != ErrReleaseNotFound {
+		t.Errorf("Expected ErrReleaseNotFound, got %v", err)
+	}
+}
+
+func TestSQLiteUpdateDelete(t *testing.T) {
+	dir := ensure.TempDir(t)
+	defer os.RemoveAll(dir)
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.StatusDeployed)
+	key := testKey(rel.Name, rel.Version)
+	s := newTestFixtureSQLite(t, dir, "default", rel)
+	defer s.Close()
+
+	rel.Info.Status = rspb.StatusSuperseded
+	if err := s.Update(key, rel); err != nil {
+		t.Fatalf("Failed to update release: %s", err)
+	}
+	got, err := s.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get release: %s", err)
+	}
+	if got.Info.Status != rspb.StatusSuperseded {
+		t.Errorf("Expected status %s, got %s", rspb.StatusSuperseded, got.Info.Status)
+	}
+	if err := s.Update("smug-pigeon.v2", releaseStub("smug-pigeon", 2, "default", rspb.StatusDeployed)); err != ErrReleaseNotFound {
+		t.Errorf("Expected ErrReleaseNotFound, got %v", err)
+	}
+
+	if _, err := s.Delete(key); err != nil {
+		t.Fatalf("Failed to delete release: %s", err)
+	}
+	if _, err := s.Get(key); err != ErrReleaseNotFound {
+		t.Errorf("Expected ErrReleaseNotFound after delete, got %v", err)
+	}
+	if _, err := s.Delete(key); err != ErrReleaseNotFound {
+		t.Errorf("Expected ErrReleaseNotFound deleting twice, got %v", err)
+	}
+}
+
+func TestSQLiteListQuery(t *testing.T) {
+	dir := ensure.TempDir(t)
+	defer os.RemoveAll(dir)
+	releases := []*rspb.Release{
+		releaseStub("rls-a", 1, "default", rspb.StatusSuperseded),
+		releaseStub("rls-a", 2, "default", rspb.StatusDeployed),
+		releaseStub("rls-b", 1, "default", rspb.StatusDeployed),
+	}
+	s := newTestFixtureSQLite(t, dir, "default", releases...)
+	defer s.Close()
+	other := newTestFixtureSQLite(t, dir, "mynamespace")
+	defer other.Close()
+	if err := other.Create(testKey("rls-c", 1), releaseStub("rls-c", 1, "mynamespace", rspb.StatusDeployed)); err != nil {
+		t.Fatal(err)
+	}
+
+	deployed, err := s.List(func(rel *rspb.Release) bool {
+		return rel.Info.Status == rspb.StatusDeployed
+	})
+	if err != nil {
+		t.Fatalf("Failed to list releases: %s", err)
+	}
+	if len(deployed) != 2 {
+		t.Errorf("Expected 2 deployed releases in namespace, got %d", len(deployed))
+	}
+
+	all := newTestFixtureSQLite(t, dir, "")
+	defer all.Close()
+	everything, err := all.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		t.Fatalf("Failed to list releases: %s", err)
+	}
+	if len(everything) != 4 {
+		t.Errorf("Expected 4 releases in all namespaces, got %d", len(everything))
+	}
+
+	results, err := s.Query(map[string]string{"name": "rls-a", "owner": "helm", "version": "2"})
+	if err != nil {
+		t.Fatalf("Failed to query releases: %s", err)
+	}
+	if len(results) != 1 || results[0].Version != 2 {
+		t.Errorf("Expected rls-a.v2, got %v", results)
+	}
+	if _, err := s.Query(map[string]string{"name": "rls-c"}); err != ErrReleaseNotFound {
+		t.Errorf("Expected ErrReleaseNotFound for another namespace, got %v", err)
+	}
+}
+
+func TestSQLiteListPage(t *testing.T) {
+	dir := ensure.TempDir(t)
+	defer os.RemoveAll(dir)
+	s := newTestFixtureSQLite(t, dir, "default",
+		releaseStub("rls-a", 1, "default", rspb.StatusSuperseded),
+		releaseStub("rls-a", 2, "default", rspb.StatusDeployed),
+		releaseStub("rls-b", 1, "default", rspb.StatusFailed),
+		releaseStub("rls-c", 1, "default", rspb.StatusDeployed),
+	)
+	defer s.Close()
+
+	opts := ListOptions{Statuses: []string{"deployed", "failed"}, Limit: 2}
+	var names []string
+	for pages := 0; ; pages++ {
+		page, next, err := s.ListPage(opts)
+		if err != nil {
+			t.Fatalf("Failed to list releases: %s", err)
+		}
+		if len(page) > opts.Limit {
+			t.Errorf("Expected at most %d releases in a page, got %d", opts.Limit, len(page))
+		}
+		for _, rls := range page {
+			names = append(names, testKey(rls.Name, rls.Version))
+		}
+		if next == "" {
+			break
+		}
+		if pages > 3 {
+			t.Fatal("Too many pages")
+		}
+		opts.Continue = next
+	}
+	if got := strings.Join(names, " "); got != "rls-a.v2 rls-b.v1 rls-c.v1" {
+		t.Errorf("Unexpected releases %q", got)
+	}
+
+	page, _, err := s.ListPage(ListOptions{Labels: map[string]string{"name": "rls-a"}})
+	if err != nil {
+		t.Fatalf("Failed to list releases: %s", err)
+	}
+	if len(page) != 2 {
+		t.Errorf("Expected 2 revisions of rls-a, got %d", len(page))
+	}
+}
@@ -23,6 +23,8 @@ import (
 	"encoding/json"
 	"io/ioutil"
 
+	"github.com/klauspost/compress/zstd"
+
 	rspb "helm.sh/helm/v3/pkg/release"
 )
 
@@ -30,6 +32,8 @@ var b64 = base64.StdEncoding
 
 var magicGzip = []byte{0x1f, 0x8b, 0x08}
 
+var magicZstd = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
 // encodeRelease encodes a release returning a base64 encoded
 // gzipped string representation, or error.
 func encodeRelease(rls *rspb.Release) (string, error) {
@@ -50,9 +54,25 @@ func encodeRelease(rls *rspb.Release) (string, error) {
 	return b64.EncodeToString(buf.Bytes()), nil
 }
 
+// encodeReleaseZstd encodes a release returning a base64 encoded
+// zstd compressed string representation, or error. It is smaller than the
+// gzipped representation but cannot be read by older versions of Helm.
+func encodeReleaseZstd(rls *rspb.Release) (string, error) {
+	b, err := json.Marshal(rls)
+	if err != nil {
+		return "", err
+	}
+	w, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
+	if err != nil {
+		return "", err
+	}
+	defer w.Close()
+	return b64.EncodeToString(w.EncodeAll(b, nil)), nil
+}
+
 // decodeRelease decodes the bytes of data into a release
-// type. Data must contain a base64 encoded gzipped string of a
-// valid release, otherwise an error is returned.
+// type. Data must contain a base64 encoded gzipped or zstd compressed
+// string of a valid release, otherwise an error is returned.
 func decodeRelease(data string) (*rspb.Release, error) {
 	// base64 decode string
 	b, err := b64.DecodeString(data)
@@ -62,8 +82,17 @@ func decodeRelease(data string) (*rspb.Release, error) {
 
 	// For backwards compatibility with releases that were stored before
 	// compression was introduced we skip decompression if the
-	// gzip magic header is not found
-	if bytes.Equal(b[0:3], magicGzip) {
+	// gzip or zstd magic header is not found
+	if len(b) >= len(magicZstd) && bytes.Equal(b[0:4], magicZstd) {
+		r, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		if b, err = r.DecodeAll(b, nil); err != nil {
+			return nil, err
+		}
+	} else if bytes.Equal(b[0:3], magicGzip) {
 		r, err := gzip.NewReader(bytes.NewReader(b))
 		if err != nil {
 			return nil, err
@@ -18,7 +18,11 @@ package storage // import "helm.sh/helm/v3/pkg/storage"
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -58,10 +62,16 @@ func (s *Storage) Get(name string, version int) (*rspb.Release, error) {
 // error is returned if the storage driver failed to store the
 // release, or a release with identical an key already exists.
 func (s *Storage) Create(rls *rspb.Release) error {
+	return s.CreateWithMaxHistory(rls, s.MaxHistory)
+}
+
+// CreateWithMaxHistory is Create retaining at most maxHistory releases of the
+// same name instead of MaxHistory, for callers sharing the storage.
+func (s *Storage) CreateWithMaxHistory(rls *rspb.Release, maxHistory int) error {
 	s.Log("creating release %q", makeKey(rls.Name, rls.Version))
-	if s.MaxHistory > 0 {
+	if maxHistory > 0 {
 		// Want to make space for one more release.
-		s.removeLeastRecent(rls.Name, s.MaxHistory-1)
+		s.removeLeastRecent(rls.Name, maxHistory-1)
 	}
 	return s.Driver.Create(makeKey(rls.Name, rls.Version), rls)
 }
@@ -89,6 +99,47 @@ func (s *Storage) ListReleases() ([]*rspb.Release, error) {
 	return s.Driver.List(func(_ *rspb.Release) bool { return true })
 }
 
+// ListPage returns a page of the releases selected by opts and the token to
+// get the next page, which is empty after the last page. Drivers that
+// implement driver.Lister filter and page releases in their backend; for
+// the others all releases are loaded and paged here.
+func (s *Storage) ListPage(opts driver.ListOptions) ([]*rspb.Release, string, error) {
+	s.Log("listing a page of releases in storage")
+	if lister, ok := s.Driver.(driver.Lister); ok {
+		return lister.ListPage(opts)
+	}
+
+	offset := 0
+	if opts.Continue != "" {
+		var err error
+		if offset, err = strconv.Atoi(opts.Continue); err != nil || offset < 0 {
+			return nil, "", errors.Errorf("invalid continue token %q", opts.Continue)
+		}
+	}
+	ls, err := s.Driver.List(opts.Matches)
+	if err != nil {
+		return nil, "", err
+	}
+	// Sort for stable pages, as drivers list releases in no particular order.
+	sort.SliceStable(ls, func(i, j int) bool {
+		if ls[i].Namespace != ls[j].Namespace {
+			return ls[i].Namespace < ls[j].Namespace
+		}
+		if ls[i].Name != ls[j].Name {
+			return ls[i].Name < ls[j].Name
+		}
+		return ls[i].Version < ls[j].Version
+	})
+	if offset >= len(ls) {
+		return nil, "", nil
+	}
+	ls = ls[offset:]
+	if opts.Limit > 0 && opts.Limit < len(ls) {
+		return ls[:opts.Limit], strconv.Itoa(offset + opts.Limit), nil
+	}
+	return ls, "", nil
+}
+
 // ListUninstalled returns all releases with Status == UNINSTALLED. An error is returned
 // if the storage backend fails to retrieve the releases.
 func (s *Storage) ListUninstalled() ([]*rspb.Release, error) {
@@ -213,6 +264,77 @@ func (s *Storage) Last(name string) (*rspb.Release, error) {
 	return h[0], nil
 }
 
+// Reencrypt writes back every stored release, so that releases are
+// encrypted with the current key of the driver after a key rotation. It
+// returns the number of releases written.
+func (s *Storage) Reencrypt() (int, error) {
+	s.Log("re-encrypting all releases")
+	releases, err := s.Driver.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		return 0, err
+	}
+	for i, rls := range releases {
+		if err := s.Update(rls); err != nil {
+			return i, errors.Wrapf(err, "unable to re-encrypt %s", makeKey(rls.Name, rls.Version))
+		}
+	}
+	return len(releases), nil
+}
+
+// Lock acquires a lock on the release name for holder, which expires after
+// ttl, and returns a function releasing it. The lease is renewed every third
+// of ttl until the lock is released, so that it outlasts operations running
+// longer than ttl, yet expires soon after a client dies holding it. It
+// returns a *driver.LockedError if another holder has locked the release.
+// Releases are not locked if the driver does not implement driver.Locker.
+func (s *Storage) Lock(name, holder string, ttl time.Duration) (func(), error) {
+	locker, ok := s.Driver.(driver.Locker)
+	if !ok {
+		return func() {}, nil
+	}
+	s.Log("locking release %q for %s", name, holder)
+	if err := locker.LockRelease(name, driver.Lock{Holder: holder, Acquired: time.Now(), TTL: ttl}); err != nil {
+		return nil, err
+	}
+	done := make(chan struct{})
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		s.renewLock(locker, name, holder, ttl, done)
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			// Stop renewing first, so that the lease is not renewed once released.
+			close(done)
+			<-renewed
+			if err := locker.UnlockRelease(name, holder); err != nil {
+				s.Log("failed to unlock release %q: %s", name, err)
+			}
+		})
+	}, nil
+}
+
+// renewLock renews the lease of holder on the release name every third of
+// ttl until done is closed. Leases that do not expire are not renewed.
+func (s *Storage) renewLock(locker driver.Locker, name, holder string, ttl time.Duration, done <-chan struct{}) {
+	if ttl <= 0 {
+		return
+	}
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := locker.LockRelease(name, driver.Lock{Holder: holder, Acquired: time.Now(), TTL: ttl}); err != nil {
+				s.Log("failed to renew the lock on release %q: %s", name, err)
+			}
+		}
+	}
+}
+
 // makeKey concatenates the Kubernetes storage object type, a release name and version
 // into a string with format:```<helm_storage_type>.<release_name>.v<release_version>```.
 // The storage type is prepended to keep name uniqueness between different
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	rspb "helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/storage/driver"
@@ -331,6 +332,18 @@ func TestStorageRemoveLeastRecent(t *testing.T) {
 			t.Errorf("Expected release %d, got %d", expect, v)
 		}
 	}
+
+	// A limit given with the release overrides MaxHistory.
+	rls6 := ReleaseTestData{Name: name, Version: 6, Status: rspb.StatusDeployed}.ToRelease()
+	assertErrNil(t.Fatal, storage.CreateWithMaxHistory(rls6, 2), "Storing release 'angry-bird' (v6)")
+	if hist, err := storage.History(name); err != nil {
+		t.Fatal(err)
+	} else if len(hist) != 2 {
+		t.Fatalf("expected 2 items in history, got %d", len(hist))
+	}
+	if storage.MaxHistory != 3 {
+		t.Errorf("expected MaxHistory to stay 3, got %d", storage.MaxHistory)
+	}
 }
 
 func TestStorageLast(t *testing.T) {
@@ -365,6 +378,93 @@ func TestStorageLast(t *testing.T) {
 	}
 }
 
+func TestStorageListPage(t *testing.T) {
+	storage := Init(driver.NewMemory())
+
+	for _, rls := range []*rspb.Release{
+		ReleaseTestData{Name: "angry-bird", Version: 1, Status: rspb.StatusSuperseded}.ToRelease(),
+		ReleaseTestData{Name: "angry-bird", Version: 2, Status: rspb.StatusDeployed}.ToRelease(),
+		ReleaseTestData{Name: "happy-cat", Version: 1, Status: rspb.StatusDeployed}.ToRelease(),
+		ReleaseTestData{Name: "sad-dog", Version: 1, Status: rspb.StatusFailed}.ToRelease(),
+	} {
+		assertErrNil(t.Fatal, storage.Create(rls), "Storing release")
+	}
+
+	opts := driver.ListOptions{Statuses: []string{"deployed", "failed"}, Limit: 2}
+	page, next, err := storage.ListPage(opts)
+	assertErrNil(t.Fatal, err, "ListPage")
+	if len(page) != 2 || page[0].Name != "angry-bird" || page[0].Version != 2 || page[1].Name != "happy-cat" {
+		t.Errorf("Unexpected first page %v", page)
+	}
+	if next == "" {
+		t.Fatal("Expected a token for the next page")
+	}
+
+	opts.Continue = next
+	page, next, err = storage.ListPage(opts)
+	assertErrNil(t.Fatal, err, "ListPage")
+	if len(page) != 1 || page[0].Name != "sad-dog" {
+		t.Errorf("Unexpected second page %v", page)
+	}
+	if next != "" {
+		t.Errorf("Expected no more pages, got token %q", next)
+	}
+}
+
+func TestStorageReencrypt(t *testing.T) {
+	storage := Init(driver.NewMemory())
+
+	assertErrNil(t.Fatal, storage.Create(ReleaseTestData{Name: "angry-bird", Version: 1, Status: rspb.StatusSuperseded}.ToRelease()), "Storing release 'angry-bird' (v1)")
+	assertErrNil(t.Fatal, storage.Create(ReleaseTestData{Name: "angry-bird", Version: 2, Status: rspb.StatusDeployed}.ToRelease()), "Storing release 'angry-bird' (v2)")
+
+	n, err := storage.Reencrypt()
+	assertErrNil(t.Fatal, err, "Reencrypt")
+	if n != 2 {
+		t.Errorf("Expected 2 releases to be written, got %d", n)
+	}
+}
+
+func TestStorageLock(t *testing.T) {
+	storage := Init(driver.NewMemory())
+
+	unlock, err := storage.Lock("angry-bird", "alice@laptop", time.Minute)
+	assertErrNil(t.Fatal, err, "Locking release 'angry-bird'")
+
+	if _, err := storage.Lock("angry-bird", "bob@desktop", time.Minute); err == nil {
+		t.Fatal("Expected release 'angry-bird' to be locked")
+	} else if _, ok := err.(*driver.LockedError); !ok {
+		t.Fatalf("Expected a LockedError, got %v", err)
+	}
+
+	unlock()
+	unlock, err = storage.Lock("angry-bird", "bob@desktop", time.Minute)
+	assertErrNil(t.Fatal, err, "Locking unlocked release 'angry-bird'")
+	unlock()
+}
+
+func TestStorageLockRenewed(t *testing.T) {
+	storage := Init(driver.NewMemory())
+
+	const ttl = 100 * time.Millisecond
+	unlock, err := storage.Lock("angry-bird", "alice@laptop", ttl)
+	assertErrNil(t.Fatal, err, "Locking release 'angry-bird'")
+
+	// The lease is renewed while the lock is held.
+	time.Sleep(4 * ttl)
+	if _, err := storage.Lock("angry-bird", "bob@desktop", ttl); err == nil {
+		t.Fatal("Expected release 'angry-bird' to still be locked after its initial TTL")
+	} else if _, ok := err.(*driver.LockedError); !ok {
+		t.Fatalf("Expected a LockedError, got %v", err)
+	}
+
+	// The lease is no longer renewed once released.
+	unlock()
+	time.Sleep(2 * ttl)
+	unlock, err = storage.Lock("angry-bird", "bob@desktop", ttl)
+	assertErrNil(t.Fatal, err, "Locking unlocked release 'angry-bird'")
+	unlock()
+}
+
 type ReleaseTestData struct {
 	Name      string
 	Version   int
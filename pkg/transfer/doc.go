@@ -0,0 +1,24 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package transfer bounds the network transfers of the Helm SDK.
+
+A Pool limits how many downloads run at once and the bandwidth shared by all
+of them. The downloader, repository updates, getters and registry client use
+the pool they are given, or else the Default pool, which is unlimited until
+it is replaced with SetDefault.
+*/
+package transfer // import "helm.sh/helm/v3/pkg/transfer"
@@ -0,0 +1,85 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transfer // import "helm.sh/helm/v3/pkg/transfer"
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// bucket is a token bucket of bytes, refilled at a constant rate up to one
+// second worth of bytes.
+type bucket struct {
+	mu     sync.Mutex
+	rate   int64
+	tokens float64
+	last   time.Time
+	// sleep is replaced by tests
+	sleep func(time.Duration)
+	now   func() time.Time
+}
+
+func newBucket(rate int64) *bucket {
+	return &bucket{
+		rate:   rate,
+		tokens: float64(rate),
+		last:   time.Now(),
+		sleep:  time.Sleep,
+		now:    time.Now,
+	}
+}
+
+// take takes n bytes from the bucket, sleeping until they are refilled if
+// the bucket runs short.
+func (b *bucket) take(n int) {
+	if n <= 0 {
+		return
+	}
+	b.mu.Lock()
+	now := b.now()
+	b.tokens += now.Sub(b.last).Seconds() * float64(b.rate)
+	if b.tokens > float64(b.rate) {
+		b.tokens = float64(b.rate)
+	}
+	b.last = now
+	b.tokens -= float64(n)
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / float64(b.rate) * float64(time.Second))
+	}
+	b.mu.Unlock()
+	if wait > 0 {
+		b.sleep(wait)
+	}
+}
+
+// reader takes the bytes it reads from a bucket.
+type reader struct {
+	r      io.Reader
+	bucket *bucket
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	// Reads are no larger than the bucket, so that they can be refilled.
+	if int64(len(p)) > r.bucket.rate {
+		p = p[:r.bucket.rate]
+	}
+	n, err := r.r.Read(p)
+	r.bucket.take(n)
+	return n, err
+}
@@ -0,0 +1,151 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transfer // import "helm.sh/helm/v3/pkg/transfer"
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Limits are the limits of a Pool.
+type Limits struct {
+	// Concurrency is the number of downloads that run at once, or unlimited
+	// if it is zero or less.
+	Concurrency int
+	// Bandwidth is the number of bytes per second shared by all transfers,
+	// or unlimited if it is zero or less.
+	Bandwidth int64
+}
+
+// Pool is a pool of workers running downloads, and a bandwidth throttle
+// shared by the transfers using it. A nil Pool is unlimited.
+type Pool struct {
+	workers chan struct{}
+	bucket  *bucket
+}
+
+// NewPool returns a pool with the given limits.
+func NewPool(limits Limits) *Pool {
+	p := &Pool{}
+	if limits.Concurrency > 0 {
+		p.workers = make(chan struct{}, limits.Concurrency)
+	}
+	if limits.Bandwidth > 0 {
+		p.bucket = newBucket(limits.Bandwidth)
+	}
+	return p
+}
+
+var (
+	defaultMu   sync.RWMutex
+	defaultPool = NewPool(Limits{})
+)
+
+// Default returns the pool used when none is set.
+func Default() *Pool {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultPool
+}
+
+// SetDefault sets the pool used when none is set. It applies to the getters
+// and registry clients created afterwards.
+func SetDefault(p *Pool) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultPool = p
+}
+
+// Group returns a new group of functions run by the workers of the pool.
+func (p *Pool) Group() *Group {
+	return &Group{pool: p}
+}
+
+// Reader returns a reader reading from r within the bandwidth of the pool.
+func (p *Pool) Reader(r io.Reader) io.Reader {
+	if p == nil || p.bucket == nil {
+		return r
+	}
+	return &reader{r: r, bucket: p.bucket}
+}
+
+// Transport returns a transport whose response bodies are read within the
+// bandwidth of the pool.
+func (p *Pool) Transport(rt http.RoundTripper) http.RoundTripper {
+	if p == nil || p.bucket == nil {
+		return rt
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &transport{rt: rt, pool: p}
+}
+
+// Group runs functions on the workers of a pool and waits for them. Groups
+// do not nest: a function run by a group must not run functions with
+// another group of the same pool, as it would wait for a worker it holds.
+type Group struct {
+	pool *Pool
+	wg   sync.WaitGroup
+}
+
+// Go runs fn in a new goroutine once a worker of the pool is free, waiting
+// for one if they are all busy.
+func (g *Group) Go(fn func()) {
+	var workers chan struct{}
+	if g.pool != nil {
+		workers = g.pool.workers
+	}
+	if workers != nil {
+		workers <- struct{}{}
+	}
+	g.wg.Add(1)
+	go func() {
+		defer func() {
+			if workers != nil {
+				<-workers
+			}
+			g.wg.Done()
+		}()
+		fn()
+	}()
+}
+
+// Wait waits for the functions run by the group to return.
+func (g *Group) Wait() {
+	g.wg.Wait()
+}
+
+type transport struct {
+	rt   http.RoundTripper
+	pool *Pool
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.rt.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	resp.Body = readCloser{Reader: t.pool.Reader(resp.Body), Closer: resp.Body}
+	return resp, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
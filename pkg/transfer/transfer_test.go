@@ -0,0 +1,162 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transfer
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGroupConcurrency(t *testing.T) {
+	p := NewPool(Limits{Concurrency: 2})
+	var mu sync.Mutex
+	running, max := 0, 0
+	g := p.Group()
+	for i := 0; i < 10; i++ {
+		g.Go(func() {
+			mu.Lock()
+			running++
+			if running > max {
+				max = running
+			}
+			mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+		})
+	}
+	g.Wait()
+	if max != 2 {
+		t.Errorf("expected at most 2 functions running at once, got %d", max)
+	}
+
+	// an unlimited pool runs every function
+	count := 0
+	g = (*Pool)(nil).Group()
+	for i := 0; i < 10; i++ {
+		g.Go(func() {
+			mu.Lock()
+			count++
+			mu.Unlock()
+		})
+	}
+	g.Wait()
+	if count != 10 {
+		t.Errorf("expected 10 functions to run, got %d", count)
+	}
+}
+
+func TestBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	var slept time.Duration
+	b := newBucket(100)
+	b.last = now
+	b.now = func() time.Time { return now }
+	b.sleep = func(d time.Duration) {
+		slept += d
+		now = now.Add(d)
+	}
+
+	// the bucket starts full
+	b.take(100)
+	if slept != 0 {
+		t.Errorf("expected no wait for a full bucket, waited %s", slept)
+	}
+	// and refills at its rate
+	b.take(50)
+	if slept != 500*time.Millisecond {
+		t.Errorf("expected to wait 500ms, waited %s", slept)
+	}
+	// up to one second worth of bytes
+	now = now.Add(time.Hour)
+	slept = 0
+	b.take(150)
+	if slept != 500*time.Millisecond {
+		t.Errorf("expected to wait 500ms, waited %s", slept)
+	}
+}
+
+func TestReader(t *testing.T) {
+	p := NewPool(Limits{Bandwidth: 4})
+	var slept time.Duration
+	p.bucket.sleep = func(d time.Duration) { slept += d }
+
+	data, err := ioutil.ReadAll(p.Reader(strings.NewReader("0123456789")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "0123456789" {
+		t.Errorf("unexpected data %q", data)
+	}
+	// 4 bytes are in the bucket, the other 6 take about 1.5s
+	if slept < time.Second {
+		t.Errorf("expected to wait for the bandwidth, waited %s", slept)
+	}
+
+	r := strings.NewReader("data")
+	if NewPool(Limits{}).Reader(r) != r {
+		t.Error("expected an unlimited pool not to wrap readers")
+	}
+}
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(bytes.Repeat([]byte("x"), 1024))
+	}))
+	defer srv.Close()
+
+	p := NewPool(Limits{Bandwidth: 256})
+	var slept time.Duration
+	p.bucket.sleep = func(d time.Duration) { slept += d }
+
+	client := &http.Client{Transport: p.Transport(nil)}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 1024 {
+		t.Errorf("expected 1024 bytes, got %d", len(data))
+	}
+	if slept < 2*time.Second {
+		t.Errorf("expected to wait for the bandwidth, waited %s", slept)
+	}
+
+	if (*Pool)(nil).Transport(http.DefaultTransport) != http.DefaultTransport {
+		t.Error("expected a nil pool not to wrap transports")
+	}
+}
+
+func TestDefault(t *testing.T) {
+	defer SetDefault(Default())
+	p := NewPool(Limits{Concurrency: 1})
+	SetDefault(p)
+	if Default() != p {
+		t.Error("expected the pool set as default")
+	}
+}
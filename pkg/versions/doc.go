@@ -0,0 +1,27 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package versions resolves chart version constraints against the versions
+that are available, such as the versions of a chart in a repository index or
+the tags of a chart in an OCI registry.
+
+The downloader, the repository indexes and the registry client resolve
+versions with a Resolver, Default unless another is set, so that consumers can
+change which versions match and which is the latest, for example to never
+install release candidates, the same way for HTTP repositories and OCI
+registries.
+*/
+package versions // import "helm.sh/helm/v3/pkg/versions"
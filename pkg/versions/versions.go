@@ -0,0 +1,177 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package versions
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
+)
+
+// Resolver resolves version constraints against available versions.
+type Resolver interface {
+	// Resolve returns the versions among versions that satisfy constraint,
+	// best first. An empty constraint asks for the latest versions.
+	Resolve(constraint string, versions []string) ([]string, error)
+}
+
+// Default is the Resolver used when none is set. It resolves versions the
+// way Helm always has.
+var Default Resolver = Strategy{}
+
+// PrereleasePolicy decides when pre-release versions match a constraint.
+type PrereleasePolicy string
+
+const (
+	// PrereleaseDefault matches pre-release versions only against
+	// constraints that have a pre-release themselves, such as ">=1.0.0-0".
+	PrereleaseDefault PrereleasePolicy = ""
+	// PrereleaseInclude also matches pre-release versions against the
+	// constraints their release satisfies, so that "^1.2.0" matches
+	// 1.3.0-rc.1.
+	PrereleaseInclude PrereleasePolicy = "include"
+	// PrereleaseExclude never matches pre-release versions.
+	PrereleaseExclude PrereleasePolicy = "exclude"
+)
+
+// Strategy is a Resolver whose semantics can be changed. Versions are
+// ordered highest first, and a version given exactly as the constraint comes
+// before all others. Other versions that are not semantic versions never
+// match. The zero value is Default.
+type Strategy struct {
+	// Prereleases decides when pre-release versions match.
+	Prereleases PrereleasePolicy
+	// ExcludePrereleases lists pre-release identifiers, such as "rc", of
+	// versions that never match, whatever the constraint.
+	ExcludePrereleases []string
+	// CompareBuildMetadata orders versions that differ only in their build
+	// metadata by it, comparing numeric identifiers as numbers. Otherwise
+	// they are equal and keep their order.
+	CompareBuildMetadata bool
+	// Latest is the constraint of the latest versions, used when none is
+	// given. It defaults to "*", any version but pre-releases.
+	Latest string
+}
+
+// Resolve returns the versions satisfying constraint, best first.
+func (s Strategy) Resolve(constraint string, versions []string) ([]string, error) {
+	c := constraint
+	if c == "" {
+		c = s.Latest
+	}
+	if c == "" {
+		c = "*"
+	}
+	constraints, err := semver.NewConstraint(c)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid version constraint %q", c)
+	}
+
+	type candidate struct {
+		version string
+		semver  *semver.Version
+		exact   bool
+	}
+	var candidates []candidate
+	for _, version := range versions {
+		if constraint != "" && version == constraint {
+			candidates = append(candidates, candidate{version: version, exact: true})
+			continue
+		}
+		v, err := semver.NewVersion(version)
+		if err != nil || !s.matches(constraints, v) {
+			continue
+		}
+		candidates = append(candidates, candidate{version, v, false})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.exact || b.exact {
+			return a.exact && !b.exact
+		}
+		if n := a.semver.Compare(b.semver); n != 0 {
+			return n > 0
+		}
+		return s.CompareBuildMetadata && compareIdentifiers(a.semver.Metadata(), b.semver.Metadata()) > 0
+	})
+
+	resolved := make([]string, len(candidates))
+	for i, c := range candidates {
+		resolved[i] = c.version
+	}
+	return resolved, nil
+}
+
+// matches reports whether v satisfies constraints under the pre-release
+// policy of s
+func (s Strategy) matches(constraints *semver.Constraints, v *semver.Version) bool {
+	pre := v.Prerelease()
+	if pre == "" {
+		return constraints.Check(v)
+	}
+	if s.Prereleases == PrereleaseExclude {
+		return false
+	}
+	for _, id := range strings.Split(pre, ".") {
+		for _, excluded := range s.ExcludePrereleases {
+			if id == excluded {
+				return false
+			}
+		}
+	}
+	if constraints.Check(v) {
+		return true
+	}
+	if s.Prereleases == PrereleaseInclude {
+		release, err := v.SetPrerelease("")
+		return err == nil && constraints.Check(&release)
+	}
+	return false
+}
+
+// compareIdentifiers compares dot-separated identifiers the way semantic
+// versions compare pre-releases: numeric identifiers as numbers and lower
+// than others, which compare as strings, and longer lists higher if all else
+// is equal.
+func compareIdentifiers(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.ParseUint(as[i], 10, 64)
+		bn, bErr := strconv.ParseUint(bs[i], 10, 64)
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				if an > bn {
+					return 1
+				}
+				return -1
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if n := strings.Compare(as[i], bs[i]); n != 0 {
+				return n
+			}
+		}
+	}
+	return len(as) - len(bs)
+}
@@ -0,0 +1,57 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package versions
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStrategyResolve(t *testing.T) {
+	available := []string{"1.0.0", "1.2.0+build.2", "1.2.0+build.10", "1.3.0-rc.1", "1.3.0-beta.2", "2.0.0", "latest"}
+
+	tests := []struct {
+		name       string
+		strategy   Strategy
+		constraint string
+		want       []string
+	}{
+		{"latest", Strategy{}, "", []string{"2.0.0", "1.2.0+build.2", "1.2.0+build.10", "1.0.0"}},
+		{"constraint", Strategy{}, "^1.0.0", []string{"1.2.0+build.2", "1.2.0+build.10", "1.0.0"}},
+		{"exact match first", Strategy{}, "1.0.0", []string{"1.0.0"}},
+		{"pre-release constraint", Strategy{}, ">=1.3.0-0", []string{"2.0.0", "1.3.0-rc.1", "1.3.0-beta.2"}},
+		{"include pre-releases", Strategy{Prereleases: PrereleaseInclude}, "^1.0.0", []string{"1.3.0-rc.1", "1.3.0-beta.2", "1.2.0+build.2", "1.2.0+build.10", "1.0.0"}},
+		{"exclude pre-releases", Strategy{Prereleases: PrereleaseExclude}, ">=1.3.0-0", []string{"2.0.0"}},
+		{"exclude release candidates", Strategy{Prereleases: PrereleaseInclude, ExcludePrereleases: []string{"rc"}}, "^1.0.0", []string{"1.3.0-beta.2", "1.2.0+build.2", "1.2.0+build.10", "1.0.0"}},
+		{"compare build metadata", Strategy{CompareBuildMetadata: true}, "~1.2.0", []string{"1.2.0+build.10", "1.2.0+build.2"}},
+		{"latest constraint", Strategy{Latest: "<2.0.0"}, "", []string{"1.2.0+build.2", "1.2.0+build.10", "1.0.0"}},
+	}
+	for _, tt := range tests {
+		got, err := tt.strategy.Resolve(tt.constraint, available)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	if _, err := Default.Resolve("not a constraint", available); err == nil {
+		t.Error("expected an error for an invalid constraint")
+	}
+}
@@ -0,0 +1,25 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package warning provides the warnings Helm returns for problems that do not
+stop an operation.
+
+Loading a chart, rendering it and installing or upgrading a release collect
+Warnings, such as values or files of the chart that are deprecated or ignored
+and resources that the cluster does not serve, so that SDK consumers can show
+them to their users rather than them being lost in log output.
+*/
+package warning // import "helm.sh/helm/v3/pkg/warning"
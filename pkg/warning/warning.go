@@ -0,0 +1,80 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warning // import "helm.sh/helm/v3/pkg/warning"
+
+import "fmt"
+
+// Kind classifies a warning.
+type Kind string
+
+const (
+	// Deprecated warns about the use of a deprecated chart, file or value.
+	Deprecated Kind = "Deprecated"
+	// IgnoredFile warns about a file of a chart that Helm skipped.
+	IgnoredFile Kind = "IgnoredFile"
+	// MissingValue warns about a required value that is not set.
+	MissingValue Kind = "MissingValue"
+	// CapabilityMismatch warns about a resource using an API that the
+	// capabilities rendered for do not include.
+	CapabilityMismatch Kind = "CapabilityMismatch"
+	// HookFailed warns about a hook that failed without failing the
+	// operation, as its failure policy allows.
+	HookFailed Kind = "HookFailed"
+)
+
+// Warning is a problem that did not stop an operation.
+type Warning struct {
+	// Kind classifies the warning.
+	Kind Kind `json:"kind"`
+	// Source is what the warning is about, such as a file of the chart, a
+	// template or the path of a value, if known.
+	Source string `json:"source,omitempty"`
+	// Message describes the problem.
+	Message string `json:"message"`
+}
+
+// String returns the message, prefixed by the source if known.
+func (w Warning) String() string {
+	if w.Source == "" {
+		return w.Message
+	}
+	return w.Source + ": " + w.Message
+}
+
+// Warnings is a list of warnings, in the order they were added.
+type Warnings []Warning
+
+// Add appends a warning of kind about source, formatting its message
+// according to format. Adding to a nil *Warnings does nothing, so that
+// collecting warnings is optional.
+func (w *Warnings) Add(kind Kind, source, format string, a ...interface{}) {
+	if w == nil {
+		return
+	}
+	*w = append(*w, Warning{Kind: kind, Source: source, Message: fmt.Sprintf(format, a...)})
+}
+
+// Of returns the warnings of the given kind.
+func (w Warnings) Of(kind Kind) Warnings {
+	var of Warnings
+	for _, x := range w {
+		if x.Kind == kind {
+			of = append(of, x)
+		}
+	}
+	return of
+}
@@ -0,0 +1,41 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warning
+
+import "testing"
+
+func TestWarnings(t *testing.T) {
+	var w Warnings
+	w.Add(Deprecated, "values.yaml", "value %q is deprecated", "image.name")
+	w.Add(IgnoredFile, "", "file skipped")
+
+	if len(w) != 2 {
+		t.Fatalf("expected 2 warnings, got %d", len(w))
+	}
+	if got, want := w[0].String(), `values.yaml: value "image.name" is deprecated`; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got, want := w[1].String(), "file skipped"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if of := w.Of(IgnoredFile); len(of) != 1 || of[0].Message != "file skipped" {
+		t.Errorf("unexpected warnings of kind %s: %v", IgnoredFile, of)
+	}
+
+	var none *Warnings
+	none.Add(Deprecated, "", "ignored")
+}